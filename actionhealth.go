@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Health of an action repository
+const (
+	HealthArchived    = "archived"    // read-only; the action still runs but is no longer maintained
+	HealthDisabled    = "disabled"    // disabled by GitHub; its actions cannot be downloaded
+	HealthUnavailable = "unavailable" // deleted, or not visible to the token; the step fails
+)
+
+// actionRepositoryHealth returns the health of an action repository, or "" when it is healthy or could not
+// be looked up for another reason than not existing
+func actionRepositoryHealth(cache *enrichmentCache, repository string) string {
+	metadata, err := fetchRepositoryMetadata(cache, repository)
+	switch {
+	case err == errFileNotFound:
+		return HealthUnavailable
+	case err != nil:
+		return ""
+	case metadata.Disabled:
+		return HealthDisabled
+	case metadata.Archived:
+		return HealthArchived
+	}
+	return ""
+}
+
+// detectActionHealthFindings flags usages of actions and reusable workflows whose repository is archived,
+// disabled, or gone, once per workflow and action reference. Repositories are looked up with at most
+// opts.Concurrency lookups in flight.
+func detectActionHealthFindings(repositories []ComprehensiveRepository, opts scanOptions) []Finding {
+	seen := make(map[string]bool)
+	var lookups []string
+	for _, repo := range repositories {
+		for _, workflow := range repo.Workflows {
+			for _, action := range workflow.Actions {
+				if strings.HasPrefix(action.Name, "./") || strings.HasPrefix(action.Name, "docker://") {
+					continue
+				}
+				// Deduplicated case-insensitively, looked up as written to share the cache with the fork check
+				repository := actionRepository(action.Name)
+				if key := strings.ToLower(repository); !seen[key] {
+					seen[key] = true
+					lookups = append(lookups, repository)
+				}
+			}
+		}
+	}
+	sort.Strings(lookups)
+
+	health := make([]string, len(lookups))
+	runConcurrently(len(lookups), opts.Concurrency, func(i int) {
+		apiRateLimit.acquire()
+		defer apiRateLimit.release()
+		health[i] = actionRepositoryHealth(opts.Cache, lookups[i])
+	})
+	byRepository := make(map[string]string, len(lookups))
+	for i, repository := range lookups {
+		byRepository[strings.ToLower(repository)] = health[i]
+	}
+
+	findings := []Finding{}
+	for _, repo := range repositories {
+		for _, workflow := range repo.Workflows {
+			flagged := make(map[string]bool)
+			for _, action := range workflow.Actions {
+				repository := actionRepository(action.Name)
+				state := byRepository[strings.ToLower(repository)]
+				reference := action.Name + "@" + action.Version
+				if state == "" || flagged[reference] {
+					continue
+				}
+				flagged[reference] = true
+				findings = append(findings, actionHealthFinding(repo.Name, workflow.Path, action, repository, state))
+			}
+		}
+	}
+
+	normalizeFindings(findings)
+	return findings
+}
+
+// countHealthFindings returns the number of archived-action and unavailable-action findings
+func countHealthFindings(findings []Finding) (archived, unavailable int) {
+	for _, finding := range findings {
+		switch finding.RuleID {
+		case RuleArchivedAction:
+			archived++
+		case RuleUnavailableAction:
+			unavailable++
+		}
+	}
+	return archived, unavailable
+}
+
+// actionHealthFinding flags one usage of an action whose repository is archived, disabled, or gone
+func actionHealthFinding(repo, workflowPath string, action ComprehensiveAction, repository, state string) Finding {
+	finding := Finding{
+		Repository: repo,
+		Workflow:   workflowPath,
+		Action:     action.Name,
+		Version:    action.Version,
+	}
+	switch state {
+	case HealthArchived:
+		finding.RuleID, finding.Severity = RuleArchivedAction, SeverityWarning
+		finding.Message = fmt.Sprintf("%s is archived; %s@%s still runs but gets no fixes", repository, action.Name, action.Version)
+		finding.Remediation = fmt.Sprintf("Replace `%s` with a maintained alternative, or vendor a reviewed copy into the organization with `gh action-lens vendor`.", action.Name)
+	case HealthDisabled:
+		finding.RuleID, finding.Severity = RuleUnavailableAction, SeverityError
+		finding.Message = fmt.Sprintf("%s is disabled; steps using %s@%s fail", repository, action.Name, action.Version)
+		finding.Remediation = fmt.Sprintf("Replace `%s` with a maintained alternative.", action.Name)
+	default:
+		finding.RuleID, finding.Severity = RuleUnavailableAction, SeverityError
+		finding.Message = fmt.Sprintf("%s does not exist or is not visible; steps using %s@%s fail", repository, action.Name, action.Version)
+		finding.Remediation = fmt.Sprintf("Check whether `%s` was renamed, made private, or deleted, and point the workflow at its new location or a maintained alternative.", repository)
+	}
+	return finding
+}
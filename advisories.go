@@ -0,0 +1,274 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// latestInLine pads a partial version such as v4 so it compares as the newest release of its line, which a
+// floating major or minor tag points to
+const latestInLine = 1 << 30
+
+// ActionVulnerability is a GitHub security advisory affecting an action version used by the scanned workflows
+type ActionVulnerability struct {
+	Advisory        string   `json:"advisory"` // GHSA ID
+	CVE             string   `json:"cve,omitempty"`
+	Severity        string   `json:"severity"` // critical, high, medium, or low
+	Summary         string   `json:"summary"`
+	URL             string   `json:"url"`
+	Action          string   `json:"action"`
+	Version         string   `json:"version"` // as referenced, or the tag a pinned SHA corresponds to
+	VulnerableRange string   `json:"vulnerable_range"`
+	PatchedVersion  string   `json:"patched_version,omitempty"`
+	Workflows       []string `json:"workflows"` // repo/path of the workflows using the version
+	Repositories    int      `json:"repositories"`
+}
+
+// securityAdvisory is the subset of the REST global advisory resource used for the vulnerability lookup
+type securityAdvisory struct {
+	GHSAID          string `json:"ghsa_id"`
+	CVEID           string `json:"cve_id"`
+	HTMLURL         string `json:"html_url"`
+	Summary         string `json:"summary"`
+	Severity        string `json:"severity"`
+	WithdrawnAt     string `json:"withdrawn_at"`
+	Vulnerabilities []struct {
+		Package struct {
+			Name string `json:"name"`
+		} `json:"package"`
+		VulnerableVersionRange string `json:"vulnerable_version_range"` // e.g. ">= 1.0.0, < 2.3.4"
+		FirstPatchedVersion    string `json:"first_patched_version"`
+	} `json:"vulnerabilities"`
+}
+
+// fetchActionAdvisories returns the reviewed advisories of the actions ecosystem affecting a repository,
+// through the enrichment cache
+func fetchActionAdvisories(cache *enrichmentCache, repository string) ([]securityAdvisory, error) {
+	var advisories []securityAdvisory
+	err := cache.fetch(EnrichmentAdvisories, repository, &advisories, func() error {
+		return restGet("advisories?ecosystem=actions&per_page=100&affects="+url.QueryEscape(repository), &advisories)
+	})
+	return advisories, err
+}
+
+// affectedVersion reports whether a version falls into an advisory's vulnerable range. Partial versions
+// are compared as the newest release of their line; a range that cannot be parsed matches nothing.
+func affectedVersion(version []int, vulnerableRange string) bool {
+	version = append([]int(nil), version...)
+	for len(version) < 3 {
+		version = append(version, latestInLine)
+	}
+	constraints, err := parseVersionConstraints(vulnerableRange)
+	if err != nil || len(constraints) == 0 {
+		return false
+	}
+	for _, constraint := range constraints {
+		if !constraint.satisfied(version) {
+			return false
+		}
+	}
+	return true
+}
+
+// advisorySeverity maps the severity of an advisory to the severity of its findings
+func advisorySeverity(severity string) string {
+	switch strings.ToLower(severity) {
+	case "critical", "high":
+		return SeverityError
+	case "medium", "moderate":
+		return SeverityWarning
+	}
+	return SeverityInfo
+}
+
+// detectVulnerabilities matches every action version of the detailed report against the GitHub Advisory
+// Database and returns the affected versions with a finding per workflow. SHA-pinned actions are checked as
+// the tag they correspond to; branches and SHAs without a known tag cannot be matched. Advisories are
+// looked up once per action repository with at most opts.Concurrency lookups in flight.
+func detectVulnerabilities(repositories []ComprehensiveRepository, opts scanOptions) ([]ActionVulnerability, []Finding) {
+	seen := make(map[string]bool)
+	var lookups []string
+	for _, repo := range repositories {
+		for _, workflow := range repo.Workflows {
+			for _, action := range workflow.Actions {
+				if strings.HasPrefix(action.Name, "./") || strings.HasPrefix(action.Name, "docker://") {
+					continue
+				}
+				repository := actionRepository(action.Name)
+				if key := strings.ToLower(repository); !seen[key] {
+					seen[key] = true
+					lookups = append(lookups, repository)
+				}
+			}
+		}
+	}
+	sort.Strings(lookups)
+
+	advisories := make([][]securityAdvisory, len(lookups))
+	runConcurrently(len(lookups), opts.Concurrency, func(i int) {
+		apiRateLimit.acquire()
+		defer apiRateLimit.release()
+		found, err := fetchActionAdvisories(opts.Cache, lookups[i])
+		if err != nil {
+			logWarnf("⚠️  Warning: Could not look up advisories of %s: %v\n", lookups[i], err)
+			return
+		}
+		advisories[i] = found
+	})
+	byRepository := make(map[string][]securityAdvisory, len(lookups))
+	for i, repository := range lookups {
+		byRepository[strings.ToLower(repository)] = advisories[i]
+	}
+
+	byKey := make(map[string]*ActionVulnerability)
+	usedBy := make(map[string]map[string]bool) // repositories, by vulnerability
+	flagged := make(map[string]bool)           // vulnerability and workflow pairs already reported
+	findings := []Finding{}
+	for _, repo := range repositories {
+		for _, workflow := range repo.Workflows {
+			for _, action := range workflow.Actions {
+				repository := actionRepository(action.Name)
+				candidates := byRepository[strings.ToLower(repository)]
+				version := displayVersion(action)
+				parsed, ok := parseVersion(version)
+				if len(candidates) == 0 || !ok {
+					continue
+				}
+				for _, advisory := range candidates {
+					if advisory.WithdrawnAt != "" {
+						continue
+					}
+					for _, vulnerability := range advisory.Vulnerabilities {
+						name := vulnerability.Package.Name
+						if !strings.EqualFold(name, repository) && !strings.EqualFold(name, action.Name) {
+							continue
+						}
+						if !affectedVersion(parsed, vulnerability.VulnerableVersionRange) {
+							continue
+						}
+
+						key := advisory.GHSAID + "|" + action.Name + "@" + version
+						entry, ok := byKey[key]
+						if !ok {
+							entry = &ActionVulnerability{
+								Advisory:        advisory.GHSAID,
+								CVE:             advisory.CVEID,
+								Severity:        strings.ToLower(advisory.Severity),
+								Summary:         advisory.Summary,
+								URL:             advisory.HTMLURL,
+								Action:          action.Name,
+								Version:         version,
+								VulnerableRange: vulnerability.VulnerableVersionRange,
+								PatchedVersion:  vulnerability.FirstPatchedVersion,
+								Workflows:       []string{},
+							}
+							byKey[key] = entry
+							usedBy[key] = make(map[string]bool)
+						}
+						usedBy[key][repo.Name] = true
+						if workflowKey := repo.Name + "/" + workflow.Path; !flagged[key+"|"+workflowKey] {
+							flagged[key+"|"+workflowKey] = true
+							entry.Workflows = append(entry.Workflows, workflowKey)
+							findings = append(findings, vulnerabilityFinding(repo.Name, workflow.Path, *entry))
+						}
+						break
+					}
+				}
+			}
+		}
+	}
+
+	vulnerabilities := []ActionVulnerability{}
+	for key, entry := range byKey {
+		entry.Repositories = len(usedBy[key])
+		vulnerabilities = append(vulnerabilities, *entry)
+	}
+	sortVulnerabilities(vulnerabilities)
+	normalizeFindings(findings)
+	return vulnerabilities, findings
+}
+
+// vulnerabilityFinding flags a workflow using an action version affected by an advisory
+func vulnerabilityFinding(repo, workflowPath string, vulnerability ActionVulnerability) Finding {
+	finding := Finding{
+		RuleID:     RuleVulnerableAction,
+		Severity:   advisorySeverity(vulnerability.Severity),
+		Repository: repo,
+		Workflow:   workflowPath,
+		Action:     vulnerability.Action,
+		Version:    vulnerability.Version,
+		Message: fmt.Sprintf("%s@%s is affected by %s (%s): %s", vulnerability.Action, vulnerability.Version,
+			vulnerability.Advisory, vulnerability.Severity, vulnerability.Summary),
+		Remediation: fmt.Sprintf("No patched version of `%s` is available; replace it or remove the step. See %s.", vulnerability.Action, vulnerability.URL),
+	}
+	if vulnerability.PatchedVersion != "" {
+		finding.Remediation = fmt.Sprintf("Upgrade to `%s@%s` or later, pinned to its commit SHA. See %s.",
+			vulnerability.Action, vulnerability.PatchedVersion, vulnerability.URL)
+	}
+	return finding
+}
+
+// advisorySeverityRank orders advisory severities from critical to low
+var advisorySeverityRank = map[string]int{"critical": 0, "high": 1, "medium": 2, "moderate": 2, "low": 3}
+
+// sortVulnerabilities orders vulnerabilities by severity, then by the number of workflows affected
+func sortVulnerabilities(vulnerabilities []ActionVulnerability) {
+	sort.Slice(vulnerabilities, func(i, j int) bool {
+		a, b := vulnerabilities[i], vulnerabilities[j]
+		if advisorySeverityRank[a.Severity] != advisorySeverityRank[b.Severity] {
+			return advisorySeverityRank[a.Severity] < advisorySeverityRank[b.Severity]
+		}
+		if len(a.Workflows) != len(b.Workflows) {
+			return len(a.Workflows) > len(b.Workflows)
+		}
+		if a.Advisory != b.Advisory {
+			return a.Advisory < b.Advisory
+		}
+		return a.Action+"@"+a.Version < b.Action+"@"+b.Version
+	})
+}
+
+// mergeVulnerabilities adds the vulnerabilities of an organization's report to those of an enterprise scan,
+// naming its workflows <org>/<repo>/<path>
+func mergeVulnerabilities(vulnerabilities, more []ActionVulnerability, org string) []ActionVulnerability {
+	index := make(map[string]int, len(vulnerabilities))
+	for i, vulnerability := range vulnerabilities {
+		index[vulnerability.Advisory+"|"+vulnerability.Action+"@"+vulnerability.Version] = i
+	}
+	for _, vulnerability := range more {
+		workflows := make([]string, len(vulnerability.Workflows))
+		for i, workflow := range vulnerability.Workflows {
+			workflows[i] = org + "/" + workflow
+		}
+		key := vulnerability.Advisory + "|" + vulnerability.Action + "@" + vulnerability.Version
+		if i, ok := index[key]; ok {
+			vulnerabilities[i].Workflows = append(vulnerabilities[i].Workflows, workflows...)
+			vulnerabilities[i].Repositories += vulnerability.Repositories
+			continue
+		}
+		vulnerability.Workflows = workflows
+		index[key] = len(vulnerabilities)
+		vulnerabilities = append(vulnerabilities, vulnerability)
+	}
+	sortVulnerabilities(vulnerabilities)
+	return vulnerabilities
+}
+
+// outputVulnerabilities writes the advisories affecting the action versions of the detailed report
+func outputVulnerabilities(vulnerabilities []ActionVulnerability, writer io.Writer) {
+	if len(vulnerabilities) == 0 {
+		return
+	}
+	fmt.Fprintf(writer, "\n🛡️  Vulnerabilities (%d advisories affecting used versions):\n", len(vulnerabilities))
+	for _, vulnerability := range vulnerabilities {
+		patched := "no patched version"
+		if vulnerability.PatchedVersion != "" {
+			patched = "fixed in " + vulnerability.PatchedVersion
+		}
+		fmt.Fprintf(writer, "   ⛔ [%s] %s@%s: %s %s (%s); %d workflows in %d repos\n", vulnerability.Severity, vulnerability.Action,
+			vulnerability.Version, vulnerability.Advisory, vulnerability.Summary, patched, len(vulnerability.Workflows), vulnerability.Repositories)
+	}
+}
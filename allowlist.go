@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// allowedActionsFormat is the --format name of the allowed actions policy list
+const allowedActionsFormat = "allowed-actions"
+
+// allowedActionsFormatter writes the patterns of the organization setting "Allow specified actions and
+// reusable workflows", one per line: owner/repo@ref for every action and reusable workflow the scan
+// observed, or owner/* per owner with CollapseOwners. Local and docker:// references are not governed
+// by the setting, and the actions of the scanned organizations are always allowed, so neither is listed.
+type allowedActionsFormatter struct {
+	CollapseOwners     bool // --collapse-owners
+	ExcludeGitHubOwned bool // --exclude-github-owned: covered by "Allow actions created by GitHub"
+}
+
+// allowedActions is the allowed-actions formatter; main sets its options from the flags
+var allowedActions = &allowedActionsFormatter{}
+
+func init() {
+	RegisterFormatter(allowedActionsFormat, allowedActions)
+}
+
+// Format writes the patterns of an action report or a detailed report
+func (f *allowedActionsFormatter) Format(report interface{}, writer io.Writer) error {
+	var references []string
+	internal := make(map[string]bool) // owners whose actions are always allowed
+	switch r := report.(type) {
+	case ActionReport:
+		internal[strings.ToLower(r.Organization)] = true
+		for _, action := range r.Actions {
+			for _, version := range action.Versions {
+				references = append(references, action.Name+"@"+version.Version)
+			}
+		}
+	case ComprehensiveReport:
+		internal[strings.ToLower(r.Organization)] = true
+		for _, org := range r.Organizations {
+			internal[strings.ToLower(org.Organization)] = true
+		}
+		for _, repo := range r.Repositories {
+			for _, workflow := range repo.Workflows {
+				for _, action := range workflow.Actions {
+					references = append(references, action.Name+"@"+action.Version)
+				}
+			}
+		}
+	default:
+		return fmt.Errorf("--format %s requires --scan actions, --detailed, or --enterprise", allowedActionsFormat)
+	}
+
+	for _, pattern := range f.patterns(references, internal) {
+		fmt.Fprintln(writer, pattern)
+	}
+	return nil
+}
+
+// patterns returns the sorted, deduplicated patterns of action references
+func (f *allowedActionsFormatter) patterns(references []string, internal map[string]bool) []string {
+	seen := make(map[string]bool)
+	var patterns []string
+	for _, reference := range references {
+		name, ref, ok := splitActionReference(reference)
+		if !ok || ref == "" || strings.HasPrefix(name, "./") || strings.HasPrefix(name, "docker://") {
+			continue
+		}
+		owner := strings.ToLower(strings.SplitN(name, "/", 2)[0])
+		if internal[owner] || (f.ExcludeGitHubOwned && actionClass(name, "") == actionClassGitHub) {
+			continue
+		}
+		pattern := name + "@" + ref
+		if f.CollapseOwners {
+			pattern = owner + "/*"
+		}
+		if !seen[strings.ToLower(pattern)] {
+			seen[strings.ToLower(pattern)] = true
+			patterns = append(patterns, pattern)
+		}
+	}
+	sort.Slice(patterns, func(i, j int) bool { return strings.ToLower(patterns[i]) < strings.ToLower(patterns[j]) })
+	return patterns
+}
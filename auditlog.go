@@ -0,0 +1,210 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxIntroductionCommits bounds the commits inspected per workflow to find where an action was added
+const maxIntroductionCommits = 30
+
+// accountabilityEvents are the audit-log actions that put a commit on a repository's branches
+var accountabilityEvents = map[string]bool{
+	"git.push":           true,
+	"pull_request.merge": true,
+}
+
+// ActionIntroduction records who introduced a new action into a workflow and when
+type ActionIntroduction struct {
+	Action      string `json:"action"`
+	Repository  string `json:"repository"`
+	Workflow    string `json:"workflow"`
+	Commit      string `json:"commit,omitempty"`
+	Author      string `json:"author,omitempty"` // commit author login, or name when not linked to an account
+	CommittedAt string `json:"committed_at,omitempty"`
+	PushedBy    string `json:"pushed_by,omitempty"` // actor of the matching audit-log event
+	PushedAt    string `json:"pushed_at,omitempty"`
+	AuditEvent  string `json:"audit_event,omitempty"` // git.push or pull_request.merge
+	Error       string `json:"error,omitempty"`
+}
+
+// auditLogEvent is the subset of an organization audit-log entry used for correlation
+type auditLogEvent struct {
+	Action    string `json:"action"`
+	Actor     string `json:"actor"`
+	CreatedAt int64  `json:"created_at"` // milliseconds since the epoch
+}
+
+// commitDetails is the subset of the REST commit resource used to find where an action was added
+type commitDetails struct {
+	SHA    string `json:"sha"`
+	Commit struct {
+		Author struct {
+			Name string    `json:"name"`
+			Date time.Time `json:"date"`
+		} `json:"author"`
+		Committer struct {
+			Date time.Time `json:"date"`
+		} `json:"committer"`
+	} `json:"commit"`
+	Author *struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	Files []struct {
+		Filename string `json:"filename"`
+		Patch    string `json:"patch"`
+	} `json:"files"`
+}
+
+// splitRepository returns the owner and name of a report repository. Enterprise reports name
+// repositories <org>/<repo>; other reports use the scanned organization.
+func splitRepository(org, repository string) (string, string) {
+	if owner, name, ok := strings.Cut(repository, "/"); ok {
+		return owner, name
+	}
+	return org, repository
+}
+
+// correlateNewActions finds, for every usage of a new action, the commit that added it since the
+// baseline scan and the audit-log event that pushed or merged that commit
+func correlateNewActions(report ComprehensiveReport, newActions []string, since time.Time) []ActionIntroduction {
+	isNew := make(map[string]bool)
+	for _, name := range newActions {
+		isNew[name] = true
+	}
+
+	introductions := []ActionIntroduction{}
+	for _, repo := range report.Repositories {
+		for _, workflow := range repo.Workflows {
+			for _, action := range workflow.Actions {
+				if !isNew[action.Name] {
+					continue
+				}
+				introduction := ActionIntroduction{Action: action.Name, Repository: repo.Name, Workflow: workflow.Path}
+				owner, name := splitRepository(report.Organization, repo.Name)
+				if err := introduction.resolve(owner, name, since); err != nil {
+					introduction.Error = err.Error()
+				}
+				introductions = append(introductions, introduction)
+			}
+		}
+	}
+
+	sort.SliceStable(introductions, func(i, j int) bool {
+		if introductions[i].Action != introductions[j].Action {
+			return introductions[i].Action < introductions[j].Action
+		}
+		return introductions[i].Repository < introductions[j].Repository
+	})
+	return introductions
+}
+
+// resolve fills in the introducing commit and the matching audit-log event
+func (a *ActionIntroduction) resolve(owner, repo string, since time.Time) error {
+	commit, err := findIntroducingCommit(owner, repo, a.Workflow, a.Action, since)
+	if err != nil {
+		return err
+	}
+	if commit == nil {
+		return fmt.Errorf("no commit since %s adds %s to %s", since.Format("2006-01-02"), a.Action, a.Workflow)
+	}
+
+	a.Commit = commit.SHA
+	a.Author = commit.Commit.Author.Name
+	if commit.Author != nil && commit.Author.Login != "" {
+		a.Author = commit.Author.Login
+	}
+	a.CommittedAt = commit.Commit.Author.Date.Format(time.RFC3339)
+
+	event, err := findAccountabilityEvent(owner, repo, commit.Commit.Committer.Date)
+	if err != nil {
+		return fmt.Errorf("audit log: %v", err)
+	}
+	if event != nil {
+		a.PushedBy = event.Actor
+		a.PushedAt = time.UnixMilli(event.CreatedAt).UTC().Format(time.RFC3339)
+		a.AuditEvent = event.Action
+	}
+	return nil
+}
+
+// findIntroducingCommit returns the oldest commit since the given time whose diff of the workflow
+// file adds a line referencing the action, or nil if there is none
+func findIntroducingCommit(owner, repo, workflowPath, action string, since time.Time) (*commitDetails, error) {
+	var commits []struct {
+		SHA string `json:"sha"`
+	}
+	query := url.Values{
+		"path":     {workflowPath},
+		"since":    {since.UTC().Format(time.RFC3339)},
+		"per_page": {fmt.Sprint(maxIntroductionCommits)},
+	}
+	if err := restGet(fmt.Sprintf("repos/%s/%s/commits?%s", owner, repo, query.Encode()), &commits); err != nil {
+		return nil, fmt.Errorf("failed to list commits of %s: %v", workflowPath, err)
+	}
+
+	// Commits are listed newest first
+	for i := len(commits) - 1; i >= 0; i-- {
+		var commit commitDetails
+		if err := restGet(fmt.Sprintf("repos/%s/%s/commits/%s", owner, repo, commits[i].SHA), &commit); err != nil {
+			return nil, fmt.Errorf("failed to read commit %s: %v", commits[i].SHA, err)
+		}
+		for _, file := range commit.Files {
+			if file.Filename == workflowPath && patchAddsAction(file.Patch, action) {
+				return &commit, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// patchAddsAction reports whether a unified diff adds a `uses:` line referencing the action
+func patchAddsAction(patch, action string) bool {
+	for _, line := range strings.Split(patch, "\n") {
+		if strings.HasPrefix(line, "+") && strings.Contains(line, "uses:") && strings.Contains(line, action+"@") {
+			return true
+		}
+	}
+	return false
+}
+
+// findAccountabilityEvent returns the first push or merge audit-log event of a repository at or
+// after a commit was created. The token needs the read:audit_log scope.
+func findAccountabilityEvent(owner, repo string, committedAt time.Time) (*auditLogEvent, error) {
+	query := url.Values{
+		"phrase":   {fmt.Sprintf("repo:%s/%s created:>=%s", owner, repo, committedAt.UTC().Format("2006-01-02T15:04:05Z"))},
+		"include":  {"all"},
+		"order":    {"asc"},
+		"per_page": {"100"},
+	}
+
+	var events []auditLogEvent
+	if err := restGet(fmt.Sprintf("orgs/%s/audit-log?%s", owner, query.Encode()), &events); err != nil {
+		return nil, err
+	}
+	for i := range events {
+		if accountabilityEvents[events[i].Action] {
+			return &events[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// describeIntroduction summarizes who introduced an action usage
+func describeIntroduction(a ActionIntroduction) string {
+	if a.Commit == "" {
+		return fmt.Sprintf("%s/%s: unknown (%s)", a.Repository, a.Workflow, a.Error)
+	}
+
+	description := fmt.Sprintf("%s/%s: added by %s in %.7s on %s", a.Repository, a.Workflow, a.Author, a.Commit, a.CommittedAt)
+	switch {
+	case a.PushedBy != "":
+		description += fmt.Sprintf(", %s by %s on %s", a.AuditEvent, a.PushedBy, a.PushedAt)
+	case a.Error != "":
+		description += fmt.Sprintf(" (%s)", a.Error)
+	}
+	return description
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/auth"
+)
+
+// hostnameFlag is the --hostname of the scan; empty resolves the host like gh
+var hostnameFlag string
+
+// githubHost returns the host the extension talks to: --hostname, then like gh itself GH_HOST, the
+// default host of the gh configuration, and github.com
+var githubHost = sync.OnceValue(func() string {
+	if hostnameFlag != "" {
+		return normalizeHostnameFlag(hostnameFlag)
+	}
+	host, _ := auth.DefaultHost()
+	return host
+})
+
+// addHostnameFlag registers --hostname on the flag set of a subcommand, so commands that read or write
+// repositories target the same host as the scan
+func addHostnameFlag(fs *flag.FlagSet) {
+	fs.StringVar(&hostnameFlag, "hostname", "", "GitHub host, e.g. a GitHub Enterprise Server (default: GH_HOST or the gh default host)")
+}
+
+// normalizeHostnameFlag accepts a GitHub Enterprise Server host written as a URL, e.g.
+// https://github.example.com/, and returns the bare host name
+func normalizeHostnameFlag(hostname string) string {
+	hostname = strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(hostname), "https://"), "http://")
+	return strings.ToLower(strings.TrimSuffix(hostname, "/"))
+}
+
+// githubToken returns the token of githubHost: GH_TOKEN/GITHUB_TOKEN (GH_ENTERPRISE_TOKEN for GitHub
+// Enterprise Server), then the token stored by `gh auth login` in the config file or the system
+// keychain. The lookup may run gh, so it is done once.
+var githubToken = sync.OnceValue(func() string {
+	token, _ := auth.TokenForHost(githubHost())
+	return token
+})
+
+// errNoToken is returned when neither the environment nor gh provide a token
+func errNoToken() error {
+	return fmt.Errorf("GitHub token not found for %s. Authenticate with 'gh auth login --hostname %s' or set GH_TOKEN (GH_ENTERPRISE_TOKEN for GitHub Enterprise Server)", githubHost(), githubHost())
+}
+
+// restURL returns the REST API URL of a path on githubHost: /api/v3 of a GitHub Enterprise Server,
+// the api. subdomain of github.com and GHE.com tenants
+func restURL(apiPath string) string {
+	apiPath = strings.TrimPrefix(apiPath, "/")
+	if host := githubHost(); auth.IsEnterprise(host) {
+		return "https://" + host + "/api/v3/" + apiPath
+	}
+	return "https://api." + auth.NormalizeHostname(githubHost()) + "/" + apiPath
+}
+
+// newGraphQLClient creates an authenticated GraphQL client for githubHost on the rate-limited transport
+func newGraphQLClient() (*api.GraphQLClient, error) {
+	token := githubToken()
+	if token == "" {
+		return nil, errNoToken()
+	}
+	return api.NewGraphQLClient(api.ClientOptions{
+		Host:      githubHost(),
+		AuthToken: token,
+		Transport: apiClient.Transport,
+	})
+}
+
+// newRESTClient creates an authenticated REST client for githubHost on the rate-limited transport
+func newRESTClient() (*api.RESTClient, error) {
+	token := githubToken()
+	if token == "" {
+		return nil, errNoToken()
+	}
+	return api.NewRESTClient(api.ClientOptions{
+		Host:      githubHost(),
+		AuthToken: token,
+		Transport: apiClient.Transport,
+	})
+}
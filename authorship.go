@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// Authorship of the last change to a workflow, recorded on its findings
+const (
+	AuthorshipBot   = "bot"   // a GitHub App or bot account such as dependabot[bot] or an internal scaffolding bot
+	AuthorshipHuman = "human" // a user account
+)
+
+// workflowAuthor is the last account that modified a workflow file
+type workflowAuthor struct {
+	Login      string
+	Authorship string
+}
+
+// lastWorkflowAuthor returns who made the latest commit to a workflow file on the default branch.
+// Commits by GitHub Apps are authored by <app>[bot] accounts of type Bot; bots listed in botAccounts
+// (logins or glob patterns) count as bots although they are regular user accounts.
+func lastWorkflowAuthor(owner, repo, workflowPath string, botAccounts []string) (workflowAuthor, error) {
+	var commits []struct {
+		Commit struct {
+			Author struct {
+				Name string `json:"name"`
+			} `json:"author"`
+		} `json:"commit"`
+		Author *struct {
+			Login string `json:"login"`
+			Type  string `json:"type"`
+		} `json:"author"`
+	}
+	query := url.Values{"path": {workflowPath}, "per_page": {"1"}}
+	if err := restGet(fmt.Sprintf("repos/%s/%s/commits?%s", owner, repo, query.Encode()), &commits); err != nil {
+		return workflowAuthor{}, fmt.Errorf("failed to list commits of %s: %v", workflowPath, err)
+	}
+	if len(commits) == 0 {
+		return workflowAuthor{}, fmt.Errorf("no commits found for %s", workflowPath)
+	}
+
+	commit := commits[0]
+	if commit.Author == nil {
+		// Not linked to an account, so the name is all there is to go by
+		name := commit.Commit.Author.Name
+		return workflowAuthor{Login: name, Authorship: classifyAuthor(name, "", botAccounts)}, nil
+	}
+	return workflowAuthor{Login: commit.Author.Login, Authorship: classifyAuthor(commit.Author.Login, commit.Author.Type, botAccounts)}, nil
+}
+
+// classifyAuthor returns AuthorshipBot for bot accounts and the configured bot logins, AuthorshipHuman otherwise
+func classifyAuthor(login, accountType string, botAccounts []string) string {
+	if accountType == "Bot" || strings.HasSuffix(login, "[bot]") {
+		return AuthorshipBot
+	}
+	for _, pattern := range botAccounts {
+		if matched, _ := path.Match(strings.ToLower(pattern), strings.ToLower(login)); matched {
+			return AuthorshipBot
+		}
+	}
+	return AuthorshipHuman
+}
+
+// classifyAuthorship records on every finding whether its workflow was last modified by a bot or a
+// human, looking up each workflow once. Workflows whose history cannot be read are left unclassified.
+func (o scanOptions) classifyAuthorship(org string, findings []Finding) {
+	if !o.Authorship || len(findings) == 0 {
+		return
+	}
+
+	seen := make(map[string]bool)
+	var workflows []Finding
+	for _, finding := range findings {
+		key := finding.Repository + "|" + finding.Workflow
+		// Gists have no commit history to look up
+		if finding.Workflow != "" && !isGistRepository(finding.Repository) && !seen[key] {
+			seen[key] = true
+			workflows = append(workflows, finding)
+		}
+	}
+
+	authors := make([]workflowAuthor, len(workflows))
+	runConcurrently(len(workflows), o.Concurrency, func(i int) {
+		owner, repo := splitRepository(org, workflows[i].Repository)
+		apiRateLimit.acquire()
+		authors[i], _ = lastWorkflowAuthor(owner, repo, workflows[i].Workflow, o.BotAccounts)
+		apiRateLimit.release()
+	})
+
+	byWorkflow := make(map[string]workflowAuthor, len(workflows))
+	for i, workflow := range workflows {
+		byWorkflow[workflow.Repository+"|"+workflow.Workflow] = authors[i]
+	}
+	for i := range findings {
+		author := byWorkflow[findings[i].Repository+"|"+findings[i].Workflow]
+		findings[i].Authorship = author.Authorship
+		findings[i].LastModifiedBy = author.Login
+	}
+}
+
+// authorshipCounts counts findings per authorship; zero when authorship was not classified
+func authorshipCounts(findings []Finding) (bot, human int) {
+	for _, finding := range findings {
+		switch finding.Authorship {
+		case AuthorshipBot:
+			bot++
+		case AuthorshipHuman:
+			human++
+		}
+	}
+	return bot, human
+}
+
+// authorshipLabel describes the last modifier of a finding's workflow, e.g. "🤖 dependabot[bot]";
+// empty when authorship was not classified
+func authorshipLabel(finding Finding) string {
+	switch finding.Authorship {
+	case AuthorshipBot:
+		return "🤖 " + finding.LastModifiedBy
+	case AuthorshipHuman:
+		return "👤 " + finding.LastModifiedBy
+	}
+	return ""
+}
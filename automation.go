@@ -0,0 +1,434 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Dependency update tools
+const (
+	ToolDependabot = "dependabot"
+	ToolRenovate   = "renovate"
+	ToolNone       = "none"
+)
+
+// dependabotConfigPaths lists where Dependabot looks for its configuration
+var dependabotConfigPaths = []string{".github/dependabot.yml", ".github/dependabot.yaml"}
+
+// renovateConfigPaths lists where Renovate looks for its configuration, in its own lookup order
+var renovateConfigPaths = []string{
+	"renovate.json",
+	"renovate.json5",
+	".github/renovate.json",
+	".github/renovate.json5",
+	".gitlab/renovate.json",
+	".gitlab/renovate.json5",
+	".renovaterc",
+	".renovaterc.json",
+	".renovaterc.json5",
+}
+
+// AutomationReport represents dependency update coverage of the github-actions ecosystem per repository
+type AutomationReport struct {
+	Organization          string                 `json:"organization"`
+	Repositories          []RepositoryAutomation `json:"repositories"`
+	Summary               AutomationSummary      `json:"summary"`
+	Truncated             bool                   `json:"truncated"`
+	RemainingRepositories []string               `json:"remaining_repositories,omitempty"`
+	ProcessTimeSeconds    float64                `json:"process_time_seconds"`
+}
+
+// RepositoryAutomation describes how a repository keeps its actions up to date
+type RepositoryAutomation struct {
+	Name            string `json:"name"`
+	Tool            string `json:"tool"`
+	ConfigPath      string `json:"config_path,omitempty"`
+	CoversActions   bool   `json:"covers_actions"`
+	ActionUsages    int    `json:"action_usages"`
+	OutdatedActions int    `json:"outdated_actions"`
+	Gap             bool   `json:"gap"` // outdated actions without automation covering them
+}
+
+// AutomationSummary represents summary statistics for dependency update coverage
+type AutomationSummary struct {
+	RepositoriesWithWorkflows int `json:"repositories_with_workflows"`
+	DependabotCovered         int `json:"dependabot_covered"`
+	RenovateCovered           int `json:"renovate_covered"`
+	ConfiguredWithoutActions  int `json:"configured_without_actions"`
+	Uncovered                 int `json:"uncovered"`
+	RepositoriesWithGaps      int `json:"repositories_with_gaps"`
+	OutdatedInUncovered       int `json:"outdated_in_uncovered"`
+}
+
+// analyzeUpdateAutomation reports Dependabot/Renovate coverage of the github-actions ecosystem per repository
+func analyzeUpdateAutomation(org string, startTime time.Time, outputFormat, outputFile string, opts scanOptions) error {
+	repositories, _, err := listRepositoryWorkflows(org, opts)
+	if err != nil {
+		return err
+	}
+
+	eolDB, err := loadEOLDatabase()
+	if err != nil {
+		return err
+	}
+
+	logInfof("🤖 Checking dependency update automation in %d repositories...\n\n", len(repositories))
+
+	report := AutomationReport{Organization: org, Repositories: []RepositoryAutomation{}}
+	latest := make(map[string]string) // action repository -> latest release tag
+	for i, repo := range repositories {
+		if opts.expired() {
+			for _, r := range repositories[i:] {
+				report.RemainingRepositories = append(report.RemainingRepositories, r.Name)
+			}
+			break
+		}
+
+		opts.repositoryStarted(org, repo.Name, len(repo.Workflows))
+		failed := 0
+
+		automation, err := detectUpdateAutomation(org, repo.Name)
+		if err != nil {
+			logWarnf("⚠️  Warning: Could not read update configuration of %s: %v\n", repo.Name, err)
+		}
+
+		for _, workflowPath := range repo.Workflows {
+			stopFetch := opts.Profile.track(stageFetch)
+			actions, err := extractActionsFromFile(org, repo.Name, workflowPath)
+			stopFetch()
+			if err != nil {
+				logWarnf("⚠️  Warning: Could not analyze %s/%s: %v\n", repo.Name, workflowPath, err)
+				failed++
+				continue
+			}
+			if opts.ExcludeGitHubOwned {
+				actions = withoutGitHubOwned(actions, org)
+			}
+			for _, action := range actions {
+				automation.ActionUsages++
+				if actionOutdated(action, eolDB, latest, opts) {
+					automation.OutdatedActions++
+				}
+			}
+		}
+		automation.Gap = automation.OutdatedActions > 0 && !automation.CoversActions
+
+		switch {
+		case automation.CoversActions && automation.Tool == ToolDependabot:
+			report.Summary.DependabotCovered++
+		case automation.CoversActions && automation.Tool == ToolRenovate:
+			report.Summary.RenovateCovered++
+		case automation.Tool != ToolNone:
+			report.Summary.ConfiguredWithoutActions++
+		}
+		if !automation.CoversActions {
+			report.Summary.Uncovered++
+			report.Summary.OutdatedInUncovered += automation.OutdatedActions
+		}
+		if automation.Gap {
+			report.Summary.RepositoriesWithGaps++
+		}
+
+		report.Repositories = append(report.Repositories, automation)
+		opts.repositoryScanned(org, repo.Name, failed)
+	}
+
+	report.Summary.RepositoriesWithWorkflows = len(report.Repositories)
+	report.Truncated = len(report.RemainingRepositories) > 0
+	report.ProcessTimeSeconds = time.Since(startTime).Seconds()
+	reportComplete(org, &report)
+
+	writer, file, err := getOutputWriter(outputFile)
+	if err != nil {
+		return fmt.Errorf("error opening output file: %v", err)
+	}
+	if file != nil {
+		defer file.Close()
+	}
+
+	return outputAutomationReport(report, outputFormat, writer)
+}
+
+// actionOutdated reports whether an action usage is on an end-of-life major or behind the latest release of
+// the action, as --scan outdated compares them. latest memoizes the latest release per action repository;
+// refs that are not versions, such as SHAs and branches, are only checked for end-of-life.
+func actionOutdated(action Action, eolDB *EOLDatabase, latest map[string]string, opts scanOptions) bool {
+	if _, eol := eolDB.lookup(action.Name, action.Version); eol {
+		return true
+	}
+	current, ok := parseVersion(action.Version)
+	if !ok || strings.HasPrefix(action.Name, "./") || strings.HasPrefix(action.Name, "docker://") {
+		return false
+	}
+
+	repository := actionRepository(action.Name)
+	tag, found := latest[repository]
+	if !found {
+		var err error
+		if tag, err = latestActionRelease(opts.Cache, repository); err != nil {
+			logWarnf("⚠️  Warning: Could not look up the latest release of %s: %v\n", repository, err)
+		}
+		latest[repository] = tag
+	}
+	latestVersion, ok := parseVersion(tag)
+	return ok && upgradeLevel(current, latestVersion) != ""
+}
+
+// detectUpdateAutomation finds the Dependabot or Renovate configuration of a repository and
+// whether it covers the github-actions ecosystem
+func detectUpdateAutomation(org, repo string) (RepositoryAutomation, error) {
+	automation := RepositoryAutomation{Name: repo, Tool: ToolNone}
+
+	for _, path := range dependabotConfigPaths {
+		content, err := fetchRepositoryFile(org, repo, path)
+		if errors.Is(err, errFileNotFound) {
+			continue
+		}
+		if err != nil {
+			return automation, err
+		}
+
+		automation.Tool = ToolDependabot
+		automation.ConfigPath = path
+		automation.CoversActions = dependabotCoversActions(content)
+		if automation.CoversActions {
+			return automation, nil
+		}
+		break
+	}
+
+	for _, path := range renovateConfigPaths {
+		content, err := fetchRepositoryFile(org, repo, path)
+		if errors.Is(err, errFileNotFound) {
+			continue
+		}
+		if err != nil {
+			return automation, err
+		}
+
+		// A Renovate config that covers actions wins over a Dependabot config that does not
+		if covers := renovateCoversActions(content); covers || automation.Tool == ToolNone {
+			automation.Tool = ToolRenovate
+			automation.ConfigPath = path
+			automation.CoversActions = covers
+		}
+		break
+	}
+
+	return automation, nil
+}
+
+// dependabotCoversActions reports whether a dependabot.yml has an update entry for github-actions
+func dependabotCoversActions(content string) bool {
+	var config struct {
+		Updates []struct {
+			PackageEcosystem string `yaml:"package-ecosystem"`
+		} `yaml:"updates"`
+	}
+	if err := yaml.Unmarshal([]byte(content), &config); err != nil {
+		return false
+	}
+
+	for _, update := range config.Updates {
+		if update.PackageEcosystem == "github-actions" {
+			return true
+		}
+	}
+	return false
+}
+
+// renovateCoversActions reports whether a Renovate config leaves the github-actions manager enabled.
+// The manager is on by default, so the config only opts out via enabledManagers or an explicit disable.
+func renovateCoversActions(content string) bool {
+	var config struct {
+		Enabled         *bool    `json:"enabled" yaml:"enabled"`
+		EnabledManagers []string `json:"enabledManagers" yaml:"enabledManagers"`
+		GitHubActions   struct {
+			Enabled *bool `json:"enabled" yaml:"enabled"`
+		} `json:"github-actions" yaml:"github-actions"`
+	}
+
+	if err := json.Unmarshal([]byte(content), &config); err != nil {
+		// JSON5 files cannot be decoded with encoding/json, but without comments their unquoted keys,
+		// single-quoted strings, and trailing commas are valid YAML flow syntax
+		if err := yaml.Unmarshal([]byte(stripJSON5Comments(content)), &config); err != nil {
+			if strings.Contains(content, "enabledManagers") {
+				return strings.Contains(content, "github-actions")
+			}
+			return true
+		}
+	}
+
+	if config.Enabled != nil && !*config.Enabled {
+		return false
+	}
+	if config.GitHubActions.Enabled != nil && !*config.GitHubActions.Enabled {
+		return false
+	}
+	if len(config.EnabledManagers) > 0 {
+		for _, manager := range config.EnabledManagers {
+			if manager == "github-actions" {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// stripJSON5Comments blanks the // and /* */ comments of a JSON5 document, leaving strings intact
+func stripJSON5Comments(content string) string {
+	var b strings.Builder
+	var quote byte
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case quote != 0:
+			b.WriteByte(c)
+			if c == '\\' && i+1 < len(content) {
+				i++
+				b.WriteByte(content[i])
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+			b.WriteByte(c)
+		case strings.HasPrefix(content[i:], "//"):
+			for i < len(content) && content[i] != '\n' {
+				i++
+			}
+			b.WriteByte('\n')
+		case strings.HasPrefix(content[i:], "/*"):
+			end := strings.Index(content[i+2:], "*/")
+			if end < 0 {
+				return b.String()
+			}
+			b.WriteByte(' ')
+			i += end + 3
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// automationStatus returns the human-readable coverage status of a repository
+func automationStatus(automation RepositoryAutomation) string {
+	switch {
+	case automation.CoversActions:
+		return "✅ " + automation.Tool
+	case automation.Tool != ToolNone:
+		return "⚠️  " + automation.Tool + " (no github-actions)"
+	default:
+		return "❌ none"
+	}
+}
+
+// outputAutomationReport outputs dependency update coverage in the specified format
+func outputAutomationReport(report AutomationReport, format string, writer io.Writer) error {
+	if formatter, ok := lookupFormatter(format); ok {
+		return formatter.Format(report, writer)
+	}
+
+	switch format {
+	case "table":
+		return outputAutomationTable(report, writer)
+
+	case "csv":
+		return outputAutomationCSV(report, writer)
+
+	default: // "default"
+		fmt.Fprintln(writer, "🤖 Dependency Update Automation Report")
+		fmt.Fprintln(writer, "="+strings.Repeat("=", 50))
+
+		for _, repo := range report.Repositories {
+			fmt.Fprintf(writer, "\n📁 %s: %s\n", repo.Name, automationStatus(repo))
+			if repo.ConfigPath != "" {
+				fmt.Fprintf(writer, "   └─ config: %s\n", repo.ConfigPath)
+			}
+			fmt.Fprintf(writer, "   └─ %d action usages, %d outdated", repo.ActionUsages, repo.OutdatedActions)
+			if repo.Gap {
+				fmt.Fprint(writer, " 🚨 automation gap")
+			}
+			fmt.Fprintln(writer)
+		}
+
+		fmt.Fprintln(writer, "\n📊 Summary:")
+		fmt.Fprintf(writer, "   • Repositories with workflows: %d\n", report.Summary.RepositoriesWithWorkflows)
+		fmt.Fprintf(writer, "   • Covered by Dependabot: %d\n", report.Summary.DependabotCovered)
+		fmt.Fprintf(writer, "   • Covered by Renovate: %d\n", report.Summary.RenovateCovered)
+		fmt.Fprintf(writer, "   • Configured without github-actions: %d\n", report.Summary.ConfiguredWithoutActions)
+		fmt.Fprintf(writer, "   • Not covered: %d\n", report.Summary.Uncovered)
+		fmt.Fprintf(writer, "   • Repositories with automation gaps: %d\n", report.Summary.RepositoriesWithGaps)
+		fmt.Fprintf(writer, "   • Outdated actions in uncovered repositories: %d\n", report.Summary.OutdatedInUncovered)
+		fmt.Fprintf(writer, "   ⏱️  Process time: %.3fs\n", report.ProcessTimeSeconds)
+
+		if report.Truncated {
+			outputTruncationNotice(report.RemainingRepositories, writer)
+		}
+
+		return nil
+	}
+}
+
+// outputAutomationTable outputs dependency update coverage in table format
+func outputAutomationTable(report AutomationReport, writer io.Writer) error {
+	fmt.Fprintln(writer, "╔════════════════════════════════════════════════════════════════════════════════════════════════════╗")
+	fmt.Fprintf(writer, "║                               🤖 DEPENDENCY UPDATE AUTOMATION                                      ║\n")
+	fmt.Fprintln(writer, "╚════════════════════════════════════════════════════════════════════════════════════════════════════╝")
+	fmt.Fprintf(writer, "  🏢 Organization: %-59s \n", report.Organization)
+	fmt.Fprintf(writer, "  ✅ Covered by Dependabot: %-51d \n", report.Summary.DependabotCovered)
+	fmt.Fprintf(writer, "  ✅ Covered by Renovate: %-53d \n", report.Summary.RenovateCovered)
+	fmt.Fprintf(writer, "  ❌ Not Covered: %-61d \n", report.Summary.Uncovered)
+	fmt.Fprintf(writer, "  🚨 Repositories with Gaps: %-50d \n", report.Summary.RepositoriesWithGaps)
+	fmt.Fprintln(writer, " ═════════════════════════════════════════════════════════════════════════════════════════════════════")
+	fmt.Fprintln(writer)
+
+	if len(report.Repositories) == 0 {
+		fmt.Fprintln(writer, "┌─────────────────────────────────────────┐")
+		fmt.Fprintln(writer, "│   No repositories with workflows found  │")
+		fmt.Fprintln(writer, "└─────────────────────────────────────────┘")
+		return nil
+	}
+
+	fmt.Fprintln(writer, "┌─────────────────────────────┬────────────┬─────────┬──────────────────────────────┬─────────┬──────────┬─────┐")
+	fmt.Fprintf(writer, "│ %-26s │ %-10s │ %-7s │ %-28s │ %-7s │ %-8s │ %-3s │\n", "📁 REPOSITORY", "TOOL", "ACTIONS", "CONFIG", "USAGES", "OUTDATED", "GAP")
+	fmt.Fprintln(writer, "├─────────────────────────────┼────────────┼─────────┼──────────────────────────────┼─────────┼──────────┼─────┤")
+
+	for _, repo := range report.Repositories {
+		covers := "no"
+		if repo.CoversActions {
+			covers = "yes"
+		}
+		gap := ""
+		if repo.Gap {
+			gap = "yes"
+		}
+		fmt.Fprintf(writer, "│ %-27s │ %-10s │ %-7s │ %-28s │ %-7d │ %-8d │ %-3s │\n",
+			truncate(repo.Name, 27), repo.Tool, covers, truncate(repo.ConfigPath, 28), repo.ActionUsages, repo.OutdatedActions, gap)
+	}
+
+	fmt.Fprintln(writer, "└─────────────────────────────┴────────────┴─────────┴──────────────────────────────┴─────────┴──────────┴─────┘")
+	fmt.Fprintln(writer)
+	return nil
+}
+
+// outputAutomationCSV outputs dependency update coverage in CSV format
+func outputAutomationCSV(report AutomationReport, writer io.Writer) error {
+	w := newCSVWriter(writer)
+	w.Write([]string{"Repository", "Tool", "Config", "Covers Actions", "Action Usages", "Outdated Actions", "Gap"})
+	for _, repo := range report.Repositories {
+		w.Write([]string{repo.Name, repo.Tool, repo.ConfigPath, strconv.FormatBool(repo.CoversActions),
+			strconv.Itoa(repo.ActionUsages), strconv.Itoa(repo.OutdatedActions), strconv.FormatBool(repo.Gap)})
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Badge is a shields.io endpoint badge; the same data renders the SVG
+type Badge struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color"`
+}
+
+// badgeColors maps shields.io color names to their hex values
+var badgeColors = map[string]string{
+	"brightgreen": "#4c1",
+	"yellow":      "#dfb317",
+	"orange":      "#fe7d37",
+	"red":         "#e05d44",
+	"lightgrey":   "#9f9f9f",
+}
+
+// pinningBadge rates the share of action usages pinned to a commit SHA
+func pinningBadge(pinned, total int) Badge {
+	if total == 0 {
+		return Badge{SchemaVersion: 1, Label: "pinning", Message: "no actions", Color: "lightgrey"}
+	}
+
+	score := pinned * 100 / total
+	color := "red"
+	switch {
+	case score >= 90:
+		color = "brightgreen"
+	case score >= 70:
+		color = "yellow"
+	case score >= 50:
+		color = "orange"
+	}
+	return Badge{SchemaVersion: 1, Label: "pinning", Message: fmt.Sprintf("%d%%", score), Color: color}
+}
+
+// complianceBadge summarizes the findings of a repository
+func complianceBadge(findings []Finding) Badge {
+	counts := make(map[string]int)
+	for _, finding := range findings {
+		counts[finding.Severity]++
+	}
+
+	switch {
+	case counts[SeverityError] > 0:
+		return Badge{SchemaVersion: 1, Label: "action policy", Message: fmt.Sprintf("%d errors", counts[SeverityError]), Color: "red"}
+	case counts[SeverityWarning] > 0:
+		return Badge{SchemaVersion: 1, Label: "action policy", Message: fmt.Sprintf("%d warnings", counts[SeverityWarning]), Color: "yellow"}
+	}
+	return Badge{SchemaVersion: 1, Label: "action policy", Message: "passing", Color: "brightgreen"}
+}
+
+// badgeTextWidth estimates the rendered width of badge text in Verdana 11px
+func badgeTextWidth(text string) int {
+	return len([]rune(text))*7 + 10
+}
+
+// renderBadgeSVG renders a flat shields.io-style badge
+func renderBadgeSVG(b Badge) string {
+	labelWidth := badgeTextWidth(b.Label)
+	messageWidth := badgeTextWidth(b.Message)
+	width := labelWidth + messageWidth
+	color := badgeColors[b.Color]
+	label := html.EscapeString(b.Label)
+	message := html.EscapeString(b.Message)
+
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s: %s">
+<title>%s: %s</title>
+<linearGradient id="s" x2="0" y2="100%%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>
+<clipPath id="r"><rect width="%d" height="20" rx="3" fill="#fff"/></clipPath>
+<g clip-path="url(#r)"><rect width="%d" height="20" fill="#555"/><rect x="%d" width="%d" height="20" fill="%s"/><rect width="%d" height="20" fill="url(#s)"/></g>
+<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">
+<text x="%d" y="14">%s</text><text x="%d" y="14">%s</text>
+</g>
+</svg>
+`, width, label, message, label, message, width, labelWidth, labelWidth, messageWidth, color, width,
+		labelWidth/2, label, labelWidth+messageWidth/2, message)
+}
+
+// badgeFileName builds a flat file name usable both on disk and in a gist, e.g. "api.ci.pinning"
+func badgeFileName(parts ...string) string {
+	name := strings.Join(parts, ".")
+	return strings.NewReplacer("/", "__", " ", "-").Replace(name)
+}
+
+// generateBadges builds the badges of a detailed report keyed by file name without extension.
+// Every repository gets a pinning and a compliance badge and every workflow a pinning badge.
+func generateBadges(report ComprehensiveReport) map[string]Badge {
+	badges := make(map[string]Badge)
+
+	findingsByRepo := make(map[string][]Finding)
+	for _, finding := range report.Findings {
+		findingsByRepo[finding.Repository] = append(findingsByRepo[finding.Repository], finding)
+	}
+
+	orgPinned, orgTotal := 0, 0
+	for _, repo := range report.Repositories {
+		repoPinned, repoTotal := 0, 0
+		for _, workflow := range repo.Workflows {
+			pinned, total := 0, 0
+			for _, action := range workflow.Actions {
+				total += action.Count
+				if isPinnedToSHA(action.Version) {
+					pinned += action.Count
+				}
+			}
+			workflowName := strings.TrimSuffix(strings.TrimSuffix(path.Base(workflow.Path), ".yml"), ".yaml")
+			badges[badgeFileName(repo.Name, workflowName, "pinning")] = pinningBadge(pinned, total)
+			repoPinned += pinned
+			repoTotal += total
+		}
+
+		badges[badgeFileName(repo.Name, "pinning")] = pinningBadge(repoPinned, repoTotal)
+		badges[badgeFileName(repo.Name, "compliance")] = complianceBadge(findingsByRepo[repo.Name])
+		orgPinned += repoPinned
+		orgTotal += repoTotal
+	}
+
+	badges[badgeFileName("_organization", "pinning")] = pinningBadge(orgPinned, orgTotal)
+	badges[badgeFileName("_organization", "compliance")] = complianceBadge(report.Findings)
+	return badges
+}
+
+// badgeFiles renders every badge as an SVG and a shields.io endpoint JSON file
+func badgeFiles(badges map[string]Badge) (map[string]string, error) {
+	files := make(map[string]string)
+	for name, badge := range badges {
+		data, err := json.Marshal(badge)
+		if err != nil {
+			return nil, err
+		}
+		files[name+".json"] = string(data)
+		files[name+".svg"] = renderBadgeSVG(badge)
+	}
+	return files, nil
+}
+
+// writeBadges writes badge files to a directory, e.g. one published with GitHub Pages
+func writeBadges(dir string, files map[string]string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create badge directory: %v", err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			return fmt.Errorf("failed to write badge %s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// publishBadgesToGist replaces the badge files of an existing gist
+func publishBadgesToGist(gistID string, files map[string]string) error {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	payload := map[string]map[string]map[string]string{"files": {}}
+	for _, name := range names {
+		payload["files"][name] = map[string]string{"content": files[name]}
+	}
+
+	if err := restSend("PATCH", "gists/"+gistID, payload, nil); err != nil {
+		return fmt.Errorf("failed to update gist %s: %v", gistID, err)
+	}
+	return nil
+}
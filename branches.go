@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// branchSeparator joins a workflow path and the branch it was found on, e.g.
+// .github/workflows/deploy.yml@feature/new-deploy
+const branchSeparator = "@"
+
+// branchWorkflowPath returns the path under which a workflow found on a non-default branch is reported
+func branchWorkflowPath(workflowPath, branch string) string {
+	return workflowPath + branchSeparator + branch
+}
+
+// splitBranchWorkflow splits a reported workflow path into the path and the branch it was found on;
+// the branch is empty for workflows of the scanned ref
+func splitBranchWorkflow(workflowPath string) (string, string) {
+	if !strings.HasPrefix(workflowPath, githubWorkflowsDir+"/") {
+		return workflowPath, ""
+	}
+	file, branch, ok := strings.Cut(workflowPath, branchSeparator)
+	if !ok {
+		return workflowPath, ""
+	}
+	return file, branch
+}
+
+// scansBranch reports whether a branch matches one of the --branches patterns. A lone * matches every
+// branch, including names with slashes such as feature/login.
+func (o scanOptions) scansBranch(branch string) bool {
+	for _, pattern := range o.Branches {
+		if ok, _ := path.Match(pattern, branch); ok || pattern == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// listBranchWorkflows returns the workflow files of a repository's branches matching --branches, other
+// than the scanned one, whose content was not seen yet. seen holds the blob SHAs of the workflows already
+// listed, so a workflow that is identical on several branches is reported once. The content of each
+// returned file is recorded with the listed workflows.
+func listBranchWorkflows(org, repo, scannedBranch string, seen map[string]bool, opts scanOptions) ([]string, error) {
+	var branches []string
+	for page := 1; ; page++ {
+		var entries []struct {
+			Name string `json:"name"`
+		}
+		if err := restGet(fmt.Sprintf("repos/%s/%s/branches?per_page=100&page=%d", org, repo, page), &entries); err != nil {
+			return nil, fmt.Errorf("failed to list the branches of %s: %v", repo, err)
+		}
+		for _, entry := range entries {
+			if entry.Name != scannedBranch && opts.scansBranch(entry.Name) {
+				branches = append(branches, entry.Name)
+			}
+		}
+		if len(entries) < 100 {
+			break
+		}
+	}
+
+	var files []string
+	for _, branch := range branches {
+		if opts.interrupted() {
+			break
+		}
+		var entries []struct {
+			Name string `json:"name"`
+			Path string `json:"path"`
+			Type string `json:"type"`
+			SHA  string `json:"sha"`
+		}
+		err := restGet(fmt.Sprintf("repos/%s/%s/contents/%s?ref=%s", org, repo, githubWorkflowsDir, url.QueryEscape(branch)), &entries)
+		if err == errFileNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the workflows of %s on %s: %v", repo, branch, err)
+		}
+		for _, entry := range entries {
+			if entry.Type != "file" || !isYAMLFile(entry.Name) || !opts.includesWorkflow(entry.Path) || seen[entry.SHA] {
+				continue
+			}
+			content, err := fetchRepositoryFileAtRef(org, repo, entry.Path, branch)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch %s of %s on %s: %v", entry.Path, repo, branch, err)
+			}
+			seen[entry.SHA] = true
+			reported := branchWorkflowPath(entry.Path, branch)
+			listedWorkflows.store(org, repo, reported, content)
+			files = append(files, reported)
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ReportBranding is the custom title, logo, and free-form metadata (ticket number, audit period, ...)
+// shown at the top of HTML, Markdown, and table reports so they can be filed as audit evidence as is
+type ReportBranding struct {
+	Title    string            `yaml:"title" json:"title,omitempty"`
+	Logo     string            `yaml:"logo" json:"logo,omitempty"` // image URL or path relative to the HTML file
+	Metadata map[string]string `yaml:"metadata" json:"metadata,omitempty"`
+}
+
+// metadataFlag collects repeated --report-meta key=value flags
+type metadataFlag map[string]string
+
+// String implements flag.Value
+func (m metadataFlag) String() string {
+	var pairs []string
+	for _, key := range sortedKeys(m) {
+		pairs = append(pairs, key+"="+m[key])
+	}
+	return strings.Join(pairs, ",")
+}
+
+// Set implements flag.Value
+func (m metadataFlag) Set(value string) error {
+	key, val, ok := strings.Cut(value, "=")
+	if key = strings.TrimSpace(key); !ok || key == "" {
+		return fmt.Errorf("expected key=value, got '%s'", value)
+	}
+	m[key] = strings.TrimSpace(val)
+	return nil
+}
+
+// reportBranding merges the branding flags over the report: section of the config file and returns
+// nil when nothing is set
+func reportBranding(config *Config, title, logo string, metadata map[string]string) *ReportBranding {
+	branding := ReportBranding{Metadata: make(map[string]string)}
+	if config != nil && config.Report != nil {
+		branding.Title = config.Report.Title
+		branding.Logo = config.Report.Logo
+		for key, value := range config.Report.Metadata {
+			branding.Metadata[key] = value
+		}
+	}
+	if title != "" {
+		branding.Title = title
+	}
+	if logo != "" {
+		branding.Logo = logo
+	}
+	for key, value := range metadata {
+		branding.Metadata[key] = value
+	}
+
+	if branding.Title == "" && branding.Logo == "" && len(branding.Metadata) == 0 {
+		return nil
+	}
+	if len(branding.Metadata) == 0 {
+		branding.Metadata = nil
+	}
+	return &branding
+}
+
+// title returns the custom title, or fallback when none is set
+func (b *ReportBranding) title(fallback string) string {
+	if b == nil || b.Title == "" {
+		return fallback
+	}
+	return b.Title
+}
+
+// metadataKeys returns the metadata keys in alphabetical order
+func (b *ReportBranding) metadataKeys() []string {
+	if b == nil {
+		return nil
+	}
+	return sortedKeys(b.Metadata)
+}
+
+// writeText writes the title and metadata lines at the top of a table report
+func (b *ReportBranding) writeText(w io.Writer) {
+	if b == nil {
+		return
+	}
+	if b.Title != "" {
+		fmt.Fprintf(w, "  📋 %s\n", b.Title)
+	}
+	for _, key := range b.metadataKeys() {
+		fmt.Fprintf(w, "  🏷️  %s: %s\n", key, b.Metadata[key])
+	}
+}
+
+// writeMarkdown writes the title, logo, and metadata list at the top of a Markdown report; without a
+// custom title the report keeps its own heading
+func (b *ReportBranding) writeMarkdown(w io.Writer) {
+	if b == nil {
+		return
+	}
+	if b.Logo != "" {
+		fmt.Fprintf(w, "![logo](%s)\n\n", b.Logo)
+	}
+	if b.Title != "" {
+		fmt.Fprintf(w, "# %s\n\n", b.Title)
+	}
+	for _, key := range b.metadataKeys() {
+		fmt.Fprintf(w, "- **%s:** %s\n", key, b.Metadata[key])
+	}
+	if len(b.Metadata) > 0 {
+		fmt.Fprintln(w)
+	}
+}
+
+// writeHTML writes the logo and metadata list below the heading of an HTML page
+func (b *ReportBranding) writeHTML(w io.Writer) {
+	if b == nil {
+		return
+	}
+	if b.Logo != "" {
+		fmt.Fprintf(w, "<img class=\"logo\" src=\"%s\" alt=\"logo\">\n", html.EscapeString(b.Logo))
+	}
+	if len(b.Metadata) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "<dl class=\"metadata\">")
+	for _, key := range b.metadataKeys() {
+		fmt.Fprintf(w, "<dt>%s</dt><dd>%s</dd>\n", html.EscapeString(key), html.EscapeString(b.Metadata[key]))
+	}
+	fmt.Fprintln(w, "</dl>")
+}
+
+// sortedKeys returns the keys of a map in alphabetical order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// scanCommand is a subcommand that runs a scan. It is a preset of the top-level scan flags, which stay
+// available unchanged for backwards compatibility: `gh action-lens pin -o myorg` runs the same scan
+// as `gh action-lens --scan pinning -o myorg`.
+type scanCommand struct {
+	Name        string
+	Synopsis    string            // arguments after the command name
+	Description string            // one line for the command list
+	Positional  []string          // flags set from the leading positional arguments, in order
+	Required    int               // positional arguments that must be given
+	Implies     map[string]string // flags the command sets
+	Examples    []string
+}
+
+// shortFlags maps the one-letter aliases of flags a command can imply to their names
+var shortFlags = map[string]string{"s": "scan", "d": "detailed"}
+
+// scanCommands are the scan subcommands in the order of the help text
+var scanCommands = []scanCommand{
+	{
+		Name:        "scan",
+		Synopsis:    "[scope] [flags]",
+		Description: "Scan workflows and actions; the scope is any --scan value (default all)",
+		Positional:  []string{"scan"},
+		Examples: []string{
+			"gh action-lens scan -o myorg",
+			"gh action-lens scan secrets -o myorg --format json",
+		},
+	},
+	{
+		Name:        "report",
+		Synopsis:    "[flags]",
+		Description: "Detailed report of repositories, actions, versions, and findings",
+		Implies:     map[string]string{"scan": "all", "detailed": "true"},
+		Examples: []string{
+			"gh action-lens report -o myorg --format html --output dashboard.html",
+			"gh action-lens report --enterprise acme --format json --output acme.json",
+		},
+	},
+	{
+		Name:        "policy check",
+		Synopsis:    "<policy.yml> [flags]",
+		Description: "Check every action against the allow/deny rules of a policy file; exits 3 on violations",
+		Positional:  []string{"policy"},
+		Required:    1,
+		Examples: []string{
+			"gh action-lens policy check policy.yml -o myorg",
+			"gh action-lens policy check policy.yml -o myorg --enforce warn",
+		},
+	},
+	{
+		Name:        "pin",
+		Synopsis:    "[flags]",
+		Description: "Tag- and branch-pinned action references with the commit SHA to pin to",
+		Implies:     map[string]string{"scan": "pinning"},
+		Examples: []string{
+			"gh action-lens pin -o myorg",
+			"gh action-lens pin -o myorg --fail-on unpinned",
+			"gh action-lens pin -o myorg --dry-run   # diffs pinning tags to their SHAs (gh action-lens pin --dry-run --help)",
+			"gh action-lens pin -o myorg --apply     # one pull request per repository",
+		},
+	},
+}
+
+// lookupScanCommand returns the scan command named by the leading arguments and the arguments after
+// its name; false when they do not start with one
+func lookupScanCommand(args []string) (scanCommand, []string, bool) {
+	for _, command := range scanCommands {
+		words := strings.Fields(command.Name)
+		if len(args) >= len(words) && strings.Join(args[:len(words)], " ") == command.Name {
+			return command, args[len(words):], true
+		}
+	}
+	return scanCommand{}, nil, false
+}
+
+// expand turns the arguments of the command into top-level scan flags: leading positional arguments
+// become their flags and the implied flags are appended
+func (c scanCommand) expand(args []string) ([]string, error) {
+	var positional []string
+	for len(args) > 0 && len(positional) < len(c.Positional) && !strings.HasPrefix(args[0], "-") {
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+	if len(positional) < c.Required {
+		return nil, fmt.Errorf("missing arguments; usage: gh action-lens %s %s", c.Name, c.Synopsis)
+	}
+
+	var expanded []string
+	for i, value := range positional {
+		expanded = append(expanded, "--"+c.Positional[i], value)
+	}
+	return append(expanded, args...), nil
+}
+
+// apply sets the flags the command implies after parsing. Flags the command sets itself cannot be
+// passed, and arguments after the flags are rejected instead of being ignored.
+func (c scanCommand) apply(flags *flag.FlagSet) error {
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected argument '%s' for gh action-lens %s; arguments go before the flags", flags.Arg(0), c.Name)
+	}
+	var conflict string
+	flags.Visit(func(f *flag.Flag) {
+		name := f.Name
+		if long, ok := shortFlags[name]; ok {
+			name = long
+		}
+		if _, ok := c.Implies[name]; ok {
+			conflict = name
+		}
+	})
+	if conflict != "" {
+		return fmt.Errorf("--%s cannot be used with gh action-lens %s", conflict, c.Name)
+	}
+	for name, value := range c.Implies {
+		if err := flags.Set(name, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// usage prints the help of a scan command
+func (c scanCommand) usage() {
+	fmt.Fprintf(os.Stderr, "\nUsage:\n")
+	fmt.Fprintf(os.Stderr, "  gh action-lens %s %s\n\n", c.Name, c.Synopsis)
+	fmt.Fprintf(os.Stderr, "%s.\n\n", c.Description)
+	fmt.Fprintf(os.Stderr, "Flags:\n")
+	fmt.Fprintf(os.Stderr, "  Every flag of the top-level scan (gh action-lens --help)")
+	if len(c.Implies) > 0 {
+		var implied []string
+		for _, name := range []string{"scan", "detailed"} {
+			if _, ok := c.Implies[name]; ok {
+				implied = append(implied, "--"+name)
+			}
+		}
+		fmt.Fprintf(os.Stderr, " except %s, which this command sets", strings.Join(implied, " and "))
+	}
+	fmt.Fprintf(os.Stderr, "\n\nExamples:\n")
+	for _, example := range c.Examples {
+		fmt.Fprintf(os.Stderr, "  %s\n", example)
+	}
+	fmt.Fprintln(os.Stderr)
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultTransitiveDepth is how many levels of composite actions --transitive resolves by default
+const defaultTransitiveDepth = 3
+
+// actionMetadata is the subset of an action's action.yml used to classify it and resolve composite steps
+type actionMetadata struct {
+	Name  string   `json:"name"`
+	Using string   `json:"using"`          // runs.using: node20, docker, composite, ...
+	Uses  []string `json:"uses,omitempty"` // uses: of the steps of a composite action
+}
+
+// actionDefinition is the YAML layout of action.yml
+type actionDefinition struct {
+	Name string `yaml:"name"`
+	Runs struct {
+		Using string `yaml:"using"`
+		Steps []struct {
+			Uses string `yaml:"uses"`
+		} `yaml:"steps"`
+	} `yaml:"runs"`
+}
+
+// actionMetadataFiles are the file names an action's metadata can have
+var actionMetadataFiles = []string{"action.yml", "action.yaml"}
+
+// fetchActionMetadata reads the action.yml of an action reference: owner/repo[/path]@ref, or a local
+// ./path of the given repository, read from its default branch. Remote lookups go through the
+// action-yml kind of the enrichment cache.
+func fetchActionMetadata(cache *enrichmentCache, org, repo, action, ref string) (actionMetadata, error) {
+	if strings.HasPrefix(action, "docker://") || isReusableWorkflowCall(action) {
+		return actionMetadata{}, errFileNotFound
+	}
+
+	owner, name, dir := org, repo, strings.TrimPrefix(action, "./")
+	if !strings.HasPrefix(action, "./") {
+		parts := strings.SplitN(action, "/", 3)
+		if len(parts) < 2 {
+			return actionMetadata{}, errFileNotFound
+		}
+		owner, name, dir = parts[0], parts[1], ""
+		if len(parts) == 3 {
+			dir = parts[2]
+		}
+	}
+
+	read := func(metadata *actionMetadata) error {
+		for _, file := range actionMetadataFiles {
+			content, err := fetchRepositoryFileAtRef(owner, name, path.Join(dir, file), ref)
+			if err == errFileNotFound {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			var definition actionDefinition
+			if err := yaml.Unmarshal([]byte(content), &definition); err != nil {
+				return fmt.Errorf("failed to parse %s: %v", file, err)
+			}
+			metadata.Name = definition.Name
+			metadata.Using = strings.ToLower(definition.Runs.Using)
+			for _, step := range definition.Runs.Steps {
+				if step.Uses != "" {
+					metadata.Uses = append(metadata.Uses, strings.TrimSpace(step.Uses))
+				}
+			}
+			return nil
+		}
+		return errFileNotFound
+	}
+
+	var metadata actionMetadata
+	if strings.HasPrefix(action, "./") {
+		// Local actions change with the default branch, so they are not cached
+		return metadata, read(&metadata)
+	}
+	err := cache.fetch(EnrichmentActionYAML, action+"@"+ref, &metadata, func() error {
+		return read(&metadata)
+	})
+	return metadata, err
+}
+
+// localActionUses returns the steps of a workflow that use a local action (./path), which carry no ref
+// and are therefore not part of the action inventory
+func localActionUses(content string) []Action {
+	documents, err := decodeYAMLDocuments(content)
+	if err != nil {
+		return nil
+	}
+
+	var actions []Action
+	for _, document := range documents {
+		jobs, _ := yamlMap(document["jobs"])
+		for _, jobID := range sortedYAMLKeys(jobs) {
+			job, ok := yamlMap(jobs[jobID])
+			if !ok {
+				continue
+			}
+			steps, _ := job["steps"].([]interface{})
+			for i, item := range steps {
+				step, ok := yamlMap(item)
+				if !ok {
+					continue
+				}
+				if uses, ok := step["uses"].(string); ok && strings.HasPrefix(strings.TrimSpace(uses), "./") {
+					actions = append(actions, Action{Name: strings.TrimSpace(uses), Job: jobID, Step: i + 1})
+				}
+			}
+		}
+	}
+	return actions
+}
+
+// resolveTransitiveActions returns the actions used inside the composite actions a workflow uses, down to
+// the given depth. Each returned action names the chain of composite actions it was reached through;
+// a composite action that appears again in its own chain is a cycle and is not descended into twice.
+func resolveTransitiveActions(org, repo, content string, direct []Action, depth int, cache *enrichmentCache) []Action {
+	var transitive []Action
+
+	var visit func(parent Action, chain []string, level int)
+	visit = func(parent Action, chain []string, level int) {
+		if level > depth {
+			return
+		}
+		metadata, err := fetchActionMetadata(cache, org, repo, parent.Name, parent.Version)
+		if err != nil || metadata.Using != "composite" {
+			return
+		}
+
+		reference := parent.Name
+		if parent.Version != "" {
+			reference += "@" + parent.Version
+		}
+		for _, seen := range chain {
+			if seen == reference {
+				return
+			}
+		}
+		chain = append(chain[:len(chain):len(chain)], reference)
+
+		for _, uses := range metadata.Uses {
+			child := Action{Job: parent.Job, Step: parent.Step, Via: strings.Join(chain, " → ")}
+			if strings.HasPrefix(uses, "./") {
+				// Local paths inside a composite action refer to the workspace of the calling repository
+				child.Name = uses
+			} else if name, ref, ok := splitActionReference(uses); ok {
+				child.Name, child.Version = name, ref
+				transitive = append(transitive, child)
+			} else {
+				continue
+			}
+			visit(child, chain, level+1)
+		}
+	}
+
+	for _, action := range append(localActionUses(content), direct...) {
+		visit(action, nil, 1)
+	}
+	return transitive
+}
@@ -0,0 +1,383 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultConcurrency is the number of workflow files fetched in parallel unless --concurrency is set
+const defaultConcurrency = 8
+
+// maxRateLimitRetries bounds how often one request is retried after a rate-limit response
+const maxRateLimitRetries = 3
+
+// workflowFetch is the result of fetching and parsing one workflow file
+type workflowFetch struct {
+	Actions    []Action
+	Containers []ContainerImage // job and service container images
+	Local      []LocalAction    // steps using an action of the same repository (./path)
+	Err        error
+	Skipped    bool // not started because the scan deadline passed before its repository was started
+}
+
+// fetchWorkflows fetches and parses workflow files through visitWorkflows. Results are returned in the
+// order of files, with the files skipped after the deadline marked. done, if not nil, is called for every
+// fetched file as it completes; calls are serialized.
+func fetchWorkflows(org string, files []WorkflowFile, opts scanOptions, done func(WorkflowFile, workflowFetch)) []workflowFetch {
+	results := make([]workflowFetch, len(files))
+	skipped := visitWorkflows(org, files, opts, func(i int, content string) error {
+		actions, err := parseActionsFromYAML(content)
+		var containers []ContainerImage
+		var local []LocalAction
+		if err == nil {
+			containers, err = parseContainerImages(content)
+		}
+		if err == nil {
+			local = localActions(content)
+		}
+		if err == nil && opts.Transitive > 0 {
+			actions = append(actions, resolveTransitiveActions(org, files[i].Repo, content, actions, opts.Transitive, opts.Cache)...)
+		}
+		if err == nil && opts.ExcludeGitHubOwned {
+			actions = withoutGitHubOwned(actions, org)
+		}
+		results[i] = workflowFetch{Actions: actions, Containers: containers, Local: local}
+		return err
+	}, func(i int, err error) {
+		results[i].Err = err
+		if done != nil {
+			done(files[i], results[i])
+		}
+	})
+
+	for i := range results {
+		results[i].Skipped = skipped[i]
+	}
+	return results
+}
+
+// visitWorkflows fetches workflow files with at most opts.Concurrency requests in flight, fewer while the
+// rate-limit gate has reduced the worker limit, and calls parse with the content of every fetched file;
+// parse calls run concurrently. Once the deadline passes, files of repositories that have not been started
+// are skipped while started repositories are finished; the returned slice marks the skipped files. done, if
+// not nil, is called for every fetched file as it completes, with the fetch or parse error; calls are
+// serialized.
+func visitWorkflows(org string, files []WorkflowFile, opts scanOptions, parse func(i int, content string) error, done func(i int, err error)) []bool {
+	skipped := make([]bool, len(files))
+	started := make(map[string]bool)
+	pending := make(map[string]int) // repository -> workflow files not yet fetched
+	failed := make(map[string]int)  // repository -> workflow files that could not be analyzed
+	for _, wf := range files {
+		pending[wf.Repo]++
+	}
+	var mu sync.Mutex
+
+	runConcurrently(len(files), opts.Concurrency, func(i int) {
+		wf := files[i]
+
+		mu.Lock()
+		if !started[wf.Repo] {
+			if opts.expired() {
+				mu.Unlock()
+				skipped[i] = true
+				return
+			}
+			started[wf.Repo] = true
+			opts.repositoryStarted(org, wf.Repo, pending[wf.Repo])
+		}
+		mu.Unlock()
+
+		apiRateLimit.acquire()
+		stopFetch := opts.Profile.track(stageFetch)
+		content, err := fetchWorkflowContent(org, wf.Repo, wf.Path)
+		if err == nil {
+			err = parse(i, content)
+		}
+		stopFetch()
+		apiRateLimit.release()
+
+		mu.Lock()
+		defer mu.Unlock()
+		if done != nil {
+			done(i, err)
+		}
+		if err != nil {
+			failed[wf.Repo]++
+		}
+		if pending[wf.Repo]--; pending[wf.Repo] == 0 {
+			opts.repositoryScanned(org, wf.Repo, failed[wf.Repo])
+		}
+	})
+
+	return skipped
+}
+
+// forEachWorkflowDefinition fetches and parses the workflow files of repositories through visitWorkflows
+// and calls visit with every definition in the order of the files, so reports do not depend on the order
+// fetches complete in. Files that cannot be fetched or parsed are logged as warnings. It returns the
+// repositories not started before the deadline.
+func forEachWorkflowDefinition(org string, repositories []RepositoryWorkflows, opts scanOptions, visit func(repo, workflowPath string, definition *workflowDefinition)) []string {
+	var files []WorkflowFile
+	for _, repo := range repositories {
+		for _, workflowPath := range repo.Workflows {
+			files = append(files, WorkflowFile{Repo: repo.Name, Path: workflowPath})
+		}
+	}
+
+	definitions := make([]*workflowDefinition, len(files))
+	skipped := visitWorkflows(org, files, opts, func(i int, content string) error {
+		definition, err := parseWorkflowDefinition(content)
+		if err == nil {
+			definitions[i] = definition
+		}
+		return err
+	}, func(i int, err error) {
+		if err != nil {
+			logWarnf("⚠️  Warning: Could not analyze %s/%s: %v\n", files[i].Repo, files[i].Path, err)
+		}
+	})
+
+	var remaining []WorkflowFile
+	for i, wf := range files {
+		switch {
+		case skipped[i]:
+			remaining = append(remaining, wf)
+		case definitions[i] != nil:
+			visit(wf.Repo, wf.Path, definitions[i])
+		}
+	}
+	return remainingRepositories(remaining)
+}
+
+// runConcurrently calls fn for every index below n using a pool of workers.
+// Indexes are handed out in ascending order.
+func runConcurrently(n, workers int, fn func(i int)) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+// rampUpAfter is the number of consecutive healthy responses after which one worker is added back
+const rampUpAfter = 50
+
+// rateLimitGate pauses all API requests of the process while GitHub reports an exhausted primary
+// or a secondary rate limit, so parallel workers back off together. It also adapts the number of
+// workers allowed to run: secondary rate limits and abuse detection halve it, and it ramps back up
+// by one worker per rampUpAfter healthy responses until it reaches --concurrency. While the budget
+// of a rate limit runs low, requests against it are spread out until the reset.
+type rateLimitGate struct {
+	mu      sync.Mutex
+	slots   *sync.Cond
+	until   time.Time
+	limit   int                         // workers currently allowed to run
+	max     int                         // --concurrency
+	active  int                         // workers currently running
+	healthy int                         // responses since the last rate limit or ramp-up
+	budgets map[string]*rateLimitBudget // resource -> last known budget
+	done    <-chan struct{}             // closed when the scan is interrupted, which ends pauses early
+	events  *eventStream                // receives rate_limit_pause and concurrency_changed events
+}
+
+// newRateLimitGate creates a gate allowing max concurrent workers
+func newRateLimitGate(max int) *rateLimitGate {
+	g := &rateLimitGate{limit: max, max: max, budgets: make(map[string]*rateLimitBudget)}
+	g.slots = sync.NewCond(&g.mu)
+	return g
+}
+
+// apiRateLimit is shared by every API request
+var apiRateLimit = newRateLimitGate(defaultConcurrency)
+
+// apiClient sends requests through apiRateLimit; the GraphQL client uses it as its base transport
+var apiClient = &http.Client{Transport: &rateLimitedTransport{base: http.DefaultTransport}}
+
+// setConcurrency sets the maximum number of concurrent workers and starts at that limit
+func (g *rateLimitGate) setConcurrency(n int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.limit, g.max = n, n
+	g.slots.Broadcast()
+}
+
+// acquire blocks until the current worker limit allows one more worker
+func (g *rateLimitGate) acquire() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for g.active >= g.limit {
+		g.slots.Wait()
+	}
+	g.active++
+}
+
+// release frees the slot of a finished worker
+func (g *rateLimitGate) release() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.active--
+	g.slots.Broadcast()
+}
+
+// wait blocks until the current pause, if any, is over or the scan is interrupted
+func (g *rateLimitGate) wait() {
+	g.mu.Lock()
+	until := g.until
+	g.mu.Unlock()
+	sleepInterruptibly(time.Until(until), g.done)
+}
+
+// pause extends the current pause to at least d and reports whether it was extended
+func (g *rateLimitGate) pause(d time.Duration) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	until := time.Now().Add(d)
+	if !until.After(g.until) {
+		return false
+	}
+	g.until = until
+	logWarnf("⏳ GitHub rate limit reached; pausing requests for %s\n", d.Round(time.Second))
+	g.events.emit(Event{Type: EventRateLimitPause, PauseSeconds: d.Seconds()})
+	return true
+}
+
+// backOff halves the worker limit after a secondary rate limit
+func (g *rateLimitGate) backOff() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.healthy = 0
+	if g.limit > 1 {
+		g.limit /= 2
+		logWarnf("🐢 Reducing concurrency to %d after a secondary rate limit\n", g.limit)
+		g.events.emit(Event{Type: EventConcurrencyChanged, Concurrency: g.limit})
+	}
+}
+
+// recordHealthy counts a response without rate limiting and ramps the worker limit back up
+func (g *rateLimitGate) recordHealthy() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.limit >= g.max {
+		return
+	}
+	if g.healthy++; g.healthy >= rampUpAfter {
+		g.healthy = 0
+		g.limit++
+		g.events.emit(Event{Type: EventConcurrencyChanged, Concurrency: g.limit})
+		g.slots.Broadcast()
+	}
+}
+
+// isSecondaryRateLimit reports whether a response is a secondary rate limit or abuse detection
+// rejection. These carry a Retry-After header or say so in the message body.
+func isSecondaryRateLimit(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return false
+	}
+	if resp.Header.Get("Retry-After") != "" {
+		return true
+	}
+
+	// Read the (short) error body and put it back for the caller
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+	message := strings.ToLower(string(body))
+	return strings.Contains(message, "secondary rate limit") || strings.Contains(message, "abuse")
+}
+
+// observe inspects the response to the attempt-th try of a request, recording the remaining budget
+// and pausing the gate and adapting the worker limit when it reports a rate limit. It reports whether
+// the request was rejected and should be retried.
+func (g *rateLimitGate) observe(resp *http.Response, attempt int) bool {
+	g.recordBudget(resp)
+	exhausted := resp.Header.Get("X-RateLimit-Remaining") == "0"
+	rejected := resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests
+
+	switch {
+	case isSecondaryRateLimit(resp):
+		// Without Retry-After GitHub asks to wait at least a minute, longer on every retry
+		d := time.Minute << attempt
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			d = time.Duration(seconds) * time.Second
+		}
+		if g.pause(d) {
+			g.backOff()
+		}
+		return true
+	case exhausted:
+		// The primary limit is exhausted: wait for the reset even if this request succeeded
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			g.pause(time.Until(time.Unix(reset, 0)) + time.Second)
+		} else if rejected {
+			g.pause(time.Minute)
+		}
+		return rejected
+	}
+	g.recordHealthy()
+	return false
+}
+
+// rateLimitedTransport sends requests through the shared rate-limit gate, retrying requests that
+// were rejected by a rate limit
+type rateLimitedTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if offline {
+		return nil, errOffline
+	}
+	for attempt := 0; ; attempt++ {
+		apiRateLimit.wait()
+		apiRateLimit.throttle(rateLimitResource(req))
+		apiCalls.Add(1)
+		start := time.Now()
+		resp, err := t.base.RoundTrip(req)
+		if err != nil {
+			logDebugf("🌐 %s %s failed after %s: %v\n", req.Method, req.URL.Path, time.Since(start).Round(time.Millisecond), err)
+			return nil, err
+		}
+		logDebugf("🌐 %s %s → %d in %s\n", req.Method, req.URL.Path, resp.StatusCode, time.Since(start).Round(time.Millisecond))
+		if !apiRateLimit.observe(resp, attempt) || attempt == maxRateLimitRetries {
+			return resp, nil
+		}
+		resp.Body.Close()
+		logDebugf("🔁 Retrying %s %s after a rate limit (retry %d of %d)\n", req.Method, req.URL.Path, attempt+1, maxRateLimitRetries)
+
+		// Requests with a body need a fresh reader for the retry
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
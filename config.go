@@ -0,0 +1,247 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Action classes a severity override can be restricted to
+const (
+	actionClassThirdParty = "third-party" // published outside GitHub and the scanned organization
+	actionClassInternal   = "internal"    // published by the scanned organization, including local ./ actions
+	actionClassGitHub     = "github"      // published by actions/* or github/*
+)
+
+// Config is the policy file passed with --config
+type Config struct {
+	SeverityOverrides []SeverityOverride  `yaml:"severity-overrides"`
+	FailOn            map[string]int      `yaml:"fail-on"` // severity or condition -> number of findings that fails the scan
+	Notifications     *NotificationConfig `yaml:"notifications"`
+	Report            *ReportBranding     `yaml:"report"`       // title, logo, and metadata of generated reports
+	BotAccounts       []string            `yaml:"bot-accounts"` // user accounts treated as bots by --authorship, e.g. scaffolding bots
+}
+
+// SeverityOverride reclassifies the findings of a rule, optionally only for some actions or repositories
+type SeverityOverride struct {
+	Rule       string `yaml:"rule"`
+	Actions    string `yaml:"actions"`    // third-party, internal, github; empty matches all
+	Repository string `yaml:"repository"` // glob pattern; empty matches all
+	Authorship string `yaml:"authorship"` // bot or human last modifier of the workflow, with --authorship; empty matches all
+	Severity   string `yaml:"severity"`
+}
+
+// loadConfig reads and validates a policy file
+func loadConfig(configPath string) (*Config, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %v", err)
+	}
+
+	var config Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %v", configPath, err)
+	}
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %v", configPath, err)
+	}
+	return &config, nil
+}
+
+// validate checks rule IDs, severities, action classes, and patterns
+func (c *Config) validate() error {
+	for i, override := range c.SeverityOverrides {
+		if _, ok := ruleRegistry[override.Rule]; !ok {
+			return fmt.Errorf("severity-overrides[%d]: unknown rule '%s'", i, override.Rule)
+		}
+		if severityRank(override.Severity) == 0 {
+			return fmt.Errorf("severity-overrides[%d]: invalid severity '%s'", i, override.Severity)
+		}
+		switch override.Actions {
+		case "", actionClassThirdParty, actionClassInternal, actionClassGitHub:
+		default:
+			return fmt.Errorf("severity-overrides[%d]: invalid actions '%s'. Valid options: third-party, internal, github", i, override.Actions)
+		}
+		if _, err := path.Match(override.Repository, ""); err != nil {
+			return fmt.Errorf("severity-overrides[%d]: invalid repository pattern '%s'", i, override.Repository)
+		}
+		switch override.Authorship {
+		case "", AuthorshipBot, AuthorshipHuman:
+		default:
+			return fmt.Errorf("severity-overrides[%d]: invalid authorship '%s'. Valid options: bot, human", i, override.Authorship)
+		}
+	}
+	for i, pattern := range c.BotAccounts {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("bot-accounts[%d]: invalid pattern '%s'", i, pattern)
+		}
+	}
+	for key, threshold := range c.FailOn {
+		if severityRank(key) == 0 && conditionRules(key) == nil {
+			return fmt.Errorf("fail-on: unknown severity or condition '%s'", key)
+		}
+		if threshold < 1 {
+			return fmt.Errorf("fail-on: threshold for '%s' must be at least 1", key)
+		}
+	}
+	return c.Notifications.validate()
+}
+
+// actionClass classifies an action reference relative to the scanned organization
+func actionClass(action, org string) string {
+	if strings.HasPrefix(action, "./") {
+		return actionClassInternal
+	}
+	owner := strings.ToLower(strings.SplitN(action, "/", 2)[0])
+	switch {
+	case owner == "actions" || owner == "github":
+		return actionClassGitHub
+	case owner == strings.ToLower(org):
+		return actionClassInternal
+	}
+	return actionClassThirdParty
+}
+
+// matches reports whether an override applies to a finding
+func (o SeverityOverride) matches(f Finding, org string) bool {
+	if o.Rule != f.RuleID {
+		return false
+	}
+	if o.Actions != "" && (f.Action == "" || actionClass(f.Action, org) != o.Actions) {
+		return false
+	}
+	if o.Repository != "" {
+		if ok, _ := path.Match(o.Repository, f.Repository); !ok {
+			return false
+		}
+	}
+	if o.Authorship != "" && o.Authorship != f.Authorship {
+		return false
+	}
+	return true
+}
+
+// applySeverityOverrides reclassifies findings in place; the last matching override wins
+func (c *Config) applySeverityOverrides(findings []Finding, org string) {
+	if c == nil {
+		return
+	}
+	for i := range findings {
+		for _, override := range c.SeverityOverrides {
+			if override.matches(findings[i], org) {
+				findings[i].Severity = override.Severity
+			}
+		}
+	}
+}
+
+// exitFailOn is the exit status when findings reach a fail-on threshold, distinct from the status 1 of a
+// scan that could not complete
+const exitFailOn = 3
+
+// failOnConditions are the fail-on conditions that stand for a group of rules
+var failOnConditions = map[string][]string{
+	"unpinned": {RuleTagPinnedAction, RuleBranchPinnedAction, RuleUnpinnedAction, RuleBranchPinnedWorkflow, RuleUnpinnedDockerAction},
+}
+
+// conditionRules returns the rule IDs a fail-on condition matches: the rules of a group, or the rule
+// with the same ID; nil when the name is neither
+func conditionRules(name string) []string {
+	if rules, ok := failOnConditions[name]; ok {
+		return rules
+	}
+	if _, ok := ruleRegistry[name]; ok {
+		return []string{name}
+	}
+	return nil
+}
+
+// failOnError reports the severities and conditions whose findings reached their threshold
+type failOnError struct {
+	exceeded []string
+}
+
+// Error implements error
+func (e *failOnError) Error() string {
+	return fmt.Sprintf("policy check failed: %s", strings.Join(e.exceeded, ", "))
+}
+
+// exitStatus returns the exit status for a failed scan: exitFailOn when only a fail-on threshold was
+// reached, 1 otherwise
+func exitStatus(err error) int {
+	var failOn *failOnError
+	if errors.As(err, &failOn) {
+		return exitFailOn
+	}
+	return 1
+}
+
+// failOnThresholds merges the config thresholds with --fail-on, a comma-separated list of a severity,
+// which fails on the first finding at or above it, and conditions, which fail on the first finding of
+// their rules
+func failOnThresholds(config *Config, failOn string) (map[string]int, error) {
+	thresholds := make(map[string]int)
+	if config != nil {
+		for key, threshold := range config.FailOn {
+			thresholds[key] = threshold
+		}
+	}
+	for _, item := range splitList(failOn) {
+		switch {
+		case severityRank(item) > 0:
+			for _, severity := range []string{SeverityError, SeverityWarning, SeverityInfo} {
+				if severityRank(severity) >= severityRank(item) {
+					thresholds[severity] = 1
+				}
+			}
+		case conditionRules(item) != nil:
+			thresholds[item] = 1
+		default:
+			return nil, fmt.Errorf("invalid --fail-on value '%s'. Valid options: error, warning, info, unpinned, or a rule ID (see `gh action-lens rules list`)", item)
+		}
+	}
+	return thresholds, nil
+}
+
+// checkFailOn returns an error when the findings of any severity or condition reach its threshold
+func checkFailOn(findings []Finding, thresholds map[string]int) error {
+	counts := make(map[string]int)
+	for _, finding := range findings {
+		counts[finding.Severity]++
+		counts[finding.RuleID]++
+	}
+
+	var exceeded []string
+	for _, severity := range []string{SeverityError, SeverityWarning, SeverityInfo} {
+		if threshold, ok := thresholds[severity]; ok && counts[severity] >= threshold {
+			exceeded = append(exceeded, fmt.Sprintf("%d %s (threshold %d)", counts[severity], severity, threshold))
+		}
+	}
+
+	var conditions []string
+	for key := range thresholds {
+		if severityRank(key) == 0 {
+			conditions = append(conditions, key)
+		}
+	}
+	sort.Strings(conditions)
+	for _, condition := range conditions {
+		count := 0
+		for _, rule := range conditionRules(condition) {
+			count += counts[rule]
+		}
+		if threshold := thresholds[condition]; count >= threshold {
+			exceeded = append(exceeded, fmt.Sprintf("%d %s (threshold %d)", count, condition, threshold))
+		}
+	}
+
+	if len(exceeded) > 0 {
+		return &failOnError{exceeded: exceeded}
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Pinning of a container image reference
+const (
+	ImagePinnedDigest = "digest"     // image@sha256:...
+	ImagePinnedTag    = "tag"        // image:1.2.3
+	ImageLatest       = "latest"     // image or image:latest, whatever was pushed last
+	ImageExpression   = "expression" // ${{ }} expression resolved at run time
+)
+
+// dockerHubRegistry is the registry of image references without a registry host
+const dockerHubRegistry = "docker.io"
+
+// ContainerImage is one image a job runs in or starts as a service container
+type ContainerImage struct {
+	Reference string `json:"reference"` // as written in the workflow
+	Registry  string `json:"registry,omitempty"`
+	Image     string `json:"image"` // registry/name without tag or digest
+	Tag       string `json:"tag,omitempty"`
+	Digest    string `json:"digest,omitempty"`
+	Pinning   string `json:"pinning"`
+	Kind      string `json:"kind"` // container or service
+	Job       string `json:"job"`
+	Service   string `json:"service,omitempty"` // service container name
+}
+
+// ContainerImageUsages rolls up the usages of one image across the scanned workflows
+type ContainerImageUsages struct {
+	Image        string         `json:"image"`
+	Registry     string         `json:"registry,omitempty"`
+	Usages       int            `json:"usages"`
+	Repositories int            `json:"repositories"`
+	References   map[string]int `json:"references"` // tag or digest -> usages
+	Unpinned     int            `json:"unpinned"`   // usages not pinned to a digest
+}
+
+// ContainerImageSummary counts the container and service images of the detailed report
+type ContainerImageSummary struct {
+	Usages       int                    `json:"usages"`
+	UniqueImages int                    `json:"unique_images"`
+	DigestPinned int                    `json:"digest_pinned"`
+	TagPinned    int                    `json:"tag_pinned"`
+	Latest       int                    `json:"latest"`
+	Expressions  int                    `json:"expressions"`
+	Registries   map[string]int         `json:"registries"` // usages per registry
+	Images       []ContainerImageUsages `json:"images"`     // most used first
+}
+
+// parseImageReference splits an image reference such as ghcr.io/owner/tool:1.2 or node@sha256:... into
+// its registry, image, tag, and digest, and classifies how it is pinned
+func parseImageReference(reference string) ContainerImage {
+	reference = strings.TrimPrefix(strings.TrimSpace(reference), "docker://")
+	image := ContainerImage{Reference: reference}
+	if strings.Contains(reference, "${{") {
+		image.Image, image.Pinning = reference, ImageExpression
+		return image
+	}
+
+	name := reference
+	if i := strings.Index(name, "@"); i >= 0 {
+		name, image.Digest = name[:i], name[i+1:]
+	}
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, image.Tag = name[:i], name[i+1:]
+	}
+
+	image.Registry = dockerHubRegistry
+	if first, rest, ok := strings.Cut(name, "/"); ok && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		image.Registry, name = first, rest
+	}
+	image.Image = name
+	if image.Registry != dockerHubRegistry {
+		image.Image = image.Registry + "/" + name
+	}
+
+	switch {
+	case image.Digest != "":
+		image.Pinning = ImagePinnedDigest
+	case image.Tag == "" || image.Tag == "latest":
+		image.Pinning = ImageLatest
+	default:
+		image.Pinning = ImagePinnedTag
+	}
+	return image
+}
+
+// containerImageReference returns the image of a container: or services: entry, which is either the image
+// itself or a map with an image key
+func containerImageReference(value interface{}) string {
+	if reference, ok := value.(string); ok {
+		return reference
+	}
+	if definition, ok := yamlMap(value); ok {
+		reference, _ := definition["image"].(string)
+		return reference
+	}
+	return ""
+}
+
+// parseContainerImages extracts the job containers and service containers of a workflow
+func parseContainerImages(yamlContent string) ([]ContainerImage, error) {
+	documents, err := decodeYAMLDocuments(yamlContent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %v", err)
+	}
+
+	var images []ContainerImage
+	for _, document := range documents {
+		jobs, _ := yamlMap(document["jobs"])
+		for _, jobID := range sortedYAMLKeys(jobs) {
+			job, ok := yamlMap(jobs[jobID])
+			if !ok {
+				continue
+			}
+			if reference := containerImageReference(job["container"]); reference != "" {
+				image := parseImageReference(reference)
+				image.Kind, image.Job = "container", jobID
+				images = append(images, image)
+			}
+			services, _ := yamlMap(job["services"])
+			for _, name := range sortedYAMLKeys(services) {
+				if reference := containerImageReference(services[name]); reference != "" {
+					image := parseImageReference(reference)
+					image.Kind, image.Job, image.Service = "service", jobID, name
+					images = append(images, image)
+				}
+			}
+		}
+	}
+	return images, nil
+}
+
+// summarizeContainerImages rolls up the container images of the scanned workflows
+func summarizeContainerImages(repositories []ComprehensiveRepository) ContainerImageSummary {
+	summary := ContainerImageSummary{Registries: make(map[string]int), Images: []ContainerImageUsages{}}
+	byImage := make(map[string]*ContainerImageUsages)
+	repositoriesByImage := make(map[string]map[string]bool)
+	for _, repo := range repositories {
+		for _, workflow := range repo.Workflows {
+			for _, image := range workflow.Containers {
+				summary.Usages++
+				switch image.Pinning {
+				case ImagePinnedDigest:
+					summary.DigestPinned++
+				case ImagePinnedTag:
+					summary.TagPinned++
+				case ImageLatest:
+					summary.Latest++
+				case ImageExpression:
+					summary.Expressions++
+					continue
+				}
+				summary.Registries[image.Registry]++
+
+				usages, ok := byImage[image.Image]
+				if !ok {
+					usages = &ContainerImageUsages{Image: image.Image, Registry: image.Registry, References: make(map[string]int)}
+					byImage[image.Image] = usages
+					repositoriesByImage[image.Image] = make(map[string]bool)
+				}
+				usages.Usages++
+				repositoriesByImage[image.Image][repo.Name] = true
+				reference := image.Tag
+				if image.Digest != "" {
+					reference = image.Digest
+				} else if reference == "" {
+					reference = "latest"
+				}
+				usages.References[reference]++
+				if image.Pinning != ImagePinnedDigest {
+					usages.Unpinned++
+				}
+			}
+		}
+	}
+
+	for name, usages := range byImage {
+		usages.Repositories = len(repositoriesByImage[name])
+		summary.Images = append(summary.Images, *usages)
+	}
+	sort.Slice(summary.Images, func(i, j int) bool {
+		if summary.Images[i].Usages != summary.Images[j].Usages {
+			return summary.Images[i].Usages > summary.Images[j].Usages
+		}
+		return summary.Images[i].Image < summary.Images[j].Image
+	})
+	summary.UniqueImages = len(summary.Images)
+	return summary
+}
+
+// String renders the pinning of the container images, e.g. "3 digest, 5 tag, 2 latest"
+func (summary ContainerImageSummary) String() string {
+	text := fmt.Sprintf("%d digest, %d tag, %d latest", summary.DigestPinned, summary.TagPinned, summary.Latest)
+	if summary.Expressions > 0 {
+		text += fmt.Sprintf(", %d expression", summary.Expressions)
+	}
+	return text
+}
+
+// formatImageReferences renders the tags and digests of an image with their usages, most used first
+func formatImageReferences(references map[string]int) string {
+	keys := sortedKeys(references)
+	sort.SliceStable(keys, func(i, j int) bool { return references[keys[i]] > references[keys[j]] })
+	var parts []string
+	for _, key := range keys {
+		if len(key) > 19 && strings.HasPrefix(key, "sha256:") {
+			parts = append(parts, fmt.Sprintf("%s… (%d)", key[:19], references[key]))
+		} else {
+			parts = append(parts, fmt.Sprintf("%s (%d)", key, references[key]))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// outputContainerImages writes the container and service images of the detailed report
+func outputContainerImages(summary ContainerImageSummary, writer io.Writer) {
+	if summary.Usages == 0 {
+		return
+	}
+	fmt.Fprintf(writer, "\n🐳 Container images (%d usages, %d images; %s):\n", summary.Usages, summary.UniqueImages, summary)
+	for _, image := range summary.Images {
+		icon := "✅"
+		if image.Unpinned > 0 {
+			icon = "⚠️ "
+		}
+		fmt.Fprintf(writer, "   %s %s: %s in %d repos\n", icon, image.Image, formatImageReferences(image.References), image.Repositories)
+	}
+}
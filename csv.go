@@ -0,0 +1,32 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"unicode/utf8"
+)
+
+// csvDelimiter separates the fields of CSV output; --csv-delimiter sets it, e.g. to ; for locales
+// that use the comma as decimal separator
+var csvDelimiter = ','
+
+// parseCSVDelimiter returns the delimiter of --csv-delimiter: a single character, or tab
+func parseCSVDelimiter(value string) (rune, error) {
+	if value == "tab" || value == `\t` {
+		return '\t', nil
+	}
+	r, size := utf8.DecodeRuneInString(value)
+	if size == 0 || size != len(value) || r == utf8.RuneError || r == '"' || r == '\r' || r == '\n' {
+		return 0, fmt.Errorf("invalid --csv-delimiter '%s'; expected a single character such as ; or tab", value)
+	}
+	return r, nil
+}
+
+// newCSVWriter returns a CSV writer with the --csv-delimiter. Fields containing the delimiter, quotes,
+// or line breaks are quoted; callers flush it and return its Error.
+func newCSVWriter(writer io.Writer) *csv.Writer {
+	w := csv.NewWriter(writer)
+	w.Comma = csvDelimiter
+	return w
+}
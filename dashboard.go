@@ -0,0 +1,319 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"sort"
+	"strings"
+)
+
+// dashboardTopActions is the number of most used actions charted by version distribution
+const dashboardTopActions = 15
+
+// chartColors are the segment colors of stacked bars, in segment order
+var chartColors = []string{"#0969da", "#1a7f37", "#9a6700", "#8250df", "#bc4c00", "#cf222e", "#1b7c83", "#6e7781"}
+
+// dashboardScript makes tables with class sortable sortable by clicking their headers, filters the
+// repository sections through the search box, and opens the section a repository link points to
+const dashboardScript = `document.querySelectorAll('table.sortable th').forEach(function (th) {
+  th.addEventListener('click', function () {
+    var table = th.closest('table'), body = table.tBodies[0];
+    var index = Array.prototype.indexOf.call(th.parentNode.children, th);
+    var ascending = th.dataset.order !== 'asc';
+    th.dataset.order = ascending ? 'asc' : 'desc';
+    var rows = Array.prototype.slice.call(body.rows);
+    rows.sort(function (a, b) {
+      var x = a.cells[index].textContent.trim(), y = b.cells[index].textContent.trim();
+      var nx = parseFloat(x), ny = parseFloat(y);
+      var order = !isNaN(nx) && !isNaN(ny) ? nx - ny : x.localeCompare(y);
+      return ascending ? order : -order;
+    });
+    rows.forEach(function (row) { body.appendChild(row); });
+  });
+});
+document.getElementById('search').addEventListener('input', function (e) {
+  var q = e.target.value.toLowerCase();
+  document.querySelectorAll('#repos tbody tr, details.repo').forEach(function (el) {
+    el.style.display = el.textContent.toLowerCase().indexOf(q) === -1 ? 'none' : '';
+  });
+});
+function openTarget() {
+  var target = location.hash && document.getElementById(decodeURIComponent(location.hash.slice(1)));
+  if (target && target.tagName === 'DETAILS') { target.open = true; }
+}
+window.addEventListener('hashchange', openTarget);
+openTarget();`
+
+// chartSegment is one part of a stacked bar
+type chartSegment struct {
+	Label string
+	Count int
+}
+
+// dashboardAction aggregates the usages of one action across a detailed report
+type dashboardAction struct {
+	Name         string
+	Usages       int
+	Repositories int
+	Versions     map[string]int
+}
+
+// collectDashboardActions returns the usage statistics of every action, most used first
+func collectDashboardActions(report ComprehensiveReport) []dashboardAction {
+	byName := make(map[string]*dashboardAction)
+	repos := make(map[string]map[string]bool)
+	for _, repo := range report.Repositories {
+		for _, workflow := range repo.Workflows {
+			for _, action := range workflow.Actions {
+				if byName[action.Name] == nil {
+					byName[action.Name] = &dashboardAction{Name: action.Name, Versions: make(map[string]int)}
+					repos[action.Name] = make(map[string]bool)
+				}
+				byName[action.Name].Usages += action.Count
+				byName[action.Name].Versions[action.Version] += action.Count
+				repos[action.Name][repo.Name] = true
+			}
+		}
+	}
+
+	var actions []dashboardAction
+	for name, action := range byName {
+		action.Repositories = len(repos[name])
+		actions = append(actions, *action)
+	}
+	sort.Slice(actions, func(i, j int) bool {
+		if actions[i].Usages != actions[j].Usages {
+			return actions[i].Usages > actions[j].Usages
+		}
+		return actions[i].Name < actions[j].Name
+	})
+	return actions
+}
+
+// repositoryAnchor returns the element ID of the drill-down section of a repository
+func repositoryAnchor(repo string) string {
+	return "repo-" + strings.ReplaceAll(repo, "/", "__")
+}
+
+// writeStackedBar writes one chart row; widths are relative to scale so rows of a chart are comparable
+func writeStackedBar(w io.Writer, label string, segments []chartSegment, colors map[string]string, scale int) {
+	total := 0
+	for _, segment := range segments {
+		total += segment.Count
+	}
+	fmt.Fprintf(w, "<div class=\"row\"><div class=\"label\" title=\"%s\">%s</div><div class=\"bar\">",
+		html.EscapeString(label), html.EscapeString(label))
+	for _, segment := range segments {
+		if segment.Count == 0 || scale == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "<span style=\"width:%.2f%%;background:%s\" title=\"%s: %d\"></span>",
+			float64(segment.Count)*100/float64(scale), colors[segment.Label], html.EscapeString(segment.Label), segment.Count)
+	}
+	fmt.Fprintf(w, "</div><div class=\"total\">%d</div></div>\n", total)
+}
+
+// writeLegend writes the color legend of a chart
+func writeLegend(w io.Writer, labels []string, colors map[string]string) {
+	fmt.Fprint(w, "<p class=\"legend\">")
+	for _, label := range labels {
+		fmt.Fprintf(w, "<span><i style=\"background:%s\"></i>%s</span>", colors[label], html.EscapeString(label))
+	}
+	fmt.Fprintln(w, "</p>")
+}
+
+// assignColors maps labels to chart colors in order, cycling through the palette
+func assignColors(labels []string) map[string]string {
+	colors := make(map[string]string)
+	for i, label := range labels {
+		colors[label] = chartColors[i%len(chartColors)]
+	}
+	return colors
+}
+
+// outputComprehensiveHTML outputs the detailed analysis as a self-contained HTML dashboard with summary
+// tiles, pinning and version-distribution charts, sortable tables, and a drill-down per repository
+func outputComprehensiveHTML(report ComprehensiveReport, writer io.Writer) error {
+	return writeDashboard(report, nil, writer)
+}
+
+// writeDashboard writes the dashboard. With pages, the repository sections are left to the pages and
+// repository links point into them.
+func writeDashboard(report ComprehensiveReport, pages []reportPage, writer io.Writer) error {
+	scope := report.Organization
+	if report.Enterprise != "" {
+		scope = report.Enterprise
+	}
+	writeSiteHeader(writer, report.Report.title("GitHub Actions dashboard – "+scope), report.Report)
+	fmt.Fprintf(writer, "<p>Scanned %s in %.1fs</p>\n", html.EscapeString(report.ScanTimestamp), report.ProcessTimeSeconds)
+	writeSummaryTiles(writer, report)
+	if report.Truncated {
+		fmt.Fprintf(writer, "<p class=\"warning\">Scan timed out; partial report. %d repositories not scanned: %s</p>\n",
+			len(report.RemainingRepositories), html.EscapeString(strings.Join(report.RemainingRepositories, ", ")))
+	}
+
+	// Pinning of all action usages
+	pinning := report.Summary.Pinning
+	pinningLabels := []string{"sha", "tag", "branch"}
+	pinningColors := map[string]string{"sha": "#1a7f37", "tag": "#9a6700", "branch": "#cf222e"}
+	fmt.Fprintln(writer, "<h2>Pinning</h2>\n<div class=\"chart\">")
+	writeLegend(writer, pinningLabels, pinningColors)
+	pinned := pinning.SHAPinned + pinning.TagPinned + pinning.BranchPinned
+	writeStackedBar(writer, "All action usages", []chartSegment{
+		{"sha", pinning.SHAPinned}, {"tag", pinning.TagPinned}, {"branch", pinning.BranchPinned},
+	}, pinningColors, pinned)
+	fmt.Fprintln(writer, "</div>")
+
+	// Version distribution of the most used actions, by major version
+	actions := collectDashboardActions(report)
+	charted := actions
+	if len(charted) > dashboardTopActions {
+		charted = charted[:dashboardTopActions]
+	}
+	if len(charted) > 0 {
+		rollups := make([][]MajorVersionCount, len(charted))
+		groups := make(map[string]bool)
+		for i, action := range charted {
+			rollups[i] = rollupMajorVersions(action.Name, action.Versions)
+			for _, major := range rollups[i] {
+				groups[major.Major] = true
+			}
+		}
+		var labels []string
+		for group := range groups {
+			labels = append(labels, group)
+		}
+		sort.Slice(labels, func(i, j int) bool {
+			a, b := majorRank(labels[i]), majorRank(labels[j])
+			if a != b {
+				return a < b
+			}
+			return labels[i] < labels[j]
+		})
+		colors := assignColors(labels)
+
+		fmt.Fprintf(writer, "<h2>Version distribution (top %d actions)</h2>\n<div class=\"chart\">\n", len(charted))
+		writeLegend(writer, labels, colors)
+		for i, action := range charted {
+			var segments []chartSegment
+			for _, major := range rollups[i] {
+				segments = append(segments, chartSegment{Label: major.Major, Count: major.Count})
+			}
+			writeStackedBar(writer, action.Name, segments, colors, charted[0].Usages)
+		}
+		fmt.Fprintln(writer, "</div>")
+	}
+
+	// Findings by rule, split by severity
+	if len(report.Findings) > 0 {
+		byRule := make(map[string]map[string]int)
+		for _, finding := range report.Findings {
+			if byRule[finding.RuleID] == nil {
+				byRule[finding.RuleID] = make(map[string]int)
+			}
+			byRule[finding.RuleID][finding.Severity]++
+		}
+		var rules []string
+		scale := 0
+		for rule, counts := range byRule {
+			rules = append(rules, rule)
+			if total := counts[SeverityError] + counts[SeverityWarning] + counts[SeverityInfo]; total > scale {
+				scale = total
+			}
+		}
+		sort.Strings(rules)
+		severities := []string{SeverityError, SeverityWarning, SeverityInfo}
+		colors := map[string]string{SeverityError: "#cf222e", SeverityWarning: "#9a6700", SeverityInfo: "#0969da"}
+
+		fmt.Fprintln(writer, "<h2>Findings by rule</h2>\n<div class=\"chart\">")
+		writeLegend(writer, severities, colors)
+		for _, rule := range rules {
+			var segments []chartSegment
+			for _, severity := range severities {
+				segments = append(segments, chartSegment{Label: severity, Count: byRule[rule][severity]})
+			}
+			writeStackedBar(writer, rule, segments, colors, scale)
+		}
+		fmt.Fprintln(writer, "</div>")
+	}
+
+	// Actions
+	fmt.Fprintln(writer, "<h2>Actions</h2>")
+	fmt.Fprintln(writer, "<table class=\"sortable\">\n<thead><tr><th>Action</th><th>Usages</th><th>Repositories</th><th>Versions</th><th>Major versions</th></tr></thead>\n<tbody>")
+	for _, action := range actions {
+		fmt.Fprintf(writer, "<tr><td>%s</td><td class=\"n\">%d</td><td class=\"n\">%d</td><td class=\"n\">%d</td><td>%s</td></tr>\n",
+			html.EscapeString(action.Name), action.Usages, action.Repositories, len(action.Versions),
+			html.EscapeString(formatMajorVersions(rollupMajorVersions(action.Name, action.Versions))))
+	}
+	fmt.Fprintln(writer, "</tbody>\n</table>")
+
+	// Repositories with drill-down
+	findingsByRepo := make(map[string][]Finding)
+	for _, finding := range report.Findings {
+		findingsByRepo[finding.Repository] = append(findingsByRepo[finding.Repository], finding)
+	}
+
+	files := pageFiles(pages)
+	fmt.Fprintln(writer, "<h2>Repositories</h2>")
+	fmt.Fprintln(writer, "<input id=\"search\" type=\"search\" placeholder=\"Filter repositories, actions, rules…\">")
+	fmt.Fprintln(writer, "<table id=\"repos\" class=\"sortable\">\n<thead><tr><th>Repository</th><th>Workflows</th><th>Action usages</th><th>SHA pinned</th><th>Findings</th></tr></thead>\n<tbody>")
+	for _, repo := range report.Repositories {
+		var counts PinningCounts
+		usages := 0
+		for _, workflow := range repo.Workflows {
+			usages += workflow.TotalActionCount
+			for _, action := range workflow.Actions {
+				counts.add(action.Pinning, action.Count)
+			}
+		}
+		rate := "–"
+		if total := counts.SHAPinned + counts.TagPinned + counts.BranchPinned; total > 0 {
+			rate = fmt.Sprintf("%.0f%%", float64(counts.SHAPinned)*100/float64(total))
+		}
+		fmt.Fprintf(writer, "<tr><td><a href=\"%s#%s\">%s</a></td><td class=\"n\">%d</td><td class=\"n\">%d</td><td class=\"n\">%s</td><td class=\"n\">%d</td></tr>\n",
+			html.EscapeString(files[repo.Name]), html.EscapeString(repositoryAnchor(repo.Name)), html.EscapeString(repo.Name),
+			repo.WorkflowCount, usages, rate, len(findingsByRepo[repo.Name]))
+	}
+	fmt.Fprintln(writer, "</tbody>\n</table>")
+
+	if len(pages) > 0 {
+		fmt.Fprintln(writer, "<h2>Pages</h2>\n<ol>")
+		for _, page := range pages {
+			fmt.Fprintf(writer, "<li><a href=\"%s\">%s</a></li>\n", html.EscapeString(page.File), html.EscapeString(page.describe()))
+		}
+		fmt.Fprintln(writer, "</ol>")
+	} else {
+		for _, repo := range report.Repositories {
+			writeDashboardRepository(writer, repo, findingsByRepo[repo.Name])
+		}
+	}
+
+	fmt.Fprintf(writer, "<script>\n%s\n</script>\n</body>\n</html>\n", dashboardScript)
+	return nil
+}
+
+// writeDashboardRepository writes the drill-down section of a repository: its action usages and findings
+func writeDashboardRepository(writer io.Writer, repo ComprehensiveRepository, findings []Finding) {
+	fmt.Fprintf(writer, "<details class=\"repo\" id=\"%s\"><summary>%s – %d workflows, %d findings</summary>\n",
+		html.EscapeString(repositoryAnchor(repo.Name)), html.EscapeString(repo.Name), repo.WorkflowCount, len(findings))
+	fmt.Fprintln(writer, "<table class=\"sortable\">\n<thead><tr><th>Workflow</th><th>Action</th><th>Version</th><th>Pinning</th><th>Count</th></tr></thead>\n<tbody>")
+	for _, workflow := range repo.Workflows {
+		for _, action := range workflow.Actions {
+			fmt.Fprintf(writer, "<tr><td>%s</td><td>%s</td><td><code>%s</code></td><td>%s</td><td class=\"n\">%d</td></tr>\n",
+				html.EscapeString(workflow.Path), html.EscapeString(action.Name), html.EscapeString(displayVersion(action)),
+				html.EscapeString(action.Pinning), action.Count)
+		}
+	}
+	fmt.Fprintln(writer, "</tbody>\n</table>")
+	if len(findings) > 0 {
+		fmt.Fprintln(writer, "<table class=\"sortable\">\n<thead><tr><th>Severity</th><th>Rule</th><th>Workflow</th><th>Details</th><th>Fix</th></tr></thead>\n<tbody>")
+		for _, finding := range findings {
+			fmt.Fprintf(writer, "<tr><td class=\"%s\">%s</td><td><code>%s</code></td><td>%s</td><td>%s</td><td>%s</td></tr>\n",
+				html.EscapeString(finding.Severity), html.EscapeString(finding.Severity), html.EscapeString(finding.RuleID),
+				html.EscapeString(finding.Workflow), html.EscapeString(finding.Message)+htmlSnippet(finding.Snippet), html.EscapeString(finding.remediation()))
+		}
+		fmt.Fprintln(writer, "</tbody>\n</table>")
+	}
+	fmt.Fprintln(writer, "</details>")
+}
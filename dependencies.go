@@ -0,0 +1,401 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Kinds of organization copies of an action repository
+const (
+	MirrorFork = "fork" // a fork of the action repository
+	MirrorCopy = "copy" // a repository of the same name that is not a fork, e.g. a vendored import
+)
+
+// DependencyReport lists the workflows whose critical path fetches third-party actions from github.com
+type DependencyReport struct {
+	Organization          string                 `json:"organization"`
+	Summary               DependencySummary      `json:"summary"`
+	Workflows             []WorkflowDependencies `json:"workflows"`    // exposed workflows, most dependencies first
+	Dependencies          []HardDependency       `json:"dependencies"` // most used first
+	Findings              []Finding              `json:"findings"`
+	Truncated             bool                   `json:"truncated"`
+	RemainingRepositories []string               `json:"remaining_repositories,omitempty"`
+	ProcessTimeSeconds    float64                `json:"process_time_seconds"`
+}
+
+// DependencySummary represents summary statistics of the hard dependency analysis
+type DependencySummary struct {
+	WorkflowsScanned    int `json:"workflows_scanned"`
+	ExposedWorkflows    int `json:"exposed_workflows"`
+	ExposedRepositories int `json:"exposed_repositories"`
+	Dependencies        int `json:"dependencies"`       // distinct third-party action repositories on a critical path
+	Mirrored            int `json:"mirrored"`           // of which the organization holds a copy
+	NonCritical         int `json:"non_critical"`       // remote action usages under continue-on-error
+	FirstPartyUsages    int `json:"first_party_usages"` // critical usages of actions/*, github/*, and organization actions
+}
+
+// WorkflowDependencies lists the third-party actions one workflow cannot run without
+type WorkflowDependencies struct {
+	Repository string   `json:"repository"`
+	Workflow   string   `json:"workflow"`
+	Jobs       []string `json:"jobs"`    // jobs with a third-party action on their critical path
+	Actions    []string `json:"actions"` // action@ref references
+}
+
+// HardDependency is one third-party action repository that workflows fetch from github.com at run time
+type HardDependency struct {
+	Repository   string   `json:"repository"` // owner/repo of the action
+	Refs         []string `json:"refs"`
+	Usages       int      `json:"usages"`
+	Workflows    int      `json:"workflows"`
+	Repositories int      `json:"repositories"`
+	Mirror       string   `json:"mirror,omitempty"`      // organization copy that could be referenced instead
+	MirrorKind   string   `json:"mirror_kind,omitempty"` // fork or copy
+}
+
+// dependencyUsage is one critical third-party reference of a job
+type dependencyUsage struct {
+	Job    string
+	Action string
+	Ref    string
+}
+
+// continuesOnError reports whether a continue-on-error value is literally true; expressions may evaluate
+// to false, so they keep the job or step on the critical path
+func continuesOnError(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		return strings.TrimSpace(v) == "true"
+	}
+	return false
+}
+
+// criticalDependencies returns the remote action and reusable workflow references a workflow cannot
+// complete without, skipping jobs and steps that continue on error, and the number of those skipped
+func criticalDependencies(definition *workflowDefinition) ([]dependencyUsage, int) {
+	var usages []dependencyUsage
+	skipped := 0
+	add := func(jobID, uses string, critical bool) {
+		name, ref, ok := splitActionReference(uses)
+		if !ok || strings.HasPrefix(uses, "./") || strings.HasPrefix(uses, "docker://") {
+			return
+		}
+		if !critical {
+			skipped++
+			return
+		}
+		usages = append(usages, dependencyUsage{Job: jobID, Action: name, Ref: ref})
+	}
+
+	for _, jobID := range definition.sortedJobIDs() {
+		job := definition.Jobs[jobID]
+		jobCritical := !continuesOnError(job.Continue)
+		if job.Uses != "" {
+			add(jobID, job.Uses, jobCritical)
+		}
+		for _, step := range job.Steps {
+			if step.Uses != "" {
+				add(jobID, step.Uses, jobCritical && !continuesOnError(step.Continue))
+			}
+		}
+	}
+	return usages, skipped
+}
+
+// findActionMirror returns the repository of the organization that holds a copy of an action repository:
+// a fork of it, or a repository with the same name
+func findActionMirror(org, repository string, cache *enrichmentCache) (string, string) {
+	_, name, _ := strings.Cut(repository, "/")
+	candidate := org + "/" + name
+	metadata, err := fetchRepositoryMetadata(cache, candidate)
+	if err != nil {
+		return "", ""
+	}
+	if metadata.Fork {
+		if metadata.Source != nil && strings.EqualFold(metadata.Source.FullName, repository) {
+			return candidate, MirrorFork
+		}
+		return "", ""
+	}
+	return candidate, MirrorCopy
+}
+
+// dependencyFinding converts a critical third-party reference of a workflow into a finding
+func dependencyFinding(repo, workflow string, usage dependencyUsage, dependency HardDependency) Finding {
+	finding := Finding{
+		RuleID:     RuleHardDependency,
+		Severity:   SeverityInfo,
+		Repository: repo,
+		Workflow:   workflow,
+		Action:     usage.Action,
+		Version:    usage.Ref,
+		Message: fmt.Sprintf("job %s cannot run without %s@%s, fetched from github.com at run time",
+			usage.Job, usage.Action, usage.Ref),
+	}
+	if dependency.Mirror != "" {
+		finding.Remediation = fmt.Sprintf("Reference the organization's %s %s instead of %s, and keep it in sync with upstream.",
+			dependency.MirrorKind, dependency.Mirror, dependency.Repository)
+	}
+	return finding
+}
+
+// analyzeHardDependencies reports the workflows whose critical path depends on third-party actions that
+// are fetched from github.com without an organization mirror or vendored copy
+func analyzeHardDependencies(org string, startTime time.Time, outputFormat, outputFile string, opts scanOptions) error {
+	repositories, _, err := listRepositoryWorkflows(org, opts)
+	if err != nil {
+		return err
+	}
+
+	logInfof("🔗 Tracing third-party action dependencies in %d repositories...\n\n", len(repositories))
+
+	report := DependencyReport{
+		Organization: org,
+		Workflows:    []WorkflowDependencies{},
+		Dependencies: []HardDependency{},
+		Findings:     []Finding{},
+	}
+
+	type workflowUsages struct {
+		repo, workflow string
+		usages         []dependencyUsage
+	}
+	var critical []workflowUsages
+
+	report.RemainingRepositories = forEachWorkflowDefinition(org, repositories, opts, func(repo, workflowPath string, definition *workflowDefinition) {
+		report.Summary.WorkflowsScanned++
+		usages, skipped := criticalDependencies(definition)
+		var thirdParty []dependencyUsage
+		for _, usage := range usages {
+			if actionClass(usage.Action, org) == actionClassThirdParty {
+				thirdParty = append(thirdParty, usage)
+			} else {
+				report.Summary.FirstPartyUsages++
+			}
+		}
+		report.Summary.NonCritical += skipped
+		if len(thirdParty) > 0 {
+			critical = append(critical, workflowUsages{repo: repo, workflow: workflowPath, usages: thirdParty})
+		}
+	})
+
+	// Aggregate per action repository and look for organization copies of each
+	dependencies := make(map[string]*HardDependency)
+	refs := make(map[string]map[string]bool)
+	workflows := make(map[string]map[string]bool)
+	repos := make(map[string]map[string]bool)
+	for _, wf := range critical {
+		for _, usage := range wf.usages {
+			repository := actionRepository(usage.Action)
+			if dependencies[repository] == nil {
+				dependencies[repository] = &HardDependency{Repository: repository}
+				refs[repository] = make(map[string]bool)
+				workflows[repository] = make(map[string]bool)
+				repos[repository] = make(map[string]bool)
+			}
+			dependencies[repository].Usages++
+			refs[repository][usage.Ref] = true
+			workflows[repository][wf.repo+"/"+wf.workflow] = true
+			repos[repository][wf.repo] = true
+		}
+	}
+	var names []string
+	for repository := range dependencies {
+		names = append(names, repository)
+	}
+	sort.Strings(names)
+	mirrors := make([][2]string, len(names))
+	runConcurrently(len(names), opts.Concurrency, func(i int) {
+		mirror, kind := findActionMirror(org, names[i], opts.Cache)
+		mirrors[i] = [2]string{mirror, kind}
+	})
+	for i, repository := range names {
+		dependency := dependencies[repository]
+		dependency.Mirror, dependency.MirrorKind = mirrors[i][0], mirrors[i][1]
+		for ref := range refs[repository] {
+			dependency.Refs = append(dependency.Refs, ref)
+		}
+		sort.Strings(dependency.Refs)
+		dependency.Workflows = len(workflows[repository])
+		dependency.Repositories = len(repos[repository])
+		report.Dependencies = append(report.Dependencies, *dependency)
+		if dependency.Mirror != "" {
+			report.Summary.Mirrored++
+		}
+	}
+	sort.SliceStable(report.Dependencies, func(i, j int) bool {
+		return report.Dependencies[i].Usages > report.Dependencies[j].Usages
+	})
+
+	exposedRepositories := make(map[string]bool)
+	for _, wf := range critical {
+		exposure := WorkflowDependencies{Repository: wf.repo, Workflow: wf.workflow}
+		jobs := make(map[string]bool)
+		actions := make(map[string]bool)
+		reported := make(map[string]bool)
+		for _, usage := range wf.usages {
+			reference := usage.Action + "@" + usage.Ref
+			if !jobs[usage.Job] {
+				jobs[usage.Job] = true
+				exposure.Jobs = append(exposure.Jobs, usage.Job)
+			}
+			if !actions[reference] {
+				actions[reference] = true
+				exposure.Actions = append(exposure.Actions, reference)
+			}
+			if !reported[reference] {
+				reported[reference] = true
+				report.Findings = append(report.Findings,
+					dependencyFinding(wf.repo, wf.workflow, usage, *dependencies[actionRepository(usage.Action)]))
+			}
+		}
+		sort.Strings(exposure.Actions)
+		report.Workflows = append(report.Workflows, exposure)
+		exposedRepositories[wf.repo] = true
+	}
+	sort.SliceStable(report.Workflows, func(i, j int) bool {
+		return len(report.Workflows[i].Actions) > len(report.Workflows[j].Actions)
+	})
+	report.Summary.ExposedWorkflows = len(report.Workflows)
+	report.Summary.ExposedRepositories = len(exposedRepositories)
+	report.Summary.Dependencies = len(report.Dependencies)
+
+	opts.finishFindings(org, &report.Findings)
+	report.Truncated = len(report.RemainingRepositories) > 0
+	report.ProcessTimeSeconds = time.Since(startTime).Seconds()
+
+	return opts.writeFindingsReport(org, &report, report.Findings, outputFile, func(writer io.Writer) error {
+		return outputDependencyReport(report, outputFormat, writer)
+	})
+}
+
+// describeMirror returns the organization copy of a dependency for display, or "none"
+func describeMirror(dependency HardDependency) string {
+	if dependency.Mirror == "" {
+		return "none"
+	}
+	return fmt.Sprintf("%s (%s)", dependency.Mirror, dependency.MirrorKind)
+}
+
+// outputDependencyReport outputs the hard dependency analysis in the specified format
+func outputDependencyReport(report DependencyReport, format string, writer io.Writer) error {
+	if formatter, ok := lookupFormatter(format); ok {
+		return formatter.Format(report, writer)
+	}
+
+	switch format {
+	case "table":
+		return outputDependencyTable(report, writer)
+
+	case "csv":
+		return outputDependencyCSV(report, writer)
+
+	case "markdown":
+		return outputFindingsMarkdown("🔗 Hard Dependencies on github.com-hosted Actions", report.Findings, report.RemainingRepositories, writer)
+
+	case "sarif":
+		return outputSARIF("dependencies", report.Findings, writer)
+
+	default: // "default"
+		fmt.Fprintln(writer, "🔗 Hard Dependencies on github.com-hosted Actions")
+		fmt.Fprintln(writer, "="+strings.Repeat("=", 50))
+
+		for _, exposure := range report.Workflows {
+			fmt.Fprintf(writer, "📄 %s → %s (jobs: %s)\n", exposure.Repository, exposure.Workflow, strings.Join(exposure.Jobs, ", "))
+			for _, action := range exposure.Actions {
+				fmt.Fprintf(writer, "   └─ %s\n", action)
+			}
+		}
+
+		if len(report.Dependencies) > 0 {
+			fmt.Fprintln(writer, "\n🧩 Third-party action repositories:")
+			for _, dependency := range report.Dependencies {
+				fmt.Fprintf(writer, "   • %s: %d usages in %d workflows of %d repositories, mirror: %s\n",
+					dependency.Repository, dependency.Usages, dependency.Workflows, dependency.Repositories, describeMirror(dependency))
+			}
+		}
+
+		fmt.Fprintln(writer, "\n📊 Summary:")
+		fmt.Fprintf(writer, "   • Workflows scanned: %d\n", report.Summary.WorkflowsScanned)
+		fmt.Fprintf(writer, "   • Exposed workflows: %d in %d repositories\n", report.Summary.ExposedWorkflows, report.Summary.ExposedRepositories)
+		fmt.Fprintf(writer, "   • Third-party action repositories: %d (%d with an organization copy)\n", report.Summary.Dependencies, report.Summary.Mirrored)
+		fmt.Fprintf(writer, "   • Usages under continue-on-error: %d\n", report.Summary.NonCritical)
+		fmt.Fprintf(writer, "   • GitHub and organization action usages: %d\n", report.Summary.FirstPartyUsages)
+		fmt.Fprintf(writer, "   ⏱️  Process time: %.3fs\n", report.ProcessTimeSeconds)
+
+		outputFindings(report.Findings, writer)
+
+		if report.Truncated {
+			outputTruncationNotice(report.RemainingRepositories, writer)
+		}
+
+		return nil
+	}
+}
+
+// outputDependencyTable outputs the hard dependency analysis in table format
+func outputDependencyTable(report DependencyReport, writer io.Writer) error {
+	fmt.Fprintln(writer, "╔════════════════════════════════════════════════════════════════════════════════════════════════════╗")
+	fmt.Fprintf(writer, "║                                     🔗 HARD DEPENDENCIES                                           ║\n")
+	fmt.Fprintln(writer, "╚════════════════════════════════════════════════════════════════════════════════════════════════════╝")
+	fmt.Fprintf(writer, "  🏢 Organization: %-59s \n", report.Organization)
+	fmt.Fprintf(writer, "  📄 Exposed Workflows: %-54d \n", report.Summary.ExposedWorkflows)
+	fmt.Fprintf(writer, "  🧩 Third-party Actions: %-52d \n", report.Summary.Dependencies)
+	fmt.Fprintf(writer, "  🪞 With Organization Copy: %-49d \n", report.Summary.Mirrored)
+	fmt.Fprintln(writer, " ═════════════════════════════════════════════════════════════════════════════════════════════════════")
+	fmt.Fprintln(writer)
+
+	if len(report.Dependencies) == 0 {
+		fmt.Fprintln(writer, "┌─────────────────────────────────────────┐")
+		fmt.Fprintln(writer, "│     No third-party hard dependencies    │")
+		fmt.Fprintln(writer, "└─────────────────────────────────────────┘")
+		return nil
+	}
+
+	fmt.Fprintln(writer, "┌────────────────────────────────────────┬────────┬───────────┬───────┬────────────────────────────────────┐")
+	fmt.Fprintf(writer, "│ %-37s │ %-6s │ %-9s │ %-5s │ %-34s │\n", "🧩 ACTION REPOSITORY", "USAGES", "WORKFLOWS", "REPOS", "MIRROR")
+	fmt.Fprintln(writer, "├────────────────────────────────────────┼────────┼───────────┼───────┼────────────────────────────────────┤")
+	for _, dependency := range report.Dependencies {
+		fmt.Fprintf(writer, "│ %-38s │ %6d │ %9d │ %5d │ %-34s │\n",
+			truncate(dependency.Repository, 38), dependency.Usages, dependency.Workflows, dependency.Repositories,
+			truncate(describeMirror(dependency), 34))
+	}
+	fmt.Fprintln(writer, "└────────────────────────────────────────┴────────┴───────────┴───────┴────────────────────────────────────┘")
+	fmt.Fprintln(writer)
+
+	fmt.Fprintln(writer, "┌─────────────────────┬────────────────────────────────┬──────────────────────────────────────────────────┐")
+	fmt.Fprintf(writer, "│ %-18s │ %-29s │ %-48s │\n", "📁 REPOSITORY", "📄 WORKFLOW", "THIRD-PARTY ACTIONS")
+	fmt.Fprintln(writer, "├─────────────────────┼────────────────────────────────┼──────────────────────────────────────────────────┤")
+	for _, exposure := range report.Workflows {
+		fmt.Fprintf(writer, "│ %-19s │ %-30s │ %-48s │\n",
+			truncate(exposure.Repository, 19), truncate(exposure.Workflow, 30), truncate(strings.Join(exposure.Actions, ", "), 48))
+	}
+	fmt.Fprintln(writer, "└─────────────────────┴────────────────────────────────┴──────────────────────────────────────────────────┘")
+	outputFindings(report.Findings, writer)
+	fmt.Fprintln(writer)
+	return nil
+}
+
+// outputDependencyCSV outputs the hard dependency analysis in CSV format, one row per workflow and action
+func outputDependencyCSV(report DependencyReport, writer io.Writer) error {
+	mirrors := make(map[string]string)
+	for _, dependency := range report.Dependencies {
+		mirrors[dependency.Repository] = dependency.Mirror
+	}
+
+	w := newCSVWriter(writer)
+	w.Write([]string{"Repository", "Workflow", "Jobs", "Action", "Mirror"})
+	for _, exposure := range report.Workflows {
+		for _, action := range exposure.Actions {
+			name, _, _ := splitActionReference(action)
+			w.Write([]string{exposure.Repository, exposure.Workflow, strings.Join(exposure.Jobs, " "), action, mirrors[actionRepository(name)]})
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,356 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DeprecatedRuntimeReport lists the workflows of an organization that use actions on deprecated Node.js runtimes
+type DeprecatedRuntimeReport struct {
+	Organization          string                    `json:"organization"`
+	Summary               DeprecatedRuntimeSummary  `json:"summary"`
+	Actions               []DeprecatedRuntimeAction `json:"actions"` // one per action@ref on a deprecated runtime, most used first
+	Usages                []DeprecatedRuntimeUsage  `json:"usages"`
+	Findings              []Finding                 `json:"findings"`
+	Truncated             bool                      `json:"truncated"`
+	RemainingRepositories []string                  `json:"remaining_repositories,omitempty"`
+	ProcessTimeSeconds    float64                   `json:"process_time_seconds"`
+}
+
+// DeprecatedRuntimeSummary represents summary statistics of the deprecated runtime scan
+type DeprecatedRuntimeSummary struct {
+	WorkflowsScanned     int             `json:"workflows_scanned"`
+	ActionsChecked       int             `json:"actions_checked"` // distinct action@ref with a readable action.yml
+	Unknown              int             `json:"unknown"`         // distinct action@ref whose action.yml could not be read
+	Runtimes             []RuntimeUsages `json:"runtimes"`        // usages per runtime, most used first
+	DeprecatedActions    int             `json:"deprecated_actions"`
+	DeprecatedUsages     int             `json:"deprecated_usages"`
+	AffectedWorkflows    int             `json:"affected_workflows"`
+	AffectedRepositories int             `json:"affected_repositories"`
+}
+
+// DeprecatedRuntimeAction is an action@ref on a deprecated runtime with the repositories using it
+type DeprecatedRuntimeAction struct {
+	Action       string   `json:"action"`
+	Ref          string   `json:"ref"`
+	Runtime      string   `json:"runtime"`
+	Usages       int      `json:"usages"`
+	Workflows    int      `json:"workflows"`
+	Repositories []string `json:"repositories"`
+}
+
+// DeprecatedRuntimeUsage is one step using an action on a deprecated runtime
+type DeprecatedRuntimeUsage struct {
+	Repository string `json:"repository"`
+	Workflow   string `json:"workflow"`
+	Job        string `json:"job"`
+	Step       int    `json:"step"`
+	Action     string `json:"action"`
+	Ref        string `json:"ref"`
+	Runtime    string `json:"runtime"`
+	Via        string `json:"via,omitempty"` // composite actions the usage was reached through (--transitive)
+}
+
+// deprecatedRuntimeFinding flags a workflow using an action on a deprecated runtime
+func deprecatedRuntimeFinding(usage DeprecatedRuntimeUsage) Finding {
+	message := fmt.Sprintf("%s@%s runs on %s, a Node.js runtime GitHub is phasing out", usage.Action, usage.Ref, usage.Runtime)
+	if usage.Via != "" {
+		message += fmt.Sprintf(" (used by %s)", usage.Via)
+	}
+	return Finding{
+		RuleID:     RuleDeprecatedRuntime,
+		Severity:   SeverityWarning,
+		Repository: usage.Repository,
+		Workflow:   usage.Workflow,
+		Action:     usage.Action,
+		Version:    usage.Ref,
+		Message:    message,
+	}
+}
+
+// analyzeDeprecatedRuntimes reads the runtime of every action the workflows of an organization use and lists
+// the workflows and repositories that use actions on deprecated Node.js runtimes
+func analyzeDeprecatedRuntimes(org string, startTime time.Time, outputFormat, outputFile string, opts scanOptions) error {
+	repositories, _, err := listRepositoryWorkflows(org, opts)
+	if err != nil {
+		return err
+	}
+
+	var files []WorkflowFile
+	for _, repo := range repositories {
+		for _, workflowPath := range repo.Workflows {
+			files = append(files, WorkflowFile{Repo: repo.Name, Path: workflowPath})
+		}
+	}
+
+	logInfof("🕰️  Checking %d workflow files for actions on deprecated runtimes...\n\n", len(files))
+
+	results := fetchWorkflows(org, files, opts, func(wf WorkflowFile, result workflowFetch) {
+		if result.Err != nil {
+			logWarnf("⚠️  Warning: Could not analyze %s/%s: %v\n", wf.Repo, wf.Path, result.Err)
+		}
+	})
+
+	// Read the action.yml of every distinct action@ref once; reusable workflow calls have none
+	seen := make(map[string]bool)
+	var references []string
+	var skipped []WorkflowFile
+	for i, result := range results {
+		if result.Skipped {
+			skipped = append(skipped, files[i])
+			continue
+		}
+		for _, action := range result.Actions {
+			reference := action.Name + "@" + action.Version
+			if !isReusableWorkflowCall(action.Name) && !seen[reference] {
+				seen[reference] = true
+				references = append(references, reference)
+			}
+		}
+	}
+	sort.Strings(references)
+	runtimes := resolveRuntimes(references, org, opts)
+
+	report := DeprecatedRuntimeReport{
+		Organization: org,
+		Actions:      []DeprecatedRuntimeAction{},
+		Usages:       []DeprecatedRuntimeUsage{},
+		Findings:     []Finding{},
+	}
+	for _, reference := range references {
+		if runtimes[reference] == "" {
+			report.Summary.Unknown++
+		} else {
+			report.Summary.ActionsChecked++
+		}
+	}
+
+	byRuntime := make(map[string]*RuntimeUsages)
+	listed := make(map[string]bool) // action@ref already listed under its runtime
+	actionIndex := make(map[string]int)
+	actionWorkflows := make(map[string]map[string]bool)
+	actionRepositories := make(map[string]map[string]bool)
+	affectedWorkflows := make(map[string]bool)
+	affectedRepositories := make(map[string]bool)
+	flagged := make(map[string]bool)
+	for i, result := range results {
+		if result.Skipped || result.Err != nil {
+			continue
+		}
+		wf := files[i]
+		report.Summary.WorkflowsScanned++
+
+		for _, action := range result.Actions {
+			reference := action.Name + "@" + action.Version
+			runtime := runtimes[reference]
+			if runtime == "" {
+				continue
+			}
+			if byRuntime[runtime] == nil {
+				byRuntime[runtime] = &RuntimeUsages{Runtime: runtime, Actions: []string{}, Deprecated: deprecatedRuntimes[runtime]}
+			}
+			byRuntime[runtime].Usages++
+			if !listed[reference] {
+				listed[reference] = true
+				byRuntime[runtime].Actions = append(byRuntime[runtime].Actions, reference)
+			}
+			if !deprecatedRuntimes[runtime] {
+				continue
+			}
+
+			usage := DeprecatedRuntimeUsage{
+				Repository: wf.Repo,
+				Workflow:   wf.Path,
+				Job:        action.Job,
+				Step:       action.Step,
+				Action:     action.Name,
+				Ref:        action.Version,
+				Runtime:    runtime,
+				Via:        action.Via,
+			}
+			report.Usages = append(report.Usages, usage)
+			report.Summary.DeprecatedUsages++
+			affectedWorkflows[wf.Repo+"/"+wf.Path] = true
+			affectedRepositories[wf.Repo] = true
+
+			j, ok := actionIndex[reference]
+			if !ok {
+				j = len(report.Actions)
+				actionIndex[reference] = j
+				actionWorkflows[reference] = make(map[string]bool)
+				actionRepositories[reference] = make(map[string]bool)
+				report.Actions = append(report.Actions, DeprecatedRuntimeAction{Action: action.Name, Ref: action.Version, Runtime: runtime})
+			}
+			report.Actions[j].Usages++
+			actionWorkflows[reference][wf.Repo+"/"+wf.Path] = true
+			actionRepositories[reference][wf.Repo] = true
+
+			// One finding per reference and workflow, however often the workflow uses it
+			if key := wf.Repo + "|" + wf.Path + "|" + reference; !flagged[key] {
+				flagged[key] = true
+				report.Findings = append(report.Findings, deprecatedRuntimeFinding(usage))
+			}
+		}
+	}
+	report.RemainingRepositories = remainingRepositories(skipped)
+
+	for i, action := range report.Actions {
+		reference := action.Action + "@" + action.Ref
+		report.Actions[i].Workflows = len(actionWorkflows[reference])
+		report.Actions[i].Repositories = []string{}
+		for repo := range actionRepositories[reference] {
+			report.Actions[i].Repositories = append(report.Actions[i].Repositories, repo)
+		}
+		sort.Strings(report.Actions[i].Repositories)
+	}
+	sort.SliceStable(report.Actions, func(i, j int) bool {
+		a, b := report.Actions[i], report.Actions[j]
+		if a.Usages != b.Usages {
+			return a.Usages > b.Usages
+		}
+		return a.Action+"@"+a.Ref < b.Action+"@"+b.Ref
+	})
+	report.Summary.Runtimes = []RuntimeUsages{}
+	for _, entry := range byRuntime {
+		sort.Strings(entry.Actions)
+		report.Summary.Runtimes = append(report.Summary.Runtimes, *entry)
+	}
+	sort.Slice(report.Summary.Runtimes, func(i, j int) bool {
+		a, b := report.Summary.Runtimes[i], report.Summary.Runtimes[j]
+		if a.Usages != b.Usages {
+			return a.Usages > b.Usages
+		}
+		return a.Runtime < b.Runtime
+	})
+	report.Summary.DeprecatedActions = len(report.Actions)
+	report.Summary.AffectedWorkflows = len(affectedWorkflows)
+	report.Summary.AffectedRepositories = len(affectedRepositories)
+
+	opts.finishFindings(org, &report.Findings)
+	report.Truncated = len(report.RemainingRepositories) > 0
+	report.ProcessTimeSeconds = time.Since(startTime).Seconds()
+
+	return opts.writeFindingsReport(org, &report, report.Findings, outputFile, func(writer io.Writer) error {
+		return outputDeprecatedRuntimeReport(report, outputFormat, writer)
+	})
+}
+
+// describeDeprecatedUsage renders a usage for status output, e.g. build step 2: actions/setup-node@v3 (node16)
+func describeDeprecatedUsage(usage DeprecatedRuntimeUsage) string {
+	description := fmt.Sprintf("%s step %d: %s@%s (%s)", usage.Job, usage.Step, usage.Action, usage.Ref, usage.Runtime)
+	if usage.Via != "" {
+		description += " via " + usage.Via
+	}
+	return description
+}
+
+// outputDeprecatedRuntimeReport outputs the deprecated runtime report in the specified format
+func outputDeprecatedRuntimeReport(report DeprecatedRuntimeReport, format string, writer io.Writer) error {
+	if formatter, ok := lookupFormatter(format); ok {
+		return formatter.Format(report, writer)
+	}
+
+	switch format {
+	case "table":
+		return outputDeprecatedRuntimeTable(report, writer)
+
+	case "csv":
+		return outputDeprecatedRuntimeCSV(report, writer)
+
+	case "markdown":
+		return outputFindingsMarkdown("🕰️ Deprecated Runtimes", report.Findings, report.RemainingRepositories, writer)
+
+	case "sarif":
+		return outputSARIF("deprecated-runtimes", report.Findings, writer)
+
+	default: // "default"
+		fmt.Fprintln(writer, "🕰️  Deprecated Runtimes")
+		fmt.Fprintln(writer, "="+strings.Repeat("=", 50))
+
+		if len(report.Usages) == 0 {
+			fmt.Fprintln(writer, "✅ No workflow uses an action on a deprecated runtime")
+		}
+		repository, workflow := "", ""
+		for _, usage := range report.Usages {
+			if usage.Repository != repository {
+				repository, workflow = usage.Repository, ""
+				fmt.Fprintf(writer, "\n📁 %s\n", repository)
+			}
+			if usage.Workflow != workflow {
+				workflow = usage.Workflow
+				fmt.Fprintf(writer, "   📄 %s\n", workflow)
+			}
+			fmt.Fprintf(writer, "      ⚠️  %s\n", describeDeprecatedUsage(usage))
+		}
+
+		if len(report.Actions) > 0 {
+			fmt.Fprintln(writer, "\n🔧 Actions to upgrade:")
+			for _, action := range report.Actions {
+				fmt.Fprintf(writer, "   • %s@%s (%s): %d usages in %d workflows of %d repositories\n",
+					action.Action, action.Ref, action.Runtime, action.Usages, action.Workflows, len(action.Repositories))
+			}
+		}
+
+		fmt.Fprintln(writer, "\n📊 Summary:")
+		fmt.Fprintf(writer, "   • Workflows scanned: %d\n", report.Summary.WorkflowsScanned)
+		fmt.Fprintf(writer, "   • Actions checked: %d (%d without readable action.yml)\n", report.Summary.ActionsChecked, report.Summary.Unknown)
+		fmt.Fprintf(writer, "   • Runtimes: %s\n", formatRuntimes(report.Summary.Runtimes))
+		fmt.Fprintf(writer, "   • Actions on deprecated runtimes: %d (%d usages)\n", report.Summary.DeprecatedActions, report.Summary.DeprecatedUsages)
+		fmt.Fprintf(writer, "   • Affected: %d workflows in %d repositories\n", report.Summary.AffectedWorkflows, report.Summary.AffectedRepositories)
+		fmt.Fprintf(writer, "   ⏱️  Process time: %.3fs\n", report.ProcessTimeSeconds)
+
+		if report.Truncated {
+			outputTruncationNotice(report.RemainingRepositories, writer)
+		}
+
+		return nil
+	}
+}
+
+// outputDeprecatedRuntimeTable outputs the actions on deprecated runtimes in table format
+func outputDeprecatedRuntimeTable(report DeprecatedRuntimeReport, writer io.Writer) error {
+	fmt.Fprintln(writer, "╔════════════════════════════════════════════════════════════════════════════════════════════════════╗")
+	fmt.Fprintf(writer, "║                                    🕰️  DEPRECATED RUNTIMES                                          ║\n")
+	fmt.Fprintln(writer, "╚════════════════════════════════════════════════════════════════════════════════════════════════════╝")
+	fmt.Fprintf(writer, "  🏢 Organization: %-59s \n", report.Organization)
+	fmt.Fprintf(writer, "  🎯 Actions Checked: %-57d \n", report.Summary.ActionsChecked)
+	fmt.Fprintf(writer, "  ⚠️  Deprecated Actions: %-53d \n", report.Summary.DeprecatedActions)
+	fmt.Fprintf(writer, "  📄 Affected Workflows: %-54d \n", report.Summary.AffectedWorkflows)
+	fmt.Fprintf(writer, "  📁 Affected Repositories: %-51d \n", report.Summary.AffectedRepositories)
+	fmt.Fprintln(writer, " ═════════════════════════════════════════════════════════════════════════════════════════════════════")
+	fmt.Fprintln(writer)
+
+	if len(report.Actions) == 0 {
+		fmt.Fprintln(writer, "┌─────────────────────────────────────────┐")
+		fmt.Fprintln(writer, "│  No actions on deprecated runtimes      │")
+		fmt.Fprintln(writer, "└─────────────────────────────────────────┘")
+		return nil
+	}
+
+	fmt.Fprintln(writer, "┌────────────────────────────────────────┬──────────────┬─────────┬────────┬───────────┬──────────────┐")
+	fmt.Fprintf(writer, "│ %-37s │ %-12s │ %-7s │ %-6s │ %-9s │ %-12s │\n", "🔧 ACTION", "REF", "RUNTIME", "USAGES", "WORKFLOWS", "REPOSITORIES")
+	fmt.Fprintln(writer, "├────────────────────────────────────────┼──────────────┼─────────┼────────┼───────────┼──────────────┤")
+	for _, action := range report.Actions {
+		fmt.Fprintf(writer, "│ %-38s │ %-12s │ %-7s │ %6d │ %9d │ %12d │\n",
+			truncate(action.Action, 38), truncate(action.Ref, 12), action.Runtime, action.Usages, action.Workflows, len(action.Repositories))
+	}
+	fmt.Fprintln(writer, "└────────────────────────────────────────┴──────────────┴─────────┴────────┴───────────┴──────────────┘")
+	fmt.Fprintln(writer)
+	return nil
+}
+
+// outputDeprecatedRuntimeCSV outputs the deprecated runtime report in CSV format, one row per usage
+func outputDeprecatedRuntimeCSV(report DeprecatedRuntimeReport, writer io.Writer) error {
+	w := newCSVWriter(writer)
+	w.Write([]string{"Repository", "Workflow", "Job", "Step", "Action", "Ref", "Runtime", "Via"})
+	for _, usage := range report.Usages {
+		w.Write([]string{usage.Repository, usage.Workflow, usage.Job, strconv.Itoa(usage.Step),
+			usage.Action, usage.Ref, usage.Runtime, usage.Via})
+	}
+	w.Flush()
+	return w.Error()
+}
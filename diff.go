@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// runDiffCommand implements `gh action-lens diff`: the changes between two saved detailed reports, in
+// the format of the digest
+func runDiffCommand(args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+
+	var outputFormat string
+	var failOnNew bool
+
+	fs.StringVar(&outputFormat, "format", "default", "Output format: default, json, markdown")
+	fs.StringVar(&outputFormat, "f", "default", "Output format: default, json, markdown")
+	fs.BoolVar(&failOnNew, "fail-on-new", false, "Exit with status 3 when the newer report has findings the older one does not")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "\nUsage:\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens diff <old.json> <new.json> [flags]\n\n")
+		fmt.Fprintf(os.Stderr, "Compare two saved detailed JSON reports (--scan all --detailed --format json): new and removed\n")
+		fmt.Fprintf(os.Stderr, "actions, the pinning rate, moved tags, upgraded and downgraded versions, added and removed\n")
+		fmt.Fprintf(os.Stderr, "workflows, and new and resolved findings.\n\n")
+		fmt.Fprintf(os.Stderr, "Flags:\n")
+		fmt.Fprintf(os.Stderr, "  -f, --format <string>\n")
+		fmt.Fprintf(os.Stderr, "        Output format: default, json, markdown (default \"default\")\n\n")
+		fmt.Fprintf(os.Stderr, "      --fail-on-new\n")
+		fmt.Fprintf(os.Stderr, "        Exit with status 3 when the newer report has findings the older one does not\n\n")
+		fmt.Fprintf(os.Stderr, "Examples:\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens diff scans/2026-10-01.json scans/2026-10-08.json\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens diff main.json pr.json --format markdown --fail-on-new\n\n")
+	}
+
+	// The reports come first, as in the usage
+	var paths []string
+	for len(args) > 0 && len(paths) < 2 && (len(args[0]) == 0 || args[0][0] != '-') {
+		paths, args = append(paths, args[0]), args[1:]
+	}
+	fs.Parse(args)
+	paths = append(paths, fs.Args()...)
+	if len(paths) != 2 {
+		fs.Usage()
+		return fmt.Errorf("diff requires exactly two reports")
+	}
+	switch outputFormat {
+	case "default", "json", "markdown":
+	default:
+		return fmt.Errorf("invalid output format '%s'. Valid options: default, json, markdown", outputFormat)
+	}
+
+	old, err := loadSnapshot(paths[0])
+	if err != nil {
+		return err
+	}
+	current, err := loadSnapshot(paths[1])
+	if err != nil {
+		return err
+	}
+	diff := buildDigest(old.Report, current.Report, old.Time, current.Time)
+
+	switch outputFormat {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(diff); err != nil {
+			return err
+		}
+	case "markdown":
+		fmt.Print(digestMarkdown(diff))
+	default:
+		outputDigest(diff, os.Stdout)
+	}
+
+	if failOnNew && len(diff.NewFindings) > 0 {
+		return &failOnError{exceeded: []string{fmt.Sprintf("%d new findings", len(diff.NewFindings))}}
+	}
+	return nil
+}
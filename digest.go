@@ -0,0 +1,569 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// digestWindow is the period a digest compares against
+const digestWindow = 7 * 24 * time.Hour
+
+// Digest summarizes what changed between two scans of an organization
+type Digest struct {
+	Organization     string               `json:"organization"`
+	From             time.Time            `json:"from"`
+	To               time.Time            `json:"to"`
+	NewActions       []string             `json:"new_actions"`
+	Introductions    []ActionIntroduction `json:"introductions,omitempty"` // who added the new actions; with --audit-log
+	RemovedActions   []string             `json:"removed_actions"`
+	PinningRate      float64              `json:"pinning_rate"`       // share of action usages pinned to a commit SHA, 0-100
+	PinningRateDelta float64              `json:"pinning_rate_delta"` // percentage points since From
+	TagDrift         []TagDrift           `json:"tag_drift"`          // tags resolving to a different commit than at From
+	VersionChanges   []VersionChange      `json:"version_changes"`
+	WorkflowChanges  []WorkflowChange     `json:"workflow_changes"` // repositories that added or removed workflows
+	NewFindings      []Finding            `json:"new_findings"`
+	ResolvedFindings int                  `json:"resolved_findings"`
+	TotalFindings    int                  `json:"total_findings"`
+	Report           *ReportBranding      `json:"report,omitempty"` // report: section of the config file
+}
+
+// TagDrift records a tag of an action that was moved to a different commit between two scans
+type TagDrift struct {
+	Action  string `json:"action"`
+	Tag     string `json:"tag"`
+	FromSHA string `json:"from_sha"`
+	ToSHA   string `json:"to_sha"`
+}
+
+// Directions of a VersionChange
+const (
+	versionUpgrade   = "upgrade"
+	versionDowngrade = "downgrade"
+	versionChanged   = "changed" // the versions cannot be ordered, e.g. a branch replaced by a tag
+)
+
+// VersionChange records an action whose versions changed in a repository between two scans
+type VersionChange struct {
+	Repository string   `json:"repository"`
+	Action     string   `json:"action"`
+	From       []string `json:"from"`
+	To         []string `json:"to"`
+	Direction  string   `json:"direction"` // upgrade, downgrade, or changed
+}
+
+// WorkflowChange records the workflow files a repository added and removed between two scans
+type WorkflowChange struct {
+	Repository string   `json:"repository"`
+	Added      []string `json:"added,omitempty"`
+	Removed    []string `json:"removed,omitempty"`
+}
+
+// snapshot is a saved detailed report and the time it was taken
+type snapshot struct {
+	Path   string
+	Time   time.Time
+	Report ComprehensiveReport
+}
+
+// runDigestCommand implements `gh action-lens digest`
+func runDigestCommand(args []string) error {
+	fs := flag.NewFlagSet("digest", flag.ExitOnError)
+
+	var historyDir string
+	var organization string
+	var outputFormat string
+	var configFile string
+	var send bool
+	var auditLog bool
+
+	fs.StringVar(&historyDir, "history", "", "Directory of saved detailed JSON reports")
+	fs.StringVar(&organization, "org", "", "Only use reports of this organization")
+	fs.StringVar(&organization, "o", "", "Only use reports of this organization")
+	fs.StringVar(&outputFormat, "format", "default", "Output format: default, json, markdown")
+	fs.StringVar(&outputFormat, "f", "default", "Output format: default, json, markdown")
+	fs.StringVar(&configFile, "config", "", "Policy file with the notification channels and report branding")
+	fs.BoolVar(&send, "send", false, "Send the digest to the notification channels of the config file")
+	fs.BoolVar(&auditLog, "audit-log", false, "Find who introduced each new action from commits and the organization audit log")
+
+	addHostnameFlag(fs)
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "\nUsage:\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens digest --history <dir> [flags]\n\n")
+		fmt.Fprintf(os.Stderr, "Summarize week-over-week changes between saved scans and optionally send them.\n\n")
+		fmt.Fprintf(os.Stderr, "Flags:\n")
+		fmt.Fprintf(os.Stderr, "      --history <dir>\n")
+		fmt.Fprintf(os.Stderr, "        Directory of saved detailed JSON reports\n\n")
+		fmt.Fprintf(os.Stderr, "  -o, --org <string>\n")
+		fmt.Fprintf(os.Stderr, "        Only use reports of this organization\n\n")
+		fmt.Fprintf(os.Stderr, "  -f, --format <string>\n")
+		fmt.Fprintf(os.Stderr, "        Output format: default, json, markdown (default \"default\")\n\n")
+		fmt.Fprintf(os.Stderr, "      --config <path>\n")
+		fmt.Fprintf(os.Stderr, "        Policy file with the notification channels and report branding\n\n")
+		fmt.Fprintf(os.Stderr, "      --send\n")
+		fmt.Fprintf(os.Stderr, "        Send the digest to the notification channels of the config file\n\n")
+		fmt.Fprintf(os.Stderr, "      --audit-log\n")
+		fmt.Fprintf(os.Stderr, "        Find who introduced each new action from commits and the organization audit log\n")
+		fmt.Fprintf(os.Stderr, "        (the token needs the read:audit_log scope)\n\n")
+		fmt.Fprintf(os.Stderr, "      --hostname <host>\n")
+		fmt.Fprintf(os.Stderr, "        GitHub host, e.g. a GitHub Enterprise Server (default: GH_HOST or the gh default host)\n\n")
+		fmt.Fprintf(os.Stderr, "Examples:\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens digest --history ./scans\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens digest --history ./scans --org myorg --config action-lens.yml --send\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens digest --history ./scans --org myorg --audit-log --format markdown\n\n")
+	}
+
+	fs.Parse(args)
+
+	if historyDir == "" {
+		fs.Usage()
+		return fmt.Errorf("--history is required")
+	}
+	switch outputFormat {
+	case "default", "json", "markdown":
+	default:
+		return fmt.Errorf("invalid output format '%s'. Valid options: default, json, markdown", outputFormat)
+	}
+
+	var config *Config
+	if configFile != "" {
+		var err error
+		if config, err = loadConfig(configFile); err != nil {
+			return err
+		}
+	}
+	if send && (config == nil || !config.Notifications.configured()) {
+		return fmt.Errorf("--send requires a --config file with notification channels")
+	}
+
+	snapshots, err := loadSnapshots(historyDir, organization)
+	if err != nil {
+		return err
+	}
+	if len(snapshots) < 2 {
+		return fmt.Errorf("at least two saved reports are needed in %s, found %d", historyDir, len(snapshots))
+	}
+
+	latest := snapshots[len(snapshots)-1]
+	baseline := selectBaseline(snapshots, latest.Time.Add(-digestWindow))
+	digest := buildDigest(baseline.Report, latest.Report, baseline.Time, latest.Time)
+	digest.Report = reportBranding(config, "", "", nil)
+	if auditLog {
+		digest.Introductions = correlateNewActions(latest.Report, digest.NewActions, baseline.Time)
+	}
+
+	switch outputFormat {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(digest); err != nil {
+			return err
+		}
+	case "markdown":
+		fmt.Print(digestMarkdown(digest))
+	default:
+		outputDigest(digest, os.Stdout)
+	}
+
+	if send {
+		subject := fmt.Sprintf("gh-action-lens digest for %s (%s)", digest.Organization, digest.To.Format("2006-01-02"))
+		if err := config.Notifications.send(subject, digestMarkdown(digest)); err != nil {
+			return err
+		}
+		logInfof("📨 Digest sent\n")
+	}
+	return nil
+}
+
+// loadSnapshots reads every detailed JSON report in a directory, oldest first.
+// Files that are not detailed reports are skipped.
+func loadSnapshots(dir, org string) ([]snapshot, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []snapshot
+	for _, path := range paths {
+		s, err := loadSnapshot(path)
+		if errors.Is(err, errNotSnapshot) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if org != "" && !strings.EqualFold(s.Report.Organization, org) {
+			continue
+		}
+		snapshots = append(snapshots, s)
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Time.Before(snapshots[j].Time)
+	})
+	return snapshots, nil
+}
+
+// errNotSnapshot is returned for JSON files that are not saved detailed reports
+var errNotSnapshot = errors.New("not a saved detailed JSON report")
+
+// loadSnapshot reads a saved detailed report
+func loadSnapshot(path string) (snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return snapshot{}, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+
+	var report ComprehensiveReport
+	if err := json.Unmarshal(data, &report); err != nil || report.ScanTimestamp == "" {
+		return snapshot{}, fmt.Errorf("%s: %w", path, errNotSnapshot)
+	}
+	taken, err := time.Parse(time.RFC3339, report.ScanTimestamp)
+	if err != nil {
+		return snapshot{}, fmt.Errorf("%s: %w", path, errNotSnapshot)
+	}
+	return snapshot{Path: path, Time: taken, Report: report}, nil
+}
+
+// selectBaseline returns the newest snapshot taken at or before cutoff, or the oldest one
+// when the history does not reach back that far
+func selectBaseline(snapshots []snapshot, cutoff time.Time) snapshot {
+	baseline := snapshots[0]
+	for _, s := range snapshots[:len(snapshots)-1] {
+		if !s.Time.After(cutoff) {
+			baseline = s
+		}
+	}
+	return baseline
+}
+
+// buildDigest compares two detailed reports
+func buildDigest(old, current ComprehensiveReport, from, to time.Time) Digest {
+	digest := Digest{
+		Organization:    current.Organization,
+		From:            from,
+		To:              to,
+		TotalFindings:   len(current.Findings),
+		NewFindings:     []Finding{},
+		TagDrift:        detectTagDrift(old, current),
+		VersionChanges:  detectVersionChanges(old, current),
+		WorkflowChanges: detectWorkflowChanges(old, current),
+	}
+
+	oldActions := reportActionNames(old)
+	currentActions := reportActionNames(current)
+	for name := range currentActions {
+		if !oldActions[name] {
+			digest.NewActions = append(digest.NewActions, name)
+		}
+	}
+	for name := range oldActions {
+		if !currentActions[name] {
+			digest.RemovedActions = append(digest.RemovedActions, name)
+		}
+	}
+	sort.Strings(digest.NewActions)
+	sort.Strings(digest.RemovedActions)
+
+	digest.PinningRate = pinningRate(current)
+	digest.PinningRateDelta = digest.PinningRate - pinningRate(old)
+
+	oldFindings := make(map[string]bool)
+	for _, f := range old.Findings {
+		oldFindings[findingKey(f)] = true
+	}
+	currentFindings := make(map[string]bool)
+	for _, f := range current.Findings {
+		currentFindings[findingKey(f)] = true
+		if !oldFindings[findingKey(f)] {
+			digest.NewFindings = append(digest.NewFindings, f)
+		}
+	}
+	for key := range oldFindings {
+		if !currentFindings[key] {
+			digest.ResolvedFindings++
+		}
+	}
+
+	return digest
+}
+
+// findingKey identifies a finding across scans
+func findingKey(f Finding) string {
+	return strings.Join([]string{f.RuleID, f.Repository, f.Workflow, f.Action, f.Version}, "|")
+}
+
+// reportActionNames returns the set of actions used in a report
+func reportActionNames(report ComprehensiveReport) map[string]bool {
+	names := make(map[string]bool)
+	for _, repo := range report.Repositories {
+		for _, workflow := range repo.Workflows {
+			for _, action := range workflow.Actions {
+				names[action.Name] = true
+			}
+		}
+	}
+	return names
+}
+
+// resolvedTags maps every tag-pinned action@tag of a report to the commit it resolved to
+func resolvedTags(report ComprehensiveReport) map[string]string {
+	tags := make(map[string]string)
+	for _, repo := range report.Repositories {
+		for _, workflow := range repo.Workflows {
+			for _, action := range workflow.Actions {
+				if action.Pinning == PinTag && action.ResolvedSHA != "" {
+					tags[action.Name+"@"+action.Version] = action.ResolvedSHA
+				}
+			}
+		}
+	}
+	return tags
+}
+
+// detectTagDrift lists the tags used in both reports that resolved to different commits
+func detectTagDrift(old, current ComprehensiveReport) []TagDrift {
+	oldTags := resolvedTags(old)
+	drift := []TagDrift{}
+	for reference, sha := range resolvedTags(current) {
+		if oldSHA, ok := oldTags[reference]; ok && oldSHA != sha {
+			action, tag, _ := splitActionReference(reference)
+			drift = append(drift, TagDrift{Action: action, Tag: tag, FromSHA: oldSHA, ToSHA: sha})
+		}
+	}
+	sort.Slice(drift, func(i, j int) bool {
+		if drift[i].Action != drift[j].Action {
+			return drift[i].Action < drift[j].Action
+		}
+		return drift[i].Tag < drift[j].Tag
+	})
+	return drift
+}
+
+// repositoryActionVersions maps repository and action of a report to the versions used, SHA-pinned
+// usages by the tag they correspond to when known
+func repositoryActionVersions(report ComprehensiveReport) map[string]map[string]map[string]bool {
+	versions := make(map[string]map[string]map[string]bool)
+	for _, repo := range report.Repositories {
+		actions := make(map[string]map[string]bool)
+		for _, workflow := range repo.Workflows {
+			for _, action := range workflow.Actions {
+				if actions[action.Name] == nil {
+					actions[action.Name] = make(map[string]bool)
+				}
+				actions[action.Name][displayVersion(action)] = true
+			}
+		}
+		versions[repo.Name] = actions
+	}
+	return versions
+}
+
+// detectVersionChanges lists the actions used in a repository in both reports whose versions changed.
+// A change is an upgrade or downgrade when the highest versions of both reports are ordered versions.
+func detectVersionChanges(old, current ComprehensiveReport) []VersionChange {
+	oldVersions := repositoryActionVersions(old)
+	changes := []VersionChange{}
+	for repo, actions := range repositoryActionVersions(current) {
+		for action, versions := range actions {
+			previous, ok := oldVersions[repo][action]
+			if !ok {
+				continue
+			}
+			from, to := sortedKeys(previous), sortedKeys(versions)
+			if strings.Join(from, ",") == strings.Join(to, ",") {
+				continue
+			}
+			changes = append(changes, VersionChange{
+				Repository: repo,
+				Action:     action,
+				From:       from,
+				To:         to,
+				Direction:  versionDirection(from, to),
+			})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Repository != changes[j].Repository {
+			return changes[i].Repository < changes[j].Repository
+		}
+		return changes[i].Action < changes[j].Action
+	})
+	return changes
+}
+
+// versionDirection compares the highest parseable versions of two version lists
+func versionDirection(from, to []string) string {
+	highest := func(refs []string) []int {
+		var max []int
+		for _, ref := range refs {
+			if version, ok := parseVersion(ref); ok && (max == nil || compareVersions(version, max) > 0) {
+				max = version
+			}
+		}
+		return max
+	}
+	a, b := highest(from), highest(to)
+	if a == nil || b == nil {
+		return versionChanged
+	}
+	switch compareVersions(a, b) {
+	case -1:
+		return versionUpgrade
+	case 1:
+		return versionDowngrade
+	}
+	return versionChanged
+}
+
+// detectWorkflowChanges lists the repositories whose workflow files were added or removed. Repositories
+// that appear or disappear entirely are included with all their workflows.
+func detectWorkflowChanges(old, current ComprehensiveReport) []WorkflowChange {
+	workflows := func(report ComprehensiveReport) map[string]map[string]bool {
+		paths := make(map[string]map[string]bool)
+		for _, repo := range report.Repositories {
+			paths[repo.Name] = make(map[string]bool)
+			for _, workflow := range repo.Workflows {
+				paths[repo.Name][workflow.Path] = true
+			}
+		}
+		return paths
+	}
+	oldWorkflows, currentWorkflows := workflows(old), workflows(current)
+
+	repositories := make(map[string]bool)
+	for repo := range oldWorkflows {
+		repositories[repo] = true
+	}
+	for repo := range currentWorkflows {
+		repositories[repo] = true
+	}
+
+	changes := []WorkflowChange{}
+	for _, repo := range sortedKeys(repositories) {
+		change := WorkflowChange{Repository: repo}
+		for _, path := range sortedKeys(currentWorkflows[repo]) {
+			if !oldWorkflows[repo][path] {
+				change.Added = append(change.Added, path)
+			}
+		}
+		for _, path := range sortedKeys(oldWorkflows[repo]) {
+			if !currentWorkflows[repo][path] {
+				change.Removed = append(change.Removed, path)
+			}
+		}
+		if len(change.Added) > 0 || len(change.Removed) > 0 {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// pinningRate returns the percentage of action usages pinned to a full commit SHA
+func pinningRate(report ComprehensiveReport) float64 {
+	total, pinned := 0, 0
+	for _, repo := range report.Repositories {
+		for _, workflow := range repo.Workflows {
+			for _, action := range workflow.Actions {
+				total += action.Count
+				if isPinnedToSHA(action.Version) {
+					pinned += action.Count
+				}
+			}
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(pinned) / float64(total) * 100
+}
+
+// outputDigest writes the digest in the default format
+func outputDigest(d Digest, writer io.Writer) {
+	fmt.Fprintf(writer, "\n📬 Digest for %s: %s → %s\n\n", d.Organization, d.From.Format("2006-01-02"), d.To.Format("2006-01-02"))
+	fmt.Fprintf(writer, "🆕 New actions: %d\n", len(d.NewActions))
+	for _, name := range d.NewActions {
+		fmt.Fprintf(writer, "   • %s\n", name)
+		for _, introduction := range d.Introductions {
+			if introduction.Action == name {
+				fmt.Fprintf(writer, "     ↳ %s\n", describeIntroduction(introduction))
+			}
+		}
+	}
+	fmt.Fprintf(writer, "🗑️  Removed actions: %d\n", len(d.RemovedActions))
+	fmt.Fprintf(writer, "📌 Pinning rate: %.1f%% (%+.1f pts)\n", d.PinningRate, d.PinningRateDelta)
+	fmt.Fprintf(writer, "🏷️  Moved tags: %d\n", len(d.TagDrift))
+	for _, drift := range d.TagDrift {
+		fmt.Fprintf(writer, "   • %s@%s: %.7s → %.7s\n", drift.Action, drift.Tag, drift.FromSHA, drift.ToSHA)
+	}
+	fmt.Fprintf(writer, "🔄 Version changes: %d\n", len(d.VersionChanges))
+	for _, change := range d.VersionChanges {
+		fmt.Fprintf(writer, "   • [%s] %s in %s: %s → %s\n", change.Direction, change.Action, change.Repository,
+			strings.Join(change.From, ", "), strings.Join(change.To, ", "))
+	}
+	fmt.Fprintf(writer, "📄 Workflow changes: %d repositories\n", len(d.WorkflowChanges))
+	for _, change := range d.WorkflowChanges {
+		fmt.Fprintf(writer, "   • %s: +%d -%d\n", change.Repository, len(change.Added), len(change.Removed))
+		for _, path := range change.Added {
+			fmt.Fprintf(writer, "     + %s\n", path)
+		}
+		for _, path := range change.Removed {
+			fmt.Fprintf(writer, "     - %s\n", path)
+		}
+	}
+	fmt.Fprintf(writer, "🚨 New findings: %d (resolved %d, total %d)\n", len(d.NewFindings), d.ResolvedFindings, d.TotalFindings)
+	for _, f := range d.NewFindings {
+		fmt.Fprintf(writer, "   %s [%s] %s: %s\n", severityIcon(f.Severity), f.RuleID, f.Repository, f.Message)
+	}
+}
+
+// digestMarkdown renders the compact digest sent to notification channels
+func digestMarkdown(d Digest) string {
+	var b strings.Builder
+
+	d.Report.writeMarkdown(&b)
+	fmt.Fprintf(&b, "**%s**: %s → %s\n\n", d.Organization, d.From.Format("2006-01-02"), d.To.Format("2006-01-02"))
+	fmt.Fprintf(&b, "- New actions: %d", len(d.NewActions))
+	if len(d.NewActions) > 0 {
+		fmt.Fprintf(&b, " (`%s`)", strings.Join(d.NewActions, "`, `"))
+	}
+	for _, introduction := range d.Introductions {
+		fmt.Fprintf(&b, "\n  - `%s` %s", introduction.Action, describeIntroduction(introduction))
+	}
+	fmt.Fprintf(&b, "\n- Removed actions: %d\n", len(d.RemovedActions))
+	fmt.Fprintf(&b, "- Pinning rate: %.1f%% (%+.1f pts)\n", d.PinningRate, d.PinningRateDelta)
+	fmt.Fprintf(&b, "- Moved tags: %d\n", len(d.TagDrift))
+	for _, drift := range d.TagDrift {
+		fmt.Fprintf(&b, "  - `%s@%s`: `%.7s` → `%.7s`\n", drift.Action, drift.Tag, drift.FromSHA, drift.ToSHA)
+	}
+	fmt.Fprintf(&b, "- Version changes: %d\n", len(d.VersionChanges))
+	for _, change := range d.VersionChanges {
+		fmt.Fprintf(&b, "  - %s `%s` in %s: `%s` → `%s`\n", change.Direction, change.Action, change.Repository,
+			strings.Join(change.From, "`, `"), strings.Join(change.To, "`, `"))
+	}
+	fmt.Fprintf(&b, "- Workflow changes: %d repositories\n", len(d.WorkflowChanges))
+	for _, change := range d.WorkflowChanges {
+		fmt.Fprintf(&b, "  - %s: %d added, %d removed", change.Repository, len(change.Added), len(change.Removed))
+		if len(change.Added) > 0 {
+			fmt.Fprintf(&b, " (+ `%s`)", strings.Join(change.Added, "`, `"))
+		}
+		if len(change.Removed) > 0 {
+			fmt.Fprintf(&b, " (- `%s`)", strings.Join(change.Removed, "`, `"))
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "- New findings: %d (resolved %d, total %d)\n", len(d.NewFindings), d.ResolvedFindings, d.TotalFindings)
+	for _, f := range d.NewFindings {
+		fmt.Fprintf(&b, "  - `%s` %s/%s: %s\n", f.RuleID, f.Repository, f.Workflow, f.Message)
+	}
+
+	return b.String()
+}
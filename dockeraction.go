@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// dockerAction parses a `uses: docker://image:tag` reference into an action named docker://image whose
+// version is the image digest, the tag, or latest when the reference has neither. Expressions are not
+// resolvable before the run and are skipped like other references that cannot be parsed.
+func dockerAction(uses string) (Action, bool) {
+	uses = strings.TrimSpace(uses)
+	if !strings.HasPrefix(uses, "docker://") {
+		return Action{}, false
+	}
+	image := parseImageReference(uses)
+	if image.Pinning == ImageExpression || image.Image == "" {
+		return Action{}, false
+	}
+
+	name := strings.TrimPrefix(uses, "docker://")
+	if i := strings.Index(name, "@"); i >= 0 {
+		name = name[:i]
+	}
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name = name[:i]
+	}
+
+	version := image.Digest
+	if version == "" {
+		version = image.Tag
+	}
+	if version == "" {
+		version = "latest"
+	}
+	return Action{Name: "docker://" + name, Version: version}, true
+}
+
+// dockerUses returns the uses: value of a Docker action as written in a workflow, e.g. docker://alpine:3.20
+// or docker://alpine@sha256:...
+func dockerUses(action, ref string) string {
+	if strings.HasPrefix(ref, "sha256:") {
+		return action + "@" + ref
+	}
+	return action + ":" + ref
+}
+
+// dockerPinningFinding flags a Docker action that runs an image by tag rather than by digest
+func dockerPinningFinding(ref MutableReference) Finding {
+	finding := Finding{
+		RuleID:      RuleUnpinnedDockerAction,
+		Severity:    SeverityWarning,
+		Repository:  ref.Repository,
+		Workflow:    ref.Workflow,
+		Action:      ref.Action,
+		Version:     ref.Ref,
+		Remediation: fmt.Sprintf("Replace the reference with `uses: %s@sha256:<digest>`, the digest the tag currently points to (`docker buildx imagetools inspect %s`).", ref.Action, strings.TrimPrefix(dockerUses(ref.Action, ref.Ref), "docker://")),
+	}
+	if ref.Ref == "latest" {
+		finding.Message = fmt.Sprintf("%s runs whatever image was last pushed as latest", dockerUses(ref.Action, ref.Ref))
+	} else {
+		finding.Message = fmt.Sprintf("%s is pinned to the tag %s, which can be re-pushed with a different image", dockerUses(ref.Action, ref.Ref), ref.Ref)
+	}
+	return finding
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// standardVersion returns the most used version of an action, preferring the higher version on a tie
+func standardVersion(versions map[string]int) string {
+	var names []string
+	for version := range versions {
+		names = append(names, version)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, b := names[i], names[j]
+		if versions[a] != versions[b] {
+			return versions[a] > versions[b]
+		}
+		va, okA := parseVersion(a)
+		vb, okB := parseVersion(b)
+		if okA && okB && compareVersions(va, vb) != 0 {
+			return compareVersions(va, vb) > 0
+		}
+		return a < b
+	})
+	return names[0]
+}
+
+// detectMultipleVersionFindings flags every usage of an action in another version than the one most
+// used across the organization, so the drift can be tracked and gated with --fail-on multiple-versions
+func detectMultipleVersionFindings(repositories []ComprehensiveRepository) []Finding {
+	usages := make(map[string]map[string]int) // action -> version -> count
+	for _, repo := range repositories {
+		for _, workflow := range repo.Workflows {
+			for _, action := range workflow.Actions {
+				if usages[action.Name] == nil {
+					usages[action.Name] = make(map[string]int)
+				}
+				usages[action.Name][action.Version] += action.Count
+			}
+		}
+	}
+
+	standards := make(map[string]string)
+	for name, versions := range usages {
+		if len(versions) > 1 {
+			standards[name] = standardVersion(versions)
+		}
+	}
+
+	findings := []Finding{}
+	for _, repo := range repositories {
+		for _, workflow := range repo.Workflows {
+			for _, action := range workflow.Actions {
+				standard, ok := standards[action.Name]
+				if !ok || action.Version == standard {
+					continue
+				}
+
+				total := 0
+				for _, count := range usages[action.Name] {
+					total += count
+				}
+				findings = append(findings, Finding{
+					RuleID:     RuleMultipleVersions,
+					Severity:   SeverityInfo,
+					Repository: repo.Name,
+					Workflow:   workflow.Path,
+					Action:     action.Name,
+					Version:    action.Version,
+					Message: fmt.Sprintf("%s@%s differs from %s, the most used of its %d versions (%d of %d usages)",
+						action.Name, action.Version, standard, len(usages[action.Name]), usages[action.Name][standard], total),
+					Remediation: fmt.Sprintf("Move to `uses: %s@%s`, the version used by most workflows of the organization, or update every workflow together.",
+						action.Name, standard),
+				})
+			}
+		}
+	}
+
+	normalizeFindings(findings)
+	return findings
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Enforcement modes of fail-on thresholds and policy violations
+const (
+	EnforceBlock = "block" // reaching a threshold fails the command with exit status 3
+	EnforceWarn  = "warn"  // reaching a threshold is reported, but the command exits 0
+)
+
+// maxAnnotations bounds the workflow command annotations of one scan; GitHub shows few per step anyway
+const maxAnnotations = 50
+
+// validEnforceModes are the accepted values of --enforce
+var validEnforceModes = []string{EnforceBlock, EnforceWarn}
+
+// isValidEnforceMode reports whether mode is an accepted --enforce value
+func isValidEnforceMode(mode string) bool {
+	for _, valid := range validEnforceModes {
+		if mode == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// enforce checks the findings against thresholds. Findings counted by a reached threshold are
+// annotated when running in GitHub Actions; in warn mode the failure is only reported on stderr.
+func (o scanOptions) enforce(findings []Finding, thresholds map[string]int) error {
+	err := checkFailOn(findings, thresholds)
+	var failOn *failOnError
+	if !errors.As(err, &failOn) {
+		return err
+	}
+
+	level := "error"
+	if o.Enforce == EnforceWarn {
+		level = "warning"
+	}
+	if os.Getenv("GITHUB_ACTIONS") == "true" {
+		writeAnnotations(os.Stderr, level, gatingFindings(findings, thresholds))
+	}
+
+	if o.Enforce == EnforceWarn {
+		fmt.Fprintf(os.Stderr, "⚠️  %v (--enforce warn, not failing)\n", err)
+		return nil
+	}
+	return err
+}
+
+// gatingFindings returns the findings counted by the severities and conditions of thresholds
+func gatingFindings(findings []Finding, thresholds map[string]int) []Finding {
+	rules := make(map[string]bool)
+	for key := range thresholds {
+		for _, rule := range conditionRules(key) {
+			rules[rule] = true
+		}
+	}
+
+	var gating []Finding
+	for _, finding := range findings {
+		if _, ok := thresholds[finding.Severity]; ok || rules[finding.RuleID] {
+			gating = append(gating, finding)
+		}
+	}
+	return gating
+}
+
+// writeAnnotations writes findings as GitHub Actions workflow commands at the given level (error or
+// warning). Findings point into other repositories, so the location is part of the title rather than a
+// file property.
+func writeAnnotations(writer io.Writer, level string, findings []Finding) {
+	for i, finding := range findings {
+		if i == maxAnnotations {
+			fmt.Fprintf(writer, "::notice title=gh-action-lens::%d more findings are not annotated\n", len(findings)-maxAnnotations)
+			return
+		}
+		title := fmt.Sprintf("%s: %s/%s", finding.RuleID, finding.Repository, finding.Workflow)
+		fmt.Fprintf(writer, "::%s title=%s::%s\n", level, escapeAnnotationProperty(title), escapeAnnotationData(finding.Message))
+	}
+}
+
+// escapeAnnotationData escapes the message of a workflow command
+func escapeAnnotationData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeAnnotationProperty escapes a property value of a workflow command
+func escapeAnnotationProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Enrichment kinds cached across runs
+const (
+	EnrichmentLatestRelease = "latest-release" // latest release/tag of the action repository
+	EnrichmentRepository    = "repository"     // repository metadata such as archived status and stars
+	EnrichmentScorecard     = "scorecard"      // OpenSSF Scorecard result
+	EnrichmentActionYAML    = "action-yml"     // parsed action.yml/action.yaml
+	EnrichmentRef           = "ref"            // tag <-> commit SHA resolution
+	EnrichmentOwner         = "owner"          // organization owning an action repository, e.g. its verified status
+	EnrichmentAdvisories    = "advisories"     // GitHub security advisories affecting an action repository
+)
+
+// enrichmentTTLs defines how long cached results of each kind stay fresh
+var enrichmentTTLs = map[string]time.Duration{
+	EnrichmentLatestRelease: 24 * time.Hour,
+	EnrichmentRepository:    24 * time.Hour,
+	EnrichmentScorecard:     7 * 24 * time.Hour,
+	EnrichmentActionYAML:    24 * time.Hour,
+	EnrichmentRef:           6 * time.Hour,
+	EnrichmentOwner:         7 * 24 * time.Hour,
+	EnrichmentAdvisories:    24 * time.Hour,
+}
+
+// immutableRefTTL applies to lookups keyed by a commit SHA, whose content can never change
+const immutableRefTTL = 365 * 24 * time.Hour
+
+var commitSHAPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// enrichmentEntry is a single cached lookup result
+type enrichmentEntry struct {
+	FetchedAt time.Time       `json:"fetched_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// enrichmentCache persists per-action metadata lookups keyed by kind and action@ref
+type enrichmentCache struct {
+	path     string
+	disabled bool
+
+	mu      sync.Mutex
+	entries map[string]enrichmentEntry
+	dirty   bool
+}
+
+// openEnrichmentCache loads the enrichment cache from dir, or from the default cache directory when dir
+// is empty. A disabled cache never returns hits and never writes to disk.
+func openEnrichmentCache(dir string, disabled bool) *enrichmentCache {
+	cache := &enrichmentCache{disabled: disabled, entries: make(map[string]enrichmentEntry)}
+	if disabled {
+		return cache
+	}
+
+	if dir == "" {
+		dir = defaultCacheDir()
+	}
+	if dir == "" {
+		cache.disabled = true
+		return cache
+	}
+	cache.path = filepath.Join(dir, enrichmentCacheFile(githubHost()))
+
+	if data, err := os.ReadFile(cache.path); err == nil {
+		// A corrupt cache is simply rebuilt
+		json.Unmarshal(data, &cache.entries)
+	}
+	return cache
+}
+
+// enrichmentCacheFile names the enrichment cache of a host. The same action name refers to different
+// repositories on github.com and on a GitHub Enterprise Server, so each host has its own file.
+func enrichmentCacheFile(host string) string {
+	if host == "" || host == "github.com" {
+		return "enrichment.json"
+	}
+	return "enrichment-" + strings.ReplaceAll(host, ":", "_") + ".json"
+}
+
+// enrichmentKey builds the cache key of a lookup
+func enrichmentKey(kind, key string) string {
+	return kind + "|" + strings.ToLower(key)
+}
+
+// ttl returns how long a cached lookup stays fresh; SHA-pinned refs never change
+func (c *enrichmentCache) ttl(kind, key string) time.Duration {
+	if _, ref, ok := splitActionReference(key); ok && isPinnedToSHA(ref) {
+		if kind == EnrichmentActionYAML || kind == EnrichmentRef {
+			return immutableRefTTL
+		}
+	}
+	return enrichmentTTLs[kind]
+}
+
+// lookup decodes a fresh cached result into v and reports whether one was found
+func (c *enrichmentCache) lookup(kind, key string, v interface{}) bool {
+	if c == nil || c.disabled {
+		return false
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[enrichmentKey(kind, key)]
+	c.mu.Unlock()
+
+	if !ok || time.Since(entry.FetchedAt) > c.ttl(kind, key) {
+		return false
+	}
+	return json.Unmarshal(entry.Data, v) == nil
+}
+
+// store records a lookup result
+func (c *enrichmentCache) store(kind, key string, v interface{}) {
+	if c == nil || c.disabled {
+		return
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	c.entries[enrichmentKey(kind, key)] = enrichmentEntry{FetchedAt: time.Now(), Data: data}
+	c.dirty = true
+	c.mu.Unlock()
+}
+
+// fetch returns a cached result in v when fresh, otherwise runs fetchFn to fill v and caches it
+func (c *enrichmentCache) fetch(kind, key string, v interface{}, fetchFn func() error) error {
+	if c.lookup(kind, key, v) {
+		return nil
+	}
+	if err := fetchFn(); err != nil {
+		return err
+	}
+	c.store(kind, key, v)
+	return nil
+}
+
+// save writes the cache to disk, dropping expired entries
+func (c *enrichmentCache) save() error {
+	if c == nil || c.disabled || !c.dirty {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, entry := range c.entries {
+		parts := strings.SplitN(key, "|", 2)
+		if len(parts) == 2 && time.Since(entry.FetchedAt) > c.ttl(parts[0], parts[1]) {
+			delete(c.entries, key)
+		}
+	}
+
+	data, err := json.Marshal(c.entries)
+	if err != nil {
+		return err
+	}
+	// The action.yml files of internal actions are cached, so only the user may read the cache
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o700); err != nil {
+		return err
+	}
+	if err := os.WriteFile(c.path, data, 0o600); err != nil {
+		return err
+	}
+	if err := os.Chmod(c.path, 0o600); err != nil {
+		return err
+	}
+	c.dirty = false
+	return nil
+}
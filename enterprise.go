@@ -0,0 +1,194 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/shurcooL/githubv4"
+)
+
+// OrganizationBreakdown is the per-organization part of an enterprise report
+type OrganizationBreakdown struct {
+	Organization string               `json:"organization"`
+	Summary      ComprehensiveSummary `json:"summary"`
+	Findings     int                  `json:"findings"`
+	Truncated    bool                 `json:"truncated"`
+	Error        string               `json:"error,omitempty"` // set when the organization could not be scanned
+}
+
+// listEnterpriseOrganizations returns the logins of all organizations in a GitHub Enterprise Cloud enterprise
+func listEnterpriseOrganizations(enterprise string) ([]string, error) {
+	client, err := newGraphQLClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var q struct {
+		Enterprise struct {
+			Organizations struct {
+				Nodes []struct {
+					Login string
+				}
+				PageInfo struct {
+					HasNextPage bool
+					EndCursor   githubv4.String
+				}
+			} `graphql:"organizations(first: 100, after: $cursor)"`
+		} `graphql:"enterprise(slug: $slug)"`
+	}
+
+	vars := map[string]interface{}{
+		"slug":   githubv4.String(enterprise),
+		"cursor": (*githubv4.String)(nil),
+	}
+
+	var orgs []string
+	for {
+		if err := client.Query("EnterpriseOrganizations", &q, vars); err != nil {
+			return nil, fmt.Errorf("failed to list organizations of enterprise %s: %v", enterprise, err)
+		}
+		for _, node := range q.Enterprise.Organizations.Nodes {
+			orgs = append(orgs, node.Login)
+		}
+		if !q.Enterprise.Organizations.PageInfo.HasNextPage {
+			break
+		}
+		vars["cursor"] = githubv4.NewString(q.Enterprise.Organizations.PageInfo.EndCursor)
+	}
+
+	return orgs, nil
+}
+
+// enterpriseAnalysis runs the comprehensive analysis for every organization of an enterprise and
+// writes one consolidated report. Repositories and findings are named <org>/<repo>.
+func enterpriseAnalysis(enterprise string, startTime time.Time, outputFormat, outputFile string, opts scanOptions) error {
+	orgs, err := listEnterpriseOrganizations(enterprise)
+	if err != nil {
+		return err
+	}
+	logInfof("🏛️  Scanning %d organizations of enterprise %s\n\n", len(orgs), enterprise)
+
+	consolidated := ComprehensiveReport{
+		Organization:  enterprise,
+		Enterprise:    enterprise,
+		ScanTimestamp: startTime.Format(time.RFC3339),
+		Ref:           workflowRef,
+		Repositories:  []ComprehensiveRepository{},
+		Findings:      []Finding{},
+		Report:        opts.Branding,
+	}
+	var counts RepositoryCounts
+	eolUsages, forkedUsages := 0, 0
+	archivedUsages, unavailableUsages, vulnerableUsages := 0, 0, 0
+
+	for i, org := range orgs {
+		if opts.expired() {
+			for _, remaining := range orgs[i:] {
+				consolidated.RemainingRepositories = append(consolidated.RemainingRepositories, remaining+"/*")
+			}
+			break
+		}
+
+		logInfof("\n🏢 %s\n", org)
+		report, err := buildComprehensiveReport(org, startTime, outputFormat, opts)
+		if err != nil {
+			// One inaccessible organization (e.g. SAML enforcement) should not abort the enterprise scan
+			logWarnf("⚠️  Warning: Could not scan organization %s: %v\n", org, err)
+			consolidated.Organizations = append(consolidated.Organizations, OrganizationBreakdown{Organization: org, Error: err.Error()})
+			continue
+		}
+
+		consolidated.Organizations = append(consolidated.Organizations, OrganizationBreakdown{
+			Organization: org,
+			Summary:      report.Summary,
+			Findings:     len(report.Findings),
+			Truncated:    report.Truncated,
+		})
+
+		for _, repo := range report.Repositories {
+			repo.Name = org + "/" + repo.Name
+			consolidated.Repositories = append(consolidated.Repositories, repo)
+		}
+		for _, finding := range report.Findings {
+			finding.Repository = org + "/" + finding.Repository
+			consolidated.Findings = append(consolidated.Findings, finding)
+		}
+		consolidated.ActionRepositories = mergeActionRepositories(consolidated.ActionRepositories, report.ActionRepositories)
+		consolidated.Vulnerabilities = mergeVulnerabilities(consolidated.Vulnerabilities, report.Vulnerabilities, org)
+		for _, remaining := range report.RemainingRepositories {
+			consolidated.RemainingRepositories = append(consolidated.RemainingRepositories, org+"/"+remaining)
+		}
+
+		counts.Total += report.Summary.RepositoryCounts.Total
+		counts.Forks += report.Summary.RepositoryCounts.Forks
+		counts.Archived += report.Summary.RepositoryCounts.Archived
+		counts.Templates += report.Summary.RepositoryCounts.Templates
+		counts.Mirrors += report.Summary.RepositoryCounts.Mirrors
+		counts.Skipped += report.Summary.RepositoryCounts.Skipped
+		eolUsages += report.Summary.EOLActionUsages
+		forkedUsages += report.Summary.ForkedActionUsages
+		archivedUsages += report.Summary.ArchivedActionUsages
+		unavailableUsages += report.Summary.UnavailableActionUsages
+		vulnerableUsages += report.Summary.VulnerableActionUsages
+	}
+
+	normalizeFindings(consolidated.Findings)
+	consolidated.Summary = summarizeComprehensive(consolidated.Repositories)
+	consolidated.Summary.TotalRepositories = counts.Total
+	consolidated.Summary.RepositoryCounts = counts
+	consolidated.Summary.EOLActionUsages = eolUsages
+	consolidated.Summary.ForkedActionUsages = forkedUsages
+	consolidated.Summary.ArchivedActionUsages = archivedUsages
+	consolidated.Summary.UnavailableActionUsages = unavailableUsages
+	consolidated.Summary.VulnerableActionUsages = vulnerableUsages
+	consolidated.PropertyGroups = opts.propertyBreakdown(consolidated.Repositories, consolidated.Findings)
+	consolidated.Truncated = len(consolidated.RemainingRepositories) > 0
+	consolidated.ProcessTimeSeconds = time.Since(startTime).Seconds()
+	reportComplete(enterprise, &consolidated)
+
+	writer, file, err := getOutputWriter(outputFile)
+	if err != nil {
+		return fmt.Errorf("error opening output file: %v", err)
+	}
+	if file != nil {
+		defer file.Close()
+	}
+
+	if pages := paginateReport(consolidated, outputFormat, outputFile, opts.MaxReportSize); len(pages) > 0 {
+		err = outputReportPages(consolidated, outputFormat, outputFile, pages, writer)
+	} else {
+		err = outputComprehensiveReport(consolidated, outputFormat, writer)
+	}
+	if err != nil {
+		return err
+	}
+	if err := opts.publishBadges(consolidated); err != nil {
+		return err
+	}
+	if err := opts.writeSite(consolidated); err != nil {
+		return err
+	}
+	if err := opts.saveSnapshot(consolidated); err != nil {
+		return err
+	}
+	return opts.enforceFailOn(consolidated.Findings)
+}
+
+// outputOrganizationBreakdown writes the per-organization summary of an enterprise report
+func outputOrganizationBreakdown(breakdown []OrganizationBreakdown, writer io.Writer) {
+	if len(breakdown) == 0 {
+		return
+	}
+
+	fmt.Fprintf(writer, "\n🏢 Organizations:\n")
+	for _, org := range breakdown {
+		if org.Error != "" {
+			fmt.Fprintf(writer, "   • %s: ❌ not scanned (%s)\n", org.Organization, org.Error)
+			continue
+		}
+		fmt.Fprintf(writer, "   • %s: %d repositories, %d workflows, %d action usages, %d unique actions, %d findings\n",
+			org.Organization, org.Summary.TotalRepositories, org.Summary.TotalWorkflows,
+			org.Summary.TotalActionUsages, org.Summary.UniqueActions, org.Findings)
+	}
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// eolDatabaseURL is where --refresh-db pulls the latest EOL dataset from
+const eolDatabaseURL = "https://raw.githubusercontent.com/jefeish/gh-action-lens/main/data/eol.json"
+
+// eolDownloadTimeout bounds the --refresh-db download
+const eolDownloadTimeout = 30 * time.Second
+
+//go:embed data/eol.json
+var embeddedEOLDatabase []byte
+
+// EOLDatabase lists action major versions that upstream has declared end-of-life
+type EOLDatabase struct {
+	Updated string     `json:"updated"`
+	Entries []EOLEntry `json:"entries"`
+}
+
+// EOLEntry describes the end-of-life majors of a single action
+type EOLEntry struct {
+	Action      string   `json:"action"`
+	Versions    []string `json:"versions"`
+	Replacement string   `json:"replacement"`
+	Reason      string   `json:"reason"`
+}
+
+// eolDatabasePath returns the location of the locally refreshed EOL dataset
+func eolDatabasePath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gh-action-lens", "eol.json"), nil
+}
+
+// loadEOLDatabase returns the newer of the refreshed and the embedded EOL dataset, by their updated date,
+// so a release with newer embedded data is not shadowed by an old refresh
+func loadEOLDatabase() (*EOLDatabase, error) {
+	var db EOLDatabase
+	if err := json.Unmarshal(embeddedEOLDatabase, &db); err != nil {
+		return nil, fmt.Errorf("failed to parse EOL database: %v", err)
+	}
+
+	if path, err := eolDatabasePath(); err == nil {
+		if data, err := os.ReadFile(path); err == nil {
+			var refreshed EOLDatabase
+			// Dates are YYYY-MM-DD, which compare like strings; a corrupt copy is ignored
+			if json.Unmarshal(data, &refreshed) == nil && refreshed.Updated > db.Updated {
+				db = refreshed
+			}
+		}
+	}
+	return &db, nil
+}
+
+// refreshEOLDatabase downloads the latest EOL dataset and stores it in the user cache directory
+func refreshEOLDatabase() (*EOLDatabase, error) {
+	client := &http.Client{Timeout: eolDownloadTimeout}
+	resp, err := client.Get(eolDatabaseURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("download failed with status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate before replacing the local copy
+	var db EOLDatabase
+	if err := json.Unmarshal(data, &db); err != nil {
+		return nil, fmt.Errorf("downloaded EOL database is invalid: %v", err)
+	}
+
+	path, err := eolDatabasePath()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return nil, err
+	}
+
+	return &db, nil
+}
+
+// lookup returns the EOL entry matching an action reference, if its major version is end-of-life
+func (db *EOLDatabase) lookup(action, version string) (EOLEntry, bool) {
+	major := majorVersion(version)
+	if major == "" {
+		return EOLEntry{}, false
+	}
+
+	for _, entry := range db.Entries {
+		if !strings.EqualFold(entry.Action, action) {
+			continue
+		}
+		for _, v := range entry.Versions {
+			if v == major {
+				return entry, true
+			}
+		}
+	}
+	return EOLEntry{}, false
+}
+
+// majorVersion extracts the major version ("v4") from a ref such as "v4.1.2", or "" if the ref is not a version tag
+func majorVersion(ref string) string {
+	if !strings.HasPrefix(ref, "v") {
+		return ""
+	}
+
+	major := strings.SplitN(ref[1:], ".", 2)[0]
+	if major == "" {
+		return ""
+	}
+	for _, c := range major {
+		if c < '0' || c > '9' {
+			return ""
+		}
+	}
+	return "v" + major
+}
+
+// detectEOLFindings flags every action usage whose major version is end-of-life
+func detectEOLFindings(repositories []ComprehensiveRepository, db *EOLDatabase) []Finding {
+	findings := []Finding{}
+
+	for _, repo := range repositories {
+		for _, workflow := range repo.Workflows {
+			for _, action := range workflow.Actions {
+				entry, ok := db.lookup(action.Name, action.Version)
+				if !ok {
+					continue
+				}
+
+				message := fmt.Sprintf("%s@%s is end-of-life (%s)", action.Name, action.Version, entry.Reason)
+				remediation := ""
+				if entry.Replacement != "" {
+					message += fmt.Sprintf("; upgrade to %s", entry.Replacement)
+					remediation = fmt.Sprintf("Replace `uses: %s@%s` with `uses: %s` and pin it to a commit SHA.",
+						action.Name, action.Version, entry.Replacement)
+				}
+
+				findings = append(findings, Finding{
+					RuleID:      RuleEOLAction,
+					Severity:    SeverityWarning,
+					Repository:  repo.Name,
+					Workflow:    workflow.Path,
+					Action:      action.Name,
+					Version:     action.Version,
+					Message:     message,
+					Remediation: remediation,
+				})
+			}
+		}
+	}
+
+	normalizeFindings(findings)
+	return findings
+}
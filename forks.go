@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// wellKnownActions maps the repository name of popular actions to their canonical publisher
+var wellKnownActions = map[string]string{
+	"checkout":                  "actions/checkout",
+	"setup-node":                "actions/setup-node",
+	"setup-python":              "actions/setup-python",
+	"setup-java":                "actions/setup-java",
+	"setup-go":                  "actions/setup-go",
+	"setup-dotnet":              "actions/setup-dotnet",
+	"cache":                     "actions/cache",
+	"upload-artifact":           "actions/upload-artifact",
+	"download-artifact":         "actions/download-artifact",
+	"github-script":             "actions/github-script",
+	"labeler":                   "actions/labeler",
+	"stale":                     "actions/stale",
+	"codeql-action":             "github/codeql-action",
+	"super-linter":              "github/super-linter",
+	"login-action":              "docker/login-action",
+	"build-push-action":         "docker/build-push-action",
+	"setup-buildx-action":       "docker/setup-buildx-action",
+	"setup-qemu-action":         "docker/setup-qemu-action",
+	"metadata-action":           "docker/metadata-action",
+	"configure-aws-credentials": "aws-actions/configure-aws-credentials",
+	"amazon-ecr-login":          "aws-actions/amazon-ecr-login",
+	"setup-gcloud":              "google-github-actions/setup-gcloud",
+	"setup-terraform":           "hashicorp/setup-terraform",
+	"codecov-action":            "codecov/codecov-action",
+	"action-gh-release":         "softprops/action-gh-release",
+	"create-pull-request":       "peter-evans/create-pull-request",
+	"golangci-lint-action":      "golangci/golangci-lint-action",
+}
+
+// repositoryMetadata is the subset of the REST repository resource used for enrichment
+type repositoryMetadata struct {
+	FullName string `json:"full_name"`
+	Fork     bool   `json:"fork"`
+	Archived bool   `json:"archived"`
+	Disabled bool   `json:"disabled"`
+	Stars    int    `json:"stargazers_count"`
+	PushedAt string `json:"pushed_at"`
+	Owner    struct {
+		Login string `json:"login"`
+		Type  string `json:"type"` // Organization or User
+	} `json:"owner"`
+	Source *struct {
+		FullName string `json:"full_name"`
+	} `json:"source,omitempty"`
+}
+
+// actionRepository returns the owner/repo part of an action reference such as owner/repo/path
+func actionRepository(action string) string {
+	parts := strings.SplitN(action, "/", 3)
+	if len(parts) < 2 {
+		return action
+	}
+	return parts[0] + "/" + parts[1]
+}
+
+// fetchRepositoryMetadata looks up an action repository through the enrichment cache
+func fetchRepositoryMetadata(cache *enrichmentCache, repository string) (repositoryMetadata, error) {
+	var metadata repositoryMetadata
+	err := cache.fetch(EnrichmentRepository, repository, &metadata, func() error {
+		return restGet("repos/"+repository, &metadata)
+	})
+	return metadata, err
+}
+
+// canonicalActionFor returns the canonical action a repository was forked from, or "" if it is not
+// a fork of a well-known action. When the repository cannot be looked up, a repository named like a
+// well-known action under a different owner is treated as a fork.
+func canonicalActionFor(repository string, cache *enrichmentCache) string {
+	owner, name, _ := strings.Cut(strings.ToLower(repository), "/")
+	canonical, wellKnown := wellKnownActions[name]
+
+	metadata, err := fetchRepositoryMetadata(cache, repository)
+	if err != nil {
+		if wellKnown && !strings.HasPrefix(canonical, owner+"/") {
+			return canonical
+		}
+		return ""
+	}
+
+	if !metadata.Fork || metadata.Source == nil {
+		return ""
+	}
+	source := strings.ToLower(metadata.Source.FullName)
+	for _, known := range wellKnownActions {
+		if source == known {
+			return known
+		}
+	}
+	return ""
+}
+
+// detectForkedActionFindings flags usages of third-party actions that are forks of well-known actions
+func detectForkedActionFindings(repositories []ComprehensiveRepository, org string, cache *enrichmentCache) []Finding {
+	findings := []Finding{}
+	canonicalByRepository := make(map[string]string)
+
+	for _, repo := range repositories {
+		for _, workflow := range repo.Workflows {
+			for _, action := range workflow.Actions {
+				if actionClass(action.Name, org) != actionClassThirdParty || strings.HasPrefix(action.Name, "docker://") {
+					continue
+				}
+
+				repository := actionRepository(action.Name)
+				canonical, ok := canonicalByRepository[repository]
+				if !ok {
+					canonical = canonicalActionFor(repository, cache)
+					canonicalByRepository[repository] = canonical
+				}
+				if canonical == "" || strings.EqualFold(repository, canonical) {
+					continue
+				}
+
+				findings = append(findings, Finding{
+					RuleID:     RuleForkedAction,
+					Severity:   SeverityWarning,
+					Repository: repo.Name,
+					Workflow:   workflow.Path,
+					Action:     action.Name,
+					Version:    action.Version,
+					Message:    fmt.Sprintf("%s is a fork of %s", repository, canonical),
+					Remediation: fmt.Sprintf("Replace `uses: %s@%s` with the canonical `%s` pinned to a commit SHA, or add the fork to the allowlist if it is maintained on purpose.",
+						action.Name, action.Version, canonical),
+				})
+			}
+		}
+	}
+
+	normalizeFindings(findings)
+	return findings
+}
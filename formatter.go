@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Formatter writes a report in one output format. Reports are the report structs of the scans
+// (ScanResult, ActionReport, ComprehensiveReport, PinningReport, ...); a formatter that only supports
+// some of them returns an error for the others.
+type Formatter interface {
+	Format(report interface{}, writer io.Writer) error
+}
+
+// FormatterFunc adapts a function to the Formatter interface
+type FormatterFunc func(report interface{}, writer io.Writer) error
+
+// Format calls f(report, writer)
+func (f FormatterFunc) Format(report interface{}, writer io.Writer) error {
+	return f(report, writer)
+}
+
+// builtinFormats are the formats the output function of each report writes itself
+var builtinFormats = []string{"default", "table", "csv", "sarif", "markdown", "html", "xlsx"}
+
+// execFormatPrefix selects a subprocess formatter: --format exec:<command>
+const execFormatPrefix = "exec:"
+
+// formatters are the registered output formats by --format name; every output function consults them
+// before its own formats
+var formatters = make(map[string]Formatter)
+
+// RegisterFormatter makes a formatter available as --format name. It panics when the name is empty,
+// taken by a built-in format, or registered twice.
+func RegisterFormatter(name string, formatter Formatter) {
+	if name == "" || strings.HasPrefix(name, execFormatPrefix) || formatter == nil {
+		panic("gh-action-lens: invalid formatter registration " + name)
+	}
+	for _, builtin := range builtinFormats {
+		if name == builtin {
+			panic("gh-action-lens: formatter " + name + " is built in")
+		}
+	}
+	if _, ok := formatters[name]; ok {
+		panic("gh-action-lens: formatter " + name + " registered twice")
+	}
+	formatters[name] = formatter
+}
+
+func init() {
+	RegisterFormatter("json", FormatterFunc(func(report interface{}, writer io.Writer) error {
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}))
+}
+
+// lookupFormatter returns the registered formatter of a format, or a subprocess formatter for
+// exec:<command>
+func lookupFormatter(format string) (Formatter, bool) {
+	if command := strings.TrimPrefix(format, execFormatPrefix); command != format {
+		return execFormatter{Command: strings.Fields(command)}, len(strings.Fields(command)) > 0
+	}
+	formatter, ok := formatters[format]
+	return formatter, ok
+}
+
+// outputFormatNames lists the built-in and registered formats, sorted after default
+func outputFormatNames() []string {
+	names := append([]string{}, builtinFormats[1:]...)
+	for name := range formatters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return append([]string{"default"}, names...)
+}
+
+// isValidOutputFormat reports whether --format names a built-in or registered format, or a subprocess
+func isValidOutputFormat(format string) bool {
+	if _, ok := lookupFormatter(format); ok {
+		return true
+	}
+	for _, builtin := range builtinFormats {
+		if format == builtin {
+			return true
+		}
+	}
+	return false
+}
+
+// formatterEnvelope is what a subprocess formatter reads from stdin
+type formatterEnvelope struct {
+	Protocol int         `json:"protocol"`
+	Type     string      `json:"type"` // Go type of the report, e.g. PinningReport
+	Report   interface{} `json:"report"`
+}
+
+// formatterProtocol is the version of the subprocess formatter protocol
+const formatterProtocol = 1
+
+// execFormatter runs an external program as a formatter: the report is written to its stdin as JSON,
+// wrapped in a formatterEnvelope, and its stdout is the output. The command is split on spaces and run
+// without a shell.
+type execFormatter struct {
+	Command []string
+}
+
+// Format runs the command with the report on stdin
+func (f execFormatter) Format(report interface{}, writer io.Writer) error {
+	input, err := json.Marshal(formatterEnvelope{
+		Protocol: formatterProtocol,
+		Type:     reflect.TypeOf(report).Name(),
+		Report:   report,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode report for %s: %v", f.Command[0], err)
+	}
+
+	cmd := exec.Command(f.Command[0], f.Command[1:]...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = writer
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("formatter %s failed: %v", f.Command[0], err)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package main
+
+// Hooks are callbacks into every scan for custom builds of gh-action-lens, e.g. to add ownership data
+// from a CMDB to findings or to drop accepted risks. They are registered from a file added to this
+// package, not imported by other modules. Every field is optional. Hooks run on the scan's goroutines;
+// OnRepositoryScanned may be called from several at once.
+type Hooks struct {
+	// OnRepositoryScanned is called when all workflow files of a repository have been processed, with
+	// the number of files that could not be analyzed
+	OnRepositoryScanned func(org, repository string, errors int)
+
+	// OnFindingEmitted is called for every finding before it is reported, after severity overrides. It
+	// may modify the finding; returning false drops it from the report, exports, notifications, and
+	// fail-on thresholds.
+	OnFindingEmitted func(org string, finding *Finding) bool
+
+	// OnReportComplete is called with a pointer to the finished report (*ScanResult, *ActionReport,
+	// *ComprehensiveReport, *PinningReport, ...) before it is written; changes appear in every format
+	OnReportComplete func(org string, report interface{})
+}
+
+// registeredHooks are called in registration order
+var registeredHooks []Hooks
+
+// RegisterHooks adds hooks called by every scan. Register them in an init function, like formatters.
+func RegisterHooks(hooks Hooks) {
+	registeredHooks = append(registeredHooks, hooks)
+}
+
+// repositoryStarted reports a repository an analysis starts on to the progress events and bar
+func (o scanOptions) repositoryStarted(org, repository string, workflows int) {
+	o.Events.emit(Event{Type: EventRepoStarted, Organization: org, Repository: repository, Workflows: workflows})
+	scanProgress.repositoryStarted(org, repository, workflows)
+}
+
+// repositoryScanned reports a finished repository to the progress events and bar and the hooks
+func (o scanOptions) repositoryScanned(org, repository string, errors int) {
+	o.Events.emit(Event{Type: EventRepoDone, Organization: org, Repository: repository, Errors: errors})
+	scanProgress.repositoryScanned(org, repository)
+	for _, hooks := range registeredHooks {
+		if hooks.OnRepositoryScanned != nil {
+			hooks.OnRepositoryScanned(org, repository, errors)
+		}
+	}
+}
+
+// emitFindings passes findings through the hooks and reports the remaining ones as progress events.
+// It returns the findings the hooks kept.
+func (o scanOptions) emitFindings(org string, findings []Finding) []Finding {
+	kept := findings[:0]
+	for _, finding := range findings {
+		if findingKept(org, &finding) {
+			kept = append(kept, finding)
+		}
+	}
+	o.Events.emitFindings(org, kept)
+	return kept
+}
+
+// findingKept runs the OnFindingEmitted hooks on a finding and reports whether all of them kept it
+func findingKept(org string, finding *Finding) bool {
+	for _, hooks := range registeredHooks {
+		if hooks.OnFindingEmitted != nil && !hooks.OnFindingEmitted(org, finding) {
+			return false
+		}
+	}
+	return true
+}
+
+// reportComplete hands a finished report to the hooks; report is a pointer to the report struct
+func reportComplete(org string, report interface{}) {
+	for _, hooks := range registeredHooks {
+		if hooks.OnReportComplete != nil {
+			hooks.OnReportComplete(org, report)
+		}
+	}
+}
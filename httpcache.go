@@ -0,0 +1,137 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// conditionalEntry is the ETag and decoded content of the last successful response for a URL
+type conditionalEntry struct {
+	ETag    string `json:"etag"`
+	Content string `json:"content"`
+}
+
+// conditionalCache persists the ETags of repository file responses so that repeated requests are sent
+// with If-None-Match; a 304 Not Modified answer is served from the stored content and does not count
+// against the primary rate limit
+type conditionalCache struct {
+	path     string
+	disabled bool
+
+	mu          sync.Mutex
+	entries     map[string]conditionalEntry // request URL -> last response
+	revalidated int                         // requests answered with 304 Not Modified
+	dirty       bool
+}
+
+// etagCache is consulted by every repository file request; it stays disabled until main opens it
+var etagCache = openConditionalCache("", true)
+
+// openConditionalCache loads the ETag cache from dir, or from the default cache directory when dir is
+// empty. A disabled cache never sends conditional requests and never writes to disk.
+func openConditionalCache(dir string, disabled bool) *conditionalCache {
+	cache := &conditionalCache{disabled: disabled, entries: make(map[string]conditionalEntry)}
+	if disabled {
+		return cache
+	}
+
+	if dir == "" {
+		dir = defaultCacheDir()
+	}
+	if dir == "" {
+		cache.disabled = true
+		return cache
+	}
+	cache.path = filepath.Join(dir, "etags.json")
+
+	if data, err := os.ReadFile(cache.path); err == nil {
+		// A corrupt cache is simply rebuilt
+		json.Unmarshal(data, &cache.entries)
+	}
+	return cache
+}
+
+// prepare adds If-None-Match to a request whose URL has a stored ETag
+func (c *conditionalCache) prepare(req *http.Request) {
+	if c == nil || c.disabled {
+		return
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[req.URL.String()]
+	c.mu.Unlock()
+	if ok && entry.ETag != "" {
+		req.Header.Set("If-None-Match", entry.ETag)
+	}
+}
+
+// notModified returns the stored content of a URL answered with 304 Not Modified
+func (c *conditionalCache) notModified(url string) (string, bool) {
+	if c == nil || c.disabled {
+		return "", false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[url]
+	if ok {
+		c.revalidated++
+	}
+	return entry.Content, ok
+}
+
+// store records the ETag and decoded content of a successful response
+func (c *conditionalCache) store(url string, resp *http.Response, content string) {
+	if c == nil || c.disabled {
+		return
+	}
+	etag := resp.Header.Get("ETag")
+	if etag == "" {
+		return
+	}
+
+	c.mu.Lock()
+	c.entries[url] = conditionalEntry{ETag: etag, Content: content}
+	c.dirty = true
+	c.mu.Unlock()
+}
+
+// revalidatedRequests returns how many requests were answered with 304 Not Modified during this run
+func (c *conditionalCache) revalidatedRequests() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.revalidated
+}
+
+// save writes the cache to disk
+func (c *conditionalCache) save() error {
+	if c == nil || c.disabled || !c.dirty {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, err := json.Marshal(c.entries)
+	if err != nil {
+		return err
+	}
+	// Response bodies of private repositories are cached, so only the user may read the cache
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o700); err != nil {
+		return err
+	}
+	if err := os.WriteFile(c.path, data, 0o600); err != nil {
+		return err
+	}
+	if err := os.Chmod(c.path, 0o600); err != nil {
+		return err
+	}
+	c.dirty = false
+	return nil
+}
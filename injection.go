@@ -0,0 +1,268 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// expressionPattern matches a ${{ }} expression
+var expressionPattern = regexp.MustCompile(`\$\{\{(.*?)\}\}`)
+
+// untrustedContextPattern matches the contexts anyone able to open an issue, pull request, comment, or push
+// to a fork controls: titles, bodies, branch names, commit messages, and author names
+var untrustedContextPattern = regexp.MustCompile(`github\.event\.(issue\.(title|body)|pull_request\.(title|body|head\.(ref|label|repo\.default_branch))|comment\.body|review\.body|review_comment\.body|discussion\.(title|body)|pages(\[[^\]]*\]|\.\*)\.page_name|(head_commit|commits(\[[^\]]*\]|\.\*))\.(message|author\.(email|name))|workflow_run\.(head_branch|display_title|head_commit\.(message|author\.(email|name))|pull_requests(\[[^\]]*\]|\.\*)\.head\.ref))|github\.head_ref`)
+
+// ScriptInjectionReport lists the run: scripts interpolating untrusted contexts
+type ScriptInjectionReport struct {
+	Organization          string                 `json:"organization"`
+	Summary               ScriptInjectionSummary `json:"summary"`
+	Injections            []ScriptInjection      `json:"injections"`
+	Findings              []Finding              `json:"findings"`
+	Truncated             bool                   `json:"truncated"`
+	RemainingRepositories []string               `json:"remaining_repositories,omitempty"`
+	ProcessTimeSeconds    float64                `json:"process_time_seconds"`
+}
+
+// ScriptInjectionSummary represents summary statistics of the script injection scan
+type ScriptInjectionSummary struct {
+	WorkflowsScanned     int            `json:"workflows_scanned"`
+	ScriptsScanned       int            `json:"scripts_scanned"`
+	Injections           int            `json:"injections"`
+	AffectedWorkflows    int            `json:"affected_workflows"`
+	AffectedRepositories int            `json:"affected_repositories"`
+	Contexts             map[string]int `json:"contexts"` // injections per untrusted context
+}
+
+// ScriptInjection is one untrusted context interpolated into a script
+type ScriptInjection struct {
+	Repository string `json:"repository"`
+	Workflow   string `json:"workflow"`
+	Job        string `json:"job"`
+	Step       int    `json:"step"` // 1-based
+	StepName   string `json:"step_name,omitempty"`
+	Script     string `json:"script"` // run, or the action whose script input interpolates it
+	Expression string `json:"expression"`
+	Context    string `json:"context"`
+
+	action, version string // uses: of the step, for snippets
+}
+
+// untrustedExpressions returns the ${{ }} expressions of a script that reference an untrusted context, with
+// the context each references
+func untrustedExpressions(script string) (expressions, contexts []string) {
+	for _, match := range expressionPattern.FindAllStringSubmatch(script, -1) {
+		if context := untrustedContextPattern.FindString(match[1]); context != "" {
+			expressions = append(expressions, match[0])
+			contexts = append(contexts, context)
+		}
+	}
+	return expressions, contexts
+}
+
+// stepScript returns the script a step executes and where it comes from: the run: script, or the script
+// input of actions/github-script
+func stepScript(step workflowStep) (script, source string) {
+	if step.Run != "" {
+		return step.Run, "run"
+	}
+	if name, _, _ := splitActionReference(step.Uses); strings.EqualFold(name, "actions/github-script") {
+		if script, ok := step.With["script"].(string); ok {
+			return script, name
+		}
+	}
+	return "", ""
+}
+
+// findScriptInjections returns the untrusted contexts interpolated into the scripts of a workflow and the
+// number of scripts checked
+func findScriptInjections(definition *workflowDefinition, repo, workflowPath string) ([]ScriptInjection, int) {
+	var injections []ScriptInjection
+	scripts := 0
+	for _, jobID := range definition.sortedJobIDs() {
+		for i, step := range definition.Jobs[jobID].Steps {
+			script, source := stepScript(step)
+			if script == "" {
+				continue
+			}
+			scripts++
+			expressions, contexts := untrustedExpressions(script)
+			for j := range expressions {
+				injection := ScriptInjection{
+					Repository: repo,
+					Workflow:   workflowPath,
+					Job:        jobID,
+					Step:       i + 1,
+					StepName:   step.Name,
+					Script:     source,
+					Expression: expressions[j],
+					Context:    contexts[j],
+				}
+				if source != "run" {
+					injection.action, injection.version, _ = splitActionReference(step.Uses)
+				}
+				injections = append(injections, injection)
+			}
+		}
+	}
+	return injections, scripts
+}
+
+// scriptInjectionFinding raises the finding of an untrusted context in a script
+func scriptInjectionFinding(injection ScriptInjection) Finding {
+	return Finding{
+		RuleID:     RuleScriptInjection,
+		Severity:   SeverityError,
+		Repository: injection.Repository,
+		Workflow:   injection.Workflow,
+		Action:     injection.action,
+		Version:    injection.version,
+		Message: fmt.Sprintf("job %s step %d interpolates %s into its %s script",
+			injection.Job, injection.Step, injection.Expression, injection.Script),
+	}
+}
+
+// analyzeScriptInjections flags run: and github-script scripts interpolating untrusted contexts
+func analyzeScriptInjections(org string, startTime time.Time, outputFormat, outputFile string, opts scanOptions) error {
+	repositories, _, err := listRepositoryWorkflows(org, opts)
+	if err != nil {
+		return err
+	}
+
+	logInfof("💉 Checking run: scripts for untrusted contexts in %d repositories...\n\n", len(repositories))
+
+	report := ScriptInjectionReport{
+		Organization: org,
+		Summary:      ScriptInjectionSummary{Contexts: make(map[string]int)},
+		Injections:   []ScriptInjection{},
+		Findings:     []Finding{},
+	}
+
+	report.RemainingRepositories = forEachWorkflowDefinition(org, repositories, opts, func(repo, workflowPath string, definition *workflowDefinition) {
+		report.Summary.WorkflowsScanned++
+		injections, scripts := findScriptInjections(definition, repo, workflowPath)
+		report.Summary.ScriptsScanned += scripts
+		report.Injections = append(report.Injections, injections...)
+	})
+
+	affectedRepositories := make(map[string]bool)
+	affectedWorkflows := make(map[string]bool)
+	for _, injection := range report.Injections {
+		report.Summary.Contexts[injection.Context]++
+		affectedRepositories[injection.Repository] = true
+		affectedWorkflows[injection.Repository+"/"+injection.Workflow] = true
+		report.Findings = append(report.Findings, scriptInjectionFinding(injection))
+	}
+	report.Summary.Injections = len(report.Injections)
+	report.Summary.AffectedWorkflows = len(affectedWorkflows)
+	report.Summary.AffectedRepositories = len(affectedRepositories)
+
+	opts.finishFindings(org, &report.Findings)
+	report.Truncated = len(report.RemainingRepositories) > 0
+	report.ProcessTimeSeconds = time.Since(startTime).Seconds()
+
+	return opts.writeFindingsReport(org, &report, report.Findings, outputFile, func(writer io.Writer) error {
+		return outputScriptInjectionReport(report, outputFormat, writer)
+	})
+}
+
+// outputScriptInjectionReport outputs the script injection scan in the specified format
+func outputScriptInjectionReport(report ScriptInjectionReport, format string, writer io.Writer) error {
+	if formatter, ok := lookupFormatter(format); ok {
+		return formatter.Format(report, writer)
+	}
+
+	switch format {
+	case "table":
+		return outputScriptInjectionTable(report, writer)
+
+	case "csv":
+		return outputScriptInjectionCSV(report, writer)
+
+	case "markdown":
+		return outputFindingsMarkdown("💉 Script Injection", report.Findings, report.RemainingRepositories, writer)
+
+	case "sarif":
+		return outputSARIF("script-injection", report.Findings, writer)
+
+	default: // "default"
+		fmt.Fprintln(writer, "💉 Script Injection")
+		fmt.Fprintln(writer, "="+strings.Repeat("=", 50))
+
+		if len(report.Injections) == 0 {
+			fmt.Fprintln(writer, "✅ No script interpolates an untrusted context")
+		}
+		for _, injection := range report.Injections {
+			step := strconv.Itoa(injection.Step)
+			if injection.StepName != "" {
+				step += " (" + injection.StepName + ")"
+			}
+			fmt.Fprintf(writer, "❌ %s → %s → %s step %s: %s in %s\n",
+				injection.Repository, injection.Workflow, injection.Job, step, injection.Expression, injection.Script)
+		}
+
+		fmt.Fprintln(writer, "\n📊 Summary:")
+		fmt.Fprintf(writer, "   • Workflows scanned: %d (%d scripts)\n", report.Summary.WorkflowsScanned, report.Summary.ScriptsScanned)
+		fmt.Fprintf(writer, "   • Injections: %d in %d workflows of %d repositories\n",
+			report.Summary.Injections, report.Summary.AffectedWorkflows, report.Summary.AffectedRepositories)
+		for _, context := range sortedKeys(report.Summary.Contexts) {
+			fmt.Fprintf(writer, "     - %s: %d\n", context, report.Summary.Contexts[context])
+		}
+		fmt.Fprintf(writer, "   ⏱️  Process time: %.3fs\n", report.ProcessTimeSeconds)
+
+		outputFindings(report.Findings, writer)
+
+		if report.Truncated {
+			outputTruncationNotice(report.RemainingRepositories, writer)
+		}
+
+		return nil
+	}
+}
+
+// outputScriptInjectionTable outputs the script injection scan in table format
+func outputScriptInjectionTable(report ScriptInjectionReport, writer io.Writer) error {
+	fmt.Fprintln(writer, "╔════════════════════════════════════════════════════════════════════════════════════════════════════╗")
+	fmt.Fprintf(writer, "║                                    💉 SCRIPT INJECTION                                              ║\n")
+	fmt.Fprintln(writer, "╚════════════════════════════════════════════════════════════════════════════════════════════════════╝")
+	fmt.Fprintf(writer, "  🏢 Organization: %-59s \n", report.Organization)
+	fmt.Fprintf(writer, "  📄 Scripts Scanned: %-56d \n", report.Summary.ScriptsScanned)
+	fmt.Fprintf(writer, "  ❌ Injections: %-61d \n", report.Summary.Injections)
+	fmt.Fprintf(writer, "  📁 Affected Repositories: %-50d \n", report.Summary.AffectedRepositories)
+	fmt.Fprintln(writer, " ═════════════════════════════════════════════════════════════════════════════════════════════════════")
+	fmt.Fprintln(writer)
+
+	if len(report.Injections) == 0 {
+		fmt.Fprintln(writer, "┌─────────────────────────────────────────┐")
+		fmt.Fprintln(writer, "│    No script injections found           │")
+		fmt.Fprintln(writer, "└─────────────────────────────────────────┘")
+		return nil
+	}
+
+	fmt.Fprintln(writer, "┌─────────────────────┬────────────────────────────────┬──────────────────┬──────┬──────────────────────────────────┐")
+	fmt.Fprintf(writer, "│ %-18s │ %-29s │ %-16s │ %-4s │ %-32s │\n", "📁 REPOSITORY", "📄 WORKFLOW", "JOB", "STEP", "CONTEXT")
+	fmt.Fprintln(writer, "├─────────────────────┼────────────────────────────────┼──────────────────┼──────┼──────────────────────────────────┤")
+	for _, injection := range report.Injections {
+		fmt.Fprintf(writer, "│ %-19s │ %-30s │ %-16s │ %4d │ %-32s │\n",
+			truncate(injection.Repository, 19), truncate(injection.Workflow, 30), truncate(injection.Job, 16), injection.Step, truncate(injection.Context, 32))
+	}
+	fmt.Fprintln(writer, "└─────────────────────┴────────────────────────────────┴──────────────────┴──────┴──────────────────────────────────┘")
+	outputFindings(report.Findings, writer)
+	fmt.Fprintln(writer)
+	return nil
+}
+
+// outputScriptInjectionCSV outputs the script injection scan in CSV format
+func outputScriptInjectionCSV(report ScriptInjectionReport, writer io.Writer) error {
+	w := newCSVWriter(writer)
+	w.Write([]string{"Repository", "Workflow", "Job", "Step", "Step Name", "Script", "Expression", "Context"})
+	for _, injection := range report.Injections {
+		w.Write([]string{injection.Repository, injection.Workflow, injection.Job, strconv.Itoa(injection.Step),
+			injection.StepName, injection.Script, injection.Expression, injection.Context})
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// exitInterrupted is the exit status of a scan stopped by SIGINT or SIGTERM, after its partial report
+// was written; shells use 128+2 for processes ended by SIGINT
+const exitInterrupted = 130
+
+// scanInterrupted is set once a signal stopped the scan, so notices can tell it from a timeout
+var scanInterrupted atomic.Bool
+
+// notifyInterrupt returns a context that is cancelled by the first SIGINT or SIGTERM. The scan then
+// starts no new repositories or listing pages and writes a partial report; a second signal ends the
+// process immediately.
+func notifyInterrupt() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		// Restore the default handling for the second signal
+		signal.Stop(signals)
+		scanInterrupted.Store(true)
+		fmt.Fprintln(os.Stderr, "\n⏹️  Interrupted; finishing started repositories and writing a partial report (interrupt again to quit immediately)")
+		cancel()
+	}()
+	return ctx
+}
+
+// interrupted reports whether a signal stopped the scan
+func (o scanOptions) interrupted() bool {
+	return o.Context != nil && o.Context.Err() != nil
+}
+
+// sleepInterruptibly sleeps for d or until done is closed, whichever comes first
+func sleepInterruptibly(d time.Duration, done <-chan struct{}) {
+	if d <= 0 {
+		return
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-done:
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// localOrganization is the owner reported for local directories scanned with --path alone
+const localOrganization = "local"
+
+// offline is set when only local directories are scanned; no API request is made then
+var offline bool
+
+// errOffline is returned by API requests of an offline scan. Analyses that enrich findings with API
+// data, such as tag resolution or action metadata, skip what they cannot look up.
+var errOffline = errors.New("not available when scanning local directories only (--path without --org)")
+
+// pathsFlag collects repeated --path flags
+type pathsFlag []string
+
+// String implements flag.Value
+func (p *pathsFlag) String() string {
+	return strings.Join(*p, ",")
+}
+
+// Set implements flag.Value
+func (p *pathsFlag) Set(value string) error {
+	*p = append(*p, value)
+	return nil
+}
+
+// listLocalWorkflows returns the workflow files of the local directories of --path as repositories
+// named after the directories. Files under .github/workflows and the --workflow-paths directories
+// passing the workflow filters are read and recorded with the listed workflows.
+func listLocalWorkflows(org string, opts scanOptions) ([]RepositoryWorkflows, error) {
+	var repositories []RepositoryWorkflows
+	names := make(map[string]string)
+	for _, dir := range opts.LocalPaths {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, err
+		}
+		if info, err := os.Stat(abs); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("--path %s is not a directory", dir)
+		}
+		name := filepath.Base(abs)
+		if previous, ok := names[name]; ok {
+			return nil, fmt.Errorf("--path %s and %s are both named %s", previous, dir, name)
+		}
+		names[name] = dir
+
+		repository := RepositoryWorkflows{Name: name}
+		for _, workflowDir := range append([]string{githubWorkflowsDir}, opts.WorkflowPaths...) {
+			depth := 0
+			if workflowDir != githubWorkflowsDir {
+				depth = maxWorkflowPathDepth
+			}
+			files, err := readLocalWorkflowDirectory(org, name, abs, path.Clean(workflowDir), depth, opts)
+			if err != nil {
+				return nil, err
+			}
+			repository.Workflows = append(repository.Workflows, files...)
+		}
+		repositories = append(repositories, repository)
+	}
+	return repositories, nil
+}
+
+// readLocalWorkflowDirectory reads the YAML files of a directory below root, and of its subdirectories
+// up to depth levels deep, that pass the workflow filters. A missing directory yields no files.
+func readLocalWorkflowDirectory(org, name, root, dir string, depth int, opts scanOptions) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(root, filepath.FromSlash(dir)))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, entry := range entries {
+		workflowPath := path.Join(dir, entry.Name())
+		switch {
+		case entry.IsDir() && depth > 0:
+			nested, err := readLocalWorkflowDirectory(org, name, root, workflowPath, depth-1, opts)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, nested...)
+		case entry.Type().IsRegular() && isYAMLFile(entry.Name()) && opts.includesWorkflow(workflowPath):
+			content, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(workflowPath)))
+			if err != nil {
+				return nil, err
+			}
+			listedWorkflows.store(org, name, workflowPath, string(content))
+			files = append(files, workflowPath)
+		}
+	}
+	return files, nil
+}
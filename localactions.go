@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+)
+
+// LocalAction is one step of a workflow using an action of its own repository (uses: ./path)
+type LocalAction struct {
+	Path    string `json:"path"` // as written, e.g. ./.github/actions/build
+	Job     string `json:"job"`
+	Step    int    `json:"step"`              // 1-based step index
+	Runtime string `json:"runtime,omitempty"` // runs.using of its action.yml: composite, node20, docker, ...
+	Missing bool   `json:"missing,omitempty"` // no action.yml at the path
+}
+
+// LocalActionSummary counts the local actions of the detailed report
+type LocalActionSummary struct {
+	Usages       int                      `json:"usages"`
+	Actions      int                      `json:"actions"` // distinct repository and path pairs
+	Composite    int                      `json:"composite"`
+	Missing      int                      `json:"missing"`
+	Repositories []RepositoryLocalActions `json:"repositories"` // repositories using local actions
+}
+
+// RepositoryLocalActions is the adoption of local actions in one repository
+type RepositoryLocalActions struct {
+	Name      string   `json:"name"`
+	Usages    int      `json:"usages"`
+	Actions   []string `json:"actions"` // distinct paths
+	Composite int      `json:"composite"`
+	Missing   []string `json:"missing,omitempty"` // paths without an action.yml
+}
+
+// localActions returns the local action steps of a workflow
+func localActions(content string) []LocalAction {
+	var actions []LocalAction
+	for _, action := range localActionUses(content) {
+		actions = append(actions, LocalAction{Path: action.Name, Job: action.Job, Step: action.Step})
+	}
+	return actions
+}
+
+// localActionKey identifies the action.yml of a local action, so ./build and ./build/ are read once
+func localActionKey(repo, actionPath string) string {
+	return repo + "\x00" + path.Clean(strings.TrimPrefix(actionPath, "./"))
+}
+
+// resolveLocalActions reads the action.yml of every local action of the detailed report at the scanned
+// ref, with at most opts.Concurrency lookups in flight, and records its runtime or that it is missing.
+// Actions whose action.yml cannot be read for another reason are left unresolved.
+func resolveLocalActions(repositories []ComprehensiveRepository, org string, opts scanOptions) {
+	type lookup struct{ repo, path string }
+	seen := make(map[string]bool)
+	var lookups []lookup
+	for _, repo := range repositories {
+		for _, workflow := range repo.Workflows {
+			for _, action := range workflow.LocalActions {
+				if key := localActionKey(repo.Name, action.Path); !seen[key] {
+					seen[key] = true
+					lookups = append(lookups, lookup{repo.Name, action.Path})
+				}
+			}
+		}
+	}
+
+	type resolution struct {
+		runtime string
+		missing bool
+	}
+	resolutions := make([]resolution, len(lookups))
+	runConcurrently(len(lookups), opts.Concurrency, func(i int) {
+		apiRateLimit.acquire()
+		defer apiRateLimit.release()
+		metadata, err := fetchActionMetadata(opts.Cache, org, lookups[i].repo, lookups[i].path, workflowRef)
+		switch err {
+		case nil:
+			resolutions[i].runtime = metadata.Using
+		case errFileNotFound:
+			resolutions[i].missing = true
+		default:
+			logWarnf("⚠️  Warning: Could not read %s/%s: %v\n", lookups[i].repo, lookups[i].path, err)
+		}
+	})
+
+	byKey := make(map[string]resolution, len(lookups))
+	for i, l := range lookups {
+		byKey[localActionKey(l.repo, l.path)] = resolutions[i]
+	}
+	for _, repo := range repositories {
+		for _, workflow := range repo.Workflows {
+			for i, action := range workflow.LocalActions {
+				resolved := byKey[localActionKey(repo.Name, action.Path)]
+				workflow.LocalActions[i].Runtime, workflow.LocalActions[i].Missing = resolved.runtime, resolved.missing
+			}
+		}
+	}
+}
+
+// summarizeLocalActions counts the local actions of the scanned repositories, repositories with the most
+// usages first
+func summarizeLocalActions(repositories []ComprehensiveRepository) LocalActionSummary {
+	summary := LocalActionSummary{Repositories: []RepositoryLocalActions{}}
+	for _, repo := range repositories {
+		usage := RepositoryLocalActions{Name: repo.Name, Actions: []string{}}
+		seen := make(map[string]bool)
+		for _, workflow := range repo.Workflows {
+			for _, action := range workflow.LocalActions {
+				usage.Usages++
+				key := localActionKey(repo.Name, action.Path)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				usage.Actions = append(usage.Actions, action.Path)
+				if action.Runtime == "composite" {
+					usage.Composite++
+				}
+				if action.Missing {
+					usage.Missing = append(usage.Missing, action.Path)
+				}
+			}
+		}
+		if usage.Usages == 0 {
+			continue
+		}
+		sort.Strings(usage.Actions)
+		sort.Strings(usage.Missing)
+		summary.Usages += usage.Usages
+		summary.Actions += len(usage.Actions)
+		summary.Composite += usage.Composite
+		summary.Missing += len(usage.Missing)
+		summary.Repositories = append(summary.Repositories, usage)
+	}
+	sort.SliceStable(summary.Repositories, func(i, j int) bool {
+		return summary.Repositories[i].Usages > summary.Repositories[j].Usages
+	})
+	return summary
+}
+
+// String summarizes the local actions, e.g. "14 usages of 5 actions in 3 repositories (4 composite, 1 missing)"
+func (summary LocalActionSummary) String() string {
+	return fmt.Sprintf("%d usages of %d actions in %d repositories (%d composite, %d missing)",
+		summary.Usages, summary.Actions, len(summary.Repositories), summary.Composite, summary.Missing)
+}
+
+// outputLocalActions writes the local action adoption of the detailed report
+func outputLocalActions(summary LocalActionSummary, writer io.Writer) {
+	if summary.Usages == 0 {
+		return
+	}
+	fmt.Fprintf(writer, "\n📂 Local actions: %s\n", summary)
+	for _, repo := range summary.Repositories {
+		fmt.Fprintf(writer, "   • %s: %d usages of %d actions (%d composite)\n", repo.Name, repo.Usages, len(repo.Actions), repo.Composite)
+		for _, missing := range repo.Missing {
+			fmt.Fprintf(writer, "     ⚠️  %s has no action.yml\n", missing)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// logLevel orders status messages by importance; messages below the --log-level are dropped
+type logLevel int
+
+const (
+	logDebug logLevel = iota // per-request API tracing, rate limit budgets, and retries
+	logInfo                  // scan status, e.g. the target and the number of workflows analyzed
+	logWarn                  // files or lookups that failed without failing the scan
+	logError                 // failures ending the command
+)
+
+// logLevels are the names accepted by --log-level
+var logLevels = map[string]logLevel{"debug": logDebug, "info": logInfo, "warn": logWarn, "error": logError}
+
+// currentLogLevel is set by --log-level, --verbose (debug), or --quiet (error)
+var currentLogLevel = logInfo
+
+// parseLogLevel returns the level of --log-level
+func parseLogLevel(name string) (logLevel, error) {
+	level, ok := logLevels[strings.ToLower(name)]
+	if !ok {
+		var names []string
+		for n := range logLevels {
+			names = append(names, n)
+		}
+		sort.Slice(names, func(i, j int) bool { return logLevels[names[i]] < logLevels[names[j]] })
+		return 0, fmt.Errorf("invalid --log-level '%s'. Valid options: %s", name, strings.Join(names, ", "))
+	}
+	return level, nil
+}
+
+// logEnabled reports whether messages of a level are written
+func logEnabled(level logLevel) bool {
+	return level >= currentLogLevel
+}
+
+// logf writes a status message to stderr, so stdout carries nothing but the report. It clears the
+// progress bar line first; the bar is redrawn below the message.
+func logf(level logLevel, format string, args ...interface{}) {
+	if !logEnabled(level) {
+		return
+	}
+	scanProgress.interrupt(func() {
+		fmt.Fprintf(os.Stderr, format, args...)
+	})
+}
+
+// logDebugf writes a message shown with --verbose or --log-level debug
+func logDebugf(format string, args ...interface{}) { logf(logDebug, format, args...) }
+
+// logInfof writes a status message
+func logInfof(format string, args ...interface{}) { logf(logInfo, format, args...) }
+
+// logWarnf writes a warning
+func logWarnf(format string, args ...interface{}) { logf(logWarn, format, args...) }
+
+// logErrorf writes an error, shown even with --quiet
+func logErrorf(format string, args ...interface{}) { logf(logError, format, args...) }
@@ -1,23 +1,20 @@
 package main
 
 import (
-	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"net/http"
+	neturl "net/url"
 	"os"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
-
-	"github.com/cli/go-gh/v2/pkg/api"
-	"github.com/shurcooL/githubv4"
-	"golang.org/x/oauth2"
-	"gopkg.in/yaml.v3"
 )
 
 func main() {
@@ -28,18 +25,147 @@ func main() {
 	var detailed bool
 	var outputFormat string
 	var outputFile string
+	var refreshDB bool
+	var includeWorkflows string
+	var excludeWorkflows string
+	var timeout time.Duration
+	var projectNumber int
+	var noCache bool
+	var cacheDir string
+	var notify bool
+	var notifyState string
+	var telemetry bool
+	var profileScan bool
+	var verbose bool
+	var quiet bool
+	var logLevelName string
+	var profileName string
+	var snippets bool
+	var configFile string
+	var failOn string
+	var enforceMode string
+	var authorship bool
+	var botAccounts string
+	var skipRepos string
+	var includeForks bool
+	var includeArchived bool
+	var branches string
+	var allBranches bool
+	var includeRepos string
+	localPaths := pathsFlag{}
+	var excludeRepos string
+	var topics string
+	var visibility string
+	repoProperties := metadataFlag{}
+	var groupByProperty string
+	var user string
+	var enterprise string
+	var badgesDir string
+	var badgesGist string
+	var outputDir string
+	var snapshotDir string
+	var concurrency int
+	var eventsFile string
+	var eventsFD int
+	var maxMatrixJobs int
+	var minScheduleInterval time.Duration
+	var maxWorkflowDepth int
+	var workflowPaths string
+	var workflowTemplates bool
+	var gists string
+	var maxReportSize int
+	var fetchMode string
+	var reportTitle string
+	var templateFile string
+	var templateString string
+	var delimiter string
+	var collapseOwners bool
+	var excludeGitHubOwned bool
+	var scorecard bool
+	var query string
+	var reportLogo string
+	reportMeta := metadataFlag{}
+	var policyFile string
+	var transitive bool
+	var transitiveDepth int
 
 	flag.BoolVar(&showHelp, "help", false, "Show help information")
 	flag.BoolVar(&showHelp, "h", false, "Show help information")
 	flag.StringVar(&organization, "org", "", "Organization name to target")
 	flag.StringVar(&organization, "o", "", "Organization name to target")
-	flag.StringVar(&scanScope, "scan", "all", "Scan scope: workflows, actions, or all")
-	flag.StringVar(&scanScope, "s", "all", "Scan scope: workflows, actions, or all")
+	flag.StringVar(&user, "user", "", "User account to target instead of an organization")
+	flag.StringVar(&user, "u", "", "User account to target instead of an organization")
+	flag.StringVar(&workflowRef, "ref", "", "Branch, tag, or commit SHA whose workflows are scanned (default: each repository's default branch)")
+	flag.Var(&localPaths, "path", "Scan the workflows of this local directory, e.g. a checkout; repeatable. Without --org no network is used")
+	flag.StringVar(&branches, "branches", "", "Also scan the workflows of branches matching these comma-separated glob patterns")
+	flag.BoolVar(&allBranches, "all-branches", false, "Also scan the workflows of every branch (same as --branches '*')")
+	flag.StringVar(&hostnameFlag, "hostname", "", "GitHub host to scan, e.g. a GitHub Enterprise Server (default: GH_HOST or the gh default host)")
+	flag.StringVar(&enterprise, "enterprise", "", "Enterprise slug; scans every organization of the enterprise into one report")
+	flag.StringVar(&badgesDir, "badges-dir", "", "Write pinning and policy compliance badges (SVG and JSON) to this directory")
+	flag.StringVar(&badgesGist, "badges-gist", "", "Publish the badges to this existing gist ID")
+	flag.StringVar(&scanScope, "scan", "all", "Scan scope: workflows, actions, secrets, automation, permissions, matrices, pinning, outdated, runners, dependencies, reusable, deprecated-runtimes, actions-permissions, pwn-requests, script-injection, triggers, or all")
+	flag.StringVar(&scanScope, "s", "all", "Scan scope: workflows, actions, secrets, automation, permissions, matrices, pinning, outdated, runners, dependencies, reusable, deprecated-runtimes, actions-permissions, pwn-requests, script-injection, triggers, or all")
 	flag.BoolVar(&detailed, "detailed", false, "Detailed analysis with comprehensive action breakdown")
 	flag.BoolVar(&detailed, "d", false, "Detailed analysis with comprehensive action breakdown")
-	flag.StringVar(&outputFormat, "format", "default", "Output format: default, json, table, csv")
-	flag.StringVar(&outputFormat, "f", "default", "Output format: default, json, table, csv")
+	flag.StringVar(&outputFormat, "format", "default", "Output format: default, json, table, csv, sarif, markdown, html, xlsx, allowed-actions, or exec:<command>")
+	flag.StringVar(&outputFormat, "f", "default", "Output format: default, json, table, csv, sarif, markdown, html, xlsx, allowed-actions, or exec:<command>")
 	flag.StringVar(&outputFile, "output", "", "Write output to file instead of stdout")
+	flag.StringVar(&delimiter, "csv-delimiter", ",", "Field delimiter of the csv format, e.g. ; or tab")
+	flag.BoolVar(&collapseOwners, "collapse-owners", false, "Write one owner/* pattern per action owner with --format allowed-actions")
+	flag.BoolVar(&excludeGitHubOwned, "exclude-github-owned", false, "Leave actions owned by actions and github out of the reports, e.g. to focus on third-party actions")
+	flag.IntVar(&concurrency, "concurrency", defaultConcurrency, "Maximum number of workflow files fetched in parallel")
+	flag.BoolVar(&transitive, "transitive", false, "Also report the actions used inside composite actions")
+	flag.IntVar(&transitiveDepth, "transitive-depth", defaultTransitiveDepth, "Levels of nested composite actions resolved with --transitive")
+	flag.BoolVar(&scorecard, "scorecard", false, "Add the OpenSSF Scorecard score and failing checks of third-party action repositories to the detailed report")
+	flag.IntVar(&maxMatrixJobs, "max-matrix-jobs", defaultMaxMatrixJobs, "Flag job matrices generating more than this many jobs (--scan matrices)")
+	flag.DurationVar(&minScheduleInterval, "min-schedule-interval", defaultMinScheduleInterval, "Flag cron schedules running more often than this (--scan triggers)")
+	flag.IntVar(&maxWorkflowDepth, "max-workflow-depth", githubWorkflowNestingLimit, "Flag reusable workflow call chains with more levels than this, the caller included (--scan reusable)")
+	flag.StringVar(&eventsFile, "events-file", "", "Write progress events as JSON lines to this file")
+	flag.IntVar(&eventsFD, "events-fd", 0, "Write progress events as JSON lines to this open file descriptor")
+	flag.StringVar(&outputDir, "output-dir", "", "Write a static HTML site (index plus one page per repository) of the detailed report")
+	flag.StringVar(&snapshotDir, "snapshot-dir", "", "Save every detailed report as a timestamped JSON file in this directory, for the digest and trend commands")
+	flag.IntVar(&maxReportSize, "max-report-size", defaultMaxReportSize, "Split HTML and Markdown detailed reports written with --output into pages above this size in MB (0 disables)")
+	flag.StringVar(&query, "query", "", "Apply this jq expression to the JSON report and print the result")
+	flag.StringVar(&templateFile, "template", "", "Render the report through this Go text/template file instead of --format")
+	flag.StringVar(&templateString, "template-string", "", "Render the report through this inline Go text/template instead of --format")
+	flag.StringVar(&reportTitle, "report-title", "", "Custom title of the detailed table report and HTML site")
+	flag.StringVar(&reportLogo, "report-logo", "", "Logo image (URL or path relative to the site) shown on the HTML site")
+	flag.Var(reportMeta, "report-meta", "Metadata line (e.g. Ticket=SEC-1234) shown under the report title; repeatable")
+	flag.BoolVar(&refreshDB, "refresh-db", false, "Download the latest action end-of-life dataset before scanning")
+	flag.StringVar(&includeWorkflows, "include-workflows", "", "Only scan workflow files matching these comma-separated glob patterns")
+	flag.StringVar(&excludeWorkflows, "exclude-workflows", "", "Skip workflow files matching these comma-separated glob patterns")
+	flag.StringVar(&workflowPaths, "workflow-paths", "", "Also search these comma-separated directories for workflow files (e.g. ci/workflows,.github/actions)")
+	flag.StringVar(&fetchMode, "fetch-mode", FetchModeAPI, "How files beyond the repository listing are read: api (contents API per file) or tarball (one archive per repository)")
+	flag.BoolVar(&workflowTemplates, "workflow-templates", false, "Also scan the workflow templates in the workflow-templates directory of the organization's .github repository")
+	flag.StringVar(&gists, "gists", "", "Also scan the YAML files of these comma-separated gist IDs, e.g. gists referenced by documentation")
+	flag.DurationVar(&timeout, "timeout", 0, "Maximum scan duration (e.g. 20m); emits a partial report when reached")
+	flag.IntVar(&projectNumber, "project", 0, "Organization project (v2) number to populate with one item per violating repository")
+	flag.BoolVar(&noCache, "no-cache", false, "Ignore and do not update cached action metadata lookups, workflow files, and ETags")
+	flag.StringVar(&cacheDir, "cache-dir", "", "Directory of the action metadata, workflow file, and ETag caches (default: user cache directory)")
+	flag.BoolVar(&telemetry, "telemetry", false, "Send anonymous scan size and duration statistics to the maintainers (opt-in)")
+	flag.BoolVar(&profileScan, "profile-scan", false, "Print per-stage scan timings to stderr")
+	flag.BoolVar(&verbose, "verbose", false, "Trace every API call and print the rate limit budgets and retries to stderr (--log-level debug)")
+	flag.BoolVar(&quiet, "quiet", false, "Print nothing but the report and errors: no status messages or progress bar (--log-level error)")
+	flag.StringVar(&logLevelName, "log-level", "info", "Least important status messages written to stderr: debug, info, warn, error")
+	flag.BoolVar(&notify, "notify", false, "Notify the channels configured in --config of new and resolved findings only")
+	flag.StringVar(&notifyState, "notify-state", "", "File recording the findings already notified (default: notified.json in the cache directory)")
+	flag.StringVar(&configFile, "config", "", "Policy file with severity overrides and fail-on thresholds")
+	flag.StringVar(&profileName, "profile", "", "Named set of flags from ~/.config/gh-action-lens/config.yml (default: its default-profile; none to skip it)")
+	flag.StringVar(&policyFile, "policy", "", "Check every action against the allow/deny rules of this YAML file; violations fail the command")
+	flag.StringVar(&failOn, "fail-on", "", "Exit with status 3 on findings at or above a severity (error, warning, info) or of the comma-separated conditions: unpinned or rule IDs")
+	flag.BoolVar(&snippets, "snippets", false, "Show the workflow lines around each finding in the default, HTML, JSON, and SARIF outputs")
+	flag.BoolVar(&authorship, "authorship", false, "Segment findings by whether a bot or a human last modified the workflow")
+	flag.StringVar(&botAccounts, "bot-accounts", "", "Comma-separated logins or glob patterns of user accounts --authorship treats as bots")
+	flag.StringVar(&enforceMode, "enforce", EnforceBlock, "What a reached fail-on threshold or policy violation does: block (exit status 3) or warn (report and exit 0)")
+	flag.StringVar(&skipRepos, "skip-repos", "", "Skip repositories of these comma-separated kinds: forks, archived, templates, mirrors")
+	flag.BoolVar(&includeForks, "include-forks", false, "Scan forked repositories, which are skipped by default")
+	flag.BoolVar(&includeArchived, "include-archived", false, "Scan archived repositories, which are skipped by default")
+	flag.StringVar(&includeRepos, "include-repos", "", "Only scan repositories matching these comma-separated glob or /regex/ patterns")
+	flag.StringVar(&excludeRepos, "exclude-repos", "", "Skip repositories matching these comma-separated glob or /regex/ patterns")
+	flag.StringVar(&topics, "topic", "", "Only scan repositories with one of these comma-separated topics")
+	flag.StringVar(&visibility, "visibility", "", "Only scan repositories with these comma-separated visibilities: public, private, internal")
+	flag.Var(repoProperties, "property", "Only scan repositories with this custom property value (e.g. criticality=tier1); repeatable")
+	flag.StringVar(&groupByProperty, "group-by-property", "", "Break the detailed report down by the values of this custom property")
 
 	// Custom usage function
 	flag.Usage = func() {
@@ -48,20 +174,177 @@ func main() {
 		fmt.Fprintf(os.Stderr, "execution history or run logs. It shows you what actions are defined in your workflows\n")
 		fmt.Fprintf(os.Stderr, "and how often they're used, but doesn't access runtime data or execution results.\n\n")
 		fmt.Fprintf(os.Stderr, "Usage:\n")
-		fmt.Fprintf(os.Stderr, "  gh action-lens [flags]\n\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens [flags]\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens <command> [flags]\n\n")
+		fmt.Fprintf(os.Stderr, "Commands:\n")
+		fmt.Fprintf(os.Stderr, "  scan        Scan workflows and actions; the scope is any --scan value (default all)\n")
+		fmt.Fprintf(os.Stderr, "  report      Detailed report of repositories, actions, versions, and findings (--scan all --detailed)\n")
+		fmt.Fprintf(os.Stderr, "  diff        Changes between two saved detailed JSON reports\n")
+		fmt.Fprintf(os.Stderr, "  policy check  Check actions against the allow/deny rules of a policy file (--policy)\n")
+		fmt.Fprintf(os.Stderr, "  pin         Tag- and branch-pinned action references with the SHA to pin to (--scan pinning);\n")
+		fmt.Fprintf(os.Stderr, "              with --apply, pull requests pinning tags to their SHAs\n")
+		fmt.Fprintf(os.Stderr, "  matrix      Repositories × versions grid for a single action\n")
+		fmt.Fprintf(os.Stderr, "  rules       List the rules gh-action-lens checks, or describe one\n")
+		fmt.Fprintf(os.Stderr, "  digest      Week-over-week changes between saved scans, optionally sent to Slack/email\n")
+		fmt.Fprintf(os.Stderr, "  trend       Adoption, pinning, and version drift trends over saved scans\n")
+		fmt.Fprintf(os.Stderr, "  vendor      Fork or copy third-party actions into an internal organization and emit a rewrite map\n")
+		fmt.Fprintf(os.Stderr, "  migrate     Apply a rewrite map to all workflows of an organization through pull requests\n")
+		fmt.Fprintf(os.Stderr, "  upgrade     Bump an outdated action across an organization through pull requests\n\n")
+		fmt.Fprintf(os.Stderr, "The scan commands take the flags below; running gh action-lens with the flags alone, as in\n")
+		fmt.Fprintf(os.Stderr, "earlier versions, is the same as gh action-lens scan.\n\n")
 		fmt.Fprintf(os.Stderr, "Flags:\n")
 		fmt.Fprintf(os.Stderr, "  -h, --help\n")
 		fmt.Fprintf(os.Stderr, "        Show help information\n\n")
 		fmt.Fprintf(os.Stderr, "  -o, --org <string>\n")
 		fmt.Fprintf(os.Stderr, "        Organization name to target\n\n")
+		fmt.Fprintf(os.Stderr, "  -u, --user <string>\n")
+		fmt.Fprintf(os.Stderr, "        User account to target instead of an organization\n\n")
+		fmt.Fprintf(os.Stderr, "      --enterprise <slug>\n")
+		fmt.Fprintf(os.Stderr, "        Enterprise slug; scans every organization of the enterprise into one report\n\n")
+		fmt.Fprintf(os.Stderr, "      --ref <branch|tag|sha>\n")
+		fmt.Fprintf(os.Stderr, "        Branch, tag, or commit SHA whose workflows are scanned (default: each repository's default branch)\n\n")
+		fmt.Fprintf(os.Stderr, "      --path <dir>\n")
+		fmt.Fprintf(os.Stderr, "        Scan the workflows of this local directory, e.g. a checkout; repeatable. Without --org,\n")
+		fmt.Fprintf(os.Stderr, "        --user, or --enterprise only local directories are scanned and no network is used\n\n")
+		fmt.Fprintf(os.Stderr, "      --branches <patterns>\n")
+		fmt.Fprintf(os.Stderr, "        Also scan the workflows of branches matching these comma-separated glob patterns; files\n")
+		fmt.Fprintf(os.Stderr, "        identical to already listed ones are skipped, the others are reported as path@branch\n\n")
+		fmt.Fprintf(os.Stderr, "      --all-branches\n")
+		fmt.Fprintf(os.Stderr, "        Also scan the workflows of every branch (same as --branches '*')\n\n")
+		fmt.Fprintf(os.Stderr, "      --hostname <host>\n")
+		fmt.Fprintf(os.Stderr, "        GitHub host to scan, e.g. a GitHub Enterprise Server (default: GH_HOST or the gh default host)\n\n")
 		fmt.Fprintf(os.Stderr, "  -s, --scan <string>\n")
-		fmt.Fprintf(os.Stderr, "        Scan scope: workflows, actions, or all (default \"all\")\n\n")
+		fmt.Fprintf(os.Stderr, "        Scan scope: workflows, actions, secrets, automation, permissions, matrices, pinning, outdated, runners, dependencies, reusable, deprecated-runtimes, actions-permissions, pwn-requests, script-injection, triggers, or all (default \"all\")\n\n")
 		fmt.Fprintf(os.Stderr, "  -d, --detailed\n")
 		fmt.Fprintf(os.Stderr, "        Detailed analysis with comprehensive action breakdown\n\n")
 		fmt.Fprintf(os.Stderr, "  -f, --format <string>\n")
-		fmt.Fprintf(os.Stderr, "        Output format: default, json, table, csv (default \"default\")\n\n")
+		fmt.Fprintf(os.Stderr, "        Output format: default, json, table, csv, sarif, markdown, html, xlsx, allowed-actions, or exec:<command> to pipe the JSON report through a program (default \"default\")\n\n")
+		fmt.Fprintf(os.Stderr, "      --query <expression>\n")
+		fmt.Fprintf(os.Stderr, "        Apply this jq expression to the JSON report and print the result, e.g.\n")
+		fmt.Fprintf(os.Stderr, "        '.summary.pinning.pinned_rate'\n\n")
+		fmt.Fprintf(os.Stderr, "      --template <file>\n")
+		fmt.Fprintf(os.Stderr, "        Render the report through this Go text/template file instead of --format\n\n")
+		fmt.Fprintf(os.Stderr, "      --template-string <template>\n")
+		fmt.Fprintf(os.Stderr, "        Render the report through this inline Go text/template instead of --format\n\n")
 		fmt.Fprintf(os.Stderr, "      --output <string>\n")
-		fmt.Fprintf(os.Stderr, "        Write output to file instead of stdout\n")
+		fmt.Fprintf(os.Stderr, "        Write output to file instead of stdout\n\n")
+		fmt.Fprintf(os.Stderr, "      --csv-delimiter <char>\n")
+		fmt.Fprintf(os.Stderr, "        Field delimiter of the csv format, e.g. ; for locales with a decimal comma, or tab (default \",\")\n\n")
+		fmt.Fprintf(os.Stderr, "      --collapse-owners\n")
+		fmt.Fprintf(os.Stderr, "        Write one owner/* pattern per action owner with --format allowed-actions\n\n")
+		fmt.Fprintf(os.Stderr, "      --exclude-github-owned\n")
+		fmt.Fprintf(os.Stderr, "        Leave actions owned by actions and github out of the reports to focus on third-party actions;\n")
+		fmt.Fprintf(os.Stderr, "        with --format allowed-actions, the setting allows them with \"Allow actions created by GitHub\"\n\n")
+		fmt.Fprintf(os.Stderr, "      --output-dir <dir>\n")
+		fmt.Fprintf(os.Stderr, "        Write a static HTML site (index plus one page per repository) of the detailed report\n\n")
+		fmt.Fprintf(os.Stderr, "      --snapshot-dir <dir>\n")
+		fmt.Fprintf(os.Stderr, "        Save every detailed report as a timestamped JSON file in this directory, for the digest and trend commands\n\n")
+		fmt.Fprintf(os.Stderr, "      --max-report-size <mb>\n")
+		fmt.Fprintf(os.Stderr, "        Split HTML and Markdown detailed reports written with --output into pages above this size (default %d, 0 disables)\n\n", defaultMaxReportSize)
+		fmt.Fprintf(os.Stderr, "      --report-title <string>\n")
+		fmt.Fprintf(os.Stderr, "        Custom title of the detailed table report and HTML site\n\n")
+		fmt.Fprintf(os.Stderr, "      --report-logo <url>\n")
+		fmt.Fprintf(os.Stderr, "        Logo image (URL or path relative to the site) shown on the HTML site\n\n")
+		fmt.Fprintf(os.Stderr, "      --report-meta <key=value>\n")
+		fmt.Fprintf(os.Stderr, "        Metadata line (e.g. Ticket=SEC-1234) shown under the report title; repeatable\n\n")
+		fmt.Fprintf(os.Stderr, "      --refresh-db\n")
+		fmt.Fprintf(os.Stderr, "        Download the latest action end-of-life dataset before scanning\n\n")
+		fmt.Fprintf(os.Stderr, "      --include-workflows <patterns>\n")
+		fmt.Fprintf(os.Stderr, "        Only scan workflow files matching these comma-separated glob patterns\n\n")
+		fmt.Fprintf(os.Stderr, "      --exclude-workflows <patterns>\n")
+		fmt.Fprintf(os.Stderr, "        Skip workflow files matching these comma-separated glob patterns\n\n")
+		fmt.Fprintf(os.Stderr, "      --workflow-paths <dirs>\n")
+		fmt.Fprintf(os.Stderr, "        Also search these comma-separated directories for workflow files (e.g. ci/workflows,.github/actions)\n\n")
+		fmt.Fprintf(os.Stderr, "      --fetch-mode <mode>\n")
+		fmt.Fprintf(os.Stderr, "        How files beyond the repository listing are read: api (contents API per file, default) or\n")
+		fmt.Fprintf(os.Stderr, "        tarball (one archive download per repository, faster with --workflow-paths)\n\n")
+		fmt.Fprintf(os.Stderr, "      --workflow-templates\n")
+		fmt.Fprintf(os.Stderr, "        Also scan the workflow templates in the workflow-templates directory of the organization's .github repository\n\n")
+		fmt.Fprintf(os.Stderr, "      --gists <ids>\n")
+		fmt.Fprintf(os.Stderr, "        Also scan the YAML files of these comma-separated gist IDs, reported as gist:<id>\n\n")
+		fmt.Fprintf(os.Stderr, "      --timeout <duration>\n")
+		fmt.Fprintf(os.Stderr, "        Maximum scan duration (e.g. 20m); emits a partial report when reached\n\n")
+		fmt.Fprintf(os.Stderr, "      --concurrency <n>\n")
+		fmt.Fprintf(os.Stderr, "        Maximum number of workflow files fetched in parallel (default %d)\n\n", defaultConcurrency)
+		fmt.Fprintf(os.Stderr, "      --transitive\n")
+		fmt.Fprintf(os.Stderr, "        Also report the actions used inside composite actions (--scan actions, deprecated-runtimes, or all)\n\n")
+		fmt.Fprintf(os.Stderr, "      --transitive-depth <n>\n")
+		fmt.Fprintf(os.Stderr, "        Levels of nested composite actions resolved with --transitive (default %d)\n\n", defaultTransitiveDepth)
+		fmt.Fprintf(os.Stderr, "      --scorecard\n")
+		fmt.Fprintf(os.Stderr, "        Add the OpenSSF Scorecard score and failing checks of third-party action repositories\n")
+		fmt.Fprintf(os.Stderr, "        (--detailed or --enterprise)\n\n")
+		fmt.Fprintf(os.Stderr, "      --max-matrix-jobs <n>\n")
+		fmt.Fprintf(os.Stderr, "        Flag job matrices generating more than this many jobs (--scan matrices, default %d)\n\n", defaultMaxMatrixJobs)
+		fmt.Fprintf(os.Stderr, "      --min-schedule-interval <duration>\n")
+		fmt.Fprintf(os.Stderr, "        Flag cron schedules running more often than this (--scan triggers, default %s)\n\n", formatInterval(defaultMinScheduleInterval))
+		fmt.Fprintf(os.Stderr, "      --max-workflow-depth <n>\n")
+		fmt.Fprintf(os.Stderr, "        Flag reusable workflow call chains with more levels than this, the caller included (--scan reusable, default %d)\n\n", githubWorkflowNestingLimit)
+		fmt.Fprintf(os.Stderr, "      --events-file <path>\n")
+		fmt.Fprintf(os.Stderr, "        Write progress events as JSON lines to this file\n\n")
+		fmt.Fprintf(os.Stderr, "      --events-fd <n>\n")
+		fmt.Fprintf(os.Stderr, "        Write progress events as JSON lines to this open file descriptor\n\n")
+		fmt.Fprintf(os.Stderr, "      --project <number>\n")
+		fmt.Fprintf(os.Stderr, "        Organization project (v2) number to populate with one item per violating repository\n\n")
+		fmt.Fprintf(os.Stderr, "      --no-cache\n")
+		fmt.Fprintf(os.Stderr, "        Ignore and do not update cached action metadata lookups, workflow files, and ETags\n\n")
+		fmt.Fprintf(os.Stderr, "      --cache-dir <path>\n")
+		fmt.Fprintf(os.Stderr, "        Directory of the action metadata, workflow file, and ETag caches; workflows of repositories\n")
+		fmt.Fprintf(os.Stderr, "        not pushed to since the last run are read from it (default: user cache directory)\n\n")
+		fmt.Fprintf(os.Stderr, "      --telemetry\n")
+		fmt.Fprintf(os.Stderr, "        Send anonymous scan size and duration statistics to the maintainers (opt-in)\n\n")
+		fmt.Fprintf(os.Stderr, "      --profile-scan\n")
+		fmt.Fprintf(os.Stderr, "        Print per-stage scan timings to stderr\n\n")
+		fmt.Fprintf(os.Stderr, "      --verbose\n")
+		fmt.Fprintf(os.Stderr, "        Trace every API call and print the rate limit budgets and retries to stderr (--log-level debug)\n\n")
+		fmt.Fprintf(os.Stderr, "      --quiet\n")
+		fmt.Fprintf(os.Stderr, "        Print nothing but the report and errors: no status messages or progress bar (--log-level error)\n\n")
+		fmt.Fprintf(os.Stderr, "      --log-level <level>\n")
+		fmt.Fprintf(os.Stderr, "        Least important status messages written to stderr: debug, info, warn, error (default \"info\")\n\n")
+		fmt.Fprintf(os.Stderr, "      --config <path>\n")
+		fmt.Fprintf(os.Stderr, "        Policy file with severity overrides and fail-on thresholds\n\n")
+		fmt.Fprintf(os.Stderr, "      --profile <name>\n")
+		fmt.Fprintf(os.Stderr, "        Named set of flags from ~/.config/gh-action-lens/config.yml (default: its default-profile; none to skip it)\n\n")
+		fmt.Fprintf(os.Stderr, "      --notify\n")
+		fmt.Fprintf(os.Stderr, "        Notify the channels configured in --config of new and resolved findings only; findings\n")
+		fmt.Fprintf(os.Stderr, "        already notified by an earlier run are not sent again\n\n")
+		fmt.Fprintf(os.Stderr, "      --notify-state <path>\n")
+		fmt.Fprintf(os.Stderr, "        File recording the findings already notified (default: notified.json in the cache directory)\n\n")
+		fmt.Fprintf(os.Stderr, "      --policy <path>\n")
+		fmt.Fprintf(os.Stderr, "        Check every action against the allow/deny rules of this YAML file; violations fail the command\n\n")
+		fmt.Fprintf(os.Stderr, "      --fail-on <severity,conditions>\n")
+		fmt.Fprintf(os.Stderr, "        Exit with status 3 on findings at or above a severity (error, warning, info) or of the comma-separated conditions: unpinned or rule IDs\n\n")
+		fmt.Fprintf(os.Stderr, "      --snippets\n")
+		fmt.Fprintf(os.Stderr, "        Show the workflow lines around each finding in the default, HTML, JSON, and SARIF outputs\n\n")
+		fmt.Fprintf(os.Stderr, "      --authorship\n")
+		fmt.Fprintf(os.Stderr, "        Segment findings by whether a bot (Dependabot, Renovate, GitHub Apps) or a human last modified the workflow\n\n")
+		fmt.Fprintf(os.Stderr, "      --bot-accounts <logins>\n")
+		fmt.Fprintf(os.Stderr, "        Comma-separated logins or glob patterns of user accounts --authorship treats as bots\n\n")
+		fmt.Fprintf(os.Stderr, "      --enforce <mode>\n")
+		fmt.Fprintf(os.Stderr, "        What a reached fail-on threshold or policy violation does: block (exit status 3, default) or\n")
+		fmt.Fprintf(os.Stderr, "        warn (report, annotate in GitHub Actions, and exit 0)\n\n")
+		fmt.Fprintf(os.Stderr, "      --skip-repos <kinds>\n")
+		fmt.Fprintf(os.Stderr, "        Skip repositories of these comma-separated kinds: forks, archived, templates, mirrors\n\n")
+		fmt.Fprintf(os.Stderr, "      --include-forks\n")
+		fmt.Fprintf(os.Stderr, "        Scan forked repositories, which are skipped by default\n\n")
+		fmt.Fprintf(os.Stderr, "      --include-archived\n")
+		fmt.Fprintf(os.Stderr, "        Scan archived repositories, which are skipped by default\n\n")
+		fmt.Fprintf(os.Stderr, "      --include-repos <patterns>\n")
+		fmt.Fprintf(os.Stderr, "        Only scan repositories matching these comma-separated glob or /regex/ patterns\n\n")
+		fmt.Fprintf(os.Stderr, "      --exclude-repos <patterns>\n")
+		fmt.Fprintf(os.Stderr, "        Skip repositories matching these comma-separated glob or /regex/ patterns\n\n")
+		fmt.Fprintf(os.Stderr, "      --topic <topics>\n")
+		fmt.Fprintf(os.Stderr, "        Only scan repositories with one of these comma-separated topics\n\n")
+		fmt.Fprintf(os.Stderr, "      --visibility <visibilities>\n")
+		fmt.Fprintf(os.Stderr, "        Only scan repositories with these comma-separated visibilities: public, private, internal\n\n")
+		fmt.Fprintf(os.Stderr, "      --property <name=value>\n")
+		fmt.Fprintf(os.Stderr, "        Only scan repositories with this custom property value (e.g. criticality=tier1); repeatable,\n")
+		fmt.Fprintf(os.Stderr, "        all must match\n\n")
+		fmt.Fprintf(os.Stderr, "      --group-by-property <name>\n")
+		fmt.Fprintf(os.Stderr, "        Break the detailed report down by the values of this custom property (e.g. team)\n\n")
+		fmt.Fprintf(os.Stderr, "      --badges-dir <dir>\n")
+		fmt.Fprintf(os.Stderr, "        Write pinning and policy compliance badges (SVG and JSON) to this directory\n\n")
+		fmt.Fprintf(os.Stderr, "      --badges-gist <id>\n")
+		fmt.Fprintf(os.Stderr, "        Publish the badges to this existing gist ID\n")
 
 		fmt.Fprintf(os.Stderr, "\nExamples:\n")
 		fmt.Fprintf(os.Stderr, "  # Basic usage\n")
@@ -72,18 +355,160 @@ func main() {
 		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg                         # Scan all workflows and actions\n")
 		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg --scan workflows        # Scan workflows only\n")
 		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg --scan actions          # Analyze actions only\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg --scan secrets          # Environment × secrets × third-party actions\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg --scan automation       # Dependabot/Renovate coverage of actions\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg --scan permissions      # Effective GITHUB_TOKEN permissions per job\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg --scan outdated         # Upgrade list against the latest action releases\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg --scan runners          # Runner inventory; OS-specific commands on mismatched or -latest runners\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg --scan dependencies     # Workflows that cannot run without github.com-hosted third-party actions\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg --scan reusable         # Reusable workflow call graph and adoption\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg --scan deprecated-runtimes  # Workflows using actions on node12/node16\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg --scan actions-permissions  # Usage the org's allowed actions setting would block\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg --scan pwn-requests     # pull_request_target/workflow_run running untrusted code\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg --scan script-injection  # Issue titles, PR bodies, ... interpolated into run: scripts\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg --scan triggers         # Events, cron schedules, and workflow_dispatch inputs\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg --scan triggers --min-schedule-interval 1h  # Flag schedules running more than hourly\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg --policy policy.yml     # Allow/deny rules; exits 3 on violations\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg --policy policy.yml --enforce warn  # Roll out the policy without failing\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg --scan pinning --config action-lens.yml --notify  # Notify new and resolved findings only\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg --include-repos 'platform-*' --exclude-repos '/-sandbox$/'  # Repositories by name\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg --topic payments --visibility private,internal  # Repositories by topic and visibility\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg --property criticality=tier1  # Only repositories with this custom property value\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg -d --group-by-property team   # Detailed report broken down by team\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens -u octocat                       # Scan a personal account\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens --enterprise acme                # All organizations of an enterprise\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg --all-branches --scan workflows  # Include workflows that exist only on other branches\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens --path . --scan pinning  # Scan the current checkout offline\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens --hostname github.example.com -o myorg  # Organization on GitHub Enterprise Server\n")
 		fmt.Fprintf(os.Stderr, "\n")
 		fmt.Fprintf(os.Stderr, "  # Detailed analysis\n")
 		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg --scan all --detailed   # Comprehensive action breakdown\n")
 		fmt.Fprintf(os.Stderr, "\n")
+		fmt.Fprintf(os.Stderr, "  # Commands\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens scan secrets -o myorg            # Same as -o myorg --scan secrets\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens report -o myorg --format json --output scan.json  # Same as --scan all --detailed\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens diff last-week.json scan.json    # What changed between two reports\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens policy check policy.yml -o myorg # Same as --policy policy.yml\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens pin -o myorg                     # Same as --scan pinning\n")
+		fmt.Fprintf(os.Stderr, "\n")
 		fmt.Fprintf(os.Stderr, "  # Output formatting\n")
 		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg --format json           # Output results as JSON\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg -d --format markdown >> \"$GITHUB_STEP_SUMMARY\"  # Job summary in a workflow\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg -d --format html --output dashboard.html  # Self-contained HTML dashboard\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg -d --transitive                            # Include actions used by composite actions\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg -d --scorecard                             # Rank third-party actions by OpenSSF Scorecard\n")
 		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg --format csv            # Output results as CSV\n")
-		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg --output results.txt    # Write output to file\n\n\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg --output results.txt    # Write output to file\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg -d --output-dir site --report-title 'Q3 Actions audit' --report-meta Ticket=SEC-1234\n")
+		fmt.Fprintf(os.Stderr, "\n")
+		fmt.Fprintf(os.Stderr, "  # Version matrix for one action\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens matrix -o myorg --action actions/setup-node --format markdown\n")
+		fmt.Fprintf(os.Stderr, "\n")
+		fmt.Fprintf(os.Stderr, "  # Rule reference\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens rules list                       # All rule IDs\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens rules describe eol-action        # Details of one rule\n")
+		fmt.Fprintf(os.Stderr, "\n")
+		fmt.Fprintf(os.Stderr, "  # Workflow filters\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg --include-workflows 'deploy-*.yml'       # Only deploy workflows\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg --exclude-workflows '*-experimental.yml' # Skip experiments\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens -o myorg --workflow-paths ci/workflows,.github/actions # Legacy locations\n")
+		fmt.Fprintf(os.Stderr, "\n")
+		fmt.Fprintf(os.Stderr, "  # End-of-life dataset\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens --refresh-db                     # Update the EOL dataset\n\n\n")
 	}
 
-	// Parse command line arguments
-	flag.Parse()
+	// Dispatch subcommands before parsing the top-level flags
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "matrix":
+			if err := runMatrixCommand(os.Args[2:]); err != nil {
+				logErrorf("❌ Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "rules":
+			if err := runRulesCommand(os.Args[2:]); err != nil {
+				logErrorf("❌ Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "digest":
+			if err := runDigestCommand(os.Args[2:]); err != nil {
+				logErrorf("❌ Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "trend":
+			if err := runTrendCommand(os.Args[2:]); err != nil {
+				logErrorf("❌ Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "vendor":
+			if err := runVendorCommand(os.Args[2:]); err != nil {
+				logErrorf("❌ Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "migrate":
+			if err := runMigrateCommand(os.Args[2:]); err != nil {
+				logErrorf("❌ Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "upgrade":
+			if err := runUpgradeCommand(os.Args[2:]); err != nil {
+				logErrorf("❌ Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "pin":
+			// With --apply or --dry-run pin opens pull requests; otherwise it is the --scan pinning report
+			if pinRewriteRequested(os.Args[2:]) {
+				if err := runPinCommand(os.Args[2:]); err != nil {
+					logErrorf("❌ Error: %v\n", err)
+					os.Exit(1)
+				}
+				return
+			}
+		case "diff":
+			if err := runDiffCommand(os.Args[2:]); err != nil {
+				logErrorf("❌ Error: %v\n", err)
+				os.Exit(exitStatus(err))
+			}
+			return
+		}
+	}
+
+	// Parse command line arguments; the scan commands are presets of the top-level flags
+	args := os.Args[1:]
+	command, commandArgs, isCommand := lookupScanCommand(args)
+	if isCommand {
+		flag.Usage = command.usage
+		expanded, err := command.expand(commandArgs)
+		if err != nil {
+			logErrorf("❌ Error: %v\n", err)
+			os.Exit(1)
+		}
+		args = expanded
+	} else if len(args) > 0 && args[0] == "policy" {
+		logErrorf("❌ Error: unknown policy command; usage: gh action-lens policy check <policy.yml> [flags]\n")
+		os.Exit(1)
+	}
+	flag.CommandLine.Parse(args)
+	if isCommand {
+		if err := command.apply(flag.CommandLine); err != nil {
+			logErrorf("❌ Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// A profile fills in the flags not given on the command line
+	appliedProfile, err := applyProfile(flag.CommandLine, profileName)
+	if err != nil {
+		logErrorf("❌ Error: %v\n", err)
+		os.Exit(1)
+	}
 
 	// Show help if requested
 	if showHelp {
@@ -91,205 +516,641 @@ func main() {
 		return
 	}
 
+	// Status messages go to stderr at the chosen level, so stdout carries only the report
+	if verbose && quiet {
+		logErrorf("❌ Error: --verbose and --quiet cannot be combined.\n")
+		os.Exit(1)
+	}
+	level, err := parseLogLevel(logLevelName)
+	if err != nil {
+		logErrorf("❌ Error: %v\n", err)
+		os.Exit(1)
+	}
+	if (verbose || quiet) && logLevelName != "info" {
+		logErrorf("❌ Error: --log-level cannot be combined with --verbose or --quiet.\n")
+		os.Exit(1)
+	}
+	switch {
+	case verbose:
+		level = logDebug
+	case quiet:
+		level = logError
+	}
+	currentLogLevel = level
+
 	// Main extension logic
-	fmt.Println("Welcome to gh-action-lens!")
-	fmt.Println("A GitHub CLI extension for scanning GitHub Actions workflows.")
+	logInfof("Welcome to gh-action-lens!\n")
+	logInfof("A GitHub CLI extension for scanning GitHub Actions workflows.\n")
+
+	// A user account is scanned exactly like an organization
+	if user != "" {
+		if organization != "" {
+			logErrorf("❌ Error: --org and --user cannot be combined.\n")
+			os.Exit(1)
+		}
+		organization = user
+	}
+
+	// Local directories alone are scanned offline under a pseudo-organization
+	if len(localPaths) > 0 && organization == "" && enterprise == "" {
+		organization = localOrganization
+		offline = true
+	}
+	if len(localPaths) > 0 && enterprise != "" {
+		logErrorf("❌ Error: --path cannot be used with --enterprise.\n")
+		os.Exit(1)
+	}
+
+	if telemetry && resolveTelemetryEndpoint() == "" {
+		logErrorf("❌ Error: --telemetry requires a statistics endpoint: set GH_ACTION_LENS_TELEMETRY_URL or build with -ldflags \"-X main.telemetryEndpoint=<url>\"\n")
+		os.Exit(1)
+	}
+
+	if workflowRef != "" && (strings.ContainsAny(workflowRef, ": ") || strings.HasPrefix(workflowRef, "-")) {
+		logErrorf("❌ Error: Invalid --ref '%s'; expected a branch, tag, or commit SHA.\n", workflowRef)
+		os.Exit(1)
+	}
+
+	if enterprise != "" {
+		if organization != "" {
+			logErrorf("❌ Error: --enterprise cannot be combined with --org or --user.\n")
+			os.Exit(1)
+		}
+		if scanScope != "all" && scanScope != "actions" {
+			logErrorf("❌ Error: --enterprise supports --scan all or actions, not '%s'.\n", scanScope)
+			os.Exit(1)
+		}
+		if projectNumber > 0 {
+			logErrorf("❌ Error: --project cannot be used with --enterprise.\n")
+			os.Exit(1)
+		}
+		if notify {
+			logErrorf("❌ Error: --notify cannot be used with --enterprise.\n")
+			os.Exit(1)
+		}
+	}
 
 	// Display target scope
-	if organization != "" {
-		fmt.Printf("🎯 Target Organization: %s\n", organization)
+	if enterprise != "" {
+		logInfof("🎯 Target Enterprise: %s\n", enterprise)
+	} else if offline {
+		logInfof("📂 Local directories: %s\n", strings.Join(localPaths, ", "))
+	} else if user != "" {
+		logInfof("🎯 Target User: %s\n", user)
+	} else if organization != "" {
+		logInfof("🎯 Target Organization: %s\n", organization)
 	} else {
-		fmt.Println("📍 Scope: Current user context")
+		logInfof("📍 Scope: Current user context\n")
 	}
-
-	client, err := api.DefaultRESTClient()
-	if err != nil {
-		fmt.Printf("Error creating GitHub client: %v\n", err)
-		return
+	if workflowRef != "" {
+		logInfof("🌿 Ref: %s\n", workflowRef)
+	}
+	if appliedProfile != "" {
+		logInfof("🧩 Profile: %s\n", appliedProfile)
 	}
 
-	response := struct{ Login string }{}
-	err = client.Get("user", &response)
-	if err != nil {
-		fmt.Printf("Error getting user info: %v\n", err)
-		return
+	// An offline scan needs no authentication
+	if !offline {
+		client, err := newRESTClient()
+		if err != nil {
+			logErrorf("❌ Error creating GitHub client: %v\n", err)
+			os.Exit(1)
+		}
+
+		response := struct{ Login string }{}
+		err = client.Get("user", &response)
+		if err != nil {
+			logErrorf("❌ Error getting user info: %v\n", err)
+			os.Exit(1)
+		}
+
+		if host := githubHost(); host != "github.com" {
+			logInfof("✓ Authenticated as: %s on %s\n", response.Login, host)
+		} else {
+			logInfof("✓ Authenticated as: %s\n", response.Login)
+		}
 	}
 
-	fmt.Printf("✓ Authenticated as: %s\n", response.Login)
+	// Refresh the end-of-life dataset if requested
+	if refreshDB {
+		db, err := refreshEOLDatabase()
+		if err != nil {
+			logErrorf("❌ Error refreshing EOL database: %v\n", err)
+			os.Exit(1)
+		}
+		logInfof("✓ EOL database refreshed (%d actions, updated %s)\n", len(db.Entries), db.Updated)
+	}
 
 	// Execute workflow scanning and/or action extraction if requested
-	if organization != "" {
+	if organization != "" || enterprise != "" {
 		// Validate scan scope
-		if scanScope != "workflows" && scanScope != "actions" && scanScope != "all" {
-			fmt.Printf("❌ Error: Invalid scan scope '%s'. Valid options: workflows, actions, all.\n", scanScope)
+		if !isValidScanScope(scanScope) {
+			logErrorf("❌ Error: Invalid scan scope '%s'. Valid options: %s.\n", scanScope, strings.Join(validScanScopes, ", "))
 			os.Exit(1)
 		}
 
+		// A template replaces the output format
+		if templateFile != "" || templateString != "" {
+			if templateFile != "" && templateString != "" {
+				logErrorf("❌ Error: --template and --template-string cannot be combined.\n")
+				os.Exit(1)
+			}
+			if outputFormat != "default" {
+				logErrorf("❌ Error: --template cannot be combined with --format.\n")
+				os.Exit(1)
+			}
+			if err := registerTemplateFormatter(templateFile, templateString); err != nil {
+				logErrorf("❌ Error: %v\n", err)
+				os.Exit(1)
+			}
+			outputFormat = templateFormat
+		}
+
+		// A query filters the JSON report
+		if query != "" {
+			if outputFormat != "default" && outputFormat != "json" {
+				logErrorf("❌ Error: --query works on the JSON report and cannot be combined with --format %s or --template.\n", outputFormat)
+				os.Exit(1)
+			}
+			if err := registerQueryFormatter(query); err != nil {
+				logErrorf("❌ Error: %v\n", err)
+				os.Exit(1)
+			}
+			outputFormat = queryFormat
+		}
+
 		// Validate output format
-		if outputFormat != "default" && outputFormat != "json" && outputFormat != "table" && outputFormat != "csv" {
-			fmt.Printf("❌ Error: Invalid output format '%s'. Valid options: default, json, table, csv.\n", outputFormat)
+		if !isValidOutputFormat(outputFormat) {
+			logErrorf("❌ Error: Invalid output format '%s'. Valid options: %s, or exec:<command>.\n", outputFormat, strings.Join(outputFormatNames(), ", "))
+			os.Exit(1)
+		}
+		if outputFormat == "markdown" && scanScope == "automation" {
+			logErrorf("❌ Error: --format markdown is not available for --scan automation\n")
+			os.Exit(1)
+		}
+		if outputFormat == "html" && enterprise == "" && !(detailed && (scanScope == "actions" || scanScope == "all")) {
+			logErrorf("❌ Error: --format html requires --detailed (with --scan actions or all) or --enterprise\n")
+			os.Exit(1)
+		}
+		if outputFormat == "xlsx" && enterprise == "" && !(detailed && (scanScope == "actions" || scanScope == "all")) {
+			logErrorf("❌ Error: --format xlsx requires --detailed (with --scan actions or all) or --enterprise\n")
+			os.Exit(1)
+		}
+		if outputFormat == allowedActionsFormat && enterprise == "" && scanScope != "actions" && !(detailed && scanScope == "all") {
+			logErrorf("❌ Error: --format allowed-actions requires --scan actions, --detailed, or --enterprise\n")
+			os.Exit(1)
+		}
+		if collapseOwners && outputFormat != allowedActionsFormat {
+			logErrorf("❌ Error: --collapse-owners requires --format allowed-actions\n")
+			os.Exit(1)
+		}
+		allowedActions.CollapseOwners = collapseOwners
+		allowedActions.ExcludeGitHubOwned = excludeGitHubOwned
+		comma, err := parseCSVDelimiter(delimiter)
+		if err != nil {
+			logErrorf("❌ Error: %v\n", err)
+			os.Exit(1)
+		}
+		csvDelimiter = comma
+		if outputFormat == "xlsx" && outputFile == "" {
+			logErrorf("❌ Error: --format xlsx writes an Excel workbook and requires --output\n")
+			os.Exit(1)
+		}
+		findingsScan := enterprise != "" || policyFile != "" || isFindingsScanScope(scanScope) || (detailed && (scanScope == "actions" || scanScope == "all"))
+		if outputFormat == "sarif" && !findingsScan {
+			logErrorf("❌ Error: --format sarif requires a scan that produces findings: %s, --policy, or --enterprise\n", findingsScanOptions())
+			os.Exit(1)
+		}
+		if groupByProperty != "" && enterprise == "" && !(detailed && (scanScope == "actions" || scanScope == "all")) {
+			logErrorf("❌ Error: --group-by-property requires --detailed (with --scan actions or all) or --enterprise\n")
+			os.Exit(1)
+		}
+		if scorecard && enterprise == "" && !(detailed && (scanScope == "actions" || scanScope == "all")) {
+			logErrorf("❌ Error: --scorecard requires --detailed (with --scan actions or all) or --enterprise\n")
+			os.Exit(1)
+		}
+		if snapshotDir != "" && enterprise == "" && !(detailed && (scanScope == "actions" || scanScope == "all")) {
+			logErrorf("❌ Error: --snapshot-dir requires --detailed (with --scan actions or all) or --enterprise\n")
+			os.Exit(1)
+		}
+		if notify && !findingsScan {
+			logErrorf("❌ Error: --notify requires a scan that produces findings: %s, or --policy\n", findingsScanOptions())
+			os.Exit(1)
+		}
+
+		opts := scanOptions{
+			IncludeWorkflows:    splitList(includeWorkflows),
+			ExcludeWorkflows:    splitList(excludeWorkflows),
+			WorkflowPaths:       splitList(workflowPaths),
+			WorkflowTemplates:   workflowTemplates,
+			FetchMode:           fetchMode,
+			Gists:               splitList(gists),
+			LocalPaths:          localPaths,
+			ProjectNumber:       projectNumber,
+			IncludeRepositories: splitList(includeRepos),
+			ExcludeRepositories: splitList(excludeRepos),
+			Topics:              splitList(topics),
+			Visibility:          splitList(visibility),
+			Properties:          repoProperties,
+			GroupByProperty:     groupByProperty,
+			BadgesDir:           badgesDir,
+			BadgesGist:          badgesGist,
+			OutputDir:           outputDir,
+			SnapshotDir:         snapshotDir,
+			MaxReportSize:       maxReportSize,
+			Concurrency:         concurrency,
+			Cache:               openEnrichmentCache(cacheDir, noCache),
+		}
+		workflowCache = openScanCache(cacheDir, noCache)
+		etagCache = openConditionalCache(cacheDir, noCache)
+		if transitive {
+			if transitiveDepth < 1 {
+				logErrorf("❌ Error: --transitive-depth must be at least 1\n")
+				os.Exit(1)
+			}
+			opts.Transitive = transitiveDepth
+		}
+		opts.ExcludeGitHubOwned = excludeGitHubOwned
+		opts.Scorecard = scorecard
+		opts.Branches = splitList(branches)
+		if allBranches {
+			opts.Branches = []string{"*"}
+		}
+		skipped, err := skippedRepositoryKinds(splitList(skipRepos), includeForks, includeArchived)
+		if err != nil {
+			logErrorf("❌ Error: %v\n", err)
+			os.Exit(1)
+		}
+		opts.SkipRepositories = skipped
+		if err := opts.validate(); err != nil {
+			logErrorf("❌ Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if configFile != "" {
+			config, err := loadConfig(configFile)
+			if err != nil {
+				logErrorf("❌ Error: %v\n", err)
+				os.Exit(1)
+			}
+			opts.Config = config
+		}
+		thresholds, err := failOnThresholds(opts.Config, failOn)
+		if err != nil {
+			logErrorf("❌ Error: %v\n", err)
+			os.Exit(1)
+		}
+		opts.FailOn = thresholds
+		if !isValidEnforceMode(enforceMode) {
+			logErrorf("❌ Error: Invalid --enforce '%s'. Valid options: %s.\n", enforceMode, strings.Join(validEnforceModes, ", "))
+			os.Exit(1)
+		}
+		opts.Enforce = enforceMode
+		opts.Authorship = authorship
+		opts.Snippets = snippets
+		opts.BotAccounts = splitList(botAccounts)
+		if opts.Config != nil {
+			opts.BotAccounts = append(opts.BotAccounts, opts.Config.BotAccounts...)
+		}
+		opts.Branding = reportBranding(opts.Config, reportTitle, reportLogo, reportMeta)
+		if policyFile != "" {
+			if enterprise != "" || detailed || scanScope != "all" {
+				logErrorf("❌ Error: --policy runs its own scan and cannot be combined with --scan, --detailed, or --enterprise\n")
+				os.Exit(1)
+			}
+			policy, err := loadPolicy(policyFile)
+			if err != nil {
+				logErrorf("❌ Error: %v\n", err)
+				os.Exit(1)
+			}
+			opts.Policy = policy
+			scanScope = "policy"
+		}
+		if notify {
+			if opts.Config == nil || !opts.Config.Notifications.configured() {
+				logErrorf("❌ Error: --notify requires --config with notifications configured\n")
+				os.Exit(1)
+			}
+			opts.NotifyState = notificationStatePath(notifyState, cacheDir)
+			if opts.NotifyState == "" {
+				logErrorf("❌ Error: --notify could not determine a state file; set --notify-state\n")
+				os.Exit(1)
+			}
+			opts.Notify = true
+		}
+		opts.Scope = scanScope
+		if maxReportSize < 0 {
+			logErrorf("❌ Error: Invalid --max-report-size %d; must be 0 or more.\n", maxReportSize)
+			os.Exit(1)
+		}
+		if maxMatrixJobs < 1 {
+			logErrorf("❌ Error: Invalid --max-matrix-jobs %d; must be at least 1.\n", maxMatrixJobs)
+			os.Exit(1)
+		}
+		if minScheduleInterval < time.Minute {
+			logErrorf("❌ Error: Invalid --min-schedule-interval %s; must be at least 1m.\n", minScheduleInterval)
+			os.Exit(1)
+		}
+		if maxWorkflowDepth < 1 || maxWorkflowDepth > githubWorkflowNestingLimit {
+			logErrorf("❌ Error: Invalid --max-workflow-depth %d; must be between 1 and GitHub's limit of %d.\n", maxWorkflowDepth, githubWorkflowNestingLimit)
 			os.Exit(1)
 		}
 
 		startTime := time.Now()
+		if timeout > 0 {
+			opts.Deadline = startTime.Add(timeout)
+		}
+		opts.Context = notifyInterrupt()
+		apiRateLimit.done = opts.Context.Done()
+		if telemetry || profileScan {
+			opts.Profile = newScanProfile()
+		}
+
+		// An enterprise scan always produces the consolidated detailed report
+		if enterprise != "" {
+			scanScope = "enterprise"
+		}
+
+		events, err := openEventStream(eventsFile, eventsFD)
+		if err != nil {
+			logErrorf("❌ Error: %v\n", err)
+			os.Exit(1)
+		}
+		defer events.close()
+		opts.Events = events
+		apiRateLimit.events = events
+		apiRateLimit.setConcurrency(concurrency)
+		target := organization
+		if enterprise != "" {
+			target = enterprise
+		}
+		events.emit(Event{Type: EventScanStarted, Schema: eventSchemaVersion, Scope: scanScope, Organization: target})
+
+		// The bar is shown on terminals with the status messages; it is cleared before the report is written
+		if logEnabled(logInfo) {
+			scanProgress = newProgress()
+		}
 
 		switch scanScope {
+		case "enterprise":
+			err := enterpriseAnalysis(enterprise, startTime, outputFormat, outputFile, opts)
+			if err != nil {
+				scanProgress.stop()
+				logErrorf("❌ Error scanning enterprise: %v\n", err)
+				os.Exit(exitStatus(err))
+			}
+
 		case "workflows":
-			err := scanOrganizationWorkflows(organization, startTime, outputFormat, outputFile)
+			err := scanOrganizationWorkflows(organization, startTime, outputFormat, outputFile, opts)
 			if err != nil {
-				fmt.Printf("❌ Error scanning workflows: %v\n", err)
-				os.Exit(1)
+				scanProgress.stop()
+				logErrorf("❌ Error scanning workflows: %v\n", err)
+				os.Exit(exitStatus(err))
 			}
 
 		case "actions":
 			if detailed {
-				if outputFormat == "default" {
-					fmt.Printf("\n🔍 Detailed action analysis of organization: %s\n\n", organization)
-				}
-				err := comprehensiveAnalysis(organization, startTime, outputFormat, outputFile)
+				logInfof("\n🔍 Detailed action analysis of organization: %s\n\n", organization)
+				err := comprehensiveAnalysis(organization, startTime, outputFormat, outputFile, opts)
 				if err != nil {
-					fmt.Printf("❌ Error: %v\n", err)
-					os.Exit(1)
+					scanProgress.stop()
+					logErrorf("❌ Error: %v\n", err)
+					os.Exit(exitStatus(err))
 				}
 			} else {
-				if outputFormat == "default" {
-					fmt.Println("\n🔍 Extracting actions from workflows...")
-				}
-				err := extractActionsFromWorkflows(organization, startTime, outputFormat, outputFile)
+				logInfof("\n🔍 Extracting actions from workflows...\n")
+				err := extractActionsFromWorkflows(organization, startTime, outputFormat, outputFile, opts)
 				if err != nil {
-					fmt.Printf("❌ Error extracting actions: %v\n", err)
-					os.Exit(1)
+					scanProgress.stop()
+					logErrorf("❌ Error extracting actions: %v\n", err)
+					os.Exit(exitStatus(err))
 				}
 			}
 
+		case "secrets":
+			err := analyzeSecretScopes(organization, startTime, outputFormat, outputFile, opts)
+			if err != nil {
+				scanProgress.stop()
+				logErrorf("❌ Error analyzing secret scopes: %v\n", err)
+				os.Exit(exitStatus(err))
+			}
+
+		case "automation":
+			err := analyzeUpdateAutomation(organization, startTime, outputFormat, outputFile, opts)
+			if err != nil {
+				scanProgress.stop()
+				logErrorf("❌ Error analyzing update automation: %v\n", err)
+				os.Exit(exitStatus(err))
+			}
+
+		case "permissions":
+			err := analyzeEffectivePermissions(organization, startTime, outputFormat, outputFile, opts)
+			if err != nil {
+				scanProgress.stop()
+				logErrorf("❌ Error resolving permissions: %v\n", err)
+				os.Exit(exitStatus(err))
+			}
+
+		case "matrices":
+			err := analyzeMatrices(organization, startTime, outputFormat, outputFile, maxMatrixJobs, opts)
+			if err != nil {
+				scanProgress.stop()
+				logErrorf("❌ Error analyzing job matrices: %v\n", err)
+				os.Exit(exitStatus(err))
+			}
+
+		case "pinning":
+			err := analyzePinning(organization, startTime, outputFormat, outputFile, opts)
+			if err != nil {
+				scanProgress.stop()
+				logErrorf("❌ Error auditing action pinning: %v\n", err)
+				os.Exit(exitStatus(err))
+			}
+
+		case "policy":
+			err := analyzePolicy(organization, startTime, outputFormat, outputFile, opts)
+			if err != nil {
+				scanProgress.stop()
+				logErrorf("❌ Error: %v\n", err)
+				os.Exit(exitStatus(err))
+			}
+
+		case "outdated":
+			err := analyzeOutdatedActions(organization, startTime, outputFormat, outputFile, opts)
+			if err != nil {
+				scanProgress.stop()
+				logErrorf("❌ Error checking for outdated actions: %v\n", err)
+				os.Exit(exitStatus(err))
+			}
+
+		case "runners":
+			err := analyzeRunnerAssumptions(organization, startTime, outputFormat, outputFile, opts)
+			if err != nil {
+				scanProgress.stop()
+				logErrorf("❌ Error checking runner OS assumptions: %v\n", err)
+				os.Exit(exitStatus(err))
+			}
+
+		case "dependencies":
+			err := analyzeHardDependencies(organization, startTime, outputFormat, outputFile, opts)
+			if err != nil {
+				scanProgress.stop()
+				logErrorf("❌ Error tracing action dependencies: %v\n", err)
+				os.Exit(exitStatus(err))
+			}
+
+		case "reusable":
+			err := analyzeReusableWorkflows(organization, startTime, outputFormat, outputFile, maxWorkflowDepth, opts)
+			if err != nil {
+				scanProgress.stop()
+				logErrorf("❌ Error tracing reusable workflow calls: %v\n", err)
+				os.Exit(exitStatus(err))
+			}
+
+		case "deprecated-runtimes":
+			err := analyzeDeprecatedRuntimes(organization, startTime, outputFormat, outputFile, opts)
+			if err != nil {
+				scanProgress.stop()
+				logErrorf("❌ Error checking action runtimes: %v\n", err)
+				os.Exit(exitStatus(err))
+			}
+
+		case "actions-permissions":
+			err := analyzeActionsPermissions(organization, startTime, outputFormat, outputFile, opts)
+			if err != nil {
+				scanProgress.stop()
+				logErrorf("❌ Error comparing usage with the Actions permissions: %v\n", err)
+				os.Exit(exitStatus(err))
+			}
+
+		case "pwn-requests":
+			err := analyzePwnRequests(organization, startTime, outputFormat, outputFile, opts)
+			if err != nil {
+				scanProgress.stop()
+				logErrorf("❌ Error checking for pwn requests: %v\n", err)
+				os.Exit(exitStatus(err))
+			}
+
+		case "script-injection":
+			err := analyzeScriptInjections(organization, startTime, outputFormat, outputFile, opts)
+			if err != nil {
+				scanProgress.stop()
+				logErrorf("❌ Error checking for script injection: %v\n", err)
+				os.Exit(exitStatus(err))
+			}
+
+		case "triggers":
+			err := analyzeTriggers(organization, startTime, outputFormat, outputFile, minScheduleInterval, opts)
+			if err != nil {
+				scanProgress.stop()
+				logErrorf("❌ Error collecting workflow triggers: %v\n", err)
+				os.Exit(exitStatus(err))
+			}
+
 		case "all":
 			if detailed {
-				if outputFormat == "default" {
-					fmt.Println("\n🔍 Starting detailed analysis...")
-				}
-				err := comprehensiveAnalysis(organization, startTime, outputFormat, outputFile)
+				logInfof("\n🔍 Starting detailed analysis...\n")
+				err := comprehensiveAnalysis(organization, startTime, outputFormat, outputFile, opts)
 				if err != nil {
-					fmt.Printf("❌ Error: %v\n", err)
-					os.Exit(1)
+					scanProgress.stop()
+					logErrorf("❌ Error: %v\n", err)
+					os.Exit(exitStatus(err))
 				}
 			} else {
-				if outputFormat == "default" {
-					fmt.Println("\n🔍 Starting workflow scan and action extraction...")
-				}
-				err := scanAndExtractActions(organization, startTime, outputFormat, outputFile)
+				logInfof("\n🔍 Starting workflow scan and action extraction...\n")
+				err := scanAndExtractActions(organization, startTime, outputFormat, outputFile, opts)
 				if err != nil {
-					fmt.Printf("❌ Error: %v\n", err)
-					os.Exit(1)
+					scanProgress.stop()
+					logErrorf("❌ Error: %v\n", err)
+					os.Exit(exitStatus(err))
 				}
 			}
 		}
+
+		scanProgress.stop()
+		events.emit(Event{Type: EventScanDone, Organization: target})
+
+		// A failed cache write only costs extra API calls on the next run
+		if err := opts.Cache.save(); err != nil {
+			logWarnf("⚠️  Could not save enrichment cache: %v\n", err)
+		}
+		if err := workflowCache.save(); err != nil {
+			logWarnf("⚠️  Could not save workflow cache: %v\n", err)
+		}
+		if err := etagCache.save(); err != nil {
+			logWarnf("⚠️  Could not save ETag cache: %v\n", err)
+		}
+
+		if profileScan {
+			outputScanProfile(opts.Profile, os.Stderr)
+		}
+		if logEnabled(logDebug) {
+			outputRateLimitBudgets(apiRateLimit, os.Stderr)
+		}
+		if telemetry {
+			sendUsageStatistics(resolveTelemetryEndpoint(), buildUsageStatistics(opts.Profile, scanScope, detailed, outputFormat, opts.expired()))
+		}
+		if opts.interrupted() {
+			os.Exit(exitInterrupted)
+		}
 		return
 	}
 
 	// Show configuration summary
-	fmt.Println("\n--- Configuration ---")
+	fmt.Fprintln(os.Stderr, "\n--- Configuration ---")
 	if organization != "" {
-		fmt.Printf("Organization: %s\n", organization)
+		fmt.Fprintf(os.Stderr, "Organization: %s\n", organization)
 	}
-	fmt.Println("\nUse 'gh action-lens --help' to see available options.")
-	fmt.Println("\nExamples:")
-	fmt.Println("  gh action-lens -o <organization>                  # Scan workflows and actions")
-	fmt.Println("  gh action-lens -o <organization> --scan workflows # Scan workflows only")
-	fmt.Println("  gh action-lens -o <organization> --scan actions   # Analyze actions only")
+	fmt.Fprintln(os.Stderr, "\nUse 'gh action-lens --help' to see available options.")
+	fmt.Fprintln(os.Stderr, "\nExamples:")
+	fmt.Fprintln(os.Stderr, "  gh action-lens -o <organization>                  # Scan workflows and actions")
+	fmt.Fprintln(os.Stderr, "  gh action-lens -o <organization> --scan workflows # Scan workflows only")
+	fmt.Fprintln(os.Stderr, "  gh action-lens -o <organization> --scan actions   # Analyze actions only")
 }
 
-// scanOrganizationWorkflows scans an organization for repositories with workflow files
-func scanOrganizationWorkflows(org string, startTime time.Time, outputFormat string, outputFile string) error {
-	// Get GitHub token from environment
-	token := os.Getenv("GITHUB_TOKEN")
-	if token == "" {
-		// Try to get token from gh CLI configuration
-		token = os.Getenv("GH_TOKEN")
-	}
-
-	if token == "" {
-		return fmt.Errorf("GitHub token not found. Please set GITHUB_TOKEN or GH_TOKEN environment variable, or authenticate with 'gh auth login'")
-	}
-
-	// Create OAuth2 client
-	src := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: token},
-	)
-	httpClient := oauth2.NewClient(context.Background(), src)
-	client := githubv4.NewClient(httpClient)
-
-	// Define GraphQL query structure
-	var q struct {
-		Organization struct {
-			Repositories struct {
-				Nodes []struct {
-					Name      string
-					Workflows struct {
-						Tree struct {
-							Entries []struct {
-								Name string
-								Path string
-								Type string
-							}
-						} `graphql:"... on Tree"`
-					} `graphql:"workflows: object(expression: \"HEAD:.github/workflows\")"`
-				}
-				PageInfo struct {
-					HasNextPage bool
-					EndCursor   githubv4.String
-				}
-			} `graphql:"repositories(first: 50, after: $cursor)"`
-		} `graphql:"organization(login: $org)"`
-	}
+// validScanScopes lists the values accepted by --scan
+var validScanScopes = []string{"workflows", "actions", "secrets", "automation", "permissions", "matrices", "pinning", "outdated", "runners", "dependencies", "reusable", "deprecated-runtimes", "actions-permissions", "pwn-requests", "script-injection", "triggers", "all"}
 
-	vars := map[string]interface{}{
-		"org":    githubv4.String(org),
-		"cursor": (*githubv4.String)(nil),
-	}
-
-	if outputFormat == "default" {
-		fmt.Printf("🔍 Scanning organization: %s\n\n", org)
+// isValidScanScope reports whether scope is one of validScanScopes
+func isValidScanScope(scope string) bool {
+	for _, valid := range validScanScopes {
+		if scope == valid {
+			return true
+		}
 	}
+	return false
+}
 
-	var repositories []RepositoryWorkflows
-	totalRepos := 0
-	reposWithWorkflows := 0
+// findingsScanScopes lists the --scan values that produce findings without --detailed
+var findingsScanScopes = []string{"secrets", "permissions", "matrices", "pinning", "outdated", "runners", "dependencies", "reusable", "deprecated-runtimes", "actions-permissions", "pwn-requests", "script-injection", "triggers"}
 
-	for {
-		err := client.Query(context.Background(), &q, vars)
-		if err != nil {
-			return fmt.Errorf("GraphQL query failed: %v", err)
+// isFindingsScanScope reports whether scope is one of findingsScanScopes
+func isFindingsScanScope(scope string) bool {
+	for _, findings := range findingsScanScopes {
+		if scope == findings {
+			return true
 		}
+	}
+	return false
+}
 
-		for _, repo := range q.Organization.Repositories.Nodes {
-			totalRepos++
-
-			if len(repo.Workflows.Tree.Entries) == 0 {
-				continue
-			}
-
-			var workflowFiles []string
-			for _, entry := range repo.Workflows.Tree.Entries {
-				if entry.Type == "blob" && (strings.HasSuffix(entry.Name, ".yml") || strings.HasSuffix(entry.Name, ".yaml")) {
-					workflowFiles = append(workflowFiles, entry.Path)
-				}
-			}
-
-			if len(workflowFiles) > 0 {
-				reposWithWorkflows++
-				repositories = append(repositories, RepositoryWorkflows{
-					Name:      repo.Name,
-					Workflows: workflowFiles,
-				})
+// findingsScanOptions lists the scan options that produce findings for usage errors: --detailed and the
+// findingsScanScopes
+func findingsScanOptions() string {
+	options := []string{"--detailed"}
+	for _, scope := range findingsScanScopes {
+		options = append(options, "--scan "+scope)
+	}
+	return strings.Join(options, ", ")
+}
 
-				// Repository data will be output later by outputScanResult
-			}
-		}
+// scanOrganizationWorkflows scans an organization for repositories with workflow files
+func scanOrganizationWorkflows(org string, startTime time.Time, outputFormat string, outputFile string, opts scanOptions) error {
+	logInfof("🔍 Scanning organization: %s\n\n", org)
 
-		if !q.Organization.Repositories.PageInfo.HasNextPage {
-			break
-		}
-		vars["cursor"] = githubv4.NewString(q.Organization.Repositories.PageInfo.EndCursor)
+	repositories, counts, err := listRepositoryWorkflows(org, opts)
+	if err != nil {
+		return err
 	}
 
 	duration := time.Since(startTime)
@@ -297,11 +1158,13 @@ func scanOrganizationWorkflows(org string, startTime time.Time, outputFormat str
 	// Output in requested format
 	result := ScanResult{
 		Organization:              org,
-		TotalRepositories:         totalRepos,
-		RepositoriesWithWorkflows: reposWithWorkflows,
+		TotalRepositories:         counts.Total,
+		RepositoryCounts:          counts,
+		RepositoriesWithWorkflows: len(repositories),
 		Repositories:              repositories,
 		ProcessTimeSeconds:        duration.Seconds(),
 	}
+	reportComplete(org, &result)
 
 	// Get the appropriate writer (file or stdout)
 	writer, file, err := getOutputWriter(outputFile)
@@ -316,193 +1179,264 @@ func scanOrganizationWorkflows(org string, startTime time.Time, outputFormat str
 }
 
 // extractActionsFromWorkflows scans workflows and extracts all actions used
-func extractActionsFromWorkflows(org string, startTime time.Time, outputFormat, outputFile string) error {
-	workflows, err := getWorkflowFiles(org)
+func extractActionsFromWorkflows(org string, startTime time.Time, outputFormat, outputFile string, opts scanOptions) error {
+	workflows, err := getWorkflowFiles(org, opts)
 	if err != nil {
 		return err
 	}
 
 	actionMap := make(map[string]map[string]int) // action -> version -> count
 	totalWorkflows := 0
+	var skipped []WorkflowFile
 
-	fmt.Printf("📊 Analyzing %d workflow files...\n\n", len(workflows))
+	logInfof("📊 Analyzing %d workflow files...\n\n", len(workflows))
 
-	for _, wf := range workflows {
-		totalWorkflows++
-		actions, err := extractActionsFromFile(org, wf.Repo, wf.Path)
-		if err != nil {
-			fmt.Printf("⚠️  Warning: Could not analyze %s/%s: %v\n", wf.Repo, wf.Path, err)
+	results := fetchWorkflows(org, workflows, opts, func(wf WorkflowFile, result workflowFetch) {
+		if result.Err != nil {
+			logWarnf("⚠️  Warning: Could not analyze %s/%s: %v\n", wf.Repo, wf.Path, result.Err)
+		}
+	})
+
+	for i, result := range results {
+		if result.Skipped {
+			skipped = append(skipped, workflows[i])
 			continue
 		}
 
-		for _, action := range actions {
+		totalWorkflows++
+		for _, action := range result.Actions {
 			if actionMap[action.Name] == nil {
 				actionMap[action.Name] = make(map[string]int)
 			}
 			actionMap[action.Name][action.Version]++
 		}
 	}
+	remaining := remainingRepositories(skipped)
 
 	// Generate report
-	generateActionReport(actionMap, totalWorkflows, startTime, outputFormat, outputFile)
-	return nil
+	return generateActionReport(org, actionMap, totalWorkflows, startTime, outputFormat, outputFile, remaining)
 }
 
 // comprehensiveAnalysis performs comprehensive analysis of repositories, workflows, and actions
-func comprehensiveAnalysis(org string, startTime time.Time, outputFormat string, outputFile string) error {
-	// Get GitHub token from environment
-	token := os.Getenv("GITHUB_TOKEN")
-	if token == "" {
-		token = os.Getenv("GH_TOKEN")
-	}
-
-	if token == "" {
-		return fmt.Errorf("GitHub token not found. Please set GITHUB_TOKEN or GH_TOKEN environment variable, or authenticate with 'gh auth login'")
-	}
-
-	// Create OAuth2 client
-	src := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
-	httpClient := oauth2.NewClient(context.Background(), src)
-	client := githubv4.NewClient(httpClient)
-
-	// Define GraphQL query structure
-	var q struct {
-		Organization struct {
-			Repositories struct {
-				Nodes []struct {
-					Name      string
-					Workflows struct {
-						Tree struct {
-							Entries []struct {
-								Name string
-								Path string
-								Type string
-							}
-						} `graphql:"... on Tree"`
-					} `graphql:"workflows: object(expression: \"HEAD:.github/workflows\")"`
-				}
-				PageInfo struct {
-					HasNextPage bool
-					EndCursor   githubv4.String
-				}
-			} `graphql:"repositories(first: 50, after: $cursor)"`
-		} `graphql:"organization(login: $org)"`
+func comprehensiveAnalysis(org string, startTime time.Time, outputFormat string, outputFile string, opts scanOptions) error {
+	report, err := buildComprehensiveReport(org, startTime, outputFormat, opts)
+	if err != nil {
+		return err
 	}
 
-	vars := map[string]interface{}{
-		"org":    githubv4.String(org),
-		"cursor": (*githubv4.String)(nil),
+	return opts.writeFindingsReport(org, &report, report.Findings, outputFile, func(writer io.Writer) error {
+		var err error
+		if pages := paginateReport(report, outputFormat, outputFile, opts.MaxReportSize); len(pages) > 0 {
+			err = outputReportPages(report, outputFormat, outputFile, pages, writer)
+		} else {
+			err = outputComprehensiveReport(report, outputFormat, writer)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := opts.publishBadges(report); err != nil {
+			return err
+		}
+		if err := opts.writeSite(report); err != nil {
+			return err
+		}
+		return opts.saveSnapshot(report)
+	})
+}
+
+// buildComprehensiveReport scans an organization and builds its comprehensive report
+func buildComprehensiveReport(org string, startTime time.Time, outputFormat string, opts scanOptions) (ComprehensiveReport, error) {
+	repoWorkflows, counts, err := listRepositoryWorkflows(org, opts)
+	if err != nil {
+		return ComprehensiveReport{}, err
 	}
 
-	var repositories []ComprehensiveRepository
-	totalRepos := 0
-	reposWithWorkflows := 0
-	totalWorkflows := 0
-	actionUsageMap := make(map[string]map[string]int) // action -> version -> count
-	actionRepoMap := make(map[string]map[string]bool) // action -> repo -> true
-	actionWorkflowMap := make(map[string]int)         // action -> workflow count
+	var files []WorkflowFile
+	for _, repo := range repoWorkflows {
+		for _, workflowPath := range repo.Workflows {
+			files = append(files, WorkflowFile{Repo: repo.Name, Path: workflowPath})
+		}
+	}
 
-	// Scan repositories
-	for {
-		err := client.Query(context.Background(), &q, vars)
-		if err != nil {
-			return fmt.Errorf("GraphQL query failed: %v", err)
+	// Fetch workflows in parallel; repositories not started before the timeout are left out
+	results := fetchWorkflows(org, files, opts, func(wf WorkflowFile, result workflowFetch) {
+		if result.Err != nil {
+			logWarnf("⚠️  Warning: Could not analyze %s/%s: %v\n", wf.Repo, wf.Path, result.Err)
+			return
+		}
+		actions, total := countWorkflowActions(result.Actions)
+		if len(actions) == total {
+			logInfof("📁 %s → 📄 %s (%d actions)\n", wf.Repo, wf.Path, len(actions))
+		} else {
+			logInfof("📁 %s → 📄 %s (%d unique, %d total actions)\n", wf.Repo, wf.Path, len(actions), total)
 		}
+	})
 
-		for _, repo := range q.Organization.Repositories.Nodes {
-			totalRepos++
+	var repositories []ComprehensiveRepository
+	var remaining []string
+	next := 0
+	for _, repo := range repoWorkflows {
+		repoResults := results[next : next+len(repo.Workflows)]
+		next += len(repo.Workflows)
+		if len(repoResults) > 0 && repoResults[0].Skipped {
+			remaining = append(remaining, repo.Name)
+			continue
+		}
 
-			if len(repo.Workflows.Tree.Entries) == 0 {
+		// Analyze workflows in this repository
+		var workflows []ComprehensiveWorkflow
+		for i, workflowPath := range repo.Workflows {
+			result := repoResults[i]
+			if result.Err != nil {
 				continue
 			}
 
-			var workflowFiles []string
-			for _, entry := range repo.Workflows.Tree.Entries {
-				if entry.Type == "blob" && (strings.HasSuffix(entry.Name, ".yml") || strings.HasSuffix(entry.Name, ".yaml")) {
-					workflowFiles = append(workflowFiles, entry.Path)
-				}
-			}
+			comprehensiveActions, totalUniqueActions := countWorkflowActions(result.Actions)
 
-			if len(workflowFiles) == 0 {
-				continue
-			}
+			workflows = append(workflows, ComprehensiveWorkflow{
+				Path:             workflowPath,
+				ActionCount:      len(comprehensiveActions),
+				TotalActionCount: totalUniqueActions,
+				Actions:          comprehensiveActions,
+				Containers:       result.Containers,
+				LocalActions:     result.Local,
+			})
+		}
 
-			reposWithWorkflows++
-			totalWorkflows += len(workflowFiles)
+		repositories = append(repositories, ComprehensiveRepository{
+			Name:          repo.Name,
+			WorkflowCount: len(repo.Workflows),
+			Workflows:     workflows,
+			Properties:    repo.Properties,
+		})
+	}
 
-			// Analyze workflows in this repository
-			var workflows []ComprehensiveWorkflow
-			for _, workflowPath := range workflowFiles {
-				actions, err := extractActionsFromFile(org, repo.Name, workflowPath)
-				if err != nil {
-					if outputFormat == "default" {
-						fmt.Printf("⚠️  Warning: Could not analyze %s/%s: %v\n", repo.Name, workflowPath, err)
-					}
-					continue
-				}
+	// Classify how every action reference is pinned
+	classifyComprehensivePinning(repositories, opts)
 
-				// Deduplicate actions within this workflow and count occurrences
-				actionCounts := make(map[string]map[string]int) // action -> version -> count
-				for _, action := range actions {
-					if actionCounts[action.Name] == nil {
-						actionCounts[action.Name] = make(map[string]int)
-					}
-					actionCounts[action.Name][action.Version]++
-				}
+	// Read the runtime of every action from its action.yml
+	classifyComprehensiveRuntimes(repositories, org, opts)
+	classifyComprehensiveOwnership(repositories, org)
+	resolveLocalActions(repositories, org, opts)
 
-				// Convert to comprehensive actions with counts
-				var comprehensiveActions []ComprehensiveAction
-				totalUniqueActions := 0
-				for actionName, versions := range actionCounts {
-					for version, count := range versions {
-						comprehensiveActions = append(comprehensiveActions, ComprehensiveAction{
-							Name:    actionName,
-							Version: version,
-							Count:   count,
-						})
-						totalUniqueActions += count
-
-						// Track usage statistics
-						if actionUsageMap[actionName] == nil {
-							actionUsageMap[actionName] = make(map[string]int)
-							actionRepoMap[actionName] = make(map[string]bool)
-						}
-						actionUsageMap[actionName][version] += count
-						actionRepoMap[actionName][repo.Name] = true
-						actionWorkflowMap[actionName] += count
-					}
-				}
+	// Flag end-of-life action versions
+	eolDB, err := loadEOLDatabase()
+	if err != nil {
+		return ComprehensiveReport{}, err
+	}
+	findings := detectEOLFindings(repositories, eolDB)
+	eolUsages := len(findings)
 
-				workflows = append(workflows, ComprehensiveWorkflow{
-					Path:             workflowPath,
-					ActionCount:      len(comprehensiveActions),
-					TotalActionCount: totalUniqueActions,
-					Actions:          comprehensiveActions,
-				})
+	// Flag forks of well-known actions
+	forkFindings := detectForkedActionFindings(repositories, org, opts.Cache)
+	findings = append(findings, forkFindings...)
 
-				if outputFormat == "default" {
-					if len(comprehensiveActions) == totalUniqueActions {
-						fmt.Printf("📁 %s → 📄 %s (%d actions)\n", repo.Name, workflowPath, len(comprehensiveActions))
-					} else {
-						fmt.Printf("📁 %s → 📄 %s (%d unique, %d total actions)\n", repo.Name, workflowPath, len(comprehensiveActions), totalUniqueActions)
-					}
+	// Look up stars, activity, and owner of the third-party action repositories
+	actionRepositories := enrichActionRepositories(repositories, org, opts)
+	if opts.Scorecard {
+		scoreActionRepositories(actionRepositories, opts)
+	}
+
+	// Flag actions whose repository is archived, disabled, or gone
+	healthFindings := detectActionHealthFindings(repositories, opts)
+	findings = append(findings, healthFindings...)
+
+	// Flag action versions covered by a GitHub security advisory
+	vulnerabilities, vulnerabilityFindings := detectVulnerabilities(repositories, opts)
+	findings = append(findings, vulnerabilityFindings...)
+
+	// Flag usages that drift from the most used version of an action
+	driftFindings := detectMultipleVersionFindings(repositories)
+	findings = append(findings, driftFindings...)
+	opts.finishFindings(org, &findings)
+
+	duration := time.Since(startTime)
+
+	// Create comprehensive report
+	summary := summarizeComprehensive(repositories)
+	summary.TotalRepositories = counts.Total
+	summary.RepositoryCounts = counts
+	summary.EOLActionUsages = eolUsages
+	summary.ForkedActionUsages = len(forkFindings)
+	summary.ArchivedActionUsages, summary.UnavailableActionUsages = countHealthFindings(healthFindings)
+	summary.VulnerableActionUsages = len(vulnerabilityFindings)
+
+	report := ComprehensiveReport{
+		Organization:          org,
+		ScanTimestamp:         startTime.Format(time.RFC3339),
+		Ref:                   workflowRef,
+		Repositories:          repositories,
+		Summary:               summary,
+		Findings:              findings,
+		ActionRepositories:    actionRepositories,
+		Vulnerabilities:       vulnerabilities,
+		Truncated:             len(remaining) > 0,
+		RemainingRepositories: remaining,
+		ProcessTimeSeconds:    duration.Seconds(),
+		Report:                opts.Branding,
+		PropertyGroups:        opts.propertyBreakdown(repositories, findings),
+	}
+
+	return report, nil
+}
+
+// countWorkflowActions deduplicates the actions of one workflow and returns them with the total number of usages
+func countWorkflowActions(actions []Action) ([]ComprehensiveAction, int) {
+	type usage struct{ name, version, via string }
+	actionCounts := make(map[usage]int)
+	for _, action := range actions {
+		actionCounts[usage{action.Name, action.Version, action.Via}]++
+	}
+
+	// Convert to comprehensive actions with counts
+	comprehensiveActions := []ComprehensiveAction{}
+	total := 0
+	for key, count := range actionCounts {
+		comprehensiveActions = append(comprehensiveActions, ComprehensiveAction{
+			Name:    key.name,
+			Version: key.version,
+			Count:   count,
+			Via:     key.via,
+		})
+		total += count
+	}
+
+	sortComprehensiveActions(comprehensiveActions)
+	return comprehensiveActions, total
+}
+
+// summarizeComprehensive computes the workflow and action statistics of scanned repositories
+func summarizeComprehensive(repositories []ComprehensiveRepository) ComprehensiveSummary {
+	actionUsageMap := make(map[string]map[string]int) // action -> version -> count
+	actionRepoMap := make(map[string]map[string]bool) // action -> repo -> true
+	actionWorkflowMap := make(map[string]int)         // action -> workflow count
+
+	totalWorkflows := 0
+	for _, repo := range repositories {
+		totalWorkflows += repo.WorkflowCount
+		for _, workflow := range repo.Workflows {
+			for _, action := range workflow.Actions {
+				if actionUsageMap[action.Name] == nil {
+					actionUsageMap[action.Name] = make(map[string]int)
+					actionRepoMap[action.Name] = make(map[string]bool)
 				}
+				actionUsageMap[action.Name][action.Version] += action.Count
+				actionRepoMap[action.Name][repo.Name] = true
+				actionWorkflowMap[action.Name] += action.Count
 			}
-
-			repositories = append(repositories, ComprehensiveRepository{
-				Name:          repo.Name,
-				WorkflowCount: len(workflowFiles),
-				Workflows:     workflows,
-			})
 		}
+	}
 
-		if !q.Organization.Repositories.PageInfo.HasNextPage {
-			break
+	var pinning PinningCounts
+	for _, repo := range repositories {
+		for _, workflow := range repo.Workflows {
+			for _, action := range workflow.Actions {
+				pinning.add(action.Pinning, action.Count)
+			}
 		}
-		vars["cursor"] = githubv4.NewString(q.Organization.Repositories.PageInfo.EndCursor)
 	}
 
-	// Generate comprehensive summary
 	uniqueActions := len(actionUsageMap)
 	totalActionUsages := 0
 	actionsWithMultipleVersions := 0
@@ -519,8 +1453,9 @@ func comprehensiveAnalysis(org string, startTime time.Time, outputFormat string,
 			actionsWithMultipleVersions++
 		}
 
-		// Track most used action
-		if actionTotal > mostUsedAction.TotalUsages {
+		// Track most used action, breaking ties by name so the result does not depend on map order
+		if actionTotal > mostUsedAction.TotalUsages ||
+			(actionTotal == mostUsedAction.TotalUsages && actionName < mostUsedAction.Name) {
 			mostUsedAction = ComprehensiveMostUsedAction{
 				Name:              actionName,
 				TotalUsages:       actionTotal,
@@ -530,40 +1465,27 @@ func comprehensiveAnalysis(org string, startTime time.Time, outputFormat string,
 		}
 	}
 
-	duration := time.Since(startTime)
-
-	// Create comprehensive report
-	report := ComprehensiveReport{
-		Organization:  org,
-		ScanTimestamp: startTime.Format(time.RFC3339),
-		Repositories:  repositories,
-		Summary: ComprehensiveSummary{
-			TotalRepositories:           totalRepos,
-			RepositoriesWithWorkflows:   reposWithWorkflows,
-			TotalWorkflows:              totalWorkflows,
-			TotalActionUsages:           totalActionUsages,
-			UniqueActions:               uniqueActions,
-			ActionsWithMultipleVersions: actionsWithMultipleVersions,
-			MostUsedAction:              mostUsedAction,
-		},
-		ProcessTimeSeconds: duration.Seconds(),
-	}
-
-	// Get the appropriate writer (file or stdout)
-	writer, file, err := getOutputWriter(outputFile)
-	if err != nil {
-		return fmt.Errorf("error opening output file: %v", err)
+	return ComprehensiveSummary{
+		RepositoriesWithWorkflows:   len(repositories),
+		TotalWorkflows:              totalWorkflows,
+		TotalActionUsages:           totalActionUsages,
+		UniqueActions:               uniqueActions,
+		ActionsWithMultipleVersions: actionsWithMultipleVersions,
+		MostUsedAction:              mostUsedAction,
+		Pinning:                     pinning,
+		Runtimes:                    summarizeRuntimes(repositories),
+		MajorVersions:               summarizeMajorVersions(repositories),
+		ContainerImages:             summarizeContainerImages(repositories),
+		LocalActions:                summarizeLocalActions(repositories),
+		Ownership:                   summarizeOwnership(repositories),
 	}
-	if file != nil {
-		defer file.Close()
-	}
-
-	return outputComprehensiveReport(report, outputFormat, writer)
 }
 
 // getOutputWriter returns the appropriate writer based on the output file flag
 func getOutputWriter(outputFile string) (io.Writer, *os.File, error) {
 	if outputFile == "" {
+		// The report may share the terminal with the progress bar
+		scanProgress.stop()
 		return os.Stdout, nil, nil
 	}
 
@@ -576,19 +1498,15 @@ func getOutputWriter(outputFile string) (io.Writer, *os.File, error) {
 }
 
 // scanAndExtractActions combines scanning and action extraction
-func scanAndExtractActions(org string, startTime time.Time, outputFormat, outputFile string) error {
-	if outputFormat == "default" {
-		fmt.Println("Phase 1: Scanning for workflow files...")
-	}
-	err := scanOrganizationWorkflows(org, startTime, outputFormat, "")
+func scanAndExtractActions(org string, startTime time.Time, outputFormat, outputFile string, opts scanOptions) error {
+	logInfof("Phase 1: Scanning for workflow files...\n")
+	err := scanOrganizationWorkflows(org, startTime, outputFormat, "", opts)
 	if err != nil {
 		return fmt.Errorf("scanning failed: %v", err)
 	}
 
-	if outputFormat == "default" {
-		fmt.Println("\nPhase 2: Extracting actions from workflows...")
-	}
-	err = extractActionsFromWorkflows(org, startTime, outputFormat, outputFile)
+	logInfof("\nPhase 2: Extracting actions from workflows...\n")
+	err = extractActionsFromWorkflows(org, startTime, outputFormat, outputFile, opts)
 	if err != nil {
 		return fmt.Errorf("action extraction failed: %v", err)
 	}
@@ -606,12 +1524,16 @@ type WorkflowFile struct {
 type Action struct {
 	Name    string `json:"name"`
 	Version string `json:"version"`
+	Job     string `json:"job,omitempty"`
+	Step    int    `json:"step,omitempty"` // 1-based step index; 0 for a reusable workflow call
+	Via     string `json:"via,omitempty"`  // composite actions the usage was reached through (--transitive)
 }
 
 // ScanResult represents the output of a workflow scan
 type ScanResult struct {
 	Organization              string                `json:"organization"`
 	TotalRepositories         int                   `json:"total_repositories"`
+	RepositoryCounts          RepositoryCounts      `json:"repository_counts"`
 	RepositoriesWithWorkflows int                   `json:"repositories_with_workflows"`
 	Repositories              []RepositoryWorkflows `json:"repositories"`
 	ProcessTimeSeconds        float64               `json:"process_time_seconds"`
@@ -619,40 +1541,63 @@ type ScanResult struct {
 
 // RepositoryWorkflows represents a repository and its workflow files
 type RepositoryWorkflows struct {
-	Name      string   `json:"name"`
-	Workflows []string `json:"workflows"`
+	Name       string            `json:"name"`
+	Workflows  []string          `json:"workflows"`
+	IsFork     bool              `json:"is_fork,omitempty"`
+	IsArchived bool              `json:"is_archived,omitempty"`
+	IsTemplate bool              `json:"is_template,omitempty"`
+	IsMirror   bool              `json:"is_mirror,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"` // custom property values, read with --property or --group-by-property
+	Visibility string            `json:"visibility,omitempty"` // public, private, or internal
+	Topics     []string          `json:"topics,omitempty"`
 }
 
 // ActionReport represents the output of action extraction
 type ActionReport struct {
-	Organization       string          `json:"organization"`
-	TotalWorkflows     int             `json:"total_workflows"`
-	UniqueActions      int             `json:"unique_actions"`
-	TotalUsages        int             `json:"total_usages"`
-	Actions            []ActionSummary `json:"actions"`
-	ProcessTimeSeconds float64         `json:"process_time_seconds"`
+	Organization          string           `json:"organization"`
+	TotalWorkflows        int              `json:"total_workflows"`
+	UniqueActions         int              `json:"unique_actions"`
+	TotalUsages           int              `json:"total_usages"`
+	Actions               []ActionSummary  `json:"actions"`
+	Ownership             OwnershipSummary `json:"ownership"` // actions by publisher: GitHub, the organization, third parties
+	Truncated             bool             `json:"truncated"`
+	RemainingRepositories []string         `json:"remaining_repositories,omitempty"`
+	ProcessTimeSeconds    float64          `json:"process_time_seconds"`
 }
 
 // ActionSummary represents an action and its usage statistics
 type ActionSummary struct {
-	Name     string         `json:"name"`
-	Total    int            `json:"total_usages"`
-	Versions []VersionUsage `json:"versions"`
+	Name          string              `json:"name"`
+	Total         int                 `json:"total_usages"`
+	Versions      []VersionUsage      `json:"versions"`
+	MajorVersions []MajorVersionCount `json:"major_versions"` // usages rolled up by major version
+	Ownership     string              `json:"ownership"`      // github, internal, or third-party
 }
 
 // VersionUsage represents version usage statistics
 type VersionUsage struct {
 	Version string `json:"version"`
 	Count   int    `json:"count"`
+	EOL     bool   `json:"eol,omitempty"`
 }
 
 // ComprehensiveReport represents the comprehensive analysis output
 type ComprehensiveReport struct {
-	Organization       string                    `json:"organization"`
-	ScanTimestamp      string                    `json:"scan_timestamp"`
-	Repositories       []ComprehensiveRepository `json:"repositories"`
-	Summary            ComprehensiveSummary      `json:"summary"`
-	ProcessTimeSeconds float64                   `json:"process_time_seconds"`
+	Organization          string                    `json:"organization"`
+	Enterprise            string                    `json:"enterprise,omitempty"`
+	Organizations         []OrganizationBreakdown   `json:"organizations,omitempty"`   // per-organization breakdown of an enterprise scan
+	PropertyGroups        []PropertyBreakdown       `json:"property_groups,omitempty"` // breakdown by the value of --group-by-property
+	ScanTimestamp         string                    `json:"scan_timestamp"`
+	Ref                   string                    `json:"ref,omitempty"` // branch, tag, or commit SHA scanned with --ref; empty for default branches
+	Repositories          []ComprehensiveRepository `json:"repositories"`
+	Summary               ComprehensiveSummary      `json:"summary"`
+	Findings              []Finding                 `json:"findings"`
+	ActionRepositories    []ActionRepository        `json:"action_repositories,omitempty"` // third-party action repositories, riskiest first
+	Vulnerabilities       []ActionVulnerability     `json:"vulnerabilities,omitempty"`     // advisories affecting used action versions, most severe first
+	Truncated             bool                      `json:"truncated"`
+	RemainingRepositories []string                  `json:"remaining_repositories,omitempty"`
+	ProcessTimeSeconds    float64                   `json:"process_time_seconds"`
+	Report                *ReportBranding           `json:"report,omitempty"` // custom title and audit metadata
 }
 
 // ComprehensiveRepository represents a repository with its workflows and actions
@@ -660,6 +1605,7 @@ type ComprehensiveRepository struct {
 	Name          string                  `json:"name"`
 	WorkflowCount int                     `json:"workflow_count"`
 	Workflows     []ComprehensiveWorkflow `json:"workflows"`
+	Properties    map[string]string       `json:"properties,omitempty"` // custom property values
 }
 
 // ComprehensiveWorkflow represents a workflow file with its actions
@@ -668,24 +1614,44 @@ type ComprehensiveWorkflow struct {
 	ActionCount      int                   `json:"action_count"`       // Number of unique actions
 	TotalActionCount int                   `json:"total_action_count"` // Total action occurrences
 	Actions          []ComprehensiveAction `json:"actions"`
+	Containers       []ContainerImage      `json:"containers,omitempty"` // job and service container images
+	LocalActions     []LocalAction         `json:"local_actions,omitempty"`
 }
 
 // ComprehensiveAction represents an action usage with metadata
 type ComprehensiveAction struct {
-	Name    string `json:"name"`
-	Version string `json:"version"`
-	Count   int    `json:"count"`
+	Name            string `json:"name"`
+	Version         string `json:"version"`
+	Count           int    `json:"count"`
+	Pinning         string `json:"pinning,omitempty"`          // sha, tag or branch
+	ResolvedSHA     string `json:"resolved_sha,omitempty"`     // commit a tag or branch pointed to during the scan
+	ResolvedVersion string `json:"resolved_version,omitempty"` // tag a pinned SHA corresponds to, e.g. v4.1.1
+	Via             string `json:"via,omitempty"`              // composite actions the usage was reached through (--transitive)
+	Runtime         string `json:"runtime,omitempty"`          // runs.using of the action.yml: node20, docker, composite, ...
+	Ownership       string `json:"ownership,omitempty"`        // github, internal, or third-party
 }
 
 // ComprehensiveSummary represents summary statistics for comprehensive analysis
 type ComprehensiveSummary struct {
 	TotalRepositories           int                         `json:"total_repositories"`
+	RepositoryCounts            RepositoryCounts            `json:"repository_counts"`
 	RepositoriesWithWorkflows   int                         `json:"repositories_with_workflows"`
 	TotalWorkflows              int                         `json:"total_workflows"`
 	TotalActionUsages           int                         `json:"total_action_usages"`
 	UniqueActions               int                         `json:"unique_actions"`
 	ActionsWithMultipleVersions int                         `json:"actions_with_multiple_versions"`
 	MostUsedAction              ComprehensiveMostUsedAction `json:"most_used_action"`
+	EOLActionUsages             int                         `json:"eol_action_usages"`
+	ForkedActionUsages          int                         `json:"forked_action_usages"`
+	ArchivedActionUsages        int                         `json:"archived_action_usages"`    // workflow usages of actions whose repository is archived
+	UnavailableActionUsages     int                         `json:"unavailable_action_usages"` // workflow usages of actions whose repository is gone or disabled
+	VulnerableActionUsages      int                         `json:"vulnerable_action_usages"`  // workflow usages of action versions with a known vulnerability
+	Pinning                     PinningCounts               `json:"pinning"`
+	Runtimes                    []RuntimeUsages             `json:"runtimes"`       // usages by action runtime, most used first
+	MajorVersions               []ActionMajorVersions       `json:"major_versions"` // usages of every action by major version
+	ContainerImages             ContainerImageSummary       `json:"container_images"`
+	LocalActions                LocalActionSummary          `json:"local_actions"`
+	Ownership                   OwnershipSummary            `json:"ownership"` // actions by publisher: GitHub, the organization, third parties
 }
 
 // ComprehensiveMostUsedAction represents the most frequently used action
@@ -697,71 +1663,20 @@ type ComprehensiveMostUsedAction struct {
 }
 
 // getWorkflowFiles retrieves all workflow files from an organization
-func getWorkflowFiles(org string) ([]WorkflowFile, error) {
-	token := os.Getenv("GITHUB_TOKEN")
-	if token == "" {
-		token = os.Getenv("GH_TOKEN")
-	}
-
-	if token == "" {
-		return nil, fmt.Errorf("GitHub token not found")
-	}
-
-	src := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
-	httpClient := oauth2.NewClient(context.Background(), src)
-	client := githubv4.NewClient(httpClient)
-
-	var q struct {
-		Organization struct {
-			Repositories struct {
-				Nodes []struct {
-					Name      string
-					Workflows struct {
-						Tree struct {
-							Entries []struct {
-								Name string
-								Path string
-								Type string
-							}
-						} `graphql:"... on Tree"`
-					} `graphql:"workflows: object(expression: \"HEAD:.github/workflows\")"`
-				}
-				PageInfo struct {
-					HasNextPage bool
-					EndCursor   githubv4.String
-				}
-			} `graphql:"repositories(first: 50, after: $cursor)"`
-		} `graphql:"organization(login: $org)"`
-	}
-
-	vars := map[string]interface{}{
-		"org":    githubv4.String(org),
-		"cursor": (*githubv4.String)(nil),
+func getWorkflowFiles(org string, opts scanOptions) ([]WorkflowFile, error) {
+	repositories, _, err := listRepositoryWorkflows(org, opts)
+	if err != nil {
+		return nil, err
 	}
 
 	var workflows []WorkflowFile
-
-	for {
-		err := client.Query(context.Background(), &q, vars)
-		if err != nil {
-			return nil, fmt.Errorf("GraphQL query failed: %v", err)
-		}
-
-		for _, repo := range q.Organization.Repositories.Nodes {
-			for _, entry := range repo.Workflows.Tree.Entries {
-				if entry.Type == "blob" && (strings.HasSuffix(entry.Name, ".yml") || strings.HasSuffix(entry.Name, ".yaml")) {
-					workflows = append(workflows, WorkflowFile{
-						Repo: repo.Name,
-						Path: entry.Path,
-					})
-				}
-			}
-		}
-
-		if !q.Organization.Repositories.PageInfo.HasNextPage {
-			break
+	for _, repo := range repositories {
+		for _, path := range repo.Workflows {
+			workflows = append(workflows, WorkflowFile{
+				Repo: repo.Name,
+				Path: path,
+			})
 		}
-		vars["cursor"] = githubv4.NewString(q.Organization.Repositories.PageInfo.EndCursor)
 	}
 
 	return workflows, nil
@@ -769,30 +1684,78 @@ func getWorkflowFiles(org string) ([]WorkflowFile, error) {
 
 // extractActionsFromFile fetches and parses a workflow file to extract actions
 func extractActionsFromFile(org, repo, path string) ([]Action, error) {
-	token := os.Getenv("GITHUB_TOKEN")
-	if token == "" {
-		token = os.Getenv("GH_TOKEN")
+	yamlContent, err := fetchWorkflowContent(org, repo, path)
+	if err != nil {
+		return nil, err
 	}
 
+	// Parse YAML and extract actions
+	return parseActionsFromYAML(yamlContent)
+}
+
+// errFileNotFound is returned when a requested repository file or API resource does not exist
+var errFileNotFound = errors.New("file not found")
+
+// fetchWorkflowContent fetches the raw YAML content of a workflow file. Files of .github/workflows
+// come with the repository listing; others reuse the cached content when the repository was not
+// pushed to since it was fetched.
+func fetchWorkflowContent(org, repo, path string) (string, error) {
+	if content, ok := listedWorkflows.lookup(org, repo, path); ok {
+		return content, nil
+	}
+	if content, ok := workflowCache.lookup(org, repo, path); ok {
+		return content, nil
+	}
+	// Workflows of other branches (--branches) are reported as path@branch
+	file, ref := path, workflowRef
+	if branchFile, branch := splitBranchWorkflow(path); branch != "" {
+		file, ref = branchFile, branch
+	}
+	content, err := fetchRepositoryFileAtRef(org, repo, file, ref)
+	if err == nil {
+		workflowCache.store(org, repo, path, content)
+	}
+	return content, err
+}
+
+// fetchRepositoryFile fetches the decoded content of a file from a repository's default branch
+func fetchRepositoryFile(org, repo, path string) (string, error) {
+	return fetchRepositoryFileAtRef(org, repo, path, "")
+}
+
+// fetchRepositoryFileAtRef fetches the decoded content of a file at a branch, tag, or commit SHA;
+// an empty ref reads the default branch
+func fetchRepositoryFileAtRef(org, repo, path, ref string) (string, error) {
 	// Use GitHub REST API to get file content
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/contents/%s", org, repo, path)
+	url := restURL(fmt.Sprintf("repos/%s/%s/contents/%s", org, repo, path))
+	if ref != "" {
+		url += "?ref=" + neturl.QueryEscape(ref)
+	}
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	req.Header.Set("Authorization", "token "+token)
+	req.Header.Set("Authorization", "token "+githubToken())
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	etagCache.prepare(req)
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := apiClient.Do(req)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotModified {
+		if content, ok := etagCache.notModified(req.URL.String()); ok {
+			return content, nil
+		}
+	}
+	if resp.StatusCode == 404 {
+		return "", errFileNotFound
+	}
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("API request failed with status %d", resp.StatusCode)
+		return "", fmt.Errorf("API request failed with status %d", resp.StatusCode)
 	}
 
 	var fileData struct {
@@ -802,7 +1765,7 @@ func extractActionsFromFile(org, repo, path string) ([]Action, error) {
 
 	err = json.NewDecoder(resp.Body).Decode(&fileData)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	// Decode base64 content
@@ -810,21 +1773,22 @@ func extractActionsFromFile(org, repo, path string) ([]Action, error) {
 	if fileData.Encoding == "base64" {
 		decoded, err := base64.StdEncoding.DecodeString(fileData.Content)
 		if err != nil {
-			return nil, fmt.Errorf("failed to decode base64 content: %v", err)
+			return "", fmt.Errorf("failed to decode base64 content: %v", err)
 		}
 		yamlContent = string(decoded)
 	} else {
 		yamlContent = fileData.Content
 	}
 
-	// Parse YAML and extract actions
-	return parseActionsFromYAML(yamlContent)
+	etagCache.store(req.URL.String(), resp, yamlContent)
+	return yamlContent, nil
 }
 
-// parseActionsFromYAML parses YAML content and extracts GitHub Actions
+// parseActionsFromYAML parses YAML content and extracts GitHub Actions with their job and step.
+// Anchors, aliases, and merge keys are resolved, so a step reused through an alias is attributed
+// to every job it appears in; all documents of a multi-document file are scanned.
 func parseActionsFromYAML(yamlContent string) ([]Action, error) {
-	var workflow map[string]interface{}
-	err := yaml.Unmarshal([]byte(yamlContent), &workflow)
+	documents, err := decodeYAMLDocuments(yamlContent)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse YAML: %v", err)
 	}
@@ -832,39 +1796,62 @@ func parseActionsFromYAML(yamlContent string) ([]Action, error) {
 	var actions []Action
 	usesPattern := regexp.MustCompile(`^([^@]+)@(.+)$`)
 
-	// Recursively search for "uses" fields
-	var extractUses func(interface{})
-	extractUses = func(obj interface{}) {
-		switch v := obj.(type) {
-		case map[string]interface{}:
-			for key, value := range v {
-				if key == "uses" {
-					if usesStr, ok := value.(string); ok {
-						matches := usesPattern.FindStringSubmatch(usesStr)
-						if len(matches) == 3 {
-							actions = append(actions, Action{
-								Name:    matches[1],
-								Version: matches[2],
-							})
-						}
-					}
-				} else {
-					extractUses(value)
+	addAction := func(uses interface{}, job string, step int) {
+		usesStr, ok := uses.(string)
+		if !ok {
+			return
+		}
+		if action, ok := dockerAction(usesStr); ok {
+			action.Job, action.Step = job, step
+			actions = append(actions, action)
+			return
+		}
+		matches := usesPattern.FindStringSubmatch(strings.TrimSpace(usesStr))
+		if len(matches) == 3 {
+			actions = append(actions, Action{
+				Name:    matches[1],
+				Version: matches[2],
+				Job:     job,
+				Step:    step,
+			})
+		}
+	}
+
+	for _, document := range documents {
+		// Composite actions (action.yml found through --workflow-paths) list their steps under runs
+		if runs, ok := yamlMap(document["runs"]); ok {
+			steps, _ := runs["steps"].([]interface{})
+			for i, item := range steps {
+				if step, ok := yamlMap(item); ok {
+					addAction(step["uses"], "", i+1)
 				}
 			}
-		case []interface{}:
-			for _, item := range v {
-				extractUses(item)
+		}
+
+		jobs, _ := yamlMap(document["jobs"])
+		for _, jobID := range sortedYAMLKeys(jobs) {
+			job, ok := yamlMap(jobs[jobID])
+			if !ok {
+				continue
+			}
+
+			// Reusable workflow call
+			addAction(job["uses"], jobID, 0)
+
+			steps, _ := job["steps"].([]interface{})
+			for i, item := range steps {
+				if step, ok := yamlMap(item); ok {
+					addAction(step["uses"], jobID, i+1)
+				}
 			}
 		}
 	}
 
-	extractUses(workflow)
 	return actions, nil
 }
 
 // generateActionReport creates a summary report of all actions found
-func generateActionReport(actionMap map[string]map[string]int, totalWorkflows int, startTime time.Time, outputFormat, outputFile string) {
+func generateActionReport(org string, actionMap map[string]map[string]int, totalWorkflows int, startTime time.Time, outputFormat, outputFile string, remaining []string) error {
 	// Sort actions by name
 	var actionNames []string
 	for name := range actionMap {
@@ -872,9 +1859,17 @@ func generateActionReport(actionMap map[string]map[string]int, totalWorkflows in
 	}
 	sort.Strings(actionNames)
 
+	// End-of-life versions are marked in the report; a broken dataset only disables the marker
+	eolDB, err := loadEOLDatabase()
+	if err != nil {
+		logWarnf("⚠️  Warning: %v\n", err)
+		eolDB = &EOLDatabase{}
+	}
+
 	// Calculate totals and build action summaries
 	totalActions := 0
 	var actions []ActionSummary
+	var ownership OwnershipSummary
 
 	for _, name := range actionNames {
 		versions := actionMap[name]
@@ -891,17 +1886,23 @@ func generateActionReport(actionMap map[string]map[string]int, totalWorkflows in
 		for _, version := range versionList {
 			count := versions[version]
 			actionTotal += count
+			_, eol := eolDB.lookup(name, version)
 			versionUsages = append(versionUsages, VersionUsage{
 				Version: version,
 				Count:   count,
+				EOL:     eol,
 			})
 		}
 
 		totalActions += actionTotal
+		class := actionClass(name, org)
+		ownership.add(class, actionTotal, true)
 		actions = append(actions, ActionSummary{
-			Name:     name,
-			Total:    actionTotal,
-			Versions: versionUsages,
+			Name:          name,
+			Total:         actionTotal,
+			Versions:      versionUsages,
+			MajorVersions: rollupMajorVersions(name, versions),
+			Ownership:     class,
 		})
 	}
 
@@ -909,30 +1910,37 @@ func generateActionReport(actionMap map[string]map[string]int, totalWorkflows in
 
 	// Create report data
 	report := ActionReport{
-		TotalWorkflows:     totalWorkflows,
-		UniqueActions:      len(actionNames),
-		TotalUsages:        totalActions,
-		Actions:            actions,
-		ProcessTimeSeconds: duration.Seconds(),
-	}
-
-	outputActionReport(report, outputFormat, outputFile)
+		Organization:          org,
+		TotalWorkflows:        totalWorkflows,
+		UniqueActions:         len(actionNames),
+		TotalUsages:           totalActions,
+		Actions:               actions,
+		Ownership:             ownership,
+		Truncated:             len(remaining) > 0,
+		RemainingRepositories: remaining,
+		ProcessTimeSeconds:    duration.Seconds(),
+	}
+	reportComplete(org, &report)
+
+	return outputActionReport(report, outputFormat, outputFile)
 }
 
 // outputScanResult outputs scan results in the specified format
 func outputScanResult(result ScanResult, format string, writer io.Writer) error {
-	switch format {
-	case "json":
-		encoder := json.NewEncoder(writer)
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(result)
+	if formatter, ok := lookupFormatter(format); ok {
+		return formatter.Format(result, writer)
+	}
 
+	switch format {
 	case "table":
 		return outputScanTable(result, writer)
 
 	case "csv":
 		return outputScanCSV(result, writer)
 
+	case "markdown":
+		return outputScanMarkdown(result, writer)
+
 	default: // "default"
 		// Output repository listing with workflows
 		for _, repo := range result.Repositories {
@@ -946,6 +1954,7 @@ func outputScanResult(result ScanResult, format string, writer io.Writer) error
 		fmt.Fprintf(writer, "✅ Scan complete!\n")
 		fmt.Fprintf(writer, "📊 Summary: Found %d repositories with workflows out of %d total repositories.\n",
 			result.RepositoriesWithWorkflows, result.TotalRepositories)
+		fmt.Fprintf(writer, "   Repository breakdown: %s\n", result.RepositoryCounts)
 		fmt.Fprintf(writer, "⏱️  Process time: %.3fs\n", result.ProcessTimeSeconds)
 
 		return nil
@@ -960,6 +1969,7 @@ func outputScanTable(result ScanResult, writer io.Writer) error {
 	fmt.Fprintln(writer, "╚════════════════════════════════════════════════════════════════════════════════════════════════════╝")
 	fmt.Fprintf(writer, "  🏢 Organization: %-59s \n", result.Organization)
 	fmt.Fprintf(writer, "  📁 Total Repositories: %-53d \n", result.TotalRepositories)
+	fmt.Fprintf(writer, "  🗂️  Breakdown: %-62s \n", result.RepositoryCounts)
 	fmt.Fprintf(writer, "  ⚙️  Repositories with Workflows: %-44d \n", result.RepositoriesWithWorkflows)
 	summaryStr := fmt.Sprintf("%d/%d repositories have GitHub Actions workflows (%.1f%%)",
 		result.RepositoriesWithWorkflows, result.TotalRepositories,
@@ -1006,26 +2016,23 @@ func outputScanTable(result ScanResult, writer io.Writer) error {
 
 // outputScanCSV outputs scan results in CSV format
 func outputScanCSV(result ScanResult, writer io.Writer) error {
-	// CSV Header
-	fmt.Fprintf(writer, "Repository,Workflow Count,Workflow Files\n")
-
-	// CSV Data rows
+	w := newCSVWriter(writer)
+	w.Write([]string{"Repository", "Workflow Count", "Workflow Files", "Fork", "Archived", "Template", "Mirror"})
 	for _, repo := range result.Repositories {
-		workflowList := strings.Join(repo.Workflows, "; ")
-		// Escape quotes in CSV by doubling them
-		workflowList = strings.ReplaceAll(workflowList, "\"", "\"\"")
-
-		fmt.Fprintf(writer, "\"%s\",%d,\"%s\"\n", repo.Name, len(repo.Workflows), workflowList)
+		w.Write([]string{repo.Name, strconv.Itoa(len(repo.Workflows)), strings.Join(repo.Workflows, "; "),
+			strconv.FormatBool(repo.IsFork), strconv.FormatBool(repo.IsArchived), strconv.FormatBool(repo.IsTemplate), strconv.FormatBool(repo.IsMirror)})
 	}
-
-	return nil
+	w.Flush()
+	return w.Error()
 }
 
 // outputActionReport outputs action report in the specified format
 func outputActionReport(report ActionReport, format, outputFile string) error {
 	// Determine output destination
 	var writer io.Writer = os.Stdout
-	if outputFile != "" {
+	if outputFile == "" {
+		scanProgress.stop()
+	} else {
 		file, err := os.Create(outputFile)
 		if err != nil {
 			return fmt.Errorf("failed to create output file: %v", err)
@@ -1034,26 +2041,35 @@ func outputActionReport(report ActionReport, format, outputFile string) error {
 		writer = file
 	}
 
-	switch format {
-	case "json":
-		encoder := json.NewEncoder(writer)
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(report)
+	if formatter, ok := lookupFormatter(format); ok {
+		return formatter.Format(report, writer)
+	}
 
+	switch format {
 	case "table":
 		return outputActionTable(report, writer)
 
 	case "csv":
 		return outputActionCSV(report, writer)
 
+	case "markdown":
+		return outputActionMarkdown(report, writer)
+
 	default: // "default"
 		fmt.Fprintln(writer, "📋 Action Reference Report")
 		fmt.Fprintln(writer, "="+strings.Repeat("=", 50))
 
 		for _, action := range report.Actions {
 			fmt.Fprintf(writer, "\n🔧 %s (used %d times)\n", action.Name, action.Total)
+			if len(action.MajorVersions) > 1 {
+				fmt.Fprintf(writer, "   🔢 %s\n", formatMajorVersions(action.MajorVersions))
+			}
 			for _, version := range action.Versions {
-				fmt.Fprintf(writer, "   └─ @%s (%d times)\n", version.Version, version.Count)
+				if version.EOL {
+					fmt.Fprintf(writer, "   └─ @%s (%d times) ⛔ EOL\n", version.Version, version.Count)
+				} else {
+					fmt.Fprintf(writer, "   └─ @%s (%d times)\n", version.Version, version.Count)
+				}
 			}
 		}
 
@@ -1061,8 +2077,13 @@ func outputActionReport(report ActionReport, format, outputFile string) error {
 		fmt.Fprintf(writer, "   • Total workflows analyzed: %d\n", report.TotalWorkflows)
 		fmt.Fprintf(writer, "   • Unique actions found: %d\n", report.UniqueActions)
 		fmt.Fprintf(writer, "   • Total action usages: %d\n", report.TotalUsages)
+		fmt.Fprintf(writer, "   • Ownership: %s\n", report.Ownership)
 		fmt.Fprintf(writer, "   ⏱️  Process time: %.3fs\n", report.ProcessTimeSeconds)
 
+		if report.Truncated {
+			outputTruncationNotice(report.RemainingRepositories, writer)
+		}
+
 		return nil
 	}
 }
@@ -1090,6 +2111,7 @@ func outputActionTable(report ActionReport, writer io.Writer) error {
 	mostUsedStr := fmt.Sprintf("%s (%d usages)", report.Actions[0].Name, report.Actions[0].Total)
 	fmt.Fprintf(writer, "  🔝 Most used action: %-83s \n", mostUsedStr)
 	fmt.Fprintf(writer, "  ⚠️  Actions with multiple versions: %-69d \n", multiVersionCount)
+	fmt.Fprintf(writer, "  🏷️  Ownership: %-90s \n", report.Ownership)
 	// processTimeStr := fmt.Sprintf("%.3fs", report.ProcessTimeSeconds)
 	// fmt.Fprintf(writer, "║ ⏱️  Process Time: %-87s ║\n", processTimeStr)
 	fmt.Fprintln(writer, " ════════════════════════════════════════════════════════════════════════════════════════════════════")
@@ -1142,41 +2164,62 @@ func outputActionTable(report ActionReport, writer io.Writer) error {
 	// Table footer
 	fmt.Fprintln(writer, "└─────────────────────────────────────────────────────────────────────┴─────────────┴─────────┴───────┘")
 	fmt.Fprintln(writer)
+
+	majors := make([]ActionMajorVersions, len(report.Actions))
+	for i, action := range report.Actions {
+		majors[i] = ActionMajorVersions{Action: action.Name, Versions: action.MajorVersions}
+	}
+	outputMajorVersionTable(majors, writer)
 	return nil
 }
 
 // outputActionCSV outputs action report in CSV format
 func outputActionCSV(report ActionReport, writer io.Writer) error {
-	fmt.Fprintln(writer, "Action,Version,Usages,Total")
-
+	w := newCSVWriter(writer)
+	w.Write([]string{"Action", "Version", "Usages", "Total", "Major"})
 	for _, action := range report.Actions {
 		for versionIdx, version := range action.Versions {
+			// Only the first version row of an action carries its total
+			total := ""
 			if versionIdx == 0 {
-				// First version row includes total
-				fmt.Fprintf(writer, "%s,@%s,%d,%d\n", action.Name, version.Version, version.Count, action.Total)
-			} else {
-				// Subsequent version rows don't repeat total
-				fmt.Fprintf(writer, "%s,@%s,%d,\n", action.Name, version.Version, version.Count)
+				total = strconv.Itoa(action.Total)
+			}
+			major := ""
+			if !strings.HasPrefix(action.Name, "docker://") {
+				major = majorGroup(version.Version)
 			}
+			w.Write([]string{action.Name, "@" + version.Version, strconv.Itoa(version.Count), total, major})
 		}
 	}
-	return nil
+	w.Flush()
+	return w.Error()
 }
 
 // outputComprehensiveReport outputs comprehensive report in the specified format
 func outputComprehensiveReport(report ComprehensiveReport, format string, writer io.Writer) error {
-	switch format {
-	case "json":
-		encoder := json.NewEncoder(writer)
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(report)
+	if formatter, ok := lookupFormatter(format); ok {
+		return formatter.Format(report, writer)
+	}
 
+	switch format {
 	case "table":
 		return outputComprehensiveTable(report, writer)
 
 	case "csv":
 		return outputComprehensiveCSV(report, writer)
 
+	case "markdown":
+		return outputComprehensiveMarkdown(report, writer)
+
+	case "html":
+		return outputComprehensiveHTML(report, writer)
+
+	case "xlsx":
+		return outputComprehensiveXLSX(report, writer)
+
+	case "sarif":
+		return outputSARIF("actions", report.Findings, writer)
+
 	default: // "default"
 		fmt.Fprintln(writer, "\n🔍 Detailed Analysis Results")
 		fmt.Fprintln(writer, "="+strings.Repeat("=", 60))
@@ -1190,17 +2233,24 @@ func outputComprehensiveReport(report ComprehensiveReport, format string, writer
 					fmt.Fprintf(writer, "   📄 %s (%d unique, %d total actions)\n", workflow.Path, workflow.ActionCount, workflow.TotalActionCount)
 				}
 				for _, action := range workflow.Actions {
+					reference := describeAction(action)
+					if action.Via != "" {
+						reference += " (via " + action.Via + ")"
+					}
+					if deprecatedRuntimes[action.Runtime] {
+						reference += " ⚠️  " + action.Runtime
+					}
 					if action.Count > 1 {
-						fmt.Fprintf(writer, "      🔧 %s@%s (%d times)\n", action.Name, action.Version, action.Count)
+						fmt.Fprintf(writer, "      🔧 %s (%d times)\n", reference, action.Count)
 					} else {
-						fmt.Fprintf(writer, "      🔧 %s@%s\n", action.Name, action.Version)
+						fmt.Fprintf(writer, "      🔧 %s\n", reference)
 					}
 				}
 			}
 		}
 
 		fmt.Fprintf(writer, "\n📊 Summary:\n")
-		fmt.Fprintf(writer, "   • Total repositories: %d\n", report.Summary.TotalRepositories)
+		fmt.Fprintf(writer, "   • Total repositories: %d (%s)\n", report.Summary.TotalRepositories, report.Summary.RepositoryCounts)
 		fmt.Fprintf(writer, "   • Repositories with workflows: %d\n", report.Summary.RepositoriesWithWorkflows)
 		fmt.Fprintf(writer, "   • Total workflows: %d\n", report.Summary.TotalWorkflows)
 		fmt.Fprintf(writer, "   • Total action usages: %d\n", report.Summary.TotalActionUsages)
@@ -1211,8 +2261,29 @@ func outputComprehensiveReport(report ComprehensiveReport, format string, writer
 			report.Summary.MostUsedAction.TotalUsages,
 			report.Summary.MostUsedAction.RepositoriesUsing,
 			report.Summary.MostUsedAction.WorkflowsUsing)
+		fmt.Fprintf(writer, "   • End-of-life action usages: %d\n", report.Summary.EOLActionUsages)
+		fmt.Fprintf(writer, "   • Forked action usages: %d\n", report.Summary.ForkedActionUsages)
+		fmt.Fprintf(writer, "   • Archived / unavailable action usages: %d / %d\n", report.Summary.ArchivedActionUsages, report.Summary.UnavailableActionUsages)
+		fmt.Fprintf(writer, "   • Vulnerable action usages: %d\n", report.Summary.VulnerableActionUsages)
+		fmt.Fprintf(writer, "   • Pinning: %s\n", report.Summary.Pinning)
+		fmt.Fprintf(writer, "   • Ownership: %s\n", report.Summary.Ownership)
+		fmt.Fprintf(writer, "   • Runtimes: %s\n", formatRuntimes(report.Summary.Runtimes))
 		fmt.Fprintf(writer, "   ⏱️  Process time: %.3fs\n", report.ProcessTimeSeconds)
 
+		outputDeprecatedRuntimes(report.Summary.Runtimes, writer)
+		outputMajorVersions(report.Summary.MajorVersions, writer)
+		outputContainerImages(report.Summary.ContainerImages, writer)
+		outputLocalActions(report.Summary.LocalActions, writer)
+		outputActionRepositories(report.ActionRepositories, writer)
+		outputVulnerabilities(report.Vulnerabilities, writer)
+		outputOrganizationBreakdown(report.Organizations, writer)
+		outputPropertyBreakdown(report.PropertyGroups, writer)
+		outputFindings(report.Findings, writer)
+
+		if report.Truncated {
+			outputTruncationNotice(report.RemainingRepositories, writer)
+		}
+
 		return nil
 	}
 }
@@ -1223,13 +2294,22 @@ func outputComprehensiveTable(report ComprehensiveReport, writer io.Writer) erro
 	fmt.Fprintln(writer, " ╔════════════════════════════════════════════════════════════════════════════════════════════════════════╗")
 	fmt.Fprintf(writer, " ║                               🔍 COMPREHENSIVE ACTION RESULTS                                          ║\n")
 	fmt.Fprintln(writer, " ╚════════════════════════════════════════════════════════════════════════════════════════════════════════╝")
+	report.Report.writeText(writer)
 	fmt.Fprintf(writer, "  🏢 Organization: %-83s \n", report.Organization)
 	fmt.Fprintf(writer, "  📁 Total Repositories: %-77d \n", report.Summary.TotalRepositories)
+	fmt.Fprintf(writer, "  🗂️  Breakdown: %-86s \n", report.Summary.RepositoryCounts)
 	fmt.Fprintf(writer, "  ⚙️  Repositories with Workflows: %-69d \n", report.Summary.RepositoriesWithWorkflows)
 	fmt.Fprintf(writer, "  📄 Total Workflows: %-80d \n", report.Summary.TotalWorkflows)
 	fmt.Fprintf(writer, "  🎯 Unique Actions: %-81d \n", report.Summary.UniqueActions)
 	fmt.Fprintf(writer, "  📈 Total Action Usages: %-76d \n", report.Summary.TotalActionUsages)
 	fmt.Fprintf(writer, "  ⚠️  Actions with Multiple Versions: %-66d \n", report.Summary.ActionsWithMultipleVersions)
+	fmt.Fprintf(writer, "  ⛔ End-of-Life Action Usages: %-71d \n", report.Summary.EOLActionUsages)
+	fmt.Fprintf(writer, "  🍴 Forked Action Usages: %-76d \n", report.Summary.ForkedActionUsages)
+	fmt.Fprintf(writer, "  🗄️  Archived / Unavailable Action Usages: %-60s \n", fmt.Sprintf("%d / %d", report.Summary.ArchivedActionUsages, report.Summary.UnavailableActionUsages))
+	fmt.Fprintf(writer, "  🛡️  Vulnerable Action Usages: %-72d \n", report.Summary.VulnerableActionUsages)
+	fmt.Fprintf(writer, "  📌 Pinning: %-88s \n", report.Summary.Pinning)
+	fmt.Fprintf(writer, "  🏷️  Ownership: %-86s \n", report.Summary.Ownership)
+	fmt.Fprintf(writer, "  🧩 Runtimes: %-87s \n", formatRuntimes(report.Summary.Runtimes))
 	mostUsedStr := fmt.Sprintf("%s (%d usages, %d repos, %d workflows)",
 		report.Summary.MostUsedAction.Name,
 		report.Summary.MostUsedAction.TotalUsages,
@@ -1296,7 +2376,7 @@ func outputComprehensiveTable(report ComprehensiveReport, writer io.Writer) erro
 				}
 
 				fmt.Fprintf(writer, "│ %-19s │ %-32s │ %-18s │ @%-6s │ %-7d │ %-5s │\n",
-					repoName, workflowName, actionName, action.Version, action.Count, totalCount)
+					repoName, workflowName, actionName, displayVersion(action), action.Count, totalCount)
 
 				totalRows++
 
@@ -1313,6 +2393,17 @@ func outputComprehensiveTable(report ComprehensiveReport, writer io.Writer) erro
 	fmt.Fprintf(writer, "\n🎯 Summary: %d repositories, %d workflows, %d unique actions, %d total usages\n",
 		report.Summary.RepositoriesWithWorkflows, report.Summary.TotalWorkflows,
 		report.Summary.UniqueActions, report.Summary.TotalActionUsages)
+	outputMajorVersions(report.Summary.MajorVersions, writer)
+	outputContainerImages(report.Summary.ContainerImages, writer)
+	outputLocalActions(report.Summary.LocalActions, writer)
+	outputActionRepositories(report.ActionRepositories, writer)
+	outputVulnerabilities(report.Vulnerabilities, writer)
+	outputOrganizationBreakdown(report.Organizations, writer)
+	outputPropertyBreakdown(report.PropertyGroups, writer)
+	outputFindings(report.Findings, writer)
+	if report.Truncated {
+		outputTruncationNotice(report.RemainingRepositories, writer)
+	}
 	fmt.Fprintln(writer)
 
 	return nil
@@ -1320,25 +2411,31 @@ func outputComprehensiveTable(report ComprehensiveReport, writer io.Writer) erro
 
 // outputComprehensiveCSV outputs comprehensive report in CSV format
 func outputComprehensiveCSV(report ComprehensiveReport, writer io.Writer) error {
-	// CSV Header
-	fmt.Fprintf(writer, "Repository,Workflow,Action,Version,Count,Total\n")
-
-	// CSV Data rows
+	w := newCSVWriter(writer)
+	w.Write([]string{"Repository", "Workflow", "Action", "Version", "Count", "Total", "Pinning", "ResolvedSHA", "ResolvedVersion", "Runtime", "Ownership"})
 	for _, repo := range report.Repositories {
 		for _, workflow := range repo.Workflows {
 			for _, action := range workflow.Actions {
-				// Escape quotes in CSV by doubling them
-				repoName := strings.ReplaceAll(repo.Name, "\"", "\"\"")
-				workflowPath := strings.ReplaceAll(workflow.Path, "\"", "\"\"")
-				actionName := strings.ReplaceAll(action.Name, "\"", "\"\"")
-
-				fmt.Fprintf(writer, "\"%s\",\"%s\",\"%s\",\"%s\",%d,%d\n",
-					repoName, workflowPath, actionName, action.Version, action.Count, workflow.TotalActionCount)
+				w.Write([]string{repo.Name, workflow.Path, action.Name, action.Version, strconv.Itoa(action.Count), strconv.Itoa(workflow.TotalActionCount),
+					action.Pinning, action.ResolvedSHA, action.ResolvedVersion, action.Runtime, action.Ownership})
 			}
 		}
 	}
+	w.Flush()
+	return w.Error()
+}
 
-	return nil
+// sortComprehensiveActions orders actions by name and version
+func sortComprehensiveActions(actions []ComprehensiveAction) {
+	sort.Slice(actions, func(i, j int) bool {
+		if actions[i].Name != actions[j].Name {
+			return actions[i].Name < actions[j].Name
+		}
+		if actions[i].Version != actions[j].Version {
+			return actions[i].Version < actions[j].Version
+		}
+		return actions[i].Via < actions[j].Via
+	})
 }
 
 // getTotalActionCount calculates the total number of action entries for table formatting
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Major version groups of refs that are not version tags
+const (
+	MajorSHAPinned = "sha-pinned"
+	MajorOther     = "other" // branches and refs that are not versions
+)
+
+// MajorVersionCount counts the usages of one major version of an action
+type MajorVersionCount struct {
+	Major string `json:"major"` // v4, sha-pinned, or other
+	Count int    `json:"count"`
+}
+
+// ActionMajorVersions is the major version rollup of one action
+type ActionMajorVersions struct {
+	Action   string              `json:"action"`
+	Versions []MajorVersionCount `json:"versions"`
+}
+
+// majorGroup returns the major version group of a ref: v4 for v4, v4.1.2 and 4.1, sha-pinned for
+// commit SHAs, and other for anything else
+func majorGroup(ref string) string {
+	if isPinnedToSHA(ref) {
+		return MajorSHAPinned
+	}
+	if version, ok := parseVersion(ref); ok {
+		return fmt.Sprintf("v%d", version[0])
+	}
+	return MajorOther
+}
+
+// rollupMajorVersions sums per-version usage counts of an action by major version, ordered by version
+// with sha-pinned and other last. Container image tags are not action versions, so docker:// references
+// have no rollup.
+func rollupMajorVersions(action string, versions map[string]int) []MajorVersionCount {
+	rollup := []MajorVersionCount{}
+	if strings.HasPrefix(action, "docker://") {
+		return rollup
+	}
+
+	counts := make(map[string]int)
+	for version, count := range versions {
+		counts[majorGroup(version)] += count
+	}
+
+	for major, count := range counts {
+		rollup = append(rollup, MajorVersionCount{Major: major, Count: count})
+	}
+	sort.Slice(rollup, func(i, j int) bool {
+		a, b := majorRank(rollup[i].Major), majorRank(rollup[j].Major)
+		if a != b {
+			return a < b
+		}
+		return rollup[i].Major < rollup[j].Major
+	})
+	return rollup
+}
+
+// majorRank orders major version groups numerically, followed by sha-pinned and other
+func majorRank(major string) int {
+	switch major {
+	case MajorSHAPinned:
+		return 1 << 30
+	case MajorOther:
+		return 1<<30 + 1
+	}
+	var n int
+	fmt.Sscanf(major, "v%d", &n)
+	return n
+}
+
+// formatMajorVersions renders a rollup as v2=41, v3=380, sha-pinned=77
+func formatMajorVersions(rollup []MajorVersionCount) string {
+	parts := make([]string, len(rollup))
+	for i, major := range rollup {
+		parts[i] = fmt.Sprintf("%s=%d", major.Major, major.Count)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// summarizeMajorVersions builds the major version rollup of every action of a detailed report,
+// ordered by action name
+func summarizeMajorVersions(repositories []ComprehensiveRepository) []ActionMajorVersions {
+	usages := make(map[string]map[string]int) // action -> version -> count
+	for _, repo := range repositories {
+		for _, workflow := range repo.Workflows {
+			for _, action := range workflow.Actions {
+				if strings.HasPrefix(action.Name, "docker://") {
+					continue
+				}
+				if usages[action.Name] == nil {
+					usages[action.Name] = make(map[string]int)
+				}
+				usages[action.Name][action.Version] += action.Count
+			}
+		}
+	}
+
+	summary := []ActionMajorVersions{}
+	for name, versions := range usages {
+		summary = append(summary, ActionMajorVersions{Action: name, Versions: rollupMajorVersions(name, versions)})
+	}
+	sort.Slice(summary, func(i, j int) bool { return summary[i].Action < summary[j].Action })
+	return summary
+}
+
+// spreadMajorVersions returns the actions used in more than one major version, the ones whose
+// upgrade progress is worth tracking
+func spreadMajorVersions(summary []ActionMajorVersions) []ActionMajorVersions {
+	var spread []ActionMajorVersions
+	for _, action := range summary {
+		if len(action.Versions) > 1 {
+			spread = append(spread, action)
+		}
+	}
+	return spread
+}
+
+// outputMajorVersions writes the major version rollup of the actions used in more than one major version
+func outputMajorVersions(summary []ActionMajorVersions, writer io.Writer) {
+	spread := spreadMajorVersions(summary)
+	if len(spread) == 0 {
+		return
+	}
+
+	fmt.Fprintln(writer, "\n🔢 Major versions (actions used in more than one):")
+	for _, action := range spread {
+		fmt.Fprintf(writer, "   • %s: %s\n", action.Action, formatMajorVersions(action.Versions))
+	}
+}
+
+// outputMajorVersionTable writes the major version rollup of the actions used in more than one
+// major version as a table
+func outputMajorVersionTable(summary []ActionMajorVersions, writer io.Writer) {
+	spread := spreadMajorVersions(summary)
+	if len(spread) == 0 {
+		return
+	}
+
+	fmt.Fprintln(writer, "┌────────────────────────────────────────┬───────────────────────────────────────────────────────────┐")
+	fmt.Fprintf(writer, "│ %-37s │ %-56s │\n", "🔧 ACTION", "🔢 MAJOR VERSIONS")
+	fmt.Fprintln(writer, "├────────────────────────────────────────┼───────────────────────────────────────────────────────────┤")
+	for _, action := range spread {
+		fmt.Fprintf(writer, "│ %-38s │ %-57s │\n", truncate(action.Action, 38), truncate(formatMajorVersions(action.Versions), 57))
+	}
+	fmt.Fprintln(writer, "└────────────────────────────────────────┴───────────────────────────────────────────────────────────┘")
+	fmt.Fprintln(writer)
+}
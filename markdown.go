@@ -0,0 +1,333 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// markdownCell escapes a value for a GitHub-flavored Markdown table cell
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	return strings.ReplaceAll(value, "\n", " ")
+}
+
+// writeMarkdownSummary writes label/value pairs as a two-column table
+func writeMarkdownSummary(rows [][2]string, writer io.Writer) {
+	fmt.Fprintln(writer, "| Metric | Value |")
+	fmt.Fprintln(writer, "|---|---|")
+	for _, row := range rows {
+		fmt.Fprintf(writer, "| %s | %s |\n", row[0], markdownCell(row[1]))
+	}
+	fmt.Fprintln(writer)
+}
+
+// writeMarkdownFindings writes findings grouped in one collapsible section per repository
+func writeMarkdownFindings(findings []Finding, writer io.Writer) {
+	if len(findings) == 0 {
+		fmt.Fprintln(writer, "### ✅ No findings")
+		fmt.Fprintln(writer)
+		return
+	}
+
+	counts := make(map[string]int)
+	for _, finding := range findings {
+		counts[finding.Severity]++
+	}
+	fmt.Fprintf(writer, "### 🚨 Findings (%d)\n\n", len(findings))
+	fmt.Fprintf(writer, "❌ %d errors · ⚠️ %d warnings · ℹ️ %d info\n\n",
+		counts[SeverityError], counts[SeverityWarning], counts[SeverityInfo])
+	bot, human := authorshipCounts(findings)
+	if bot+human > 0 {
+		fmt.Fprintf(writer, "Last modified by: 🤖 bots %d · 👤 humans %d\n\n", bot, human)
+	}
+
+	// Findings are ordered by repository, so each repository is one run
+	for start := 0; start < len(findings); {
+		end := start
+		for end < len(findings) && findings[end].Repository == findings[start].Repository {
+			end++
+		}
+		fmt.Fprintf(writer, "<details><summary><b>%s</b> (%d)</summary>\n\n", findings[start].Repository, end-start)
+		if bot+human > 0 {
+			fmt.Fprintln(writer, "| Severity | Rule | Workflow | Last modified by | Message | Fix |")
+			fmt.Fprintln(writer, "|---|---|---|---|---|---|")
+		} else {
+			fmt.Fprintln(writer, "| Severity | Rule | Workflow | Message | Fix |")
+			fmt.Fprintln(writer, "|---|---|---|---|---|")
+		}
+		for _, finding := range findings[start:end] {
+			workflow := fmt.Sprintf("`%s`", markdownCell(finding.Workflow))
+			if bot+human > 0 {
+				workflow += " | " + markdownCell(authorshipLabel(finding))
+			}
+			fmt.Fprintf(writer, "| %s %s | `%s` | %s | %s | %s |\n",
+				strings.TrimSpace(severityIcon(finding.Severity)), finding.Severity, finding.RuleID,
+				workflow, markdownCell(finding.Message), markdownCell(finding.remediation()))
+		}
+		fmt.Fprintln(writer, "\n</details>")
+		fmt.Fprintln(writer)
+		start = end
+	}
+}
+
+// writeMarkdownTruncation writes the repositories skipped because the scan timed out
+func writeMarkdownTruncation(remaining []string, writer io.Writer) {
+	if len(remaining) == 0 {
+		return
+	}
+	fmt.Fprintf(writer, "> [!WARNING]\n> Scan timed out; partial report. %d repositories not scanned: %s\n\n",
+		len(remaining), strings.Join(remaining, ", "))
+}
+
+// outputFindingsMarkdown outputs the findings of a scan as a Markdown report
+func outputFindingsMarkdown(title string, findings []Finding, remaining []string, writer io.Writer) error {
+	fmt.Fprintf(writer, "## %s\n\n", title)
+	writeMarkdownFindings(findings, writer)
+	writeMarkdownTruncation(remaining, writer)
+	return nil
+}
+
+// outputScanMarkdown outputs the workflow scan as a Markdown report
+func outputScanMarkdown(result ScanResult, writer io.Writer) error {
+	fmt.Fprintf(writer, "## 🔍 Workflows of `%s`\n\n", result.Organization)
+	writeMarkdownSummary([][2]string{
+		{"Total repositories", fmt.Sprintf("%d (%s)", result.TotalRepositories, result.RepositoryCounts)},
+		{"Repositories with workflows", fmt.Sprint(result.RepositoriesWithWorkflows)},
+		{"Process time", fmt.Sprintf("%.3fs", result.ProcessTimeSeconds)},
+	}, writer)
+
+	for _, repo := range result.Repositories {
+		fmt.Fprintf(writer, "<details><summary><b>%s</b> (%d workflows)</summary>\n\n", repo.Name, len(repo.Workflows))
+		for _, workflow := range repo.Workflows {
+			fmt.Fprintf(writer, "- `%s`\n", workflow)
+		}
+		fmt.Fprintln(writer, "\n</details>")
+		fmt.Fprintln(writer)
+	}
+	return nil
+}
+
+// outputActionMarkdown outputs the action reference report as a Markdown report
+func outputActionMarkdown(report ActionReport, writer io.Writer) error {
+	fmt.Fprintln(writer, "## 📋 Action Reference Report")
+	fmt.Fprintln(writer)
+	writeMarkdownSummary([][2]string{
+		{"Workflows analyzed", fmt.Sprint(report.TotalWorkflows)},
+		{"Unique actions", fmt.Sprint(report.UniqueActions)},
+		{"Total action usages", fmt.Sprint(report.TotalUsages)},
+		{"Ownership", report.Ownership.String()},
+		{"Process time", fmt.Sprintf("%.3fs", report.ProcessTimeSeconds)},
+	}, writer)
+
+	if len(report.Actions) > 0 {
+		fmt.Fprintln(writer, "| Action | Usages | Versions | Major versions |")
+		fmt.Fprintln(writer, "|---|---:|---|---|")
+		for _, action := range report.Actions {
+			var versions []string
+			for _, version := range action.Versions {
+				entry := fmt.Sprintf("`@%s` (%d)", version.Version, version.Count)
+				if version.EOL {
+					entry += " ⛔ EOL"
+				}
+				versions = append(versions, entry)
+			}
+			fmt.Fprintf(writer, "| `%s` | %d | %s | %s |\n",
+				action.Name, action.Total, markdownCell(strings.Join(versions, ", ")), formatMajorVersions(action.MajorVersions))
+		}
+		fmt.Fprintln(writer)
+	}
+
+	writeMarkdownTruncation(report.RemainingRepositories, writer)
+	return nil
+}
+
+// outputComprehensiveMarkdown outputs the detailed analysis as a Markdown report with one collapsible
+// section per repository
+func outputComprehensiveMarkdown(report ComprehensiveReport, writer io.Writer) error {
+	return writeComprehensiveMarkdown(report, nil, writer)
+}
+
+// writeComprehensiveMarkdown writes the Markdown report. With pages, the findings and repository
+// sections are left to the pages and a list of the pages takes their place.
+func writeComprehensiveMarkdown(report ComprehensiveReport, pages []reportPage, writer io.Writer) error {
+	report.Report.writeMarkdown(writer)
+	scope := report.Organization
+	if report.Enterprise != "" {
+		scope = report.Enterprise
+	}
+	fmt.Fprintf(writer, "## 🔍 Detailed Analysis of `%s`\n\n", scope)
+
+	writeMarkdownSummary([][2]string{
+		{"Total repositories", fmt.Sprintf("%d (%s)", report.Summary.TotalRepositories, report.Summary.RepositoryCounts)},
+		{"Repositories with workflows", fmt.Sprint(report.Summary.RepositoriesWithWorkflows)},
+		{"Total workflows", fmt.Sprint(report.Summary.TotalWorkflows)},
+		{"Total action usages", fmt.Sprint(report.Summary.TotalActionUsages)},
+		{"Unique actions", fmt.Sprint(report.Summary.UniqueActions)},
+		{"Actions with multiple versions", fmt.Sprint(report.Summary.ActionsWithMultipleVersions)},
+		{"Most used action", fmt.Sprintf("`%s` (%d usages)", report.Summary.MostUsedAction.Name, report.Summary.MostUsedAction.TotalUsages)},
+		{"End-of-life action usages", fmt.Sprint(report.Summary.EOLActionUsages)},
+		{"Forked action usages", fmt.Sprint(report.Summary.ForkedActionUsages)},
+		{"Archived / unavailable action usages", fmt.Sprintf("%d / %d", report.Summary.ArchivedActionUsages, report.Summary.UnavailableActionUsages)},
+		{"Vulnerable action usages", fmt.Sprint(report.Summary.VulnerableActionUsages)},
+		{"Pinning", report.Summary.Pinning.String()},
+		{"Ownership", report.Summary.Ownership.String()},
+		{"Runtimes", formatRuntimes(report.Summary.Runtimes)},
+		{"Container images", fmt.Sprintf("%d usages, %d images (%s)", report.Summary.ContainerImages.Usages, report.Summary.ContainerImages.UniqueImages, report.Summary.ContainerImages)},
+		{"Local actions", report.Summary.LocalActions.String()},
+		{"Process time", fmt.Sprintf("%.3fs", report.ProcessTimeSeconds)},
+	}, writer)
+
+	if spread := spreadMajorVersions(report.Summary.MajorVersions); len(spread) > 0 {
+		fmt.Fprintln(writer, "### 🔢 Major versions")
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "| Action | Major versions |")
+		fmt.Fprintln(writer, "|---|---|")
+		for _, action := range spread {
+			fmt.Fprintf(writer, "| `%s` | %s |\n", action.Action, formatMajorVersions(action.Versions))
+		}
+		fmt.Fprintln(writer)
+	}
+
+	if images := report.Summary.ContainerImages.Images; len(images) > 0 {
+		fmt.Fprintln(writer, "### 🐳 Container images")
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "| Image | Usages | Repositories | Not pinned to a digest | Tags / digests |")
+		fmt.Fprintln(writer, "|---|---:|---:|---:|---|")
+		for _, image := range images {
+			fmt.Fprintf(writer, "| `%s` | %d | %d | %d | %s |\n", image.Image, image.Usages, image.Repositories, image.Unpinned, markdownCell(formatImageReferences(image.References)))
+		}
+		fmt.Fprintln(writer)
+	}
+
+	if repositories := report.Summary.LocalActions.Repositories; len(repositories) > 0 {
+		fmt.Fprintln(writer, "### 📂 Local actions")
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "| Repository | Usages | Actions | Composite | Missing action.yml |")
+		fmt.Fprintln(writer, "|---|---:|---:|---:|---|")
+		for _, repo := range repositories {
+			fmt.Fprintf(writer, "| %s | %d | %d | %d | %s |\n", markdownCell(repo.Name), repo.Usages, len(repo.Actions), repo.Composite, markdownCell(strings.Join(repo.Missing, ", ")))
+		}
+		fmt.Fprintln(writer)
+	}
+
+	if len(report.ActionRepositories) > 0 {
+		fmt.Fprintln(writer, "### 🛒 Third-party action repositories")
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "| Repository | Stars | Last push | Health | Verified owner | Scorecard | Usages | Repositories |")
+		fmt.Fprintln(writer, "|---|---:|---:|---|---|---|---:|---:|")
+		for _, repository := range report.ActionRepositories {
+			lastPush, health, verified := "unknown", "ok", "no"
+			if repository.DaysSincePush >= 0 {
+				lastPush = fmt.Sprintf("%d days ago", repository.DaysSincePush)
+			}
+			if repository.Health != "" {
+				health = "⛔ " + repository.Health
+			}
+			if repository.VerifiedOwner {
+				verified = "yes"
+			}
+			fmt.Fprintf(writer, "| `%s` | %d | %s | %s | %s | %s | %d | %d |\n", repository.Repository, repository.Stars, lastPush,
+				health, verified, markdownCell(strings.TrimPrefix(describeScorecard(repository.Scorecard), "scorecard ")), repository.Usages, repository.Repositories)
+		}
+		fmt.Fprintln(writer)
+	}
+
+	if len(report.Vulnerabilities) > 0 {
+		fmt.Fprintln(writer, "### 🛡️ Vulnerabilities")
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "| Severity | Advisory | Action | Vulnerable range | Patched in | Summary | Workflows |")
+		fmt.Fprintln(writer, "|---|---|---|---|---|---|---|")
+		for _, vulnerability := range report.Vulnerabilities {
+			advisory := fmt.Sprintf("[%s](%s)", vulnerability.Advisory, vulnerability.URL)
+			if vulnerability.CVE != "" {
+				advisory += " " + vulnerability.CVE
+			}
+			fmt.Fprintf(writer, "| %s | %s | `%s@%s` | %s | %s | %s | %s |\n", vulnerability.Severity, advisory, vulnerability.Action, vulnerability.Version,
+				markdownCell(vulnerability.VulnerableRange), markdownCell(vulnerability.PatchedVersion), markdownCell(vulnerability.Summary), markdownCell(strings.Join(vulnerability.Workflows, ", ")))
+		}
+		fmt.Fprintln(writer)
+	}
+
+	for _, runtime := range report.Summary.Runtimes {
+		if !runtime.Deprecated {
+			continue
+		}
+		fmt.Fprintf(writer, "### 🕰️ Deprecated runtime %s (%d usages)\n\n", runtime.Runtime, runtime.Usages)
+		for _, action := range runtime.Actions {
+			fmt.Fprintf(writer, "- `%s`\n", action)
+		}
+		fmt.Fprintln(writer)
+	}
+
+	if len(report.Organizations) > 0 {
+		fmt.Fprintln(writer, "### 🏢 Organizations")
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "| Organization | Repositories | Workflows | Action usages | Unique actions | Findings |")
+		fmt.Fprintln(writer, "|---|---:|---:|---:|---:|---:|")
+		for _, org := range report.Organizations {
+			if org.Error != "" {
+				fmt.Fprintf(writer, "| %s | ❌ not scanned: %s | | | | |\n", org.Organization, markdownCell(org.Error))
+				continue
+			}
+			fmt.Fprintf(writer, "| %s | %d | %d | %d | %d | %d |\n", org.Organization, org.Summary.TotalRepositories,
+				org.Summary.TotalWorkflows, org.Summary.TotalActionUsages, org.Summary.UniqueActions, org.Findings)
+		}
+		fmt.Fprintln(writer)
+	}
+
+	if len(report.PropertyGroups) > 0 {
+		fmt.Fprintf(writer, "### 🏷️ By %s\n", report.PropertyGroups[0].Property)
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "| Value | Repositories | Workflows | Action usages | Unique actions | Findings |")
+		fmt.Fprintln(writer, "|---|---:|---:|---:|---:|---:|")
+		for _, group := range report.PropertyGroups {
+			fmt.Fprintf(writer, "| %s | %d | %d | %d | %d | %d |\n", markdownCell(group.Value), group.Repositories,
+				group.Workflows, group.ActionUsages, group.UniqueActions, group.Findings)
+		}
+		fmt.Fprintln(writer)
+	}
+
+	if len(pages) > 0 {
+		counts := make(map[string]int)
+		for _, finding := range report.Findings {
+			counts[finding.Severity]++
+		}
+		fmt.Fprintf(writer, "### 🚨 Findings (%d)\n\n", len(report.Findings))
+		fmt.Fprintf(writer, "❌ %d errors · ⚠️ %d warnings · ℹ️ %d info\n\n",
+			counts[SeverityError], counts[SeverityWarning], counts[SeverityInfo])
+		fmt.Fprintln(writer, "### 📄 Pages")
+		fmt.Fprintln(writer)
+		for i, page := range pages {
+			fmt.Fprintf(writer, "%d. [%s](%s)\n", i+1, page.describe(), page.File)
+		}
+		fmt.Fprintln(writer)
+	} else {
+		writeMarkdownFindings(report.Findings, writer)
+		writeMarkdownRepositories(report.Repositories, writer)
+	}
+
+	writeMarkdownTruncation(report.RemainingRepositories, writer)
+	return nil
+}
+
+// writeMarkdownRepositories writes one collapsible section per repository with its action usages
+func writeMarkdownRepositories(repositories []ComprehensiveRepository, writer io.Writer) {
+	if len(repositories) > 0 {
+		fmt.Fprintln(writer, "### 📁 Repositories")
+		fmt.Fprintln(writer)
+	}
+	for _, repo := range repositories {
+		fmt.Fprintf(writer, "<details><summary><b>%s</b> (%d workflows)</summary>\n\n", repo.Name, repo.WorkflowCount)
+		fmt.Fprintln(writer, "| Workflow | Action | Version | Pinning | Count |")
+		fmt.Fprintln(writer, "|---|---|---|---|---:|")
+		for _, workflow := range repo.Workflows {
+			for _, action := range workflow.Actions {
+				fmt.Fprintf(writer, "| `%s` | `%s` | `%s` | %s | %d |\n", markdownCell(workflow.Path), action.Name,
+					markdownCell(displayVersion(action)), action.Pinning, action.Count)
+			}
+		}
+		fmt.Fprintln(writer, "\n</details>")
+		fmt.Fprintln(writer)
+	}
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ActionRepository is the public metadata of the repository of a third-party action, used to rank the
+// supply-chain risk of the actions an organization depends on
+type ActionRepository struct {
+	Repository    string           `json:"repository"`
+	Stars         int              `json:"stars"`
+	Archived      bool             `json:"archived"`
+	Health        string           `json:"health,omitempty"` // archived, disabled, or unavailable; empty when healthy
+	PushedAt      string           `json:"pushed_at,omitempty"`
+	DaysSincePush int              `json:"days_since_push"`      // -1 when unknown
+	OwnerType     string           `json:"owner_type,omitempty"` // Organization or User
+	VerifiedOwner bool             `json:"verified_owner"`       // owner is an organization with a verified domain
+	Usages        int              `json:"usages"`
+	Repositories  int              `json:"repositories"`        // scanned repositories using its actions
+	Scorecard     *ScorecardResult `json:"scorecard,omitempty"` // OpenSSF Scorecard result (--scorecard)
+	Error         string           `json:"error,omitempty"`     // why the repository could not be looked up
+}
+
+// ownerMetadata is the subset of the REST organization resource used for enrichment
+type ownerMetadata struct {
+	Login      string `json:"login"`
+	IsVerified bool   `json:"is_verified"`
+}
+
+// fetchOwnerMetadata looks up the organization owning an action repository through the enrichment cache
+func fetchOwnerMetadata(cache *enrichmentCache, owner string) (ownerMetadata, error) {
+	var metadata ownerMetadata
+	err := cache.fetch(EnrichmentOwner, owner, &metadata, func() error {
+		return restGet("orgs/"+owner, &metadata)
+	})
+	return metadata, err
+}
+
+// enrichActionRepositories looks up the repository and owner of every third-party action of the detailed
+// report, with at most opts.Concurrency lookups in flight
+func enrichActionRepositories(repositories []ComprehensiveRepository, org string, opts scanOptions) []ActionRepository {
+	byRepository := make(map[string]*ActionRepository)
+	usedBy := make(map[string]map[string]bool)
+	for _, repo := range repositories {
+		for _, workflow := range repo.Workflows {
+			for _, action := range workflow.Actions {
+				if actionClass(action.Name, org) != actionClassThirdParty || strings.HasPrefix(action.Name, "docker://") {
+					continue
+				}
+				repository := actionRepository(action.Name)
+				entry, ok := byRepository[repository]
+				if !ok {
+					entry = &ActionRepository{Repository: repository, DaysSincePush: -1}
+					byRepository[repository] = entry
+					usedBy[repository] = make(map[string]bool)
+				}
+				entry.Usages += action.Count
+				usedBy[repository][repo.Name] = true
+			}
+		}
+	}
+
+	result := make([]ActionRepository, 0, len(byRepository))
+	for repository, entry := range byRepository {
+		entry.Repositories = len(usedBy[repository])
+		result = append(result, *entry)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Repository < result[j].Repository })
+
+	now := time.Now()
+	runConcurrently(len(result), opts.Concurrency, func(i int) {
+		apiRateLimit.acquire()
+		defer apiRateLimit.release()
+		entry := &result[i]
+		metadata, err := fetchRepositoryMetadata(opts.Cache, entry.Repository)
+		if err == errFileNotFound {
+			entry.Health = HealthUnavailable
+			return
+		}
+		if err != nil {
+			entry.Error = err.Error()
+			return
+		}
+		entry.Stars, entry.Archived, entry.PushedAt, entry.OwnerType = metadata.Stars, metadata.Archived, metadata.PushedAt, metadata.Owner.Type
+		if metadata.Disabled {
+			entry.Health = HealthDisabled
+		} else if metadata.Archived {
+			entry.Health = HealthArchived
+		}
+		if pushed, err := time.Parse(time.RFC3339, metadata.PushedAt); err == nil {
+			entry.DaysSincePush = int(now.Sub(pushed).Hours() / 24)
+		}
+		if metadata.Owner.Type == "Organization" {
+			if owner, err := fetchOwnerMetadata(opts.Cache, metadata.Owner.Login); err == nil {
+				entry.VerifiedOwner = owner.IsVerified
+			}
+		}
+	})
+
+	sortActionRepositories(result)
+	return result
+}
+
+// healthRank orders the health of action repositories from broken to healthy
+var healthRank = map[string]int{HealthUnavailable: 0, HealthDisabled: 1, HealthArchived: 2, "": 3}
+
+// sortActionRepositories orders action repositories by risk: unavailable, disabled, and archived first,
+// then by Scorecard score (--scorecard), then those of unverified owners, then the least starred
+func sortActionRepositories(repositories []ActionRepository) {
+	sort.SliceStable(repositories, func(i, j int) bool {
+		a, b := repositories[i], repositories[j]
+		if healthRank[a.Health] != healthRank[b.Health] {
+			return healthRank[a.Health] < healthRank[b.Health]
+		}
+		if scorecardRank(a) != scorecardRank(b) {
+			return scorecardRank(a) < scorecardRank(b)
+		}
+		if a.VerifiedOwner != b.VerifiedOwner {
+			return !a.VerifiedOwner
+		}
+		if a.Stars != b.Stars {
+			return a.Stars < b.Stars
+		}
+		return a.Repository < b.Repository
+	})
+}
+
+// mergeActionRepositories adds the action repositories of another organization's report, summing the
+// usages of repositories both depend on
+func mergeActionRepositories(repositories, more []ActionRepository) []ActionRepository {
+	index := make(map[string]int, len(repositories))
+	for i, repository := range repositories {
+		index[repository.Repository] = i
+	}
+	for _, repository := range more {
+		if i, ok := index[repository.Repository]; ok {
+			repositories[i].Usages += repository.Usages
+			repositories[i].Repositories += repository.Repositories
+			continue
+		}
+		index[repository.Repository] = len(repositories)
+		repositories = append(repositories, repository)
+	}
+	sortActionRepositories(repositories)
+	return repositories
+}
+
+// describeActionRepository renders the metadata of an action repository, e.g.
+// "★ 1520, last push 12 days ago, verified owner"
+func describeActionRepository(repository ActionRepository) string {
+	if repository.Error != "" {
+		return "could not be looked up: " + repository.Error
+	}
+	if repository.Health == HealthUnavailable {
+		return "does not exist or is not visible"
+	}
+	parts := []string{fmt.Sprintf("★ %d", repository.Stars)}
+	if repository.DaysSincePush >= 0 {
+		parts = append(parts, fmt.Sprintf("last push %d days ago", repository.DaysSincePush))
+	}
+	if repository.Health == HealthDisabled || repository.Health == HealthArchived {
+		parts = append(parts, repository.Health)
+	}
+	if repository.VerifiedOwner {
+		parts = append(parts, "verified owner")
+	} else {
+		parts = append(parts, "unverified owner")
+	}
+	if repository.Scorecard != nil {
+		parts = append(parts, describeScorecard(repository.Scorecard))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// outputActionRepositories writes the third-party action repositories of the detailed report, riskiest first
+func outputActionRepositories(repositories []ActionRepository, writer io.Writer) {
+	if len(repositories) == 0 {
+		return
+	}
+	fmt.Fprintf(writer, "\n🛒 Third-party action repositories (%d):\n", len(repositories))
+	for _, repository := range repositories {
+		icon := "✅"
+		switch {
+		case repository.Health != "" || repository.Error != "":
+			icon = "⛔"
+		case !repository.VerifiedOwner:
+			icon = "⚠️ "
+		}
+		fmt.Fprintf(writer, "   %s %s: %s; %d usages in %d repos\n", icon, repository.Repository, describeActionRepository(repository), repository.Usages, repository.Repositories)
+	}
+}
@@ -0,0 +1,386 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// VersionMatrix represents a repositories × versions grid for a single action
+type VersionMatrix struct {
+	Organization       string             `json:"organization"`
+	Action             string             `json:"action"`
+	Versions           []string           `json:"versions"`
+	Repositories       []VersionMatrixRow `json:"repositories"`
+	Totals             map[string]int     `json:"totals"`
+	ProcessTimeSeconds float64            `json:"process_time_seconds"`
+	Report             *ReportBranding    `json:"report,omitempty"` // custom title and audit metadata
+}
+
+// VersionMatrixRow holds the per-version usage counts of the action in one repository
+type VersionMatrixRow struct {
+	Name   string         `json:"name"`
+	Counts map[string]int `json:"counts"`
+}
+
+// runMatrixCommand implements `gh action-lens matrix`
+func runMatrixCommand(args []string) error {
+	fs := flag.NewFlagSet("matrix", flag.ExitOnError)
+
+	var organization string
+	var action string
+	var outputFormat string
+	var outputFile string
+	var includeWorkflows string
+	var excludeWorkflows string
+	var delimiter string
+	var reportTitle string
+	var reportLogo string
+	reportMeta := metadataFlag{}
+
+	fs.StringVar(&organization, "org", "", "Organization name to target")
+	fs.StringVar(&organization, "o", "", "Organization name to target")
+	fs.StringVar(&action, "action", "", "Action to build the matrix for (e.g. actions/setup-node)")
+	fs.StringVar(&action, "a", "", "Action to build the matrix for (e.g. actions/setup-node)")
+	fs.StringVar(&outputFormat, "format", "default", "Output format: default, json, csv, markdown, html")
+	fs.StringVar(&outputFormat, "f", "default", "Output format: default, json, csv, markdown, html")
+	fs.StringVar(&outputFile, "output", "", "Write output to file instead of stdout")
+	fs.StringVar(&delimiter, "csv-delimiter", ",", "Field delimiter of the csv format, e.g. ; or tab")
+	fs.StringVar(&includeWorkflows, "include-workflows", "", "Only scan workflow files matching these comma-separated glob patterns")
+	fs.StringVar(&excludeWorkflows, "exclude-workflows", "", "Skip workflow files matching these comma-separated glob patterns")
+	fs.StringVar(&reportTitle, "report-title", "", "Custom title of the markdown and html output")
+	fs.StringVar(&reportLogo, "report-logo", "", "Logo image (URL or path) shown in the markdown and html output")
+	fs.Var(reportMeta, "report-meta", "Metadata line (e.g. Ticket=SEC-1234) shown under the title; repeatable")
+
+	addHostnameFlag(fs)
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "\nUsage:\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens matrix --org <org> --action <owner/repo> [flags]\n\n")
+		fmt.Fprintf(os.Stderr, "Produce a repositories × versions grid for a single action.\n\n")
+		fmt.Fprintf(os.Stderr, "Flags:\n")
+		fmt.Fprintf(os.Stderr, "  -o, --org <string>\n")
+		fmt.Fprintf(os.Stderr, "        Organization name to target\n\n")
+		fmt.Fprintf(os.Stderr, "  -a, --action <string>\n")
+		fmt.Fprintf(os.Stderr, "        Action to build the matrix for (e.g. actions/setup-node)\n\n")
+		fmt.Fprintf(os.Stderr, "  -f, --format <string>\n")
+		fmt.Fprintf(os.Stderr, "        Output format: default, json, csv, markdown, html (default \"default\")\n\n")
+		fmt.Fprintf(os.Stderr, "      --output <string>\n")
+		fmt.Fprintf(os.Stderr, "        Write output to file instead of stdout\n\n")
+		fmt.Fprintf(os.Stderr, "      --csv-delimiter <char>\n")
+		fmt.Fprintf(os.Stderr, "        Field delimiter of the csv format, e.g. ; or tab (default \",\")\n\n")
+		fmt.Fprintf(os.Stderr, "      --include-workflows <patterns>\n")
+		fmt.Fprintf(os.Stderr, "        Only scan workflow files matching these comma-separated glob patterns\n\n")
+		fmt.Fprintf(os.Stderr, "      --exclude-workflows <patterns>\n")
+		fmt.Fprintf(os.Stderr, "        Skip workflow files matching these comma-separated glob patterns\n\n")
+		fmt.Fprintf(os.Stderr, "      --report-title <string>\n")
+		fmt.Fprintf(os.Stderr, "        Custom title of the markdown and html output\n\n")
+		fmt.Fprintf(os.Stderr, "      --report-logo <url>\n")
+		fmt.Fprintf(os.Stderr, "        Logo image (URL or path) shown in the markdown and html output\n\n")
+		fmt.Fprintf(os.Stderr, "      --report-meta <key=value>\n")
+		fmt.Fprintf(os.Stderr, "        Metadata line (e.g. Ticket=SEC-1234) shown under the title; repeatable\n\n")
+		fmt.Fprintf(os.Stderr, "      --hostname <host>\n")
+		fmt.Fprintf(os.Stderr, "        GitHub host, e.g. a GitHub Enterprise Server (default: GH_HOST or the gh default host)\n\n")
+		fmt.Fprintf(os.Stderr, "Examples:\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens matrix -o myorg --action actions/setup-node --format markdown\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens matrix -o myorg --action actions/checkout --format csv --output checkout.csv\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens matrix -o myorg --action actions/checkout --format html --report-meta 'Audit period=2026 Q3'\n\n")
+	}
+
+	fs.Parse(args)
+
+	if organization == "" || action == "" {
+		fs.Usage()
+		return fmt.Errorf("both --org and --action are required")
+	}
+
+	switch outputFormat {
+	case "default", "json", "csv", "markdown", "html":
+	default:
+		return fmt.Errorf("invalid output format '%s'. Valid options: default, json, csv, markdown, html", outputFormat)
+	}
+	comma, err := parseCSVDelimiter(delimiter)
+	if err != nil {
+		return err
+	}
+	csvDelimiter = comma
+
+	opts := subcommandScanOptions()
+	opts.IncludeWorkflows = splitList(includeWorkflows)
+	opts.ExcludeWorkflows = splitList(excludeWorkflows)
+	if err := opts.validate(); err != nil {
+		return err
+	}
+
+	matrix, err := buildVersionMatrix(organization, action, opts)
+	if err != nil {
+		return err
+	}
+	matrix.Report = reportBranding(nil, reportTitle, reportLogo, reportMeta)
+	reportComplete(organization, &matrix)
+
+	writer, file, err := getOutputWriter(outputFile)
+	if err != nil {
+		return fmt.Errorf("error opening output file: %v", err)
+	}
+	if file != nil {
+		defer file.Close()
+	}
+
+	return outputVersionMatrix(matrix, outputFormat, writer)
+}
+
+// buildVersionMatrix counts the versions of one action used in every repository of an organization
+func buildVersionMatrix(org, action string, opts scanOptions) (VersionMatrix, error) {
+	startTime := time.Now()
+
+	workflows, err := getWorkflowFiles(org, opts)
+	if err != nil {
+		return VersionMatrix{}, err
+	}
+
+	logInfof("📊 Building %s version matrix from %d workflow files...\n\n", action, len(workflows))
+
+	repoCounts := make(map[string]map[string]int) // repo -> version -> count
+	totals := make(map[string]int)
+	for _, wf := range workflows {
+		actions, err := extractActionsFromFile(org, wf.Repo, wf.Path)
+		if err != nil {
+			logWarnf("⚠️  Warning: Could not analyze %s/%s: %v\n", wf.Repo, wf.Path, err)
+			continue
+		}
+
+		for _, a := range actions {
+			if !strings.EqualFold(a.Name, action) {
+				continue
+			}
+			if repoCounts[wf.Repo] == nil {
+				repoCounts[wf.Repo] = make(map[string]int)
+			}
+			repoCounts[wf.Repo][a.Version]++
+			totals[a.Version]++
+		}
+	}
+
+	var versions []string
+	for version := range totals {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+
+	var repoNames []string
+	for name := range repoCounts {
+		repoNames = append(repoNames, name)
+	}
+	sort.Strings(repoNames)
+
+	matrix := VersionMatrix{
+		Organization: org,
+		Action:       action,
+		Versions:     versions,
+		Repositories: []VersionMatrixRow{},
+		Totals:       totals,
+	}
+	for _, name := range repoNames {
+		matrix.Repositories = append(matrix.Repositories, VersionMatrixRow{
+			Name:   name,
+			Counts: repoCounts[name],
+		})
+	}
+	matrix.ProcessTimeSeconds = time.Since(startTime).Seconds()
+
+	return matrix, nil
+}
+
+// outputVersionMatrix outputs the version matrix in the specified format
+func outputVersionMatrix(matrix VersionMatrix, format string, writer io.Writer) error {
+	if formatter, ok := lookupFormatter(format); ok {
+		return formatter.Format(matrix, writer)
+	}
+
+	switch format {
+	case "csv":
+		return outputVersionMatrixCSV(matrix, writer)
+
+	case "markdown":
+		return outputVersionMatrixMarkdown(matrix, writer)
+
+	case "html":
+		return outputVersionMatrixHTML(matrix, writer)
+
+	default: // "default"
+		fmt.Fprintf(writer, "📊 Version Matrix: %s\n", matrix.Action)
+		fmt.Fprintln(writer, "="+strings.Repeat("=", 50))
+
+		if len(matrix.Repositories) == 0 {
+			fmt.Fprintf(writer, "\nNo repositories use %s.\n", matrix.Action)
+			return nil
+		}
+
+		repoWidth := len("REPOSITORY")
+		for _, row := range matrix.Repositories {
+			if len(row.Name) > repoWidth {
+				repoWidth = len(row.Name)
+			}
+		}
+
+		fmt.Fprintf(writer, "\n%-*s", repoWidth, "REPOSITORY")
+		for _, version := range matrix.Versions {
+			fmt.Fprintf(writer, "  %*s", versionColumnWidth(version), "@"+version)
+		}
+		fmt.Fprintln(writer)
+
+		for _, row := range matrix.Repositories {
+			fmt.Fprintf(writer, "%-*s", repoWidth, row.Name)
+			for _, version := range matrix.Versions {
+				fmt.Fprintf(writer, "  %*s", versionColumnWidth(version), matrixCell(row.Counts[version]))
+			}
+			fmt.Fprintln(writer)
+		}
+
+		fmt.Fprintf(writer, "%-*s", repoWidth, "TOTAL")
+		for _, version := range matrix.Versions {
+			fmt.Fprintf(writer, "  %*d", versionColumnWidth(version), matrix.Totals[version])
+		}
+		fmt.Fprintln(writer)
+
+		fmt.Fprintf(writer, "\n📊 Summary: %d repositories use %d versions of %s\n",
+			len(matrix.Repositories), len(matrix.Versions), matrix.Action)
+		fmt.Fprintf(writer, "⏱️  Process time: %.3fs\n", matrix.ProcessTimeSeconds)
+		return nil
+	}
+}
+
+// versionColumnWidth returns the display width of a version column
+func versionColumnWidth(version string) int {
+	if len(version)+1 < 5 {
+		return 5
+	}
+	return len(version) + 1
+}
+
+// matrixCell renders a usage count, leaving unused cells blank
+func matrixCell(count int) string {
+	if count == 0 {
+		return "·"
+	}
+	return fmt.Sprintf("%d", count)
+}
+
+// outputVersionMatrixCSV outputs the version matrix in CSV format
+func outputVersionMatrixCSV(matrix VersionMatrix, writer io.Writer) error {
+	w := newCSVWriter(writer)
+	header := []string{"Repository"}
+	for _, version := range matrix.Versions {
+		header = append(header, "@"+version)
+	}
+	w.Write(header)
+
+	for _, row := range matrix.Repositories {
+		record := []string{row.Name}
+		for _, version := range matrix.Versions {
+			record = append(record, strconv.Itoa(row.Counts[version]))
+		}
+		w.Write(record)
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// outputVersionMatrixMarkdown outputs the version matrix as a GitHub-flavored Markdown table
+func outputVersionMatrixMarkdown(matrix VersionMatrix, writer io.Writer) error {
+	matrix.Report.writeMarkdown(writer)
+	fmt.Fprintf(writer, "## Version matrix: `%s`\n\n", matrix.Action)
+
+	if len(matrix.Repositories) == 0 {
+		fmt.Fprintf(writer, "No repositories in `%s` use `%s`.\n", matrix.Organization, matrix.Action)
+		return nil
+	}
+
+	fmt.Fprint(writer, "| Repository |")
+	for _, version := range matrix.Versions {
+		fmt.Fprintf(writer, " `@%s` |", version)
+	}
+	fmt.Fprint(writer, "\n|---|")
+	for range matrix.Versions {
+		fmt.Fprint(writer, "---:|")
+	}
+	fmt.Fprintln(writer)
+
+	for _, row := range matrix.Repositories {
+		fmt.Fprintf(writer, "| %s |", row.Name)
+		for _, version := range matrix.Versions {
+			if count := row.Counts[version]; count > 0 {
+				fmt.Fprintf(writer, " %d |", count)
+			} else {
+				fmt.Fprint(writer, "  |")
+			}
+		}
+		fmt.Fprintln(writer)
+	}
+
+	fmt.Fprint(writer, "| **Total** |")
+	for _, version := range matrix.Versions {
+		fmt.Fprintf(writer, " **%d** |", matrix.Totals[version])
+	}
+	fmt.Fprintln(writer)
+
+	fmt.Fprintf(writer, "\n_%d repositories in `%s` use %d versions of `%s`._\n",
+		len(matrix.Repositories), matrix.Organization, len(matrix.Versions), matrix.Action)
+	return nil
+}
+
+// outputVersionMatrixHTML outputs the version matrix as a self-contained HTML page
+func outputVersionMatrixHTML(matrix VersionMatrix, writer io.Writer) error {
+	title := html.EscapeString(matrix.Report.title(fmt.Sprintf("%s version matrix – %s", matrix.Action, matrix.Organization)))
+
+	fmt.Fprintln(writer, "<!DOCTYPE html>")
+	fmt.Fprintln(writer, "<html lang=\"en\">")
+	fmt.Fprintf(writer, "<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n", title)
+	fmt.Fprintln(writer, "<style>")
+	fmt.Fprintln(writer, "body{font-family:-apple-system,Segoe UI,Helvetica,Arial,sans-serif;margin:2rem;color:#1f2328}")
+	fmt.Fprintln(writer, "table{border-collapse:collapse}th,td{border:1px solid #d0d7de;padding:4px 10px}")
+	fmt.Fprintln(writer, "th{background:#f6f8fa}td.n{text-align:right}td.empty{background:#fafbfc}tfoot td{font-weight:bold}")
+	fmt.Fprintln(writer, "img.logo{max-height:64px}dl.metadata dt{font-weight:bold}dl.metadata dd{margin:0 0 4px}")
+	fmt.Fprintln(writer, "</style>\n</head>\n<body>")
+	fmt.Fprintf(writer, "<h1>%s</h1>\n", title)
+	matrix.Report.writeHTML(writer)
+
+	if len(matrix.Repositories) == 0 {
+		fmt.Fprintf(writer, "<p>No repositories use <code>%s</code>.</p>\n</body>\n</html>\n", html.EscapeString(matrix.Action))
+		return nil
+	}
+
+	fmt.Fprintln(writer, "<table>\n<thead><tr><th>Repository</th>")
+	for _, version := range matrix.Versions {
+		fmt.Fprintf(writer, "<th>@%s</th>", html.EscapeString(version))
+	}
+	fmt.Fprintln(writer, "</tr></thead>\n<tbody>")
+
+	for _, row := range matrix.Repositories {
+		fmt.Fprintf(writer, "<tr><td>%s</td>", html.EscapeString(row.Name))
+		for _, version := range matrix.Versions {
+			if count := row.Counts[version]; count > 0 {
+				fmt.Fprintf(writer, "<td class=\"n\">%d</td>", count)
+			} else {
+				fmt.Fprint(writer, "<td class=\"empty\"></td>")
+			}
+		}
+		fmt.Fprintln(writer, "</tr>")
+	}
+
+	fmt.Fprint(writer, "</tbody>\n<tfoot><tr><td>Total</td>")
+	for _, version := range matrix.Versions {
+		fmt.Fprintf(writer, "<td class=\"n\">%d</td>", matrix.Totals[version])
+	}
+	fmt.Fprintln(writer, "</tr></tfoot>\n</table>")
+	fmt.Fprintf(writer, "<p>%d repositories use %d versions.</p>\n</body>\n</html>\n",
+		len(matrix.Repositories), len(matrix.Versions))
+	return nil
+}
@@ -0,0 +1,496 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Migration outcomes of a repository
+const (
+	MigrationPlanned = "planned" // --dry-run: a pull request would be opened
+	MigrationOpened  = "opened"
+	MigrationExists  = "exists" // the migration branch already exists, e.g. from an earlier run
+	MigrationFailed  = "failed"
+)
+
+// defaultMigrationBranch is the branch the rewrites are committed to
+const defaultMigrationBranch = "action-lens/migrate-references"
+
+// usesLinePattern matches a `uses:` line: prefix, opening quote, reference, closing quote, comment, carriage return
+var usesLinePattern = regexp.MustCompile(`(?m)^([ \t]*(?:-[ \t]+)?uses:[ \t]*)(["']?)([^\s"'#]+)(["']?)([ \t]*#[^\r\n]*)?(\r?)$`)
+
+// MigrationReport is the result of the migrate command
+type MigrationReport struct {
+	Organization       string                `json:"organization"`
+	DryRun             bool                  `json:"dry_run"`
+	Branch             string                `json:"branch"`
+	Summary            MigrationSummary      `json:"summary"`
+	Repositories       []MigrationRepository `json:"repositories"`
+	ProcessTimeSeconds float64               `json:"process_time_seconds"`
+}
+
+// MigrationSummary represents summary statistics of a migration
+type MigrationSummary struct {
+	WorkflowsScanned    int `json:"workflows_scanned"`
+	WorkflowsChanged    int `json:"workflows_changed"`
+	RepositoriesChanged int `json:"repositories_changed"`
+	Rewrites            int `json:"rewrites"`
+	PullRequests        int `json:"pull_requests"`
+	Failed              int `json:"failed"`
+}
+
+// MigrationRepository is the pull request migrating the workflows of one repository
+type MigrationRepository struct {
+	Name        string              `json:"name"`
+	Status      string              `json:"status"`
+	PullRequest string              `json:"pull_request,omitempty"`
+	Error       string              `json:"error,omitempty"`
+	Workflows   []MigrationWorkflow `json:"workflows"`
+
+	contents  map[string]string // rewritten content by workflow path
+	originals map[string]string // content before the rewrites by workflow path
+}
+
+// MigrationWorkflow is a workflow file with its rewritten references
+type MigrationWorkflow struct {
+	Path    string           `json:"path"`
+	Changes []AppliedRewrite `json:"changes"`
+	Diff    string           `json:"diff,omitempty"` // unified diff of the change, in dry runs of the pin command
+}
+
+// AppliedRewrite is one rewritten `uses:` line
+type AppliedRewrite struct {
+	Line int    `json:"line"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// loadRewriteMap reads and validates a rewrite map file
+func loadRewriteMap(path string) (RewriteMap, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return RewriteMap{}, err
+	}
+	var rewrites RewriteMap
+	if err := yaml.Unmarshal(data, &rewrites); err != nil {
+		return RewriteMap{}, fmt.Errorf("failed to parse rewrite map: %v", err)
+	}
+	if len(rewrites.Rewrites) == 0 {
+		return RewriteMap{}, fmt.Errorf("rewrite map %s has no rewrites", path)
+	}
+	for i, rewrite := range rewrites.Rewrites {
+		if rewrite.From == "" || rewrite.To == "" {
+			return RewriteMap{}, fmt.Errorf("rewrite %d: both from and to are required", i+1)
+		}
+		fromOwner, fromWildcard := strings.CutSuffix(rewrite.From, "/*")
+		_, toWildcard := strings.CutSuffix(rewrite.To, "/*")
+		if fromWildcard != toWildcard || (fromWildcard && strings.Contains(fromOwner, "/")) {
+			return RewriteMap{}, fmt.Errorf("rewrite %d: owner renames are written as from: owner/* to: new-owner/*", i+1)
+		}
+		if !fromWildcard && !strings.Contains(actionRepository(strings.SplitN(rewrite.From, "@", 2)[0]), "/") {
+			return RewriteMap{}, fmt.Errorf("rewrite %d: '%s' is not an action or workflow reference", i+1, rewrite.From)
+		}
+	}
+	return rewrites, nil
+}
+
+// apply returns the reference replacing a `uses:` reference, or false if no rewrite matches. A rewrite
+// of action@ref takes precedence over one of the action's repository or path, the longest of which wins,
+// and those over an owner rename. Rewrites without a ref in `to` keep the original ref.
+func (m RewriteMap) apply(uses string) (string, bool) {
+	name, ref, ok := splitActionReference(uses)
+	if !ok || strings.HasPrefix(name, "./") || strings.HasPrefix(name, "docker://") {
+		return "", false
+	}
+
+	best, bestLength := "", -1
+	for _, rewrite := range m.Rewrites {
+		if fromName, fromRef, pinned := splitActionReference(rewrite.From); pinned {
+			if strings.EqualFold(fromName, name) && fromRef == ref {
+				if _, _, pinned := splitActionReference(rewrite.To); !pinned {
+					return rewrite.To + "@" + ref, true
+				}
+				return rewrite.To, true
+			}
+			continue
+		}
+
+		if owner, wildcard := strings.CutSuffix(rewrite.From, "/*"); wildcard {
+			if bestLength < 0 && strings.EqualFold(owner, strings.SplitN(name, "/", 2)[0]) {
+				best = strings.TrimSuffix(rewrite.To, "/*") + name[len(owner):] + "@" + ref
+				bestLength = 0
+			}
+			continue
+		}
+
+		if !strings.EqualFold(rewrite.From, name) && !strings.HasPrefix(strings.ToLower(name), strings.ToLower(rewrite.From)+"/") {
+			continue
+		}
+		if len(rewrite.From) <= bestLength {
+			continue
+		}
+		suffix := name[len(rewrite.From):]
+		if toName, toRef, pinned := splitActionReference(rewrite.To); pinned {
+			best = toName + suffix + "@" + toRef
+		} else {
+			best = rewrite.To + suffix + "@" + ref
+		}
+		bestLength = len(rewrite.From)
+	}
+	return best, bestLength >= 0
+}
+
+// rewriteWorkflow applies a rewrite map to the `uses:` lines of a workflow, keeping quotes and layout.
+// A reference rewritten to a commit SHA gets the original ref as a comment for update tools.
+func rewriteWorkflow(content string, rewrites RewriteMap) (string, []AppliedRewrite) {
+	var changes []AppliedRewrite
+	var builder strings.Builder
+	last := 0
+	for _, match := range usesLinePattern.FindAllStringSubmatchIndex(content, -1) {
+		group := func(i int) string {
+			if match[2*i] < 0 {
+				return ""
+			}
+			return content[match[2*i]:match[2*i+1]]
+		}
+		uses := group(3)
+		replacement, ok := rewrites.apply(uses)
+		if !ok || replacement == uses {
+			continue
+		}
+
+		comment := group(5)
+		_, oldRef, _ := splitActionReference(uses)
+		_, newRef, _ := splitActionReference(replacement)
+		switch {
+		case isPinnedToSHA(newRef) && !isPinnedToSHA(oldRef):
+			comment = " # " + oldRef
+		case !isPinnedToSHA(newRef) && isPinnedToSHA(oldRef):
+			comment = "" // the version comment described the old SHA
+		}
+
+		builder.WriteString(content[last:match[0]])
+		builder.WriteString(group(1) + group(2) + replacement + group(4) + comment + group(6))
+		last = match[1]
+		changes = append(changes, AppliedRewrite{
+			Line: strings.Count(content[:match[0]], "\n") + 1,
+			From: uses,
+			To:   replacement,
+		})
+	}
+	if len(changes) == 0 {
+		return content, nil
+	}
+	builder.WriteString(content[last:])
+	return builder.String(), changes
+}
+
+// runMigrateCommand implements `gh action-lens migrate`
+func runMigrateCommand(args []string) error {
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+
+	var organization string
+	var mapFile string
+	var branch string
+	var title string
+	var outputFormat string
+	var includeWorkflows string
+	var excludeWorkflows string
+	var skipRepos string
+	var dryRun bool
+
+	fs.StringVar(&organization, "org", "", "Organization whose workflows are migrated")
+	fs.StringVar(&organization, "o", "", "Organization whose workflows are migrated")
+	fs.StringVar(&mapFile, "map", "", "Rewrite map file (YAML or JSON) of old → new action references")
+	fs.StringVar(&branch, "branch", defaultMigrationBranch, "Branch the rewrites are committed to")
+	fs.StringVar(&title, "title", "Migrate GitHub Actions references", "Title of the pull requests")
+	fs.StringVar(&outputFormat, "format", "default", "Output format: default, json")
+	fs.StringVar(&outputFormat, "f", "default", "Output format: default, json")
+	fs.StringVar(&includeWorkflows, "include-workflows", "", "Only migrate workflow files matching these comma-separated glob patterns")
+	fs.StringVar(&excludeWorkflows, "exclude-workflows", "", "Skip workflow files matching these comma-separated glob patterns")
+	fs.StringVar(&skipRepos, "skip-repos", "", "Comma-separated repository names to leave unchanged")
+	fs.BoolVar(&dryRun, "dry-run", false, "Print the rewrites without opening pull requests")
+
+	addHostnameFlag(fs)
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "\nUsage:\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens migrate --org <org> --map <file> [flags]\n\n")
+		fmt.Fprintf(os.Stderr, "Rewrite action and reusable workflow references across an organization and open one pull\n")
+		fmt.Fprintf(os.Stderr, "request per affected repository.\n\n")
+		fmt.Fprintf(os.Stderr, "Flags:\n")
+		fmt.Fprintf(os.Stderr, "  -o, --org <string>\n")
+		fmt.Fprintf(os.Stderr, "        Organization whose workflows are migrated\n\n")
+		fmt.Fprintf(os.Stderr, "      --map <path>\n")
+		fmt.Fprintf(os.Stderr, "        Rewrite map file (YAML or JSON) of old → new action references\n\n")
+		fmt.Fprintf(os.Stderr, "      --branch <string>\n")
+		fmt.Fprintf(os.Stderr, "        Branch the rewrites are committed to (default %q)\n\n", defaultMigrationBranch)
+		fmt.Fprintf(os.Stderr, "      --title <string>\n")
+		fmt.Fprintf(os.Stderr, "        Title of the pull requests (default \"Migrate GitHub Actions references\")\n\n")
+		fmt.Fprintf(os.Stderr, "  -f, --format <string>\n")
+		fmt.Fprintf(os.Stderr, "        Output format: default, json (default \"default\")\n\n")
+		fmt.Fprintf(os.Stderr, "      --include-workflows <patterns>\n")
+		fmt.Fprintf(os.Stderr, "        Only migrate workflow files matching these comma-separated glob patterns\n\n")
+		fmt.Fprintf(os.Stderr, "      --exclude-workflows <patterns>\n")
+		fmt.Fprintf(os.Stderr, "        Skip workflow files matching these comma-separated glob patterns\n\n")
+		fmt.Fprintf(os.Stderr, "      --skip-repos <list>\n")
+		fmt.Fprintf(os.Stderr, "        Comma-separated repository names to leave unchanged\n\n")
+		fmt.Fprintf(os.Stderr, "      --dry-run\n")
+		fmt.Fprintf(os.Stderr, "        Print the rewrites without opening pull requests\n\n")
+		fmt.Fprintf(os.Stderr, "Map file:\n")
+		fmt.Fprintf(os.Stderr, "  rewrites:\n")
+		fmt.Fprintf(os.Stderr, "    - from: docker/login-action@v3          # one ref\n")
+		fmt.Fprintf(os.Stderr, "      to: myorg-actions/login-action@9780b0c442fbb1117ed29e0efdff1e18412f7567\n")
+		fmt.Fprintf(os.Stderr, "    - from: myorg/deprecated-action         # every ref, kept as is\n")
+		fmt.Fprintf(os.Stderr, "      to: myorg/new-action\n")
+		fmt.Fprintf(os.Stderr, "    - from: oldorg/*                        # organization rename\n")
+		fmt.Fprintf(os.Stderr, "      to: neworg/*\n\n")
+		fmt.Fprintf(os.Stderr, "      --hostname <host>\n")
+		fmt.Fprintf(os.Stderr, "        GitHub host, e.g. a GitHub Enterprise Server (default: GH_HOST or the gh default host)\n\n")
+		fmt.Fprintf(os.Stderr, "Examples:\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens migrate -o myorg --map rewrite-map.yml --dry-run\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens migrate -o myorg --map rewrite-map.yml --skip-repos legacy-app\n\n")
+	}
+
+	fs.Parse(args)
+
+	if organization == "" || mapFile == "" {
+		fs.Usage()
+		return fmt.Errorf("both --org and --map are required")
+	}
+	switch outputFormat {
+	case "default", "json":
+	default:
+		return fmt.Errorf("invalid output format '%s'. Valid options: default, json", outputFormat)
+	}
+
+	rewrites, err := loadRewriteMap(mapFile)
+	if err != nil {
+		return err
+	}
+	opts := rewriteScanOptions(includeWorkflows, excludeWorkflows, skipRepos)
+	if err := opts.validate(); err != nil {
+		return err
+	}
+
+	startTime := time.Now()
+	report, err := planMigration(organization, func(content string) (string, []AppliedRewrite) {
+		return rewriteWorkflow(content, rewrites)
+	}, opts)
+	if err != nil {
+		return err
+	}
+	report.DryRun = dryRun
+	report.Branch = branch
+
+	for i := range report.Repositories {
+		repo := &report.Repositories[i]
+		if dryRun {
+			repo.Status = MigrationPlanned
+			continue
+		}
+		logInfof("🔀 Opening pull request for %s...\n", repo.Name)
+		if err := openMigrationPullRequest(organization, repo, branch, title, migrationPullRequestBody(*repo)); err != nil {
+			repo.Status = MigrationFailed
+			repo.Error = err.Error()
+			report.Summary.Failed++
+			continue
+		}
+		if repo.Status == MigrationOpened {
+			report.Summary.PullRequests++
+		}
+	}
+	report.ProcessTimeSeconds = time.Since(startTime).Seconds()
+
+	if outputFormat == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		outputMigrationReport(report, os.Stdout)
+	}
+
+	if report.Summary.Failed > 0 {
+		return fmt.Errorf("%d of %d pull requests could not be opened", report.Summary.Failed, len(report.Repositories))
+	}
+	return nil
+}
+
+// rewriteScanOptions returns the scan options of the commands that open pull requests against an
+// organization's repositories: --skip-repos names repositories to leave unchanged, and forks and archived
+// repositories, which are read-only or not the organization's own, are always skipped
+func rewriteScanOptions(includeWorkflows, excludeWorkflows, skipRepos string) scanOptions {
+	opts := subcommandScanOptions()
+	opts.IncludeWorkflows = splitList(includeWorkflows)
+	opts.ExcludeWorkflows = splitList(excludeWorkflows)
+	opts.ExcludeRepositories = splitList(skipRepos)
+	return opts
+}
+
+// planMigration rewrites every workflow of an organization, e.g. with a rewrite map, and returns the
+// changes per repository
+func planMigration(org string, rewrite func(content string) (string, []AppliedRewrite), opts scanOptions) (MigrationReport, error) {
+	repositories, _, err := listRepositoryWorkflows(org, opts)
+	if err != nil {
+		return MigrationReport{}, err
+	}
+	logInfof("🔍 Rewriting references in the workflows of %d repositories...\n\n", len(repositories))
+
+	report := MigrationReport{Organization: org, Repositories: []MigrationRepository{}}
+	for _, repo := range repositories {
+		migration := MigrationRepository{Name: repo.Name, Workflows: []MigrationWorkflow{},
+			contents: make(map[string]string), originals: make(map[string]string)}
+		for _, workflowPath := range repo.Workflows {
+			content, err := fetchWorkflowContent(org, repo.Name, workflowPath)
+			if err != nil {
+				logWarnf("⚠️  Warning: Could not read %s/%s: %v\n", repo.Name, workflowPath, err)
+				continue
+			}
+			report.Summary.WorkflowsScanned++
+
+			rewritten, changes := rewrite(content)
+			if len(changes) == 0 {
+				continue
+			}
+			migration.contents[workflowPath] = rewritten
+			migration.originals[workflowPath] = content
+			migration.Workflows = append(migration.Workflows, MigrationWorkflow{Path: workflowPath, Changes: changes})
+			report.Summary.WorkflowsChanged++
+			report.Summary.Rewrites += len(changes)
+		}
+		if len(migration.Workflows) > 0 {
+			report.Repositories = append(report.Repositories, migration)
+		}
+	}
+	report.Summary.RepositoriesChanged = len(report.Repositories)
+	return report, nil
+}
+
+// openMigrationPullRequest commits the rewritten workflows of a repository to a new branch in a single
+// commit and opens a pull request with the given body against the default branch
+func openMigrationPullRequest(org string, repo *MigrationRepository, branch, title, description string) error {
+	repository := org + "/" + repo.Name
+	if _, err := lookupGitRef(repository, "heads/"+branch); err == nil {
+		repo.Status = MigrationExists
+		return nil
+	}
+
+	var metadata struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := restGet("repos/"+repository, &metadata); err != nil {
+		return fmt.Errorf("could not read the repository: %v", err)
+	}
+	base, err := lookupGitRef(repository, "heads/"+metadata.DefaultBranch)
+	if err != nil {
+		return fmt.Errorf("could not resolve %s: %v", metadata.DefaultBranch, err)
+	}
+
+	var paths []string
+	for path := range repo.contents {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	var entries []map[string]string
+	for _, path := range paths {
+		entries = append(entries, map[string]string{"path": path, "mode": "100644", "type": "blob", "content": repo.contents[path]})
+	}
+
+	var tree, commit struct {
+		SHA string `json:"sha"`
+	}
+	if err := restSend("POST", "repos/"+repository+"/git/trees", map[string]interface{}{"base_tree": base, "tree": entries}, &tree); err != nil {
+		return fmt.Errorf("could not create the tree: %v", err)
+	}
+	message := fmt.Sprintf("%s\n\nRewrites %d references in %d workflow files.", title, countMigrationChanges(*repo), len(repo.Workflows))
+	if err := restSend("POST", "repos/"+repository+"/git/commits", map[string]interface{}{"message": message, "tree": tree.SHA, "parents": []string{base}}, &commit); err != nil {
+		return fmt.Errorf("could not create the commit: %v", err)
+	}
+	if err := restSend("POST", "repos/"+repository+"/git/refs", map[string]string{"ref": "refs/heads/" + branch, "sha": commit.SHA}, nil); err != nil {
+		return fmt.Errorf("could not create branch %s: %v", branch, err)
+	}
+
+	var pull struct {
+		HTMLURL string `json:"html_url"`
+	}
+	body := map[string]string{"title": title, "head": branch, "base": metadata.DefaultBranch, "body": description}
+	if err := restSend("POST", "repos/"+repository+"/pulls", body, &pull); err != nil {
+		return fmt.Errorf("could not open the pull request: %v", err)
+	}
+	repo.Status = MigrationOpened
+	repo.PullRequest = pull.HTMLURL
+	return nil
+}
+
+// countMigrationChanges returns the number of rewritten references of a repository
+func countMigrationChanges(repo MigrationRepository) int {
+	count := 0
+	for _, workflow := range repo.Workflows {
+		count += len(workflow.Changes)
+	}
+	return count
+}
+
+// migrationPullRequestBody lists the rewritten references of a repository as Markdown
+func migrationPullRequestBody(repo MigrationRepository) string {
+	var b strings.Builder
+	b.WriteString("This pull request rewrites action and reusable workflow references as listed below.\n\n")
+	b.WriteString("| Workflow | Line | From | To |\n|---|---:|---|---|\n")
+	for _, workflow := range repo.Workflows {
+		for _, change := range workflow.Changes {
+			fmt.Fprintf(&b, "| `%s` | %d | `%s` | `%s` |\n", markdownCell(workflow.Path), change.Line, change.From, change.To)
+		}
+	}
+	b.WriteString("\n_Opened by gh-action-lens migrate._\n")
+	return b.String()
+}
+
+// outputMigrationReport outputs the migration plan or outcome
+func outputMigrationReport(report MigrationReport, writer io.Writer) {
+	fmt.Fprintln(writer, "\n🔀 Reference Migration")
+	fmt.Fprintln(writer, "="+strings.Repeat("=", 60))
+	if report.DryRun {
+		fmt.Fprintln(writer, "🧪 Dry run: no pull requests were opened")
+	}
+
+	icons := map[string]string{MigrationPlanned: "📝", MigrationOpened: "✅", MigrationExists: "♻️ ", MigrationFailed: "❌"}
+	for _, repo := range report.Repositories {
+		fmt.Fprintf(writer, "\n%s %s (%s)", icons[repo.Status], repo.Name, repo.Status)
+		switch {
+		case repo.PullRequest != "":
+			fmt.Fprintf(writer, ": %s", repo.PullRequest)
+		case repo.Status == MigrationExists:
+			fmt.Fprintf(writer, ": branch %s already exists", report.Branch)
+		}
+		fmt.Fprintln(writer)
+		if repo.Error != "" {
+			fmt.Fprintf(writer, "   ⚠️  %s\n", repo.Error)
+		}
+		for _, workflow := range repo.Workflows {
+			fmt.Fprintf(writer, "   📄 %s\n", workflow.Path)
+			for _, change := range workflow.Changes {
+				fmt.Fprintf(writer, "      ├── line %d: %s → %s\n", change.Line, change.From, change.To)
+			}
+		}
+	}
+
+	fmt.Fprintln(writer, "\n📊 Summary:")
+	fmt.Fprintf(writer, "   • Workflows scanned: %d\n", report.Summary.WorkflowsScanned)
+	fmt.Fprintf(writer, "   • References rewritten: %d in %d workflows of %d repositories\n",
+		report.Summary.Rewrites, report.Summary.WorkflowsChanged, report.Summary.RepositoriesChanged)
+	if !report.DryRun {
+		fmt.Fprintf(writer, "   • Pull requests opened: %d (%d failed)\n", report.Summary.PullRequests, report.Summary.Failed)
+	}
+	fmt.Fprintf(writer, "   ⏱️  Process time: %.3fs\n\n", report.ProcessTimeSeconds)
+}
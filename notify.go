@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"os"
+	"strings"
+	"time"
+)
+
+// NotificationConfig configures the channels messages such as digests are sent to
+type NotificationConfig struct {
+	SlackWebhook string       `yaml:"slack-webhook"` // Slack incoming webhook URL
+	Email        *EmailConfig `yaml:"email"`
+}
+
+// EmailConfig configures delivery over SMTP. The password is read from GH_ACTION_LENS_SMTP_PASSWORD.
+type EmailConfig struct {
+	SMTPHost string   `yaml:"smtp-host"` // host:port
+	Username string   `yaml:"username"`
+	From     string   `yaml:"from"`
+	To       []string `yaml:"to"`
+}
+
+// notificationTimeout bounds each webhook delivery
+const notificationTimeout = 10 * time.Second
+
+// configured reports whether at least one channel is set up
+func (n *NotificationConfig) configured() bool {
+	return n != nil && (n.SlackWebhook != "" || n.Email != nil)
+}
+
+// validate checks that configured channels are complete
+func (n *NotificationConfig) validate() error {
+	if n == nil || n.Email == nil {
+		return nil
+	}
+	if n.Email.SMTPHost == "" || n.Email.From == "" || len(n.Email.To) == 0 {
+		return fmt.Errorf("notifications.email requires smtp-host, from, and to")
+	}
+	if _, _, err := net.SplitHostPort(n.Email.SMTPHost); err != nil {
+		return fmt.Errorf("notifications.email.smtp-host must be host:port: %v", err)
+	}
+	return nil
+}
+
+// send delivers a message to every configured channel. The Markdown body is used as-is for
+// Slack and as plain text for email.
+func (n *NotificationConfig) send(subject, body string) error {
+	if !n.configured() {
+		return fmt.Errorf("no notification channels configured")
+	}
+
+	var failures []string
+	if n.SlackWebhook != "" {
+		if err := sendSlackMessage(n.SlackWebhook, "*"+subject+"*\n"+body); err != nil {
+			failures = append(failures, fmt.Sprintf("slack: %v", err))
+		}
+	}
+	if n.Email != nil {
+		if err := sendEmail(*n.Email, subject, body); err != nil {
+			failures = append(failures, fmt.Sprintf("email: %v", err))
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("notification failed: %s", strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// sendSlackMessage posts a message to a Slack incoming webhook
+func sendSlackMessage(webhook, text string) error {
+	payload, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{Timeout: notificationTimeout}
+	resp, err := client.Post(webhook, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// sendEmail sends a plain-text message over SMTP
+func sendEmail(config EmailConfig, subject, body string) error {
+	host, _, _ := net.SplitHostPort(config.SMTPHost)
+
+	var auth smtp.Auth
+	if config.Username != "" {
+		auth = smtp.PlainAuth("", config.Username, os.Getenv("GH_ACTION_LENS_SMTP_PASSWORD"), host)
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", config.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(config.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", subject)
+	fmt.Fprintf(&msg, "Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+
+	return smtp.SendMail(config.SMTPHost, auth, config.From, config.To, []byte(msg.String()))
+}
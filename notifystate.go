@@ -0,0 +1,180 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxNotifiedFindings bounds the findings listed per section of a notification; the counts stay exact
+const maxNotifiedFindings = 50
+
+// notificationState records, per organization and scan, the findings already notified, keyed by
+// fingerprint, so reruns only notify on new and resolved findings
+type notificationState struct {
+	path   string
+	Scopes map[string]map[string]Finding `json:"scopes"` // org|scan -> fingerprint -> finding
+}
+
+// findingFingerprint is the stable identity of a finding across scans, the same one SARIF results carry
+func findingFingerprint(f Finding) string {
+	sum := sha256.Sum256([]byte(findingKey(f)))
+	return hex.EncodeToString(sum[:])
+}
+
+// notificationStatePath returns the state file: path when set, otherwise notified.json in the cache
+// directory; "" when neither is known
+func notificationStatePath(path, cacheDir string) string {
+	if path != "" {
+		return path
+	}
+	if cacheDir == "" {
+		cacheDir = defaultCacheDir()
+	}
+	if cacheDir == "" {
+		return ""
+	}
+	return filepath.Join(cacheDir, "notified.json")
+}
+
+// loadNotificationState reads the notification state; a missing file is an empty state
+func loadNotificationState(path string) (*notificationState, error) {
+	state := &notificationState{path: path, Scopes: make(map[string]map[string]Finding)}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read notification state: %v", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse notification state %s: %v", path, err)
+	}
+	if state.Scopes == nil {
+		state.Scopes = make(map[string]map[string]Finding)
+	}
+	return state, nil
+}
+
+// delta returns the findings not notified yet and the notified findings that are gone
+func (s *notificationState) delta(scope string, findings []Finding) (added, resolved []Finding) {
+	notified := s.Scopes[scope]
+	current := make(map[string]bool, len(findings))
+	for _, finding := range findings {
+		fingerprint := findingFingerprint(finding)
+		current[fingerprint] = true
+		if _, ok := notified[fingerprint]; !ok {
+			added = append(added, finding)
+		}
+	}
+	for fingerprint, finding := range notified {
+		if !current[fingerprint] {
+			resolved = append(resolved, finding)
+		}
+	}
+	normalizeFindings(resolved)
+	return added, resolved
+}
+
+// record replaces the notified findings of a scope with the current findings
+func (s *notificationState) record(scope string, findings []Finding) {
+	notified := make(map[string]Finding, len(findings))
+	for _, finding := range findings {
+		notified[findingFingerprint(finding)] = finding
+	}
+	s.Scopes[scope] = notified
+}
+
+// add records findings as notified, keeping the findings notified before
+func (s *notificationState) add(scope string, findings []Finding) {
+	if s.Scopes[scope] == nil {
+		s.Scopes[scope] = make(map[string]Finding, len(findings))
+	}
+	for _, finding := range findings {
+		s.Scopes[scope][findingFingerprint(finding)] = finding
+	}
+}
+
+// save writes the notification state
+func (s *notificationState) save() error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	// The state holds finding details, so it is readable by the user only, like the caches
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return err
+	}
+	if err := os.WriteFile(s.path, data, 0o600); err != nil {
+		return err
+	}
+	return os.Chmod(s.path, 0o600)
+}
+
+// notifyFindingChanges sends the new and resolved findings of a scan to the configured notification
+// channels and records them as notified. Nothing is sent when nothing changed; the state is only
+// updated after a successful delivery, so a failed one is retried on the next run.
+func (o scanOptions) notifyFindingChanges(org string, findings []Finding) (added, resolved int, err error) {
+	state, err := loadNotificationState(o.NotifyState)
+	if err != nil {
+		return 0, 0, err
+	}
+	scope := org + "|" + o.Scope
+	newFindings, resolvedFindings := state.delta(scope, findings)
+	// A timed-out, interrupted, or filtered scan did not see every repository, so missing findings are not resolved
+	partial := !o.coversOrganization()
+	if partial {
+		resolvedFindings = nil
+	}
+	if len(newFindings) == 0 && len(resolvedFindings) == 0 {
+		return 0, 0, nil
+	}
+
+	subject := fmt.Sprintf("gh-action-lens %s findings for %s: %d new, %d resolved (%s)",
+		o.Scope, org, len(newFindings), len(resolvedFindings), time.Now().Format("2006-01-02"))
+	if err := o.Config.Notifications.send(subject, findingChangesMarkdown(newFindings, resolvedFindings, len(findings))); err != nil {
+		return 0, 0, err
+	}
+
+	if partial {
+		state.add(scope, findings)
+	} else {
+		state.record(scope, findings)
+	}
+	if err := state.save(); err != nil {
+		return 0, 0, fmt.Errorf("failed to save notification state: %v", err)
+	}
+	return len(newFindings), len(resolvedFindings), nil
+}
+
+// findingChangesMarkdown renders the new and resolved findings sent to notification channels
+func findingChangesMarkdown(added, resolved []Finding, total int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "- New findings: %d\n", len(added))
+	writeNotifiedFindings(&b, added)
+	fmt.Fprintf(&b, "- Resolved findings: %d\n", len(resolved))
+	writeNotifiedFindings(&b, resolved)
+	fmt.Fprintf(&b, "- Open findings: %d\n", total)
+	return b.String()
+}
+
+// writeNotifiedFindings lists findings, most severe first, up to maxNotifiedFindings
+func writeNotifiedFindings(b *strings.Builder, findings []Finding) {
+	sorted := append([]Finding{}, findings...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return severityRank(sorted[i].Severity) > severityRank(sorted[j].Severity)
+	})
+	for i, f := range sorted {
+		if i == maxNotifiedFindings {
+			fmt.Fprintf(b, "  - … and %d more\n", len(sorted)-maxNotifiedFindings)
+			break
+		}
+		fmt.Fprintf(b, "  - %s `%s` %s/%s: %s\n", strings.TrimSpace(severityIcon(f.Severity)), f.RuleID, f.Repository, f.Workflow, f.Message)
+	}
+}
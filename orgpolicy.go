@@ -0,0 +1,448 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Decisions of the organization's Actions permissions on an action reference
+const (
+	PolicyAllowed      = "allowed"
+	PolicyBlocked      = "blocked"
+	PolicyVerifiedOnly = "verified-creator" // allowed only if the action is a Marketplace action of a verified creator
+)
+
+// OrgActionsPolicy is the Actions permissions setting of an organization, read from
+// orgs/{org}/actions/permissions and, for allowed_actions "selected", its selected-actions
+type OrgActionsPolicy struct {
+	EnabledRepositories string   `json:"enabled_repositories"` // all, none, or selected
+	AllowedActions      string   `json:"allowed_actions"`      // all, local_only, or selected
+	SHAPinningRequired  bool     `json:"sha_pinning_required"`
+	GitHubOwnedAllowed  bool     `json:"github_owned_allowed"`
+	VerifiedAllowed     bool     `json:"verified_allowed"`
+	PatternsAllowed     []string `json:"patterns_allowed"`
+}
+
+// ActionsPermissionsReport compares the actions the workflows of an organization use with its Actions
+// permissions: the references the setting blocks and the allowed patterns nothing uses
+type ActionsPermissionsReport struct {
+	Organization          string                     `json:"organization"`
+	Policy                OrgActionsPolicy           `json:"policy"`
+	Summary               ActionsPermissionsSummary  `json:"summary"`
+	Actions               []ActionPermissionDecision `json:"actions"`         // every action@ref, blocked first
+	UnusedPatterns        []string                   `json:"unused_patterns"` // allowed patterns matching no reference
+	Findings              []Finding                  `json:"findings"`
+	Truncated             bool                       `json:"truncated"`
+	RemainingRepositories []string                   `json:"remaining_repositories,omitempty"`
+	ProcessTimeSeconds    float64                    `json:"process_time_seconds"`
+}
+
+// ActionsPermissionsSummary represents summary statistics of the Actions permissions comparison
+type ActionsPermissionsSummary struct {
+	WorkflowsScanned     int `json:"workflows_scanned"`
+	ActionsChecked       int `json:"actions_checked"` // distinct action@ref
+	Allowed              int `json:"allowed"`
+	Blocked              int `json:"blocked"`
+	VerifiedOnly         int `json:"verified_only"`
+	BlockedUsages        int `json:"blocked_usages"`
+	AffectedWorkflows    int `json:"affected_workflows"`
+	AffectedRepositories int `json:"affected_repositories"`
+	UnusedPatterns       int `json:"unused_patterns"`
+}
+
+// ActionPermissionDecision is the decision of the Actions permissions on an action@ref with its usages
+type ActionPermissionDecision struct {
+	Action       string   `json:"action"`
+	Ref          string   `json:"ref"`
+	Decision     string   `json:"decision"`          // allowed, blocked, or verified-creator
+	Reason       string   `json:"reason"`            // why the reference is allowed or blocked
+	Pattern      string   `json:"pattern,omitempty"` // allowed pattern matching the reference
+	Usages       int      `json:"usages"`
+	Workflows    int      `json:"workflows"`
+	Repositories []string `json:"repositories"`
+}
+
+// fetchOrgActionsPolicy reads the Actions permissions of an organization; it requires an organization owner
+// or a token with the admin:org scope
+func fetchOrgActionsPolicy(org string) (OrgActionsPolicy, error) {
+	var policy OrgActionsPolicy
+	if err := restGet("orgs/"+org+"/actions/permissions", &policy); err != nil {
+		return policy, fmt.Errorf("could not read the Actions permissions of %s (organization owners or the admin:org scope can): %v", org, err)
+	}
+	if policy.AllowedActions != "selected" {
+		return policy, nil
+	}
+	if err := restGet("orgs/"+org+"/actions/permissions/selected-actions", &policy); err != nil {
+		return policy, fmt.Errorf("could not read the selected actions of %s: %v", org, err)
+	}
+	return policy, nil
+}
+
+// allowedPatternMatches reports whether an allowed actions pattern matches a reference. A * matches any
+// characters; a pattern without @ matches every ref. Owner and repository compare case-insensitively.
+func allowedPatternMatches(pattern, name, ref string) bool {
+	patternName, patternRef, hasRef := strings.Cut(pattern, "@")
+	if !globMatches(patternName, name, true) {
+		return false
+	}
+	return !hasRef || globMatches(patternRef, ref, false)
+}
+
+// globMatches matches a value against a pattern in which * matches any characters, including /
+func globMatches(pattern, value string, foldCase bool) bool {
+	expression := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*") + "$"
+	if foldCase {
+		expression = "(?i)" + expression
+	}
+	matched, err := regexp.MatchString(expression, value)
+	return err == nil && matched
+}
+
+// decide returns the decision of the policy on a reference, the reason, and the matching pattern
+func (p OrgActionsPolicy) decide(org, name, ref string) (string, string, string) {
+	owner := strings.ToLower(strings.SplitN(name, "/", 2)[0])
+	local := strings.EqualFold(owner, org)
+
+	decision, reason, pattern := PolicyAllowed, "", ""
+	switch {
+	case p.EnabledRepositories == "none":
+		return PolicyBlocked, "GitHub Actions is disabled for the organization's repositories", ""
+	case p.AllowedActions == "all":
+		reason = "all actions are allowed"
+	case local:
+		reason = "actions of the organization are always allowed"
+	case p.AllowedActions == "local_only":
+		return PolicyBlocked, "only actions and reusable workflows of the organization are allowed", ""
+	case p.GitHubOwnedAllowed && actionClass(name, org) == actionClassGitHub:
+		reason = "actions created by GitHub are allowed"
+	default:
+		for _, allowed := range p.PatternsAllowed {
+			if allowedPatternMatches(allowed, name, ref) {
+				pattern = allowed
+				break
+			}
+		}
+		switch {
+		case pattern != "":
+			reason = "matches an allowed pattern"
+		case p.VerifiedAllowed:
+			return PolicyVerifiedOnly, "allowed only if published on the Marketplace by a verified creator", ""
+		default:
+			reason = "no allowed pattern matches"
+			if actionClass(name, org) == actionClassGitHub {
+				reason = "actions created by GitHub are not allowed and no allowed pattern matches"
+			}
+			return PolicyBlocked, reason, ""
+		}
+	}
+	if p.SHAPinningRequired && !isPinnedToSHA(ref) {
+		return PolicyBlocked, "the organization requires actions pinned to a full-length commit SHA", pattern
+	}
+	return decision, reason, pattern
+}
+
+// blockedActionFinding flags a workflow using a reference the organization's Actions permissions block
+func blockedActionFinding(repo, workflow string, decision ActionPermissionDecision) Finding {
+	return Finding{
+		RuleID:     RuleBlockedByOrgPolicy,
+		Severity:   SeverityError,
+		Repository: repo,
+		Workflow:   workflow,
+		Action:     decision.Action,
+		Version:    decision.Ref,
+		Message:    fmt.Sprintf("%s@%s is blocked by the organization's Actions permissions: %s", decision.Action, decision.Ref, decision.Reason),
+	}
+}
+
+// analyzeActionsPermissions reads the Actions permissions of an organization and checks every action and
+// reusable workflow reference of its workflows against them
+func analyzeActionsPermissions(org string, startTime time.Time, outputFormat, outputFile string, opts scanOptions) error {
+	policy, err := fetchOrgActionsPolicy(org)
+	if err != nil {
+		return err
+	}
+
+	repositories, _, err := listRepositoryWorkflows(org, opts)
+	if err != nil {
+		return err
+	}
+
+	var files []WorkflowFile
+	for _, repo := range repositories {
+		for _, workflowPath := range repo.Workflows {
+			files = append(files, WorkflowFile{Repo: repo.Name, Path: workflowPath})
+		}
+	}
+
+	logInfof("🛂 Checking %d workflow files against the Actions permissions of %s (allowed actions: %s)...\n\n", len(files), org, policy.AllowedActions)
+
+	results := fetchWorkflows(org, files, opts, func(wf WorkflowFile, result workflowFetch) {
+		if result.Err != nil {
+			logWarnf("⚠️  Warning: Could not analyze %s/%s: %v\n", wf.Repo, wf.Path, result.Err)
+		}
+	})
+
+	report := ActionsPermissionsReport{
+		Organization:   org,
+		Policy:         policy,
+		Actions:        []ActionPermissionDecision{},
+		UnusedPatterns: []string{},
+		Findings:       []Finding{},
+	}
+	if report.Policy.PatternsAllowed == nil {
+		report.Policy.PatternsAllowed = []string{}
+	}
+
+	var skipped []WorkflowFile
+	actionIndex := make(map[string]int)
+	actionWorkflows := make(map[string]map[string]bool)
+	actionRepositories := make(map[string]map[string]bool)
+	affectedWorkflows := make(map[string]bool)
+	affectedRepositories := make(map[string]bool)
+	usedPatterns := make(map[string]bool)
+	flagged := make(map[string]bool)
+	for i, result := range results {
+		if result.Skipped {
+			skipped = append(skipped, files[i])
+			continue
+		}
+		if result.Err != nil {
+			continue
+		}
+		wf := files[i]
+		report.Summary.WorkflowsScanned++
+
+		for _, action := range result.Actions {
+			// Local actions and container images are not governed by the setting
+			if strings.HasPrefix(action.Name, "./") || strings.HasPrefix(action.Name, "docker://") {
+				continue
+			}
+			reference := action.Name + "@" + action.Version
+			j, ok := actionIndex[reference]
+			if !ok {
+				decision, reason, pattern := policy.decide(org, action.Name, action.Version)
+				j = len(report.Actions)
+				actionIndex[reference] = j
+				actionWorkflows[reference] = make(map[string]bool)
+				actionRepositories[reference] = make(map[string]bool)
+				report.Actions = append(report.Actions, ActionPermissionDecision{
+					Action: action.Name, Ref: action.Version, Decision: decision, Reason: reason, Pattern: pattern,
+				})
+			}
+			decision := &report.Actions[j]
+			decision.Usages++
+			actionWorkflows[reference][wf.Repo+"/"+wf.Path] = true
+			actionRepositories[reference][wf.Repo] = true
+
+			// A pattern is used when it matches any reference, not only the first one deciding it
+			for _, allowed := range policy.PatternsAllowed {
+				if allowedPatternMatches(allowed, action.Name, action.Version) {
+					usedPatterns[allowed] = true
+				}
+			}
+
+			if decision.Decision != PolicyBlocked {
+				continue
+			}
+			report.Summary.BlockedUsages++
+			affectedWorkflows[wf.Repo+"/"+wf.Path] = true
+			affectedRepositories[wf.Repo] = true
+
+			// One finding per reference and workflow, however often the workflow uses it
+			if key := wf.Repo + "|" + wf.Path + "|" + reference; !flagged[key] {
+				flagged[key] = true
+				report.Findings = append(report.Findings, blockedActionFinding(wf.Repo, wf.Path, *decision))
+			}
+		}
+	}
+	report.RemainingRepositories = remainingRepositories(skipped)
+
+	decisionRank := map[string]int{PolicyBlocked: 0, PolicyVerifiedOnly: 1, PolicyAllowed: 2}
+	for i, action := range report.Actions {
+		reference := action.Action + "@" + action.Ref
+		report.Actions[i].Workflows = len(actionWorkflows[reference])
+		report.Actions[i].Repositories = []string{}
+		for repo := range actionRepositories[reference] {
+			report.Actions[i].Repositories = append(report.Actions[i].Repositories, repo)
+		}
+		sort.Strings(report.Actions[i].Repositories)
+		switch action.Decision {
+		case PolicyAllowed:
+			report.Summary.Allowed++
+		case PolicyBlocked:
+			report.Summary.Blocked++
+		case PolicyVerifiedOnly:
+			report.Summary.VerifiedOnly++
+		}
+	}
+	sort.SliceStable(report.Actions, func(i, j int) bool {
+		a, b := report.Actions[i], report.Actions[j]
+		if a.Decision != b.Decision {
+			return decisionRank[a.Decision] < decisionRank[b.Decision]
+		}
+		if a.Usages != b.Usages {
+			return a.Usages > b.Usages
+		}
+		return a.Action+"@"+a.Ref < b.Action+"@"+b.Ref
+	})
+	for _, allowed := range policy.PatternsAllowed {
+		if !usedPatterns[allowed] {
+			report.UnusedPatterns = append(report.UnusedPatterns, allowed)
+		}
+	}
+	report.Summary.ActionsChecked = len(report.Actions)
+	report.Summary.UnusedPatterns = len(report.UnusedPatterns)
+	report.Summary.AffectedWorkflows = len(affectedWorkflows)
+	report.Summary.AffectedRepositories = len(affectedRepositories)
+
+	opts.finishFindings(org, &report.Findings)
+	report.Truncated = len(report.RemainingRepositories) > 0
+	report.ProcessTimeSeconds = time.Since(startTime).Seconds()
+
+	return opts.writeFindingsReport(org, &report, report.Findings, outputFile, func(writer io.Writer) error {
+		return outputActionsPermissionsReport(report, outputFormat, writer)
+	})
+}
+
+// describeOrgActionsPolicy summarizes the Actions permissions, e.g. "selected (GitHub-owned, 12 patterns)"
+func describeOrgActionsPolicy(policy OrgActionsPolicy) string {
+	if policy.EnabledRepositories == "none" {
+		return "disabled"
+	}
+	description := policy.AllowedActions
+	if policy.AllowedActions == "selected" {
+		var parts []string
+		if policy.GitHubOwnedAllowed {
+			parts = append(parts, "GitHub-owned")
+		}
+		if policy.VerifiedAllowed {
+			parts = append(parts, "verified creators")
+		}
+		parts = append(parts, fmt.Sprintf("%d patterns", len(policy.PatternsAllowed)))
+		description += " (" + strings.Join(parts, ", ") + ")"
+	}
+	if policy.SHAPinningRequired {
+		description += ", SHA pinning required"
+	}
+	return description
+}
+
+// outputActionsPermissionsReport outputs the Actions permissions comparison in the specified format
+func outputActionsPermissionsReport(report ActionsPermissionsReport, format string, writer io.Writer) error {
+	if formatter, ok := lookupFormatter(format); ok {
+		return formatter.Format(report, writer)
+	}
+
+	switch format {
+	case "table":
+		return outputActionsPermissionsTable(report, writer)
+
+	case "csv":
+		return outputActionsPermissionsCSV(report, writer)
+
+	case "markdown":
+		return outputFindingsMarkdown("🛂 Actions Permissions", report.Findings, report.RemainingRepositories, writer)
+
+	case "sarif":
+		return outputSARIF("actions-permissions", report.Findings, writer)
+
+	default: // "default"
+		fmt.Fprintln(writer, "🛂 Actions Permissions")
+		fmt.Fprintln(writer, "="+strings.Repeat("=", 50))
+		fmt.Fprintf(writer, "🏢 %s: allowed actions %s\n", report.Organization, describeOrgActionsPolicy(report.Policy))
+
+		icons := map[string]string{PolicyBlocked: "❌", PolicyVerifiedOnly: "❔", PolicyAllowed: "✅"}
+		headings := map[string]string{
+			PolicyBlocked:      "Blocked references",
+			PolicyVerifiedOnly: "Allowed only for verified Marketplace creators",
+		}
+		decision := ""
+		for _, action := range report.Actions {
+			if action.Decision == PolicyAllowed {
+				break
+			}
+			if action.Decision != decision {
+				decision = action.Decision
+				fmt.Fprintf(writer, "\n%s %s:\n", icons[decision], headings[decision])
+			}
+			fmt.Fprintf(writer, "   • %s@%s: %d usages in %d workflows of %d repositories (%s)\n",
+				action.Action, action.Ref, action.Usages, action.Workflows, len(action.Repositories), action.Reason)
+		}
+		if report.Summary.Blocked == 0 && report.Summary.VerifiedOnly == 0 {
+			fmt.Fprintln(writer, "\n✅ The Actions permissions allow every reference the workflows use")
+		}
+
+		if len(report.UnusedPatterns) > 0 {
+			fmt.Fprintln(writer, "\n🧹 Allowed patterns no workflow uses:")
+			for _, pattern := range report.UnusedPatterns {
+				fmt.Fprintf(writer, "   • %s\n", pattern)
+			}
+		}
+
+		fmt.Fprintln(writer, "\n📊 Summary:")
+		fmt.Fprintf(writer, "   • Workflows scanned: %d\n", report.Summary.WorkflowsScanned)
+		fmt.Fprintf(writer, "   • References checked: %d (%d allowed, %d blocked, %d verified creators only)\n",
+			report.Summary.ActionsChecked, report.Summary.Allowed, report.Summary.Blocked, report.Summary.VerifiedOnly)
+		fmt.Fprintf(writer, "   • Blocked usages: %d in %d workflows of %d repositories\n",
+			report.Summary.BlockedUsages, report.Summary.AffectedWorkflows, report.Summary.AffectedRepositories)
+		if report.Policy.AllowedActions == "selected" {
+			fmt.Fprintf(writer, "   • Unused allowed patterns: %d of %d\n", report.Summary.UnusedPatterns, len(report.Policy.PatternsAllowed))
+		}
+		fmt.Fprintf(writer, "   ⏱️  Process time: %.3fs\n", report.ProcessTimeSeconds)
+
+		if report.Truncated {
+			outputTruncationNotice(report.RemainingRepositories, writer)
+		}
+
+		return nil
+	}
+}
+
+// outputActionsPermissionsTable outputs the decisions of the Actions permissions in table format
+func outputActionsPermissionsTable(report ActionsPermissionsReport, writer io.Writer) error {
+	fmt.Fprintln(writer, "╔════════════════════════════════════════════════════════════════════════════════════════════════════╗")
+	fmt.Fprintf(writer, "║                                    🛂 ACTIONS PERMISSIONS                                           ║\n")
+	fmt.Fprintln(writer, "╚════════════════════════════════════════════════════════════════════════════════════════════════════╝")
+	fmt.Fprintf(writer, "  🏢 Organization: %-59s \n", report.Organization)
+	fmt.Fprintf(writer, "  🛂 Allowed Actions: %-57s \n", describeOrgActionsPolicy(report.Policy))
+	fmt.Fprintf(writer, "  🎯 References Checked: %-54d \n", report.Summary.ActionsChecked)
+	fmt.Fprintf(writer, "  ❌ Blocked References: %-54d \n", report.Summary.Blocked)
+	fmt.Fprintf(writer, "  🧹 Unused Patterns: %-57d \n", report.Summary.UnusedPatterns)
+	fmt.Fprintln(writer, " ═════════════════════════════════════════════════════════════════════════════════════════════════════")
+	fmt.Fprintln(writer)
+
+	if len(report.Actions) == 0 {
+		fmt.Fprintln(writer, "┌─────────────────────────────────────────┐")
+		fmt.Fprintln(writer, "│  No action references found             │")
+		fmt.Fprintln(writer, "└─────────────────────────────────────────┘")
+		return nil
+	}
+
+	fmt.Fprintln(writer, "┌────────────────────────────────────────┬──────────────┬──────────────────┬────────┬───────────┬──────────────┐")
+	fmt.Fprintf(writer, "│ %-37s │ %-12s │ %-16s │ %-6s │ %-9s │ %-12s │\n", "🔧 ACTION", "REF", "DECISION", "USAGES", "WORKFLOWS", "REPOSITORIES")
+	fmt.Fprintln(writer, "├────────────────────────────────────────┼──────────────┼──────────────────┼────────┼───────────┼──────────────┤")
+	for _, action := range report.Actions {
+		fmt.Fprintf(writer, "│ %-38s │ %-12s │ %-16s │ %6d │ %9d │ %12d │\n",
+			truncate(action.Action, 38), truncate(action.Ref, 12), action.Decision, action.Usages, action.Workflows, len(action.Repositories))
+	}
+	fmt.Fprintln(writer, "└────────────────────────────────────────┴──────────────┴──────────────────┴────────┴───────────┴──────────────┘")
+	fmt.Fprintln(writer)
+	return nil
+}
+
+// outputActionsPermissionsCSV outputs the Actions permissions comparison in CSV format, one row per reference
+func outputActionsPermissionsCSV(report ActionsPermissionsReport, writer io.Writer) error {
+	w := newCSVWriter(writer)
+	w.Write([]string{"Action", "Ref", "Decision", "Reason", "Pattern", "Usages", "Workflows", "Repositories"})
+	for _, action := range report.Actions {
+		w.Write([]string{action.Action, action.Ref, action.Decision, action.Reason, action.Pattern,
+			strconv.Itoa(action.Usages), strconv.Itoa(action.Workflows), strings.Join(action.Repositories, ";")})
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,506 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Upgrade levels of an action reference behind the latest release
+const (
+	UpgradeMajor = "major"
+	UpgradeMinor = "minor"
+	UpgradePatch = "patch"
+)
+
+// OutdatedReport compares every action reference of an organization with the latest release of the action
+type OutdatedReport struct {
+	Organization          string               `json:"organization"`
+	Summary               OutdatedSummary      `json:"summary"`
+	LatestReleases        map[string]string    `json:"latest_releases"` // action repository -> latest release tag
+	Repositories          []RepositoryUpgrades `json:"repositories"`    // repositories with at least one outdated action
+	Findings              []Finding            `json:"findings"`
+	Truncated             bool                 `json:"truncated"`
+	RemainingRepositories []string             `json:"remaining_repositories,omitempty"`
+	ProcessTimeSeconds    float64              `json:"process_time_seconds"`
+}
+
+// OutdatedSummary counts action usages by how far they are behind the latest release
+type OutdatedSummary struct {
+	WorkflowsScanned int `json:"workflows_scanned"`
+	ActionsChecked   int `json:"actions_checked"` // action repositories with a known latest release
+	UpToDate         int `json:"up_to_date"`
+	MajorBehind      int `json:"major_behind"`
+	MinorBehind      int `json:"minor_behind"`
+	PatchBehind      int `json:"patch_behind"`
+	Unknown          int `json:"unknown"` // branches, unreleased actions, and refs that are not versions
+}
+
+// RepositoryUpgrades is the upgrade list of one repository
+type RepositoryUpgrades struct {
+	Name     string          `json:"name"`
+	Upgrades []ActionUpgrade `json:"upgrades"`
+}
+
+// ActionUpgrade is one outdated action reference of a repository and the reference to move to
+type ActionUpgrade struct {
+	Action    string   `json:"action"`
+	Current   string   `json:"current"`
+	Version   string   `json:"version,omitempty"` // release tag of a SHA-pinned current ref
+	Latest    string   `json:"latest"`
+	Level     string   `json:"level"`     // major, minor, or patch
+	Suggested string   `json:"suggested"` // replacement uses: value
+	Usages    int      `json:"usages"`
+	Workflows []string `json:"workflows"`
+}
+
+// latestActionRelease returns the tag of the latest release of an action repository, or its highest
+// version tag when it publishes no releases; "" when it has neither
+func latestActionRelease(cache *enrichmentCache, repository string) (string, error) {
+	var latest string
+	err := cache.fetch(EnrichmentLatestRelease, repository, &latest, func() error {
+		var release struct {
+			TagName string `json:"tag_name"`
+		}
+		switch err := restGet("repos/"+repository+"/releases/latest", &release); err {
+		case nil:
+			latest = release.TagName
+			return nil
+		case errFileNotFound:
+		default:
+			return err
+		}
+
+		tags, err := listRepositoryTags(cache, repository)
+		if err != nil {
+			return err
+		}
+		var highest []int
+		for _, tag := range tags {
+			if version, ok := parseVersion(tag.Name); ok && compareVersions(version, highest) > 0 {
+				highest, latest = version, tag.Name
+			}
+		}
+		return nil
+	})
+	return latest, err
+}
+
+// parseVersion splits a version ref such as v4.1.2 or 1.2 into its numeric components, ignoring
+// pre-release and build suffixes
+func parseVersion(ref string) ([]int, bool) {
+	if !versionRefPattern.MatchString(ref) {
+		return nil, false
+	}
+	ref = strings.TrimPrefix(ref, "v")
+	if i := strings.IndexAny(ref, "-+"); i >= 0 {
+		ref = ref[:i]
+	}
+
+	var version []int
+	for _, part := range strings.Split(ref, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, false
+		}
+		version = append(version, n)
+	}
+	return version, true
+}
+
+// compareVersions returns -1, 0, or 1 as a is lower than, equal to, or higher than b; missing
+// components count as zero
+func compareVersions(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+	return 0
+}
+
+// upgradeLevel compares a version with the latest release up to the precision of the version, so a
+// floating v4 tag is current while v4.2.1 is the latest release; "" means up to date
+func upgradeLevel(current, latest []int) string {
+	levels := []string{UpgradeMajor, UpgradeMinor}
+	for i := 0; i < len(current) && i < len(latest); i++ {
+		switch {
+		case current[i] > latest[i]:
+			return ""
+		case current[i] < latest[i]:
+			if i < len(levels) {
+				return levels[i]
+			}
+			return UpgradePatch
+		}
+	}
+	return ""
+}
+
+// suggestedRef returns the latest release at the precision of the current ref, e.g. v4 for v3 when
+// v4.2.1 is the latest release
+func suggestedRef(current, latest string) string {
+	currentVersion, _ := parseVersion(current)
+	latestVersion, ok := parseVersion(latest)
+	if !ok || len(currentVersion) >= len(latestVersion) {
+		return latest
+	}
+
+	parts := make([]string, len(currentVersion))
+	for i := range parts {
+		parts[i] = strconv.Itoa(latestVersion[i])
+	}
+	if strings.HasPrefix(current, "v") {
+		return "v" + strings.Join(parts, ".")
+	}
+	return strings.Join(parts, ".")
+}
+
+// outdatedFinding raises a finding for an outdated action reference in one workflow
+func outdatedFinding(repo, workflow string, upgrade ActionUpgrade) Finding {
+	current := upgrade.Current
+	if upgrade.Version != "" {
+		current = fmt.Sprintf("%.7s (%s)", upgrade.Current, upgrade.Version)
+	}
+	finding := Finding{
+		RuleID:      RuleOutdatedAction,
+		Severity:    SeverityInfo,
+		Repository:  repo,
+		Workflow:    workflow,
+		Action:      upgrade.Action,
+		Version:     upgrade.Current,
+		Message:     fmt.Sprintf("%s@%s is a %s version behind the latest release %s", upgrade.Action, current, upgrade.Level, upgrade.Latest),
+		Remediation: fmt.Sprintf("Review the release notes and replace the reference with `uses: %s`.", upgrade.Suggested),
+	}
+	if upgrade.Level == UpgradeMajor {
+		finding.RuleID = RuleOutdatedMajorVersion
+		finding.Severity = SeverityWarning
+	}
+	return finding
+}
+
+// analyzeOutdatedActions compares every `uses:` reference with the latest release of the action and
+// lists the upgrades per repository
+func analyzeOutdatedActions(org string, startTime time.Time, outputFormat, outputFile string, opts scanOptions) error {
+	repositories, _, err := listRepositoryWorkflows(org, opts)
+	if err != nil {
+		return err
+	}
+
+	var files []WorkflowFile
+	for _, repo := range repositories {
+		for _, workflowPath := range repo.Workflows {
+			files = append(files, WorkflowFile{Repo: repo.Name, Path: workflowPath})
+		}
+	}
+
+	logInfof("🆕 Checking %d workflow files for outdated actions...\n\n", len(files))
+
+	results := fetchWorkflows(org, files, opts, func(wf WorkflowFile, result workflowFetch) {
+		if result.Err != nil {
+			logWarnf("⚠️  Warning: Could not analyze %s/%s: %v\n", wf.Repo, wf.Path, result.Err)
+		}
+	})
+
+	// Look up the latest release of every action repository once, and the tags of SHA-pinned refs
+	var skipped []WorkflowFile
+	seenRepository := make(map[string]bool)
+	seenSHA := make(map[string]bool)
+	var actionRepositories, shaReferences []string
+	for i, result := range results {
+		if result.Skipped {
+			skipped = append(skipped, files[i])
+			continue
+		}
+		for _, action := range result.Actions {
+			if strings.HasPrefix(action.Name, "./") || strings.HasPrefix(action.Name, "docker://") {
+				continue
+			}
+			if repository := actionRepository(action.Name); !seenRepository[repository] {
+				seenRepository[repository] = true
+				actionRepositories = append(actionRepositories, repository)
+			}
+			if reference := action.Name + "@" + action.Version; isPinnedToSHA(action.Version) && !seenSHA[reference] {
+				seenSHA[reference] = true
+				shaReferences = append(shaReferences, reference)
+			}
+		}
+	}
+	sort.Strings(actionRepositories)
+	sort.Strings(shaReferences)
+
+	latest := make([]string, len(actionRepositories))
+	runConcurrently(len(actionRepositories), opts.Concurrency, func(i int) {
+		apiRateLimit.acquire()
+		defer apiRateLimit.release()
+		if tag, err := latestActionRelease(opts.Cache, actionRepositories[i]); err == nil {
+			latest[i] = tag
+		} else {
+			logWarnf("⚠️  Warning: Could not look up the latest release of %s: %v\n", actionRepositories[i], err)
+		}
+	})
+	shaResolutions := resolvePinning(shaReferences, opts)
+
+	report := OutdatedReport{
+		Organization:   org,
+		LatestReleases: make(map[string]string),
+		Repositories:   []RepositoryUpgrades{},
+		Findings:       []Finding{},
+	}
+	for i, repository := range actionRepositories {
+		if latest[i] != "" {
+			report.LatestReleases[repository] = latest[i]
+		}
+	}
+	report.Summary.ActionsChecked = len(report.LatestReleases)
+
+	// SHA-pinned upgrades are suggested as the commit of the latest release
+	latestSHAs := make(map[string]string)
+	for _, reference := range shaReferences {
+		action, _, _ := splitActionReference(reference)
+		repository := actionRepository(action)
+		if _, ok := latestSHAs[repository]; !ok && report.LatestReleases[repository] != "" {
+			latestSHAs[repository] = resolveRef(opts.Cache, action, report.LatestReleases[repository]).SHA
+		}
+	}
+
+	repoIndex := make(map[string]int)
+	upgradeIndex := make(map[string]int) // repository|action@ref -> index in the repository's upgrades
+	flagged := make(map[string]bool)
+	for i, result := range results {
+		if result.Skipped || result.Err != nil {
+			continue
+		}
+		wf := files[i]
+		report.Summary.WorkflowsScanned++
+
+		for _, action := range result.Actions {
+			if strings.HasPrefix(action.Name, "./") || strings.HasPrefix(action.Name, "docker://") {
+				continue
+			}
+			repository := actionRepository(action.Name)
+			latestTag := report.LatestReleases[repository]
+			latestVersion, latestOK := parseVersion(latestTag)
+
+			upgrade := ActionUpgrade{Action: action.Name, Current: action.Version, Latest: latestTag}
+			current := action.Version
+			if isPinnedToSHA(action.Version) {
+				upgrade.Version = shaResolutions[action.Name+"@"+action.Version].Version
+				current = upgrade.Version
+			}
+			currentVersion, currentOK := parseVersion(current)
+			if !latestOK || !currentOK {
+				report.Summary.Unknown++
+				continue
+			}
+
+			upgrade.Level = upgradeLevel(currentVersion, latestVersion)
+			switch upgrade.Level {
+			case UpgradeMajor:
+				report.Summary.MajorBehind++
+			case UpgradeMinor:
+				report.Summary.MinorBehind++
+			case UpgradePatch:
+				report.Summary.PatchBehind++
+			default:
+				report.Summary.UpToDate++
+				continue
+			}
+
+			upgrade.Suggested = action.Name + "@" + suggestedRef(action.Version, latestTag)
+			if isPinnedToSHA(action.Version) {
+				upgrade.Suggested = action.Name + "@" + latestTag
+				if sha := latestSHAs[repository]; sha != "" {
+					upgrade.Suggested = pinnedUses(action.Name, latestTag, sha)
+				}
+			}
+
+			index, ok := repoIndex[wf.Repo]
+			if !ok {
+				index = len(report.Repositories)
+				repoIndex[wf.Repo] = index
+				report.Repositories = append(report.Repositories, RepositoryUpgrades{Name: wf.Repo})
+			}
+			repo := &report.Repositories[index]
+			key := wf.Repo + "|" + action.Name + "@" + action.Version
+			if j, ok := upgradeIndex[key]; ok {
+				repo.Upgrades[j].Usages++
+				if last := repo.Upgrades[j].Workflows; last[len(last)-1] != wf.Path {
+					repo.Upgrades[j].Workflows = append(repo.Upgrades[j].Workflows, wf.Path)
+				}
+			} else {
+				upgradeIndex[key] = len(repo.Upgrades)
+				upgrade.Usages = 1
+				upgrade.Workflows = []string{wf.Path}
+				repo.Upgrades = append(repo.Upgrades, upgrade)
+			}
+
+			// One finding per reference and workflow, however often the workflow uses it
+			if key := wf.Repo + "|" + wf.Path + "|" + action.Name + "@" + action.Version; !flagged[key] {
+				flagged[key] = true
+				report.Findings = append(report.Findings, outdatedFinding(wf.Repo, wf.Path, upgrade))
+			}
+		}
+	}
+	report.RemainingRepositories = remainingRepositories(skipped)
+	for _, repo := range report.Repositories {
+		sort.SliceStable(repo.Upgrades, func(i, j int) bool {
+			a, b := repo.Upgrades[i], repo.Upgrades[j]
+			if a.Level != b.Level {
+				return upgradeRank(a.Level) < upgradeRank(b.Level)
+			}
+			return a.Action+"@"+a.Current < b.Action+"@"+b.Current
+		})
+	}
+
+	opts.finishFindings(org, &report.Findings)
+	report.Truncated = len(report.RemainingRepositories) > 0
+	report.ProcessTimeSeconds = time.Since(startTime).Seconds()
+
+	return opts.writeFindingsReport(org, &report, report.Findings, outputFile, func(writer io.Writer) error {
+		return outputOutdatedReport(report, outputFormat, writer)
+	})
+}
+
+// upgradeRank orders upgrade levels from major to patch
+func upgradeRank(level string) int {
+	switch level {
+	case UpgradeMajor:
+		return 0
+	case UpgradeMinor:
+		return 1
+	}
+	return 2
+}
+
+// describeUpgrade renders an upgrade for status output, e.g. actions/checkout@v3 → actions/checkout@v4
+func describeUpgrade(upgrade ActionUpgrade) string {
+	current := upgrade.Action + "@" + upgrade.Current
+	if upgrade.Version != "" {
+		current = fmt.Sprintf("%s@%.7s (%s)", upgrade.Action, upgrade.Current, upgrade.Version)
+	}
+	return fmt.Sprintf("%s → %s", current, upgrade.Suggested)
+}
+
+// outputOutdatedReport outputs the outdated action report in the specified format
+func outputOutdatedReport(report OutdatedReport, format string, writer io.Writer) error {
+	if formatter, ok := lookupFormatter(format); ok {
+		return formatter.Format(report, writer)
+	}
+
+	switch format {
+	case "table":
+		return outputOutdatedTable(report, writer)
+
+	case "csv":
+		return outputOutdatedCSV(report, writer)
+
+	case "markdown":
+		return outputFindingsMarkdown("🆕 Outdated Actions", report.Findings, report.RemainingRepositories, writer)
+
+	case "sarif":
+		return outputSARIF("outdated", report.Findings, writer)
+
+	default: // "default"
+		fmt.Fprintln(writer, "🆕 Outdated Actions")
+		fmt.Fprintln(writer, "="+strings.Repeat("=", 50))
+
+		if len(report.Repositories) == 0 {
+			fmt.Fprintln(writer, "✅ Every versioned action reference is on its latest release")
+		}
+		for _, repo := range report.Repositories {
+			fmt.Fprintf(writer, "\n📁 %s (%d upgrades)\n", repo.Name, len(repo.Upgrades))
+			for _, upgrade := range repo.Upgrades {
+				icon := "🔹"
+				if upgrade.Level == UpgradeMajor {
+					icon = "⚠️ "
+				}
+				fmt.Fprintf(writer, "   %s [%s] %s (%d usages in %s)\n", icon, upgrade.Level, describeUpgrade(upgrade),
+					upgrade.Usages, strings.Join(upgrade.Workflows, ", "))
+			}
+		}
+
+		fmt.Fprintln(writer, "\n📊 Summary:")
+		fmt.Fprintf(writer, "   • Workflows scanned: %d\n", report.Summary.WorkflowsScanned)
+		fmt.Fprintf(writer, "   • Actions checked: %d\n", report.Summary.ActionsChecked)
+		fmt.Fprintf(writer, "   • Up-to-date usages: %d\n", report.Summary.UpToDate)
+		fmt.Fprintf(writer, "   • Major versions behind: %d\n", report.Summary.MajorBehind)
+		fmt.Fprintf(writer, "   • Minor versions behind: %d\n", report.Summary.MinorBehind)
+		fmt.Fprintf(writer, "   • Patch versions behind: %d\n", report.Summary.PatchBehind)
+		fmt.Fprintf(writer, "   • Unknown (branches, unreleased actions): %d\n", report.Summary.Unknown)
+		fmt.Fprintf(writer, "   ⏱️  Process time: %.3fs\n", report.ProcessTimeSeconds)
+
+		if report.Truncated {
+			outputTruncationNotice(report.RemainingRepositories, writer)
+		}
+
+		return nil
+	}
+}
+
+// outputOutdatedTable outputs the outdated action report in table format
+func outputOutdatedTable(report OutdatedReport, writer io.Writer) error {
+	fmt.Fprintln(writer, "╔════════════════════════════════════════════════════════════════════════════════════════════════════╗")
+	fmt.Fprintf(writer, "║                                       🆕 OUTDATED ACTIONS                                          ║\n")
+	fmt.Fprintln(writer, "╚════════════════════════════════════════════════════════════════════════════════════════════════════╝")
+	fmt.Fprintf(writer, "  🏢 Organization: %-59s \n", report.Organization)
+	fmt.Fprintf(writer, "  🎯 Actions Checked: %-57d \n", report.Summary.ActionsChecked)
+	fmt.Fprintf(writer, "  ✅ Up to Date: %-62d \n", report.Summary.UpToDate)
+	fmt.Fprintf(writer, "  ⚠️  Major Behind: %-60d \n", report.Summary.MajorBehind)
+	fmt.Fprintf(writer, "  🔹 Minor/Patch Behind: %-54d \n", report.Summary.MinorBehind+report.Summary.PatchBehind)
+	fmt.Fprintf(writer, "  ❔ Unknown: %-65d \n", report.Summary.Unknown)
+	fmt.Fprintln(writer, " ═════════════════════════════════════════════════════════════════════════════════════════════════════")
+	fmt.Fprintln(writer)
+
+	if len(report.Repositories) == 0 {
+		fmt.Fprintln(writer, "┌─────────────────────────────────────────┐")
+		fmt.Fprintln(writer, "│        No outdated actions found        │")
+		fmt.Fprintln(writer, "└─────────────────────────────────────────┘")
+		return nil
+	}
+
+	fmt.Fprintln(writer, "┌─────────────────────┬────────────────────────────────────┬──────────────┬──────────────┬───────┬────────┐")
+	fmt.Fprintf(writer, "│ %-18s │ %-33s │ %-12s │ %-12s │ %-5s │ %-6s │\n", "📁 REPOSITORY", "🔧 ACTION", "CURRENT", "LATEST", "LEVEL", "USAGES")
+	fmt.Fprintln(writer, "├─────────────────────┼────────────────────────────────────┼──────────────┼──────────────┼───────┼────────┤")
+	for _, repo := range report.Repositories {
+		for _, upgrade := range repo.Upgrades {
+			current := upgrade.Current
+			if upgrade.Version != "" {
+				current = upgrade.Version
+			}
+			fmt.Fprintf(writer, "│ %-19s │ %-34s │ %-12s │ %-12s │ %-5s │ %6d │\n",
+				truncate(repo.Name, 19), truncate(upgrade.Action, 34), truncate(current, 12),
+				truncate(upgrade.Latest, 12), upgrade.Level, upgrade.Usages)
+		}
+	}
+	fmt.Fprintln(writer, "└─────────────────────┴────────────────────────────────────┴──────────────┴──────────────┴───────┴────────┘")
+	fmt.Fprintln(writer)
+	return nil
+}
+
+// outputOutdatedCSV outputs the outdated action report in CSV format, one row per repository and upgrade
+func outputOutdatedCSV(report OutdatedReport, writer io.Writer) error {
+	w := newCSVWriter(writer)
+	w.Write([]string{"Repository", "Action", "Current", "Version", "Latest", "Level", "Suggested", "Usages", "Workflows"})
+	for _, repo := range report.Repositories {
+		for _, upgrade := range repo.Upgrades {
+			w.Write([]string{repo.Name, upgrade.Action, upgrade.Current, upgrade.Version, upgrade.Latest, upgrade.Level,
+				upgrade.Suggested, strconv.Itoa(upgrade.Usages), strings.Join(upgrade.Workflows, ";")})
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
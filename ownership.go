@@ -0,0 +1,78 @@
+package main
+
+import "fmt"
+
+// OwnershipCounts counts the distinct actions and the usages of one ownership class
+type OwnershipCounts struct {
+	Actions int `json:"actions"`
+	Usages  int `json:"usages"`
+}
+
+// OwnershipSummary splits the actions of a report by who publishes them: GitHub (actions/* and github/*),
+// the scanned organization, or anyone else
+type OwnershipSummary struct {
+	GitHub     OwnershipCounts `json:"github"`
+	Internal   OwnershipCounts `json:"internal"`
+	ThirdParty OwnershipCounts `json:"third_party"`
+}
+
+// add counts usages of an action of the given class; newAction is set on the first usage of the action
+func (s *OwnershipSummary) add(class string, usages int, newAction bool) {
+	counts := &s.ThirdParty
+	switch class {
+	case actionClassGitHub:
+		counts = &s.GitHub
+	case actionClassInternal:
+		counts = &s.Internal
+	}
+	counts.Usages += usages
+	if newAction {
+		counts.Actions++
+	}
+}
+
+// String renders the split, e.g. "GitHub 12 actions (340 usages), internal 3 (20), third-party 25 (110)"
+func (s OwnershipSummary) String() string {
+	return fmt.Sprintf("GitHub %d actions (%d usages), internal %d (%d), third-party %d (%d)",
+		s.GitHub.Actions, s.GitHub.Usages, s.Internal.Actions, s.Internal.Usages, s.ThirdParty.Actions, s.ThirdParty.Usages)
+}
+
+// classifyComprehensiveOwnership sets the ownership class of every action of the detailed report
+func classifyComprehensiveOwnership(repositories []ComprehensiveRepository, org string) {
+	for _, repo := range repositories {
+		for _, workflow := range repo.Workflows {
+			for i, action := range workflow.Actions {
+				workflow.Actions[i].Ownership = actionClass(action.Name, org)
+			}
+		}
+	}
+}
+
+// summarizeOwnership counts the actions of the detailed report by ownership class
+func summarizeOwnership(repositories []ComprehensiveRepository) OwnershipSummary {
+	var summary OwnershipSummary
+	seen := make(map[string]bool)
+	for _, repo := range repositories {
+		for _, workflow := range repo.Workflows {
+			for _, action := range workflow.Actions {
+				if action.Ownership == "" {
+					continue
+				}
+				summary.add(action.Ownership, action.Count, !seen[action.Name])
+				seen[action.Name] = true
+			}
+		}
+	}
+	return summary
+}
+
+// withoutGitHubOwned drops the usages of actions published by GitHub (--exclude-github-owned)
+func withoutGitHubOwned(actions []Action, org string) []Action {
+	kept := actions[:0]
+	for _, action := range actions {
+		if actionClass(action.Name, org) != actionClassGitHub {
+			kept = append(kept, action)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultMaxReportSize is the size in MB above which HTML and Markdown reports written with --output are
+// split into pages; browsers struggle with single files far beyond it
+const defaultMaxReportSize = 20
+
+// reportPage is one page of a paginated report: a run of repositories with their findings
+type reportPage struct {
+	File         string // file name, relative to the index
+	Repositories []ComprehensiveRepository
+	Findings     []Finding
+}
+
+// describe names the repositories of a page, e.g. "api-gateway – billing (42 repositories)"
+func (p reportPage) describe() string {
+	first, last := p.Repositories[0].Name, p.Repositories[len(p.Repositories)-1].Name
+	if len(p.Repositories) == 1 {
+		return fmt.Sprintf("%s (1 repository)", first)
+	}
+	return fmt.Sprintf("%s – %s (%d repositories)", first, last, len(p.Repositories))
+}
+
+// pageFiles maps every repository of a paginated report to the file of the page holding its section
+func pageFiles(pages []reportPage) map[string]string {
+	files := make(map[string]string)
+	for _, page := range pages {
+		for _, repo := range page.Repositories {
+			files[repo.Name] = page.File
+		}
+	}
+	return files
+}
+
+// byteCounter is a writer that only counts what is written to it
+type byteCounter int64
+
+// Write implements io.Writer
+func (c *byteCounter) Write(p []byte) (int, error) {
+	*c += byteCounter(len(p))
+	return len(p), nil
+}
+
+// pageFileName returns the file of page n next to the index file, e.g. report-page-2.html
+func pageFileName(outputFile string, n int) string {
+	ext := filepath.Ext(outputFile)
+	return fmt.Sprintf("%s-page-%d%s", strings.TrimSuffix(filepath.Base(outputFile), ext), n, ext)
+}
+
+// paginateReport splits the repositories of an HTML or Markdown report written to outputFile into pages
+// of at most maxSizeMB each, in report order. It returns nil when the whole report fits, when the report
+// goes to stdout, and for other formats.
+func paginateReport(report ComprehensiveReport, format, outputFile string, maxSizeMB int) []reportPage {
+	if outputFile == "" || maxSizeMB <= 0 || (format != "html" && format != "markdown") {
+		return nil
+	}
+	maxSize := byteCounter(maxSizeMB) << 20
+
+	var total byteCounter
+	outputComprehensiveReport(report, format, &total)
+	if total <= maxSize {
+		return nil
+	}
+
+	findingsByRepo := make(map[string][]Finding)
+	for _, finding := range report.Findings {
+		findingsByRepo[finding.Repository] = append(findingsByRepo[finding.Repository], finding)
+	}
+
+	var pages []reportPage
+	var current reportPage
+	var size byteCounter
+	for _, repo := range report.Repositories {
+		var section byteCounter
+		if format == "html" {
+			writeDashboardRepository(&section, repo, findingsByRepo[repo.Name])
+		} else {
+			writeMarkdownFindings(findingsByRepo[repo.Name], &section)
+			writeMarkdownRepositories([]ComprehensiveRepository{repo}, &section)
+		}
+		// A repository larger than a page gets a page of its own
+		if len(current.Repositories) > 0 && size+section > maxSize {
+			pages = append(pages, current)
+			current, size = reportPage{}, 0
+		}
+		current.Repositories = append(current.Repositories, repo)
+		current.Findings = append(current.Findings, findingsByRepo[repo.Name]...)
+		size += section
+	}
+	if len(current.Repositories) > 0 {
+		pages = append(pages, current)
+	}
+	if len(pages) == 0 {
+		return nil
+	}
+
+	// Findings of repositories without a section, such as organization-level ones, go on the last page
+	listed := make(map[string]bool)
+	for _, repo := range report.Repositories {
+		listed[repo.Name] = true
+	}
+	for _, finding := range report.Findings {
+		if !listed[finding.Repository] {
+			pages[len(pages)-1].Findings = append(pages[len(pages)-1].Findings, finding)
+		}
+	}
+	for i := range pages {
+		pages[i].File = pageFileName(outputFile, i+1)
+	}
+	return pages
+}
+
+// outputReportPages writes the index of a paginated report to writer and each page next to outputFile
+func outputReportPages(report ComprehensiveReport, format, outputFile string, pages []reportPage, writer io.Writer) error {
+	var err error
+	if format == "html" {
+		err = writeDashboard(report, pages, writer)
+	} else {
+		err = writeComprehensiveMarkdown(report, pages, writer)
+	}
+	if err != nil {
+		return err
+	}
+
+	index := filepath.Base(outputFile)
+	for i, page := range pages {
+		file, err := os.Create(filepath.Join(filepath.Dir(outputFile), page.File))
+		if err != nil {
+			return fmt.Errorf("error creating report page: %v", err)
+		}
+		if format == "html" {
+			writeDashboardPage(file, report, pages, i, index)
+		} else {
+			writeMarkdownPage(file, report, pages, i, index)
+		}
+		if err := file.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pageNeighbors returns the files of the pages before and after page i; empty when there is none
+func pageNeighbors(pages []reportPage, i int) (previous, next string) {
+	if i > 0 {
+		previous = pages[i-1].File
+	}
+	if i < len(pages)-1 {
+		next = pages[i+1].File
+	}
+	return previous, next
+}
+
+// writeDashboardPage writes page i of a paginated dashboard
+func writeDashboardPage(w io.Writer, report ComprehensiveReport, pages []reportPage, i int, index string) {
+	page := pages[i]
+	scope := report.Organization
+	if report.Enterprise != "" {
+		scope = report.Enterprise
+	}
+	writeSiteHeader(w, fmt.Sprintf("%s – page %d of %d", report.Report.title("GitHub Actions dashboard – "+scope), i+1, len(pages)), report.Report)
+
+	previous, next := pageNeighbors(pages, i)
+	var links []string
+	if previous != "" {
+		links = append(links, fmt.Sprintf("<a href=\"%s\">← Previous</a>", html.EscapeString(previous)))
+	}
+	links = append(links, fmt.Sprintf("<a href=\"%s\">Index</a>", html.EscapeString(index)))
+	if next != "" {
+		links = append(links, fmt.Sprintf("<a href=\"%s\">Next →</a>", html.EscapeString(next)))
+	}
+	nav := "<nav>" + strings.Join(links, " · ") + "</nav>"
+
+	fmt.Fprintln(w, nav)
+	fmt.Fprintf(w, "<h2>%s</h2>\n", html.EscapeString(page.describe()))
+	fmt.Fprintln(w, "<input id=\"search\" type=\"search\" placeholder=\"Filter repositories, actions, rules…\">")
+	findingsByRepo := make(map[string][]Finding)
+	for _, finding := range page.Findings {
+		findingsByRepo[finding.Repository] = append(findingsByRepo[finding.Repository], finding)
+	}
+	for _, repo := range page.Repositories {
+		writeDashboardRepository(w, repo, findingsByRepo[repo.Name])
+	}
+	fmt.Fprintln(w, nav)
+	fmt.Fprintf(w, "<script>\n%s\n</script>\n</body>\n</html>\n", dashboardScript)
+}
+
+// writeMarkdownPage writes page i of a paginated Markdown report
+func writeMarkdownPage(w io.Writer, report ComprehensiveReport, pages []reportPage, i int, index string) {
+	page := pages[i]
+	scope := report.Organization
+	if report.Enterprise != "" {
+		scope = report.Enterprise
+	}
+	fmt.Fprintf(w, "## 🔍 Detailed Analysis of `%s` – page %d of %d\n\n", scope, i+1, len(pages))
+
+	previous, next := pageNeighbors(pages, i)
+	var links []string
+	if previous != "" {
+		links = append(links, fmt.Sprintf("[← Previous](%s)", previous))
+	}
+	links = append(links, fmt.Sprintf("[Index](%s)", index))
+	if next != "" {
+		links = append(links, fmt.Sprintf("[Next →](%s)", next))
+	}
+	nav := strings.Join(links, " · ")
+
+	fmt.Fprintf(w, "%s\n\n**%s**\n\n", nav, page.describe())
+	writeMarkdownFindings(page.Findings, w)
+	writeMarkdownRepositories(page.Repositories, w)
+	fmt.Fprintln(w, nav)
+}
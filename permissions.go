@@ -0,0 +1,408 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Permission sources, from most to least specific
+const (
+	PermissionSourceJob      = "job"
+	PermissionSourceWorkflow = "workflow"
+	PermissionSourceDefault  = "default"
+)
+
+// permissionUnknown is the default workflow permissions of a repository when neither its setting nor the
+// organization's can be read, and the level of the jobs inheriting it
+const permissionUnknown = "unknown"
+
+// tokenScopes lists the GITHUB_TOKEN permission scopes that can be granted
+var tokenScopes = []string{
+	"actions", "attestations", "checks", "contents", "deployments", "discussions", "id-token",
+	"issues", "packages", "pages", "pull-requests", "repository-projects", "security-events", "statuses",
+}
+
+// PermissionsReport represents the effective GITHUB_TOKEN permissions of every job
+type PermissionsReport struct {
+	Organization          string                  `json:"organization"`
+	OrganizationDefault   string                  `json:"organization_default"` // empty for user accounts, unknown when not readable
+	UserAccount           bool                    `json:"user_account,omitempty"`
+	Repositories          []RepositoryPermissions `json:"repositories"`
+	Findings              []Finding               `json:"findings"`
+	Summary               PermissionsSummary      `json:"summary"`
+	Truncated             bool                    `json:"truncated"`
+	RemainingRepositories []string                `json:"remaining_repositories,omitempty"`
+	ProcessTimeSeconds    float64                 `json:"process_time_seconds"`
+}
+
+// RepositoryPermissions lists the effective permissions of the jobs in one repository
+type RepositoryPermissions struct {
+	Name               string           `json:"name"`
+	DefaultPermissions string           `json:"default_permissions"` // read, write, or unknown
+	Jobs               []JobPermissions `json:"jobs"`
+}
+
+// JobPermissions describes the permissions a job's GITHUB_TOKEN effectively receives
+type JobPermissions struct {
+	Workflow  string            `json:"workflow"`
+	Job       string            `json:"job"`
+	Source    string            `json:"source"`
+	Level     string            `json:"level"` // write-all, read-all, custom, none, or unknown
+	Effective map[string]string `json:"effective"`
+}
+
+// PermissionsSummary represents summary statistics for effective permissions
+type PermissionsSummary struct {
+	TotalJobs             int `json:"total_jobs"`
+	WriteAllJobs          int `json:"write_all_jobs"`
+	InheritedDefaultJobs  int `json:"inherited_default_jobs"`
+	UnknownDefaultJobs    int `json:"unknown_default_jobs"` // inherit a default that could not be read
+	ExplicitlyScopedJobs  int `json:"explicitly_scoped_jobs"`
+	RepositoriesWithWrite int `json:"repositories_with_write_default"`
+}
+
+// analyzeEffectivePermissions resolves job permissions from org/repo defaults and workflow/job overrides
+func analyzeEffectivePermissions(org string, startTime time.Time, outputFormat, outputFile string, opts scanOptions) error {
+	repositories, _, err := listRepositoryWorkflows(org, opts)
+	if err != nil {
+		return err
+	}
+
+	// User accounts have no organization-level setting, so every repository's own setting applies
+	userAccount := false
+	if ownerType, err := fetchOwnerType(org); err == nil && ownerType == "User" {
+		userAccount = true
+	}
+
+	orgDefault := ""
+	if !userAccount {
+		orgDefault, err = fetchDefaultWorkflowPermissions("orgs/" + org + "/actions/permissions/workflow")
+		if err != nil {
+			// Reading org settings needs admin access; the repository settings may still be readable
+			logWarnf("⚠️  Warning: Could not read organization workflow permissions (%v), reading each repository's setting\n", err)
+			orgDefault = permissionUnknown
+		}
+	}
+
+	logInfof("🔑 Resolving effective permissions in %d repositories...\n\n", len(repositories))
+
+	report := PermissionsReport{
+		Organization:        org,
+		OrganizationDefault: orgDefault,
+		UserAccount:         userAccount,
+		Repositories:        []RepositoryPermissions{},
+		Findings:            []Finding{},
+	}
+
+	for i, repo := range repositories {
+		if opts.expired() {
+			for _, r := range repositories[i:] {
+				report.RemainingRepositories = append(report.RemainingRepositories, r.Name)
+			}
+			break
+		}
+
+		opts.repositoryStarted(org, repo.Name, len(repo.Workflows))
+		failed := 0
+
+		// Repositories cannot be more permissive than their organization
+		repoDefault := orgDefault
+		if userAccount {
+			repoDefault = "read"
+		}
+		if orgDefault == "write" || orgDefault == permissionUnknown || userAccount {
+			if setting, err := fetchDefaultWorkflowPermissions("repos/" + org + "/" + repo.Name + "/actions/permissions/workflow"); err == nil {
+				repoDefault = setting
+			}
+		}
+		if repoDefault == "write" {
+			report.Summary.RepositoriesWithWrite++
+		}
+
+		repoPermissions := RepositoryPermissions{Name: repo.Name, DefaultPermissions: repoDefault}
+		for _, workflowPath := range repo.Workflows {
+			stopFetch := opts.Profile.track(stageFetch)
+			content, err := fetchWorkflowContent(org, repo.Name, workflowPath)
+			stopFetch()
+			if err != nil {
+				logWarnf("⚠️  Warning: Could not analyze %s/%s: %v\n", repo.Name, workflowPath, err)
+				failed++
+				continue
+			}
+			definition, err := parseWorkflowDefinition(content)
+			if err != nil {
+				logWarnf("⚠️  Warning: Could not analyze %s/%s: %v\n", repo.Name, workflowPath, err)
+				failed++
+				continue
+			}
+
+			for _, jobID := range definition.sortedJobIDs() {
+				job := resolveJobPermissions(definition, jobID, repoDefault)
+				job.Workflow = workflowPath
+				repoPermissions.Jobs = append(repoPermissions.Jobs, job)
+
+				report.Summary.TotalJobs++
+				if job.Source == PermissionSourceDefault {
+					report.Summary.InheritedDefaultJobs++
+					if job.Level == permissionUnknown {
+						report.Summary.UnknownDefaultJobs++
+					}
+				} else {
+					report.Summary.ExplicitlyScopedJobs++
+				}
+				if job.Level == "write-all" {
+					report.Summary.WriteAllJobs++
+				}
+
+				if finding, ok := permissionFinding(repo.Name, job); ok {
+					report.Findings = append(report.Findings, finding)
+				}
+			}
+		}
+
+		report.Repositories = append(report.Repositories, repoPermissions)
+		opts.repositoryScanned(org, repo.Name, failed)
+	}
+
+	opts.finishFindings(org, &report.Findings)
+	report.Truncated = len(report.RemainingRepositories) > 0
+	report.ProcessTimeSeconds = time.Since(startTime).Seconds()
+
+	return opts.writeFindingsReport(org, &report, report.Findings, outputFile, func(writer io.Writer) error {
+		return outputPermissionsReport(report, outputFormat, writer)
+	})
+}
+
+// fetchDefaultWorkflowPermissions reads the default_workflow_permissions setting ("read" or "write")
+func fetchDefaultWorkflowPermissions(apiPath string) (string, error) {
+	var setting struct {
+		DefaultWorkflowPermissions string `json:"default_workflow_permissions"`
+	}
+	if err := restGet(apiPath, &setting); err != nil {
+		if errors.Is(err, errFileNotFound) {
+			return "", fmt.Errorf("setting not accessible")
+		}
+		return "", err
+	}
+	return setting.DefaultWorkflowPermissions, nil
+}
+
+// resolveJobPermissions combines the default token permissions with workflow- and job-level overrides
+func resolveJobPermissions(definition *workflowDefinition, jobID, repoDefault string) JobPermissions {
+	job := definition.Jobs[jobID]
+	result := JobPermissions{Job: jobID}
+
+	var declared interface{}
+	switch {
+	case job.Permissions != nil:
+		result.Source = PermissionSourceJob
+		declared = job.Permissions
+	case definition.Permissions != nil:
+		result.Source = PermissionSourceWorkflow
+		declared = definition.Permissions
+	default:
+		result.Source = PermissionSourceDefault
+		if repoDefault == permissionUnknown {
+			result.Effective, result.Level = map[string]string{}, permissionUnknown
+			return result
+		}
+		if repoDefault == "write" {
+			declared = "write-all"
+		} else {
+			declared = map[string]interface{}{"contents": "read", "packages": "read"}
+		}
+	}
+
+	result.Effective, result.Level = expandPermissions(declared)
+	return result
+}
+
+// expandPermissions turns a permissions: value into a scope -> level map and an overall level
+func expandPermissions(declared interface{}) (map[string]string, string) {
+	effective := make(map[string]string)
+
+	switch v := declared.(type) {
+	case string:
+		level := strings.TrimSuffix(v, "-all")
+		if level != "read" && level != "write" {
+			return effective, "none"
+		}
+		for _, scope := range tokenScopes {
+			effective[scope] = level
+		}
+		// id-token has no read level
+		if level == "read" {
+			delete(effective, "id-token")
+		}
+		return effective, v
+
+	case map[string]interface{}:
+		for scope, level := range v {
+			if levelStr, ok := level.(string); ok && levelStr != "none" {
+				effective[scope] = levelStr
+			}
+		}
+	}
+
+	if len(effective) == 0 {
+		return effective, "none"
+	}
+	return effective, "custom"
+}
+
+// permissionFinding returns a finding for jobs whose effective permissions are broader than needed
+func permissionFinding(repo string, job JobPermissions) (Finding, bool) {
+	if job.Level != "write-all" {
+		return Finding{}, false
+	}
+
+	if job.Source == PermissionSourceDefault {
+		return Finding{
+			RuleID:     RuleDefaultWritePermissions,
+			Severity:   SeverityWarning,
+			Repository: repo,
+			Workflow:   job.Workflow,
+			Message:    fmt.Sprintf("job '%s' inherits write access to all scopes from the default workflow permissions", job.Job),
+		}, true
+	}
+
+	return Finding{
+		RuleID:     RuleWriteAllPermissions,
+		Severity:   SeverityWarning,
+		Repository: repo,
+		Workflow:   job.Workflow,
+		Message:    fmt.Sprintf("job '%s' is granted write-all permissions at %s level", job.Job, job.Source),
+	}, true
+}
+
+// formatPermissions renders an effective permission map as "scope:level" pairs
+func formatPermissions(effective map[string]string) string {
+	var pairs []string
+	for scope, level := range effective {
+		pairs = append(pairs, scope+":"+level)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
+
+// describeJobPermissions returns a compact description of a job's effective permissions
+func describeJobPermissions(job JobPermissions) string {
+	if job.Level == "custom" {
+		return formatPermissions(job.Effective)
+	}
+	return job.Level
+}
+
+// outputPermissionsReport outputs effective permissions in the specified format
+func outputPermissionsReport(report PermissionsReport, format string, writer io.Writer) error {
+	if formatter, ok := lookupFormatter(format); ok {
+		return formatter.Format(report, writer)
+	}
+
+	switch format {
+	case "table":
+		return outputPermissionsTable(report, writer)
+
+	case "csv":
+		return outputPermissionsCSV(report, writer)
+
+	case "markdown":
+		return outputFindingsMarkdown("🔑 Effective Workflow Permissions", report.Findings, report.RemainingRepositories, writer)
+
+	case "sarif":
+		return outputSARIF("permissions", report.Findings, writer)
+
+	default: // "default"
+		fmt.Fprintln(writer, "🔑 Effective Workflow Permissions")
+		fmt.Fprintln(writer, "="+strings.Repeat("=", 50))
+		fmt.Fprintf(writer, "Organization default: %s\n", organizationDefaultLabel(report))
+
+		for _, repo := range report.Repositories {
+			fmt.Fprintf(writer, "\n📁 %s (default: %s)\n", repo.Name, repo.DefaultPermissions)
+			for _, job := range repo.Jobs {
+				fmt.Fprintf(writer, "   📄 %s → %s: %s (from %s)\n", job.Workflow, job.Job, describeJobPermissions(job), job.Source)
+			}
+		}
+
+		fmt.Fprintln(writer, "\n📊 Summary:")
+		fmt.Fprintf(writer, "   • Total jobs: %d\n", report.Summary.TotalJobs)
+		fmt.Fprintf(writer, "   • Jobs with explicit permissions: %d\n", report.Summary.ExplicitlyScopedJobs)
+		fmt.Fprintf(writer, "   • Jobs inheriting the default: %d (%d unknown)\n", report.Summary.InheritedDefaultJobs, report.Summary.UnknownDefaultJobs)
+		fmt.Fprintf(writer, "   • Jobs with write-all: %d\n", report.Summary.WriteAllJobs)
+		fmt.Fprintf(writer, "   • Repositories with write default: %d\n", report.Summary.RepositoriesWithWrite)
+		fmt.Fprintf(writer, "   ⏱️  Process time: %.3fs\n", report.ProcessTimeSeconds)
+
+		outputFindings(report.Findings, writer)
+
+		if report.Truncated {
+			outputTruncationNotice(report.RemainingRepositories, writer)
+		}
+
+		return nil
+	}
+}
+
+// organizationDefaultLabel describes the organization default, which user accounts do not have
+func organizationDefaultLabel(report PermissionsReport) string {
+	if report.UserAccount {
+		return "n/a (user account)"
+	}
+	return report.OrganizationDefault
+}
+
+// outputPermissionsTable outputs effective permissions in table format
+func outputPermissionsTable(report PermissionsReport, writer io.Writer) error {
+	fmt.Fprintln(writer, "╔════════════════════════════════════════════════════════════════════════════════════════════════════╗")
+	fmt.Fprintf(writer, "║                                 🔑 EFFECTIVE WORKFLOW PERMISSIONS                                  ║\n")
+	fmt.Fprintln(writer, "╚════════════════════════════════════════════════════════════════════════════════════════════════════╝")
+	fmt.Fprintf(writer, "  🏢 Organization: %-59s \n", report.Organization)
+	fmt.Fprintf(writer, "  ⚙️  Organization Default: %-51s \n", organizationDefaultLabel(report))
+	fmt.Fprintf(writer, "  📄 Total Jobs: %-62d \n", report.Summary.TotalJobs)
+	fmt.Fprintf(writer, "  ⚠️  Jobs with write-all: %-52d \n", report.Summary.WriteAllJobs)
+	fmt.Fprintln(writer, " ═════════════════════════════════════════════════════════════════════════════════════════════════════")
+	fmt.Fprintln(writer)
+
+	if len(report.Repositories) == 0 {
+		fmt.Fprintln(writer, "┌─────────────────────────────────────────┐")
+		fmt.Fprintln(writer, "│   No repositories with workflows found  │")
+		fmt.Fprintln(writer, "└─────────────────────────────────────────┘")
+		return nil
+	}
+
+	fmt.Fprintln(writer, "┌─────────────────────┬────────────────────────────────┬──────────────────┬──────────┬──────────────────────────────┐")
+	fmt.Fprintf(writer, "│ %-18s │ %-29s │ %-15s │ %-8s │ %-28s │\n", "📁 REPOSITORY", "📄 WORKFLOW", "JOB", "SOURCE", "EFFECTIVE")
+	fmt.Fprintln(writer, "├─────────────────────┼────────────────────────────────┼──────────────────┼──────────┼──────────────────────────────┤")
+
+	for _, repo := range report.Repositories {
+		for i, job := range repo.Jobs {
+			repoName := ""
+			if i == 0 {
+				repoName = truncate(repo.Name, 19)
+			}
+			fmt.Fprintf(writer, "│ %-19s │ %-30s │ %-16s │ %-8s │ %-28s │\n",
+				repoName, truncate(job.Workflow, 30), truncate(job.Job, 16), job.Source, truncate(describeJobPermissions(job), 28))
+		}
+	}
+
+	fmt.Fprintln(writer, "└─────────────────────┴────────────────────────────────┴──────────────────┴──────────┴──────────────────────────────┘")
+	outputFindings(report.Findings, writer)
+	fmt.Fprintln(writer)
+	return nil
+}
+
+// outputPermissionsCSV outputs effective permissions in CSV format
+func outputPermissionsCSV(report PermissionsReport, writer io.Writer) error {
+	w := newCSVWriter(writer)
+	w.Write([]string{"Repository", "Default", "Workflow", "Job", "Source", "Level", "Effective"})
+	for _, repo := range report.Repositories {
+		for _, job := range repo.Jobs {
+			w.Write([]string{repo.Name, repo.DefaultPermissions, job.Workflow, job.Job, job.Source, job.Level, formatPermissions(job.Effective)})
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// defaultPinBranch is the branch the pinned workflows are committed to
+const defaultPinBranch = "action-lens/pin-actions"
+
+// tagPinner rewrites tag-pinned `uses:` references to the commit SHAs of their tags. Every tag is
+// resolved once per run, bypassing the enrichment cache, so the pins match the tags as they are now.
+type tagPinner struct {
+	cache    *enrichmentCache
+	resolved map[string]string // action@tag -> action@sha, empty when not a resolvable tag
+}
+
+// newTagPinner creates a pinner resolving tags through the API
+func newTagPinner() *tagPinner {
+	return &tagPinner{cache: openEnrichmentCache("", true), resolved: make(map[string]string)}
+}
+
+// pin returns the SHA-pinned reference of a tag-pinned one, or false for SHAs, branches, local and
+// container actions, and tags that could not be resolved
+func (p *tagPinner) pin(uses string) (string, bool) {
+	name, ref, ok := splitActionReference(uses)
+	if !ok || isPinnedToSHA(ref) || strings.HasPrefix(name, "./") || strings.HasPrefix(name, "docker://") {
+		return "", false
+	}
+	if pinned, seen := p.resolved[uses]; seen {
+		return pinned, pinned != ""
+	}
+
+	apiRateLimit.acquire()
+	resolution := resolveRef(p.cache, name, ref)
+	apiRateLimit.release()
+	pinned := ""
+	if resolution.Kind == PinTag && resolution.SHA != "" {
+		pinned = name + "@" + resolution.SHA
+	}
+	p.resolved[uses] = pinned
+	return pinned, pinned != ""
+}
+
+// rewrite pins the tag-pinned references of a workflow, keeping each tag as a trailing comment
+func (p *tagPinner) rewrite(content string) (string, []AppliedRewrite) {
+	var rewrites RewriteMap
+	for _, match := range usesLinePattern.FindAllStringSubmatch(content, -1) {
+		if pinned, ok := p.pin(match[3]); ok {
+			rewrites.Rewrites = append(rewrites.Rewrites, Rewrite{From: match[3], To: pinned})
+		}
+	}
+	if len(rewrites.Rewrites) == 0 {
+		return content, nil
+	}
+	return rewriteWorkflow(content, rewrites)
+}
+
+// pinRewriteRequested reports whether the pin command arguments ask for rewrites (--apply or --dry-run)
+// rather than the --scan pinning report
+func pinRewriteRequested(args []string) bool {
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+		name, _, _ := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if strings.HasPrefix(arg, "-") && (name == "apply" || name == "dry-run") {
+			return true
+		}
+	}
+	return false
+}
+
+// runPinCommand implements `gh action-lens pin --apply` and `gh action-lens pin --dry-run`
+func runPinCommand(args []string) error {
+	fs := flag.NewFlagSet("pin", flag.ExitOnError)
+
+	var organization string
+	var apply bool
+	var dryRun bool
+	var branch string
+	var title string
+	var outputFormat string
+	var includeWorkflows string
+	var excludeWorkflows string
+	var skipRepos string
+
+	fs.StringVar(&organization, "org", "", "Organization whose workflows are pinned")
+	fs.StringVar(&organization, "o", "", "Organization whose workflows are pinned")
+	fs.BoolVar(&apply, "apply", false, "Open a pull request per repository pinning its tag-pinned actions")
+	fs.BoolVar(&dryRun, "dry-run", false, "Print the diff of every workflow without opening pull requests")
+	fs.StringVar(&branch, "branch", defaultPinBranch, "Branch the pinned workflows are committed to")
+	fs.StringVar(&title, "title", "Pin GitHub Actions to commit SHAs", "Title of the pull requests")
+	fs.StringVar(&outputFormat, "format", "default", "Output format: default, json")
+	fs.StringVar(&outputFormat, "f", "default", "Output format: default, json")
+	fs.StringVar(&includeWorkflows, "include-workflows", "", "Only pin workflow files matching these comma-separated glob patterns")
+	fs.StringVar(&excludeWorkflows, "exclude-workflows", "", "Skip workflow files matching these comma-separated glob patterns")
+	fs.StringVar(&skipRepos, "skip-repos", "", "Comma-separated repository names to leave unchanged")
+
+	addHostnameFlag(fs)
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "\nUsage:\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens pin --org <org> (--apply | --dry-run) [flags]\n\n")
+		fmt.Fprintf(os.Stderr, "Rewrite tag-pinned `uses:` references to the commit SHA of the tag, keeping the tag as a\n")
+		fmt.Fprintf(os.Stderr, "trailing comment, and open one pull request per affected repository. Without --apply or\n")
+		fmt.Fprintf(os.Stderr, "--dry-run, gh action-lens pin reports the tag- and branch-pinned references (--scan pinning).\n\n")
+		fmt.Fprintf(os.Stderr, "Flags:\n")
+		fmt.Fprintf(os.Stderr, "  -o, --org <string>\n")
+		fmt.Fprintf(os.Stderr, "        Organization whose workflows are pinned\n\n")
+		fmt.Fprintf(os.Stderr, "      --apply\n")
+		fmt.Fprintf(os.Stderr, "        Open a pull request per repository pinning its tag-pinned actions\n\n")
+		fmt.Fprintf(os.Stderr, "      --dry-run\n")
+		fmt.Fprintf(os.Stderr, "        Print the diff of every workflow without opening pull requests\n\n")
+		fmt.Fprintf(os.Stderr, "      --branch <string>\n")
+		fmt.Fprintf(os.Stderr, "        Branch the pinned workflows are committed to (default %q)\n\n", defaultPinBranch)
+		fmt.Fprintf(os.Stderr, "      --title <string>\n")
+		fmt.Fprintf(os.Stderr, "        Title of the pull requests (default \"Pin GitHub Actions to commit SHAs\")\n\n")
+		fmt.Fprintf(os.Stderr, "  -f, --format <string>\n")
+		fmt.Fprintf(os.Stderr, "        Output format: default, json (default \"default\")\n\n")
+		fmt.Fprintf(os.Stderr, "      --include-workflows <patterns>\n")
+		fmt.Fprintf(os.Stderr, "        Only pin workflow files matching these comma-separated glob patterns\n\n")
+		fmt.Fprintf(os.Stderr, "      --exclude-workflows <patterns>\n")
+		fmt.Fprintf(os.Stderr, "        Skip workflow files matching these comma-separated glob patterns\n\n")
+		fmt.Fprintf(os.Stderr, "      --skip-repos <list>\n")
+		fmt.Fprintf(os.Stderr, "        Comma-separated repository names to leave unchanged\n\n")
+		fmt.Fprintf(os.Stderr, "      --hostname <host>\n")
+		fmt.Fprintf(os.Stderr, "        GitHub host, e.g. a GitHub Enterprise Server (default: GH_HOST or the gh default host)\n\n")
+		fmt.Fprintf(os.Stderr, "Examples:\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens pin -o myorg --dry-run\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens pin -o myorg --apply --skip-repos legacy-app\n\n")
+	}
+
+	fs.Parse(args)
+
+	if organization == "" {
+		fs.Usage()
+		return fmt.Errorf("--org is required")
+	}
+	if apply == dryRun {
+		fs.Usage()
+		return fmt.Errorf("exactly one of --apply and --dry-run is required")
+	}
+	switch outputFormat {
+	case "default", "json":
+	default:
+		return fmt.Errorf("invalid output format '%s'. Valid options: default, json", outputFormat)
+	}
+
+	opts := rewriteScanOptions(includeWorkflows, excludeWorkflows, skipRepos)
+	if err := opts.validate(); err != nil {
+		return err
+	}
+
+	startTime := time.Now()
+	report, err := planMigration(organization, newTagPinner().rewrite, opts)
+	if err != nil {
+		return err
+	}
+	report.DryRun = dryRun
+	report.Branch = branch
+
+	for i := range report.Repositories {
+		repo := &report.Repositories[i]
+		if dryRun {
+			repo.Status = MigrationPlanned
+			for j := range repo.Workflows {
+				workflow := &repo.Workflows[j]
+				workflow.Diff = workflowDiff(workflow.Path, repo.originals[workflow.Path], repo.contents[workflow.Path])
+			}
+			continue
+		}
+		logInfof("📌 Opening pull request for %s...\n", repo.Name)
+		if err := openMigrationPullRequest(organization, repo, branch, title, pinPullRequestBody(*repo)); err != nil {
+			repo.Status = MigrationFailed
+			repo.Error = err.Error()
+			report.Summary.Failed++
+			continue
+		}
+		if repo.Status == MigrationOpened {
+			report.Summary.PullRequests++
+		}
+	}
+	report.ProcessTimeSeconds = time.Since(startTime).Seconds()
+
+	if outputFormat == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		outputPinDiffs(report, os.Stdout)
+		outputMigrationReport(report, os.Stdout)
+	}
+
+	if report.Summary.Failed > 0 {
+		return fmt.Errorf("%d of %d pull requests could not be opened", report.Summary.Failed, len(report.Repositories))
+	}
+	return nil
+}
+
+// workflowDiff returns a unified diff of a pinned workflow. Pinning replaces lines one for one, so
+// every changed line is a hunk of its own.
+func workflowDiff(path, original, rewritten string) string {
+	before, after := strings.Split(original, "\n"), strings.Split(rewritten, "\n")
+	if len(before) != len(after) {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- a/%s\n+++ b/%s\n", path, path)
+	for i := range before {
+		if before[i] == after[i] {
+			continue
+		}
+		fmt.Fprintf(&b, "@@ -%d +%d @@\n-%s\n+%s\n", i+1, i+1, strings.TrimSuffix(before[i], "\r"), strings.TrimSuffix(after[i], "\r"))
+	}
+	return b.String()
+}
+
+// outputPinDiffs writes the diffs of a dry run
+func outputPinDiffs(report MigrationReport, writer io.Writer) {
+	for _, repo := range report.Repositories {
+		for _, workflow := range repo.Workflows {
+			if workflow.Diff != "" {
+				fmt.Fprintf(writer, "\n# %s\n%s", repo.Name, workflow.Diff)
+			}
+		}
+	}
+}
+
+// pinPullRequestBody lists the pinned references of a repository as Markdown
+func pinPullRequestBody(repo MigrationRepository) string {
+	tags := make(map[string]string) // action@tag -> SHA
+	for _, workflow := range repo.Workflows {
+		for _, change := range workflow.Changes {
+			_, sha, _ := splitActionReference(change.To)
+			tags[change.From] = sha
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("This pull request pins actions and reusable workflows referenced by a tag to the commit SHA the tag ")
+	b.WriteString("points to now. Tags can be moved to other commits; a SHA cannot. The tag is kept as a trailing ")
+	b.WriteString("comment, so Dependabot and Renovate keep proposing updates.\n\n")
+	b.WriteString("| Reference | Commit SHA |\n|---|---|\n")
+	for _, reference := range sortedKeys(tags) {
+		fmt.Fprintf(&b, "| `%s` | `%s` |\n", reference, tags[reference])
+	}
+	fmt.Fprintf(&b, "\n%d references in %d workflow files: ", countMigrationChanges(repo), len(repo.Workflows))
+	var paths []string
+	for _, workflow := range repo.Workflows {
+		paths = append(paths, "`"+markdownCell(workflow.Path)+"`")
+	}
+	sort.Strings(paths)
+	b.WriteString(strings.Join(paths, ", "))
+	b.WriteString(".\n\n_Opened by gh-action-lens pin._\n")
+	return b.String()
+}
@@ -0,0 +1,610 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Pinning kinds of a `uses:` reference
+const (
+	PinSHA    = "sha"    // full commit SHA or image digest; immutable
+	PinTag    = "tag"    // tag; can be moved to another commit
+	PinBranch = "branch" // branch; changes with every push
+)
+
+// versionRefPattern matches refs that look like release tags (v4, 1.2.3, v2.0.0-beta)
+var versionRefPattern = regexp.MustCompile(`^v?\d+(\.\d+)*([-+].*)?$`)
+
+// PinningReport classifies every `uses:` reference of an organization by how it is pinned
+type PinningReport struct {
+	Organization          string              `json:"organization"`
+	Summary               PinningSummary      `json:"summary"`
+	Repositories          []RepositoryPinning `json:"repositories"`
+	MutableReferences     []MutableReference  `json:"mutable_references"` // tag- and branch-pinned usages
+	Migrations            []PinMigration      `json:"migrations"`         // one per resolved mutable action@ref
+	Findings              []Finding           `json:"findings"`
+	Truncated             bool                `json:"truncated"`
+	RemainingRepositories []string            `json:"remaining_repositories,omitempty"`
+	ProcessTimeSeconds    float64             `json:"process_time_seconds"`
+}
+
+// PinningCounts counts action usages by pinning kind
+type PinningCounts struct {
+	SHAPinned    int     `json:"sha_pinned"`
+	TagPinned    int     `json:"tag_pinned"`
+	BranchPinned int     `json:"branch_pinned"`
+	PinnedRate   float64 `json:"pinned_rate"` // percentage of usages pinned to a SHA
+}
+
+// PinningSummary represents summary statistics of the pinning audit
+type PinningSummary struct {
+	WorkflowsScanned int `json:"workflows_scanned"`
+	PinningCounts
+}
+
+// RepositoryPinning counts the action usages of one repository by pinning kind
+type RepositoryPinning struct {
+	Name string `json:"name"`
+	PinningCounts
+}
+
+// MutableReference is one usage of an action by tag or branch
+type MutableReference struct {
+	Repository  string `json:"repository"`
+	Workflow    string `json:"workflow"`
+	Job         string `json:"job"`
+	Step        int    `json:"step,omitempty"` // 1-based step index; 0 for a reusable workflow call
+	Action      string `json:"action"`
+	Ref         string `json:"ref"`
+	Pinning     string `json:"pinning"`
+	ResolvedSHA string `json:"resolved_sha,omitempty"` // commit the ref pointed to during the scan
+}
+
+// PinMigration is one step of the plan to pin a mutable reference to the commit it currently resolves to
+type PinMigration struct {
+	Action      string `json:"action"`
+	Ref         string `json:"ref"`
+	Pinning     string `json:"pinning"`
+	ResolvedSHA string `json:"resolved_sha"`
+	Usages      int    `json:"usages"`
+	PinnedUses  string `json:"pinned_uses"` // replacement uses: value
+}
+
+// add counts n usages of the given pinning kind and updates the pinned rate
+func (c *PinningCounts) add(kind string, n int) {
+	switch kind {
+	case PinSHA:
+		c.SHAPinned += n
+	case PinTag:
+		c.TagPinned += n
+	case PinBranch:
+		c.BranchPinned += n
+	}
+	if total := c.SHAPinned + c.TagPinned + c.BranchPinned; total > 0 {
+		c.PinnedRate = float64(c.SHAPinned) / float64(total) * 100
+	}
+}
+
+// String summarizes the counts for status output
+func (c PinningCounts) String() string {
+	return fmt.Sprintf("%d SHA, %d tag, %d branch (%.1f%% SHA-pinned)", c.SHAPinned, c.TagPinned, c.BranchPinned, c.PinnedRate)
+}
+
+// guessPinning classifies a ref without the API: version-like refs are tags, anything else a branch
+func guessPinning(ref string) string {
+	if versionRefPattern.MatchString(ref) {
+		return PinTag
+	}
+	return PinBranch
+}
+
+// refResolution is the cached result of resolving an action ref
+type refResolution struct {
+	Kind    string `json:"kind"`
+	SHA     string `json:"sha,omitempty"`     // commit the tag or branch pointed to when resolved
+	Version string `json:"version,omitempty"` // for SHA refs, the most specific tag pointing to the commit
+}
+
+// repositoryTag is the subset of the REST tag resource used to name pinned commits
+type repositoryTag struct {
+	Name   string `json:"name"`
+	Commit struct {
+		SHA string `json:"sha"`
+	} `json:"commit"`
+}
+
+// maxTagPages bounds the pages of tags listed per action repository to name pinned commits
+const maxTagPages = 3
+
+// listRepositoryTags returns the newest tags of a repository through the enrichment cache
+func listRepositoryTags(cache *enrichmentCache, repository string) ([]repositoryTag, error) {
+	var tags []repositoryTag
+	err := cache.fetch(EnrichmentRef, repository, &tags, func() error {
+		for page := 1; page <= maxTagPages; page++ {
+			var batch []repositoryTag
+			if err := restGet(fmt.Sprintf("repos/%s/tags?per_page=100&page=%d", repository, page), &batch); err != nil {
+				return err
+			}
+			tags = append(tags, batch...)
+			if len(batch) < 100 {
+				break
+			}
+		}
+		return nil
+	})
+	return tags, err
+}
+
+// tagForCommit returns the most specific tag pointing to a commit, e.g. v4.1.1 rather than v4,
+// or "" when none of the listed tags does
+func tagForCommit(cache *enrichmentCache, repository, sha string) string {
+	tags, err := listRepositoryTags(cache, repository)
+	if err != nil {
+		return ""
+	}
+
+	best := ""
+	for _, tag := range tags {
+		if tag.Commit.SHA != sha {
+			continue
+		}
+		if best == "" || strings.Count(tag.Name, ".") > strings.Count(best, ".") ||
+			(strings.Count(tag.Name, ".") == strings.Count(best, ".") && len(tag.Name) > len(best)) {
+			best = tag.Name
+		}
+	}
+	return best
+}
+
+// gitObject is the object a REST git reference or annotated tag points to
+type gitObject struct {
+	Object struct {
+		SHA  string `json:"sha"`
+		Type string `json:"type"` // commit, or tag for annotated tags
+	} `json:"object"`
+}
+
+// maxTagIndirections bounds how many annotated tags are followed to reach a commit
+const maxTagIndirections = 3
+
+// lookupGitRef returns the commit SHA a tag or branch of a repository points to, following
+// annotated tags
+func lookupGitRef(repository, ref string) (string, error) {
+	var object gitObject
+	if err := restGet(fmt.Sprintf("repos/%s/git/ref/%s", repository, ref), &object); err != nil {
+		return "", err
+	}
+	for i := 0; object.Object.Type == "tag" && i < maxTagIndirections; i++ {
+		if err := restGet(fmt.Sprintf("repos/%s/git/tags/%s", repository, object.Object.SHA), &object); err != nil {
+			return "", err
+		}
+	}
+	return object.Object.SHA, nil
+}
+
+// resolveRef classifies an action reference, resolves tags and branches to the commit they
+// currently point to, and names SHA refs after the tag pointing to them. Refs that are not a commit SHA are looked up as a tag, then as a branch, of
+// the action repository; when neither exists or the repository cannot be read, the kind is guessed
+// from the ref and no SHA is resolved.
+func resolveRef(cache *enrichmentCache, action, ref string) refResolution {
+	if isPinnedToSHA(ref) {
+		if strings.HasPrefix(action, "docker://") {
+			return refResolution{Kind: PinSHA, SHA: ref}
+		}
+		return refResolution{Kind: PinSHA, SHA: ref, Version: tagForCommit(cache, actionRepository(action), ref)}
+	}
+	if strings.HasPrefix(action, "docker://") {
+		// Only digests (image@sha256:...) are captured as refs of container actions
+		if strings.HasPrefix(ref, "sha256:") {
+			return refResolution{Kind: PinSHA}
+		}
+		return refResolution{Kind: PinTag}
+	}
+
+	repository := actionRepository(action)
+	var resolution refResolution
+	err := cache.fetch(EnrichmentRef, repository+"@"+ref, &resolution, func() error {
+		sha, err := lookupGitRef(repository, "tags/"+ref)
+		switch err {
+		case nil:
+			resolution = refResolution{Kind: PinTag, SHA: sha}
+			return nil
+		case errFileNotFound:
+		default:
+			return err
+		}
+		sha, err = lookupGitRef(repository, "heads/"+ref)
+		switch err {
+		case nil:
+			resolution = refResolution{Kind: PinBranch, SHA: sha}
+		case errFileNotFound:
+			resolution = refResolution{Kind: guessPinning(ref)}
+		default:
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return refResolution{Kind: guessPinning(ref)}
+	}
+	return resolution
+}
+
+// resolvePinning resolves the given action@ref references with at most opts.Concurrency lookups
+// in flight
+func resolvePinning(references []string, opts scanOptions) map[string]refResolution {
+	resolutions := make([]refResolution, len(references))
+	runConcurrently(len(references), opts.Concurrency, func(i int) {
+		action, ref, _ := splitActionReference(references[i])
+		apiRateLimit.acquire()
+		resolutions[i] = resolveRef(opts.Cache, action, ref)
+		apiRateLimit.release()
+	})
+
+	resolved := make(map[string]refResolution, len(references))
+	for i, reference := range references {
+		resolved[reference] = resolutions[i]
+	}
+	return resolved
+}
+
+// classifyComprehensivePinning sets the pinning kind of every action of the detailed report, the
+// resolved commit SHA of tags and branches, and the tag of SHA-pinned actions
+func classifyComprehensivePinning(repositories []ComprehensiveRepository, opts scanOptions) {
+	seen := make(map[string]bool)
+	var references []string
+	for _, repo := range repositories {
+		for _, workflow := range repo.Workflows {
+			for _, action := range workflow.Actions {
+				reference := action.Name + "@" + action.Version
+				if !seen[reference] {
+					seen[reference] = true
+					references = append(references, reference)
+				}
+			}
+		}
+	}
+	sort.Strings(references)
+
+	resolutions := resolvePinning(references, opts)
+	for _, repo := range repositories {
+		for _, workflow := range repo.Workflows {
+			for i, action := range workflow.Actions {
+				resolution := resolutions[action.Name+"@"+action.Version]
+				workflow.Actions[i].Pinning = resolution.Kind
+				if resolution.Kind == PinSHA {
+					workflow.Actions[i].ResolvedVersion = resolution.Version
+				} else {
+					workflow.Actions[i].ResolvedSHA = resolution.SHA
+				}
+			}
+		}
+	}
+}
+
+// describeAction returns action@ref with the resolved commit of tags and branches, or the tag of a
+// pinned SHA, e.g. actions/checkout@b4ffde6 (v4.1.1)
+func describeAction(action ComprehensiveAction) string {
+	switch {
+	case action.ResolvedVersion != "":
+		return fmt.Sprintf("%s@%.7s (%s)", action.Name, action.Version, action.ResolvedVersion)
+	case action.ResolvedSHA != "":
+		return fmt.Sprintf("%s@%s → %.7s", action.Name, action.Version, action.ResolvedSHA)
+	}
+	return action.Name + "@" + action.Version
+}
+
+// displayVersion returns the tag of a pinned SHA when known, otherwise the ref
+func displayVersion(action ComprehensiveAction) string {
+	if action.ResolvedVersion != "" {
+		return action.ResolvedVersion
+	}
+	return action.Version
+}
+
+// pinnedUses returns the SHA-pinned form of a reference, keeping the ref as a comment for update tools
+func pinnedUses(action, ref, sha string) string {
+	return fmt.Sprintf("%s@%s # %s", action, sha, ref)
+}
+
+// pinningFinding flags a tag- or branch-pinned reference
+func pinningFinding(ref MutableReference) Finding {
+	if strings.HasPrefix(ref.Action, "docker://") {
+		return dockerPinningFinding(ref)
+	}
+	finding := Finding{
+		Repository: ref.Repository,
+		Workflow:   ref.Workflow,
+		Action:     ref.Action,
+		Version:    ref.Ref,
+	}
+	if ref.Pinning == PinBranch {
+		finding.RuleID = RuleBranchPinnedAction
+		finding.Severity = SeverityWarning
+		finding.Message = fmt.Sprintf("%s@%s follows branch %s; every push to it changes the code this workflow runs", ref.Action, ref.Ref, ref.Ref)
+	} else {
+		finding.RuleID = RuleTagPinnedAction
+		finding.Severity = SeverityInfo
+		finding.Message = fmt.Sprintf("%s@%s is pinned to a tag, which can be moved to a different commit", ref.Action, ref.Ref)
+	}
+	if ref.ResolvedSHA != "" {
+		finding.Message += fmt.Sprintf(" (currently %.7s)", ref.ResolvedSHA)
+		finding.Remediation = fmt.Sprintf("Replace the reference with `uses: %s`, the commit %s currently points to.",
+			pinnedUses(ref.Action, ref.Ref, ref.ResolvedSHA), ref.Ref)
+	}
+	return finding
+}
+
+// analyzePinning classifies every `uses:` reference as SHA-, tag- or branch-pinned and lists the mutable ones
+func analyzePinning(org string, startTime time.Time, outputFormat, outputFile string, opts scanOptions) error {
+	repositories, _, err := listRepositoryWorkflows(org, opts)
+	if err != nil {
+		return err
+	}
+
+	var files []WorkflowFile
+	for _, repo := range repositories {
+		for _, workflowPath := range repo.Workflows {
+			files = append(files, WorkflowFile{Repo: repo.Name, Path: workflowPath})
+		}
+	}
+
+	logInfof("📌 Auditing action pinning in %d workflow files...\n\n", len(files))
+
+	results := fetchWorkflows(org, files, opts, func(wf WorkflowFile, result workflowFetch) {
+		if result.Err != nil {
+			logWarnf("⚠️  Warning: Could not analyze %s/%s: %v\n", wf.Repo, wf.Path, result.Err)
+		}
+	})
+
+	report := PinningReport{
+		Organization:      org,
+		Repositories:      []RepositoryPinning{},
+		MutableReferences: []MutableReference{},
+		Migrations:        []PinMigration{},
+		Findings:          []Finding{},
+	}
+
+	// Classify every distinct reference once
+	seen := make(map[string]bool)
+	var references []string
+	var skipped []WorkflowFile
+	for i, result := range results {
+		if result.Skipped {
+			skipped = append(skipped, files[i])
+			continue
+		}
+		for _, action := range result.Actions {
+			reference := action.Name + "@" + action.Version
+			if !seen[reference] {
+				seen[reference] = true
+				references = append(references, reference)
+			}
+		}
+	}
+	sort.Strings(references)
+	resolutions := resolvePinning(references, opts)
+
+	repoIndex := make(map[string]int)
+	migrationIndex := make(map[string]int)
+	flagged := make(map[string]bool)
+	for i, result := range results {
+		if result.Skipped || result.Err != nil {
+			continue
+		}
+		wf := files[i]
+		report.Summary.WorkflowsScanned++
+		index, ok := repoIndex[wf.Repo]
+		if !ok {
+			index = len(report.Repositories)
+			repoIndex[wf.Repo] = index
+			report.Repositories = append(report.Repositories, RepositoryPinning{Name: wf.Repo})
+		}
+
+		for _, action := range result.Actions {
+			reference := action.Name + "@" + action.Version
+			resolution := resolutions[reference]
+			kind := resolution.Kind
+			report.Repositories[index].add(kind, 1)
+			report.Summary.add(kind, 1)
+			if kind == PinSHA {
+				continue
+			}
+
+			if resolution.SHA != "" {
+				if i, ok := migrationIndex[reference]; ok {
+					report.Migrations[i].Usages++
+				} else {
+					migrationIndex[reference] = len(report.Migrations)
+					report.Migrations = append(report.Migrations, PinMigration{
+						Action:      action.Name,
+						Ref:         action.Version,
+						Pinning:     kind,
+						ResolvedSHA: resolution.SHA,
+						Usages:      1,
+						PinnedUses:  pinnedUses(action.Name, action.Version, resolution.SHA),
+					})
+				}
+			}
+
+			ref := MutableReference{
+				Repository:  wf.Repo,
+				Workflow:    wf.Path,
+				Job:         action.Job,
+				Step:        action.Step,
+				Action:      action.Name,
+				Ref:         action.Version,
+				Pinning:     kind,
+				ResolvedSHA: resolution.SHA,
+			}
+			report.MutableReferences = append(report.MutableReferences, ref)
+
+			// One finding per reference and workflow, however often the workflow uses it
+			key := wf.Repo + "|" + wf.Path + "|" + reference
+			if !flagged[key] {
+				flagged[key] = true
+				report.Findings = append(report.Findings, pinningFinding(ref))
+			}
+		}
+	}
+	report.RemainingRepositories = remainingRepositories(skipped)
+	sort.SliceStable(report.Migrations, func(i, j int) bool {
+		if report.Migrations[i].Usages != report.Migrations[j].Usages {
+			return report.Migrations[i].Usages > report.Migrations[j].Usages
+		}
+		return report.Migrations[i].Action+"@"+report.Migrations[i].Ref < report.Migrations[j].Action+"@"+report.Migrations[j].Ref
+	})
+
+	opts.finishFindings(org, &report.Findings)
+	report.Truncated = len(report.RemainingRepositories) > 0
+	report.ProcessTimeSeconds = time.Since(startTime).Seconds()
+
+	return opts.writeFindingsReport(org, &report, report.Findings, outputFile, func(writer io.Writer) error {
+		return outputPinningReport(report, outputFormat, writer)
+	})
+}
+
+// describeStep returns the job and step of a reference, e.g. build step 3
+func describeStep(ref MutableReference) string {
+	if ref.Step == 0 {
+		return ref.Job
+	}
+	return fmt.Sprintf("%s step %d", ref.Job, ref.Step)
+}
+
+// outputPinningReport outputs the pinning audit in the specified format
+func outputPinningReport(report PinningReport, format string, writer io.Writer) error {
+	if formatter, ok := lookupFormatter(format); ok {
+		return formatter.Format(report, writer)
+	}
+
+	switch format {
+	case "table":
+		return outputPinningTable(report, writer)
+
+	case "csv":
+		return outputPinningCSV(report, writer)
+
+	case "markdown":
+		return outputFindingsMarkdown("📌 Action Pinning", report.Findings, report.RemainingRepositories, writer)
+
+	case "sarif":
+		return outputSARIF("pinning", report.Findings, writer)
+
+	default: // "default"
+		fmt.Fprintln(writer, "📌 Action Pinning")
+		fmt.Fprintln(writer, "="+strings.Repeat("=", 50))
+
+		for _, repo := range report.Repositories {
+			icon := "✅"
+			switch {
+			case repo.BranchPinned > 0:
+				icon = "❌"
+			case repo.TagPinned > 0:
+				icon = "⚠️ "
+			}
+			fmt.Fprintf(writer, "%s %s: %s\n", icon, repo.Name, repo.PinningCounts)
+		}
+
+		if len(report.MutableReferences) > 0 {
+			fmt.Fprintln(writer, "\n🔓 Mutable references:")
+			for _, ref := range report.MutableReferences {
+				fmt.Fprintf(writer, "   • [%s] %s@%s in %s → %s (%s)\n",
+					ref.Pinning, ref.Action, ref.Ref, ref.Repository, ref.Workflow, describeStep(ref))
+			}
+		}
+
+		if len(report.Migrations) > 0 {
+			fmt.Fprintln(writer, "\n🛠️  Pin migration plan:")
+			for _, migration := range report.Migrations {
+				fmt.Fprintf(writer, "   • %s@%s → %s (%d usages)\n", migration.Action, migration.Ref, migration.PinnedUses, migration.Usages)
+			}
+		}
+
+		fmt.Fprintln(writer, "\n📊 Summary:")
+		fmt.Fprintf(writer, "   • Workflows scanned: %d\n", report.Summary.WorkflowsScanned)
+		fmt.Fprintf(writer, "   • SHA-pinned usages: %d\n", report.Summary.SHAPinned)
+		fmt.Fprintf(writer, "   • Tag-pinned usages: %d\n", report.Summary.TagPinned)
+		fmt.Fprintf(writer, "   • Branch-pinned usages: %d\n", report.Summary.BranchPinned)
+		fmt.Fprintf(writer, "   • Pinned to a SHA: %.1f%%\n", report.Summary.PinnedRate)
+		fmt.Fprintf(writer, "   ⏱️  Process time: %.3fs\n", report.ProcessTimeSeconds)
+
+		if report.Truncated {
+			outputTruncationNotice(report.RemainingRepositories, writer)
+		}
+
+		return nil
+	}
+}
+
+// outputPinningTable outputs the pinning audit in table format
+func outputPinningTable(report PinningReport, writer io.Writer) error {
+	fmt.Fprintln(writer, "╔════════════════════════════════════════════════════════════════════════════════════════════════════╗")
+	fmt.Fprintf(writer, "║                                       📌 ACTION PINNING                                            ║\n")
+	fmt.Fprintln(writer, "╚════════════════════════════════════════════════════════════════════════════════════════════════════╝")
+	fmt.Fprintf(writer, "  🏢 Organization: %-59s \n", report.Organization)
+	fmt.Fprintf(writer, "  🔒 SHA-Pinned: %-62d \n", report.Summary.SHAPinned)
+	fmt.Fprintf(writer, "  🏷️  Tag-Pinned: %-61d \n", report.Summary.TagPinned)
+	fmt.Fprintf(writer, "  🌿 Branch-Pinned: %-59d \n", report.Summary.BranchPinned)
+	fmt.Fprintf(writer, "  📈 Pinned Rate: %-61s \n", fmt.Sprintf("%.1f%%", report.Summary.PinnedRate))
+	fmt.Fprintln(writer, " ═════════════════════════════════════════════════════════════════════════════════════════════════════")
+	fmt.Fprintln(writer)
+
+	if len(report.Repositories) == 0 {
+		fmt.Fprintln(writer, "┌─────────────────────────────────────────┐")
+		fmt.Fprintln(writer, "│        No action references found       │")
+		fmt.Fprintln(writer, "└─────────────────────────────────────────┘")
+		return nil
+	}
+
+	fmt.Fprintln(writer, "┌──────────────────────────────────────────┬────────┬────────┬────────┬─────────┐")
+	fmt.Fprintf(writer, "│ %-39s │ %-6s │ %-6s │ %-6s │ %-7s │\n", "📁 REPOSITORY", "SHA", "TAG", "BRANCH", "PINNED")
+	fmt.Fprintln(writer, "├──────────────────────────────────────────┼────────┼────────┼────────┼─────────┤")
+	for _, repo := range report.Repositories {
+		fmt.Fprintf(writer, "│ %-40s │ %6d │ %6d │ %6d │ %6.1f%% │\n",
+			truncate(repo.Name, 40), repo.SHAPinned, repo.TagPinned, repo.BranchPinned, repo.PinnedRate)
+	}
+	fmt.Fprintln(writer, "└──────────────────────────────────────────┴────────┴────────┴────────┴─────────┘")
+
+	if len(report.MutableReferences) > 0 {
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "┌─────────────────────┬────────────────────────────────┬────────────────────────────────────┬────────┐")
+		fmt.Fprintf(writer, "│ %-18s │ %-29s │ %-34s │ %-6s │\n", "📁 REPOSITORY", "📄 WORKFLOW", "🔧 REFERENCE", "KIND")
+		fmt.Fprintln(writer, "├─────────────────────┼────────────────────────────────┼────────────────────────────────────┼────────┤")
+		for _, ref := range report.MutableReferences {
+			fmt.Fprintf(writer, "│ %-19s │ %-30s │ %-34s │ %-6s │\n",
+				truncate(ref.Repository, 19), truncate(ref.Workflow, 30), truncate(ref.Action+"@"+ref.Ref, 34), ref.Pinning)
+		}
+		fmt.Fprintln(writer, "└─────────────────────┴────────────────────────────────┴────────────────────────────────────┴────────┘")
+	}
+
+	if len(report.Migrations) > 0 {
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "┌────────────────────────────────────┬──────────────────────────────────────────┬────────┐")
+		fmt.Fprintf(writer, "│ %-33s │ %-40s │ %-6s │\n", "🔧 REFERENCE", "RESOLVED SHA", "USAGES")
+		fmt.Fprintln(writer, "├────────────────────────────────────┼──────────────────────────────────────────┼────────┤")
+		for _, migration := range report.Migrations {
+			fmt.Fprintf(writer, "│ %-34s │ %-40s │ %6d │\n",
+				truncate(migration.Action+"@"+migration.Ref, 34), migration.ResolvedSHA, migration.Usages)
+		}
+		fmt.Fprintln(writer, "└────────────────────────────────────┴──────────────────────────────────────────┴────────┘")
+	}
+	fmt.Fprintln(writer)
+	return nil
+}
+
+// outputPinningCSV outputs the pinning audit in CSV format, one row per action usage that is not SHA-pinned
+func outputPinningCSV(report PinningReport, writer io.Writer) error {
+	w := newCSVWriter(writer)
+	w.Write([]string{"Repository", "Workflow", "Job", "Step", "Action", "Ref", "Pinning", "ResolvedSHA"})
+	for _, ref := range report.MutableReferences {
+		w.Write([]string{ref.Repository, ref.Workflow, ref.Job, strconv.Itoa(ref.Step), ref.Action, ref.Ref, ref.Pinning, ref.ResolvedSHA})
+	}
+	w.Flush()
+	return w.Error()
+}
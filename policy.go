@@ -0,0 +1,575 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Pin requirements of a policy
+const (
+	PolicyPinSHA = "sha" // full commit SHA or image digest
+	PolicyPinTag = "tag" // tag or commit SHA; branches are rejected
+)
+
+// pinDescriptions names pin requirements in violation messages
+var pinDescriptions = map[string]string{
+	PolicyPinSHA: "a commit SHA",
+	PolicyPinTag: "a tag or commit SHA",
+}
+
+// Policy is the allow/deny action policy passed with --policy
+type Policy struct {
+	RequirePin string       `yaml:"require-pin"` // sha or tag for every action; empty for none
+	Allow      []PolicyRule `yaml:"allow"`       // when set, only actions matching a rule may be used
+	Deny       []PolicyRule `yaml:"deny"`
+
+	path string
+}
+
+// PolicyRule matches action references by owner, name, and version
+type PolicyRule struct {
+	Owner   string `yaml:"owner"`   // action owner, e.g. actions; empty matches all
+	Action  string `yaml:"action"`  // glob matched against the action name or its repository; empty matches all
+	Version string `yaml:"version"` // comma-separated constraints, e.g. ">=3, <5"; empty matches all
+	Pin     string `yaml:"pin"`     // allow rules only: pin requirement of the matched actions, overriding require-pin
+	Reason  string `yaml:"reason"`  // shown with the violations of the rule
+
+	constraints []versionConstraint
+}
+
+// versionConstraint is one comparison of a PolicyRule version, e.g. >=3
+type versionConstraint struct {
+	op      string // =, >, >=, <, or <=
+	version []int
+}
+
+// PolicyReport lists the policy violations of an organization per repository and workflow
+type PolicyReport struct {
+	Organization          string                       `json:"organization"`
+	Policy                string                       `json:"policy"` // path of the policy file
+	Summary               PolicySummary                `json:"summary"`
+	Repositories          []RepositoryPolicyViolations `json:"repositories"` // repositories with at least one violation
+	Findings              []Finding                    `json:"findings"`
+	Truncated             bool                         `json:"truncated"`
+	RemainingRepositories []string                     `json:"remaining_repositories,omitempty"`
+	ProcessTimeSeconds    float64                      `json:"process_time_seconds"`
+}
+
+// PolicySummary represents summary statistics of the policy check
+type PolicySummary struct {
+	WorkflowsScanned      int `json:"workflows_scanned"`
+	ActionUsages          int `json:"action_usages"`
+	Violations            int `json:"violations"`
+	WorkflowsViolating    int `json:"workflows_violating"`
+	RepositoriesViolating int `json:"repositories_violating"`
+	CompliantRepositories int `json:"compliant_repositories"`
+}
+
+// RepositoryPolicyViolations holds the violating workflows of one repository
+type RepositoryPolicyViolations struct {
+	Name      string                     `json:"name"`
+	Workflows []WorkflowPolicyViolations `json:"workflows"`
+}
+
+// WorkflowPolicyViolations holds the violations of one workflow file
+type WorkflowPolicyViolations struct {
+	Path       string            `json:"path"`
+	Violations []PolicyViolation `json:"violations"`
+}
+
+// PolicyViolation is one action usage breaking the policy
+type PolicyViolation struct {
+	Rule    string `json:"rule"` // denied-action, action-not-allowed, or unpinned-action
+	Job     string `json:"job"`
+	Step    int    `json:"step,omitempty"` // 1-based step index; 0 for a reusable workflow call
+	Action  string `json:"action"`
+	Ref     string `json:"ref"`
+	Message string `json:"message"`
+}
+
+// loadPolicy reads and validates a policy file
+func loadPolicy(policyPath string) (*Policy, error) {
+	data, err := os.ReadFile(policyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy: %v", err)
+	}
+
+	var policy Policy
+	if err := yaml.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("failed to parse policy %s: %v", policyPath, err)
+	}
+	if err := policy.validate(); err != nil {
+		return nil, fmt.Errorf("invalid policy %s: %v", policyPath, err)
+	}
+	policy.path = policyPath
+	return &policy, nil
+}
+
+// validate checks pin requirements, patterns, and version constraints, and parses the constraints
+func (p *Policy) validate() error {
+	if err := validatePin(p.RequirePin); err != nil {
+		return fmt.Errorf("require-pin: %v", err)
+	}
+	if len(p.Allow) == 0 && len(p.Deny) == 0 && p.RequirePin == "" {
+		return fmt.Errorf("no allow, deny, or require-pin rules")
+	}
+	for _, list := range []struct {
+		name  string
+		rules []PolicyRule
+	}{{"allow", p.Allow}, {"deny", p.Deny}} {
+		for i := range list.rules {
+			rule := &list.rules[i]
+			if rule.Owner == "" && rule.Action == "" {
+				return fmt.Errorf("%s[%d]: owner or action is required", list.name, i)
+			}
+			if _, err := path.Match(rule.Action, ""); err != nil {
+				return fmt.Errorf("%s[%d]: invalid action pattern '%s'", list.name, i, rule.Action)
+			}
+			if err := validatePin(rule.Pin); err != nil {
+				return fmt.Errorf("%s[%d]: pin: %v", list.name, i, err)
+			}
+			if rule.Pin != "" && list.name == "deny" {
+				return fmt.Errorf("%s[%d]: pin is only supported on allow rules", list.name, i)
+			}
+			constraints, err := parseVersionConstraints(rule.Version)
+			if err != nil {
+				return fmt.Errorf("%s[%d]: %v", list.name, i, err)
+			}
+			rule.constraints = constraints
+		}
+	}
+	return nil
+}
+
+// validatePin checks a pin requirement
+func validatePin(pin string) error {
+	switch pin {
+	case "", PolicyPinSHA, PolicyPinTag:
+		return nil
+	}
+	return fmt.Errorf("invalid pin requirement '%s'. Valid options: sha, tag", pin)
+}
+
+// parseVersionConstraints parses comma-separated version constraints such as ">=3, <5" or "v4"
+func parseVersionConstraints(value string) ([]versionConstraint, error) {
+	var constraints []versionConstraint
+	for _, part := range splitList(value) {
+		op := "="
+		for _, candidate := range []string{">=", "<=", ">", "<", "="} {
+			if strings.HasPrefix(part, candidate) {
+				op, part = candidate, strings.TrimSpace(part[len(candidate):])
+				break
+			}
+		}
+		version, ok := parseVersion(part)
+		if !ok {
+			return nil, fmt.Errorf("invalid version constraint '%s'", value)
+		}
+		constraints = append(constraints, versionConstraint{op: op, version: version})
+	}
+	return constraints, nil
+}
+
+// satisfied reports whether a version meets the constraint. Versions are compared at the precision of
+// the constraint, so v4.1.2 is =4 and <5 but not >4.
+func (c versionConstraint) satisfied(version []int) bool {
+	if len(version) > len(c.version) {
+		version = version[:len(c.version)]
+	}
+	cmp := compareVersions(version, c.version)
+	switch c.op {
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	}
+	return cmp == 0
+}
+
+// matchesName reports whether the owner and action pattern of a rule match an action
+func (r PolicyRule) matchesName(action string) bool {
+	if r.Owner != "" && !strings.EqualFold(r.Owner, strings.SplitN(action, "/", 2)[0]) {
+		return false
+	}
+	if r.Action != "" {
+		byName, _ := path.Match(r.Action, action)
+		byRepository, _ := path.Match(r.Action, actionRepository(action))
+		if !byName && !byRepository {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesVersion reports whether a version meets every constraint of a rule; a version that is not
+// known never meets a constraint
+func (r PolicyRule) matchesVersion(version string) bool {
+	if len(r.constraints) == 0 {
+		return true
+	}
+	parsed, ok := parseVersion(version)
+	if !ok {
+		return false
+	}
+	for _, constraint := range r.constraints {
+		if !constraint.satisfied(parsed) {
+			return false
+		}
+	}
+	return true
+}
+
+// describe renders a rule for violation messages, e.g. docker/* >=3
+func (r PolicyRule) describe() string {
+	var parts []string
+	if r.Owner != "" {
+		parts = append(parts, "owner "+r.Owner)
+	}
+	if r.Action != "" {
+		parts = append(parts, r.Action)
+	}
+	if r.Version != "" {
+		parts = append(parts, r.Version)
+	}
+	description := strings.Join(parts, " ")
+	if r.Reason != "" {
+		description += ": " + r.Reason
+	}
+	return description
+}
+
+// needsResolution reports whether evaluating the policy needs tags and branches told apart or the
+// versions of SHA-pinned refs
+func (p *Policy) needsResolution() bool {
+	if p.RequirePin != "" {
+		return true
+	}
+	for _, rule := range append(append([]PolicyRule{}, p.Allow...), p.Deny...) {
+		if rule.Pin != "" || rule.Version != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluate returns the violations of one action reference. SHA-pinned refs are compared by the tag
+// pointing to their commit.
+func (p *Policy) evaluate(action, ref string, resolution refResolution) []PolicyViolation {
+	if strings.HasPrefix(action, "./") {
+		return nil
+	}
+	version := ref
+	if resolution.Kind == PinSHA {
+		version = resolution.Version
+	}
+
+	var violations []PolicyViolation
+	for _, rule := range p.Deny {
+		if rule.matchesName(action) && rule.matchesVersion(version) {
+			violations = append(violations, PolicyViolation{
+				Rule:    RuleDeniedAction,
+				Message: fmt.Sprintf("%s@%s is denied by policy (%s)", action, ref, rule.describe()),
+			})
+			break
+		}
+	}
+
+	pin := p.RequirePin
+	if len(p.Allow) > 0 {
+		var allowed *PolicyRule
+		var nameMatches []string
+		for i, rule := range p.Allow {
+			if !rule.matchesName(action) {
+				continue
+			}
+			if rule.matchesVersion(version) {
+				allowed = &p.Allow[i]
+				break
+			}
+			nameMatches = append(nameMatches, rule.Version)
+		}
+		switch {
+		case allowed != nil:
+			if allowed.Pin != "" {
+				pin = allowed.Pin
+			}
+		case len(nameMatches) > 0:
+			violations = append(violations, PolicyViolation{
+				Rule:    RuleActionNotAllowed,
+				Message: fmt.Sprintf("%s@%s is not an allowed version (allowed: %s)", action, ref, strings.Join(nameMatches, " or ")),
+			})
+		default:
+			violations = append(violations, PolicyViolation{
+				Rule:    RuleActionNotAllowed,
+				Message: fmt.Sprintf("%s@%s is not on the allowlist", action, ref),
+			})
+		}
+	}
+
+	if (pin == PolicyPinSHA && resolution.Kind != PinSHA) || (pin == PolicyPinTag && resolution.Kind == PinBranch) {
+		violations = append(violations, PolicyViolation{
+			Rule:    RuleUnpinnedAction,
+			Message: fmt.Sprintf("%s@%s is pinned to a %s; policy requires pinning to %s", action, ref, resolution.Kind, pinDescriptions[pin]),
+		})
+	}
+
+	for i := range violations {
+		violations[i].Action, violations[i].Ref = action, ref
+	}
+	return violations
+}
+
+// analyzePolicy checks every `uses:` reference of an organization against the policy and fails when
+// violations remain errors after severity overrides
+func analyzePolicy(org string, startTime time.Time, outputFormat, outputFile string, opts scanOptions) error {
+	repositories, _, err := listRepositoryWorkflows(org, opts)
+	if err != nil {
+		return err
+	}
+
+	var files []WorkflowFile
+	for _, repo := range repositories {
+		for _, workflowPath := range repo.Workflows {
+			files = append(files, WorkflowFile{Repo: repo.Name, Path: workflowPath})
+		}
+	}
+
+	logInfof("🛡️  Checking %d workflow files against policy %s...\n\n", len(files), opts.Policy.path)
+
+	results := fetchWorkflows(org, files, opts, func(wf WorkflowFile, result workflowFetch) {
+		if result.Err != nil {
+			logWarnf("⚠️  Warning: Could not analyze %s/%s: %v\n", wf.Repo, wf.Path, result.Err)
+		}
+	})
+
+	// Resolve every distinct reference once when the policy looks at pinning or versions
+	seen := make(map[string]bool)
+	var references []string
+	var skipped []WorkflowFile
+	for i, result := range results {
+		if result.Skipped {
+			skipped = append(skipped, files[i])
+			continue
+		}
+		for _, action := range result.Actions {
+			reference := action.Name + "@" + action.Version
+			if !seen[reference] {
+				seen[reference] = true
+				references = append(references, reference)
+			}
+		}
+	}
+	sort.Strings(references)
+	resolutions := make(map[string]refResolution, len(references))
+	if opts.Policy.needsResolution() {
+		resolutions = resolvePinning(references, opts)
+	} else {
+		for _, reference := range references {
+			_, ref, _ := splitActionReference(reference)
+			resolutions[reference] = refResolution{Kind: guessPinning(ref)}
+			if isPinnedToSHA(ref) {
+				resolutions[reference] = refResolution{Kind: PinSHA}
+			}
+		}
+	}
+
+	report := PolicyReport{
+		Organization: org,
+		Policy:       opts.Policy.path,
+		Repositories: []RepositoryPolicyViolations{},
+		Findings:     []Finding{},
+	}
+	scannedRepositories := make(map[string]bool)
+	repoIndex := make(map[string]int)
+	flagged := make(map[string]bool)
+	for i, result := range results {
+		if result.Skipped || result.Err != nil {
+			continue
+		}
+		wf := files[i]
+		report.Summary.WorkflowsScanned++
+		scannedRepositories[wf.Repo] = true
+
+		var workflowViolations []PolicyViolation
+		for _, action := range result.Actions {
+			report.Summary.ActionUsages++
+			for _, violation := range opts.Policy.evaluate(action.Name, action.Version, resolutions[action.Name+"@"+action.Version]) {
+				violation.Job, violation.Step = action.Job, action.Step
+				workflowViolations = append(workflowViolations, violation)
+
+				// One finding per rule, reference, and workflow, however often the workflow uses it
+				key := wf.Repo + "|" + wf.Path + "|" + violation.Rule + "|" + action.Name + "@" + action.Version
+				if !flagged[key] {
+					flagged[key] = true
+					report.Findings = append(report.Findings, Finding{
+						RuleID:     violation.Rule,
+						Severity:   SeverityError,
+						Repository: wf.Repo,
+						Workflow:   wf.Path,
+						Action:     action.Name,
+						Version:    action.Version,
+						Message:    violation.Message,
+					})
+				}
+			}
+		}
+		if len(workflowViolations) == 0 {
+			continue
+		}
+
+		index, ok := repoIndex[wf.Repo]
+		if !ok {
+			index = len(report.Repositories)
+			repoIndex[wf.Repo] = index
+			report.Repositories = append(report.Repositories, RepositoryPolicyViolations{Name: wf.Repo})
+		}
+		report.Repositories[index].Workflows = append(report.Repositories[index].Workflows,
+			WorkflowPolicyViolations{Path: wf.Path, Violations: workflowViolations})
+		report.Summary.Violations += len(workflowViolations)
+		report.Summary.WorkflowsViolating++
+	}
+	report.Summary.RepositoriesViolating = len(report.Repositories)
+	report.Summary.CompliantRepositories = len(scannedRepositories) - len(report.Repositories)
+	report.RemainingRepositories = remainingRepositories(skipped)
+
+	opts.finishFindings(org, &report.Findings)
+	report.Truncated = len(report.RemainingRepositories) > 0
+	report.ProcessTimeSeconds = time.Since(startTime).Seconds()
+
+	// Violations gate CI on their own; --fail-on and config thresholds can only make the check stricter
+	thresholds := map[string]int{SeverityError: 1}
+	for severity, threshold := range opts.FailOn {
+		if current, ok := thresholds[severity]; !ok || threshold < current {
+			thresholds[severity] = threshold
+		}
+	}
+	opts.FailOn = thresholds
+
+	return opts.writeFindingsReport(org, &report, report.Findings, outputFile, func(writer io.Writer) error {
+		return outputPolicyReport(report, outputFormat, writer)
+	})
+}
+
+// describeViolation returns the job and step of a violation, e.g. build step 3
+func describeViolation(violation PolicyViolation) string {
+	if violation.Step == 0 {
+		return violation.Job
+	}
+	return fmt.Sprintf("%s step %d", violation.Job, violation.Step)
+}
+
+// outputPolicyReport outputs the policy check in the specified format
+func outputPolicyReport(report PolicyReport, format string, writer io.Writer) error {
+	if formatter, ok := lookupFormatter(format); ok {
+		return formatter.Format(report, writer)
+	}
+
+	switch format {
+	case "table":
+		return outputPolicyTable(report, writer)
+
+	case "csv":
+		return outputPolicyCSV(report, writer)
+
+	case "markdown":
+		return outputFindingsMarkdown("🛡️ Action Policy", report.Findings, report.RemainingRepositories, writer)
+
+	case "sarif":
+		return outputSARIF("policy", report.Findings, writer)
+
+	default: // "default"
+		fmt.Fprintln(writer, "🛡️  Action Policy")
+		fmt.Fprintln(writer, "="+strings.Repeat("=", 50))
+
+		if len(report.Repositories) == 0 {
+			fmt.Fprintln(writer, "✅ Every action reference complies with the policy")
+		}
+		for _, repo := range report.Repositories {
+			fmt.Fprintf(writer, "\n📁 %s\n", repo.Name)
+			for _, workflow := range repo.Workflows {
+				fmt.Fprintf(writer, "   📄 %s (%d violations)\n", workflow.Path, len(workflow.Violations))
+				for _, violation := range workflow.Violations {
+					fmt.Fprintf(writer, "      ❌ [%s] %s (%s)\n", violation.Rule, violation.Message, describeViolation(violation))
+				}
+			}
+		}
+
+		fmt.Fprintln(writer, "\n📊 Summary:")
+		fmt.Fprintf(writer, "   • Policy: %s\n", report.Policy)
+		fmt.Fprintf(writer, "   • Workflows scanned: %d\n", report.Summary.WorkflowsScanned)
+		fmt.Fprintf(writer, "   • Action usages checked: %d\n", report.Summary.ActionUsages)
+		fmt.Fprintf(writer, "   • Violations: %d in %d workflows\n", report.Summary.Violations, report.Summary.WorkflowsViolating)
+		fmt.Fprintf(writer, "   • Repositories violating / compliant: %d / %d\n", report.Summary.RepositoriesViolating, report.Summary.CompliantRepositories)
+		fmt.Fprintf(writer, "   ⏱️  Process time: %.3fs\n", report.ProcessTimeSeconds)
+
+		if report.Truncated {
+			outputTruncationNotice(report.RemainingRepositories, writer)
+		}
+
+		return nil
+	}
+}
+
+// outputPolicyTable outputs the policy check in table format
+func outputPolicyTable(report PolicyReport, writer io.Writer) error {
+	fmt.Fprintln(writer, "╔════════════════════════════════════════════════════════════════════════════════════════════════════╗")
+	fmt.Fprintf(writer, "║                                       🛡️  ACTION POLICY                                            ║\n")
+	fmt.Fprintln(writer, "╚════════════════════════════════════════════════════════════════════════════════════════════════════╝")
+	fmt.Fprintf(writer, "  🏢 Organization: %-59s \n", report.Organization)
+	fmt.Fprintf(writer, "  📜 Policy: %-65s \n", report.Policy)
+	fmt.Fprintf(writer, "  📄 Workflows Scanned: %-55d \n", report.Summary.WorkflowsScanned)
+	fmt.Fprintf(writer, "  ❌ Violations: %-62d \n", report.Summary.Violations)
+	fmt.Fprintf(writer, "  📁 Repositories Violating: %-50d \n", report.Summary.RepositoriesViolating)
+	fmt.Fprintln(writer, " ═════════════════════════════════════════════════════════════════════════════════════════════════════")
+	fmt.Fprintln(writer)
+
+	if len(report.Repositories) == 0 {
+		fmt.Fprintln(writer, "┌─────────────────────────────────────────┐")
+		fmt.Fprintln(writer, "│         No policy violations found      │")
+		fmt.Fprintln(writer, "└─────────────────────────────────────────┘")
+		return nil
+	}
+
+	fmt.Fprintln(writer, "┌─────────────────────┬────────────────────────────────┬────────────────────────────────────┬────────────────────┐")
+	fmt.Fprintf(writer, "│ %-18s │ %-29s │ %-34s │ %-18s │\n", "📁 REPOSITORY", "📄 WORKFLOW", "🔧 REFERENCE", "RULE")
+	fmt.Fprintln(writer, "├─────────────────────┼────────────────────────────────┼────────────────────────────────────┼────────────────────┤")
+	for _, repo := range report.Repositories {
+		for _, workflow := range repo.Workflows {
+			for _, violation := range workflow.Violations {
+				fmt.Fprintf(writer, "│ %-19s │ %-30s │ %-34s │ %-18s │\n",
+					truncate(repo.Name, 19), truncate(workflow.Path, 30), truncate(violation.Action+"@"+violation.Ref, 34), violation.Rule)
+			}
+		}
+	}
+	fmt.Fprintln(writer, "└─────────────────────┴────────────────────────────────┴────────────────────────────────────┴────────────────────┘")
+	fmt.Fprintln(writer)
+	return nil
+}
+
+// outputPolicyCSV outputs the policy check in CSV format, one row per violating action usage
+func outputPolicyCSV(report PolicyReport, writer io.Writer) error {
+	w := newCSVWriter(writer)
+	w.Write([]string{"Repository", "Workflow", "Job", "Step", "Action", "Ref", "Rule", "Message"})
+	for _, repo := range report.Repositories {
+		for _, workflow := range repo.Workflows {
+			for _, violation := range workflow.Violations {
+				w.Write([]string{repo.Name, workflow.Path, violation.Job, strconv.Itoa(violation.Step),
+					violation.Action, violation.Ref, violation.Rule, violation.Message})
+			}
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Scan stages timed by --profile-scan
+const (
+	stageEnumerate = "enumerate" // repository and workflow discovery
+	stageFetch     = "fetch"     // workflow and config file downloads, including parsing
+	stageExport    = "export"    // integrations such as the Projects export
+)
+
+// stageOrder fixes the order stages are printed in
+var stageOrder = []string{stageEnumerate, stageFetch, stageExport}
+
+// stageTiming accumulates the time spent in one scan stage
+type stageTiming struct {
+	Calls    int           `json:"calls"`
+	Duration time.Duration `json:"duration_ns"`
+}
+
+// scanProfile records stage timings and the shape of a scan.
+// All methods are safe on a nil profile, which records nothing.
+type scanProfile struct {
+	mu           sync.Mutex
+	started      time.Time
+	stages       map[string]*stageTiming
+	Repositories int
+	Workflows    int
+}
+
+// newScanProfile starts profiling a scan
+func newScanProfile() *scanProfile {
+	return &scanProfile{started: time.Now(), stages: make(map[string]*stageTiming)}
+}
+
+// track starts timing a stage and returns the function that stops it
+func (p *scanProfile) track(stage string) func() {
+	if p == nil {
+		return func() {}
+	}
+	start := time.Now()
+	return func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		timing, ok := p.stages[stage]
+		if !ok {
+			timing = &stageTiming{}
+			p.stages[stage] = timing
+		}
+		timing.Calls++
+		timing.Duration += time.Since(start)
+	}
+}
+
+// recordShape stores the number of repositories and workflows selected for scanning
+func (p *scanProfile) recordShape(repositories, workflows int) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	p.Repositories = repositories
+	p.Workflows = workflows
+	p.mu.Unlock()
+}
+
+// timings returns a snapshot of the stage timings and the total elapsed time
+func (p *scanProfile) timings() (map[string]stageTiming, time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	snapshot := make(map[string]stageTiming, len(p.stages))
+	for stage, timing := range p.stages {
+		snapshot[stage] = *timing
+	}
+	return snapshot, time.Since(p.started)
+}
+
+// outputScanProfile writes the stage timing breakdown
+func outputScanProfile(p *scanProfile, writer io.Writer) {
+	if p == nil {
+		return
+	}
+	stages, total := p.timings()
+
+	fmt.Fprintf(writer, "\n⏱️  Scan profile (%d repositories, %d workflows)\n", p.Repositories, p.Workflows)
+	accounted := time.Duration(0)
+	for _, stage := range stageOrder {
+		timing, ok := stages[stage]
+		if !ok {
+			continue
+		}
+		accounted += timing.Duration
+		fmt.Fprintf(writer, "  %-10s %10s  %5d calls  %s avg\n", stage, timing.Duration.Round(time.Millisecond),
+			timing.Calls, (timing.Duration / time.Duration(timing.Calls)).Round(time.Millisecond))
+	}
+	// Fetch calls are sequential, so the rest is analysis and output
+	if other := total - accounted; other > 0 {
+		fmt.Fprintf(writer, "  %-10s %10s\n", "other", other.Round(time.Millisecond))
+	}
+	fmt.Fprintf(writer, "  %-10s %10s\n", "total", total.Round(time.Millisecond))
+	if reused := workflowCache.reusedFiles(); reused > 0 {
+		fmt.Fprintf(writer, "  %d workflow files read from the cache of unchanged repositories\n", reused)
+	}
+	if revalidated := etagCache.revalidatedRequests(); revalidated > 0 {
+		fmt.Fprintf(writer, "  %d file requests answered 304 Not Modified\n", revalidated)
+	}
+}
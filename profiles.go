@@ -0,0 +1,160 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// noProfile is the --profile that skips the default profile
+const noProfile = "none"
+
+// profileExcludedFlags cannot be set by a profile: they select the profile or only print help
+var profileExcludedFlags = map[string]bool{"profile": true, "help": true, "h": true}
+
+// UserConfig is the user configuration file, ~/.config/gh-action-lens/config.yml. Each profile is a named
+// set of scan flags, keyed by their long names without dashes, e.g.
+//
+//	default-profile: platform
+//	profiles:
+//	  platform:
+//	    org: myorg
+//	    exclude-repos: "sandbox-*,*-archive"
+//	    format: json
+//	    policy: ~/policies/actions.yml
+//	    concurrency: 8
+type UserConfig struct {
+	DefaultProfile string                            `yaml:"default-profile"` // applied when --profile is not given
+	Profiles       map[string]map[string]interface{} `yaml:"profiles"`
+}
+
+// userConfigPath returns the path of the user configuration file; $XDG_CONFIG_HOME replaces ~/.config
+func userConfigPath() (string, error) {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "gh-action-lens", "config.yml"), nil
+}
+
+// loadUserConfig reads the user configuration file; a missing file yields nil
+func loadUserConfig(configPath string) (*UserConfig, error) {
+	data, err := os.ReadFile(configPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", configPath, err)
+	}
+
+	var config UserConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", configPath, err)
+	}
+	if config.DefaultProfile != "" && config.Profiles[config.DefaultProfile] == nil {
+		return nil, fmt.Errorf("invalid %s: default-profile '%s' is not defined", configPath, config.DefaultProfile)
+	}
+	return &config, nil
+}
+
+// applyProfile sets the flags of a profile of the user configuration file, or of its default profile
+// when name is empty, and returns the name of the profile applied. Flags given on the command line,
+// under any of their names, take precedence.
+func applyProfile(flags *flag.FlagSet, name string) (string, error) {
+	if name == noProfile {
+		return "", nil
+	}
+	configPath, err := userConfigPath()
+	if err != nil {
+		return "", err
+	}
+	config, err := loadUserConfig(configPath)
+	if err != nil {
+		return "", err
+	}
+	if name == "" {
+		if config == nil || config.DefaultProfile == "" {
+			return "", nil
+		}
+		name = config.DefaultProfile
+	}
+	if config == nil {
+		return "", fmt.Errorf("--profile %s: %s does not exist", name, configPath)
+	}
+	profile, ok := config.Profiles[name]
+	if !ok {
+		return "", fmt.Errorf("--profile %s is not defined in %s; available profiles: %s", name, configPath, strings.Join(sortedKeys(config.Profiles), ", "))
+	}
+
+	// Aliases such as -o and --org share their value
+	var given []flag.Value
+	flags.Visit(func(f *flag.Flag) { given = append(given, f.Value) })
+	isGiven := func(f *flag.Flag) bool {
+		for _, value := range given {
+			if value == f.Value {
+				return true
+			}
+		}
+		return false
+	}
+
+	keys := make([]string, 0, len(profile))
+	for key := range profile {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		f := flags.Lookup(key)
+		if f == nil || profileExcludedFlags[key] {
+			return "", fmt.Errorf("profile %s in %s: unknown flag '%s'", name, configPath, key)
+		}
+		if isGiven(f) {
+			continue
+		}
+		values, err := profileValues(profile[key])
+		if err != nil {
+			return "", fmt.Errorf("profile %s in %s: %s: %v", name, configPath, key, err)
+		}
+		for _, value := range values {
+			if err := flags.Set(key, value); err != nil {
+				return "", fmt.Errorf("profile %s in %s: %s: %v", name, configPath, key, err)
+			}
+		}
+	}
+	return name, nil
+}
+
+// profileValues converts a profile value to flag values: a scalar, or a list for repeatable flags such
+// as property or path. A leading ~/ is expanded to the home directory.
+func profileValues(value interface{}) ([]string, error) {
+	items, ok := value.([]interface{})
+	if !ok {
+		items = []interface{}{value}
+	}
+	var values []string
+	for _, item := range items {
+		switch item.(type) {
+		case string, bool, int, float64:
+		default:
+			return nil, fmt.Errorf("expected a string, number, boolean, or a list of them")
+		}
+		text := fmt.Sprint(item)
+		if strings.HasPrefix(text, "~/") {
+			if home, err := os.UserHomeDir(); err == nil {
+				text = filepath.Join(home, text[2:])
+			}
+		}
+		values = append(values, text)
+	}
+	return values, nil
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+// progressInterval is how often the progress bar is redrawn
+const progressInterval = 200 * time.Millisecond
+
+// progressBarWidth is the number of cells of the progress bar
+const progressBarWidth = 24
+
+// apiCalls counts the API requests sent by the process, including retries
+var apiCalls atomic.Int64
+
+// scanProgress is the progress bar of the running scan; nil when it is not shown
+var scanProgress *progress
+
+// progress renders a single-line progress bar on stderr: repositories scanned of those listed,
+// workflows parsed, API calls made, the remaining rate limit budget, and the estimated time left.
+// All methods are safe on a nil progress, which shows nothing.
+type progress struct {
+	mu        sync.Mutex
+	writer    *os.File
+	start     time.Time       // start of the scan after the listing, for the estimated time left
+	listing   bool            // an organization's repositories are being listed
+	seen      int             // repositories seen by the running listing
+	listed    map[string]bool // org/repo -> listed with workflows
+	workflows map[string]int  // org/repo -> workflows of a started repository
+	done      map[string]bool // org/repo -> scanned
+	parsed    int             // workflows of the scanned repositories
+	stopped   chan struct{}
+	finished  sync.WaitGroup
+}
+
+// newProgress starts a progress bar on stderr. It returns nil, showing nothing, when stderr is not a
+// terminal, e.g. in CI or when piped.
+func newProgress() *progress {
+	if !term.IsTerminal(int(os.Stderr.Fd())) {
+		return nil
+	}
+	p := &progress{
+		writer:    os.Stderr,
+		start:     time.Now(),
+		listing:   true,
+		listed:    make(map[string]bool),
+		workflows: make(map[string]int),
+		done:      make(map[string]bool),
+		stopped:   make(chan struct{}),
+	}
+	p.finished.Add(1)
+	go p.run()
+	return p
+}
+
+// run redraws the bar until the progress is stopped
+func (p *progress) run() {
+	defer p.finished.Done()
+	ticker := time.NewTicker(progressInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.stopped:
+			fmt.Fprint(p.writer, "\r\033[K")
+			return
+		case <-ticker.C:
+			// The budgets are read first: the rate limit gate logs while holding its lock
+			budgets := remainingBudgets()
+			p.mu.Lock()
+			fmt.Fprint(p.writer, "\r\033[K"+fitTerminal(p.render(budgets), p.writer))
+			p.mu.Unlock()
+		}
+	}
+}
+
+// stop clears the bar; it is called before the report is written and may be called repeatedly
+func (p *progress) stop() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	select {
+	case <-p.stopped:
+	default:
+		close(p.stopped)
+	}
+	p.mu.Unlock()
+	p.finished.Wait()
+}
+
+// interrupt clears the bar line and runs write, e.g. to log a message; the next redraw shows the bar
+// below it. Without a bar write runs directly.
+func (p *progress) interrupt(write func()) {
+	if p == nil {
+		write()
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	select {
+	case <-p.stopped:
+	default:
+		fmt.Fprint(p.writer, "\r\033[K")
+	}
+	write()
+}
+
+// listingRepositories shows the number of repositories the listing of an organization has seen so far
+func (p *progress) listingRepositories(seen int) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.listing, p.seen = true, seen
+}
+
+// listedRepositories adds the repositories with workflows of a completed listing to the total
+func (p *progress) listedRepositories(org string, repositories []RepositoryWorkflows) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, repo := range repositories {
+		p.listed[org+"/"+repo.Name] = true
+	}
+	// The estimate starts with the scan of the listed repositories
+	if p.listing && len(p.done) == 0 {
+		p.start = time.Now()
+	}
+	p.listing = false
+}
+
+// repositoryStarted records the number of workflows of a repository an analysis started on
+func (p *progress) repositoryStarted(org, repository string, workflows int) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	key := org + "/" + repository
+	p.listed[key] = true
+	if !p.done[key] {
+		p.workflows[key] = workflows
+	}
+}
+
+// repositoryScanned counts a repository once, however many analyses scan it
+func (p *progress) repositoryScanned(org, repository string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	key := org + "/" + repository
+	p.listed[key] = true
+	if !p.done[key] {
+		p.done[key] = true
+		p.parsed += p.workflows[key]
+	}
+}
+
+// render formats the progress line, e.g.
+// "⏳ [██████░░░░] 42/180 repositories · 310 workflows · 1204 API calls · core 3912 left · ETA 2m10s"
+func (p *progress) render(budgets []string) string {
+	total, done := len(p.listed), len(p.done)
+	parts := []string{}
+	if p.listing {
+		parts = append(parts, fmt.Sprintf("⏳ Listing repositories: %d seen", p.seen))
+	} else {
+		filled := 0
+		if total > 0 {
+			filled = done * progressBarWidth / total
+		}
+		bar := strings.Repeat("█", filled) + strings.Repeat("░", progressBarWidth-filled)
+		parts = append(parts, fmt.Sprintf("⏳ [%s] %d/%d repositories", bar, done, total))
+	}
+	parts = append(parts, fmt.Sprintf("%d workflows", p.parsed), fmt.Sprintf("%d API calls", apiCalls.Load()))
+	parts = append(parts, budgets...)
+	if !p.listing && done > 0 && done < total {
+		elapsed := time.Since(p.start)
+		eta := time.Duration(float64(elapsed) / float64(done) * float64(total-done))
+		parts = append(parts, "ETA "+eta.Round(time.Second).String())
+	}
+	return strings.Join(parts, " · ")
+}
+
+// remainingBudgets describes the remaining core and graphql rate limit budgets, e.g. "core 3912 left"
+func remainingBudgets() []string {
+	var budgets []string
+	for _, resource := range []string{"core", "graphql"} {
+		if remaining, ok := apiRateLimit.remaining(resource); ok {
+			budgets = append(budgets, fmt.Sprintf("%s %d left", resource, remaining))
+		}
+	}
+	return budgets
+}
+
+// fitTerminal truncates a line to the width of the terminal, so redrawing it never wraps
+func fitTerminal(line string, writer io.Writer) string {
+	file, ok := writer.(*os.File)
+	if !ok {
+		return line
+	}
+	width, _, err := term.GetSize(int(file.Fd()))
+	if err != nil || width <= 1 || utf8.RuneCountInString(line) < width {
+		return line
+	}
+	runes := []rune(line)
+	return string(runes[:width-2]) + "…"
+}
@@ -0,0 +1,390 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/shurcooL/githubv4"
+)
+
+// projectItemTitlePrefix identifies the draft items managed by gh-action-lens on a project board
+const projectItemTitlePrefix = "[action-lens] "
+
+// projectItemTitle returns the title of a repository's draft item for a scan scope, e.g.
+// "[action-lens] pinning: api-server"; each scan manages its own items on a shared board
+func projectItemTitle(scope, repo string) string {
+	return projectItemTitlePrefix + scope + ": " + repo
+}
+
+// Project field names populated for every violating repository, matched case-insensitively
+const (
+	projectFieldSeverity  = "Severity"
+	projectFieldOwnerTeam = "Owner Team"
+	projectFieldFindings  = "Findings"
+	projectFieldErrors    = "Errors"
+	projectFieldWarnings  = "Warnings"
+)
+
+// RepositoryViolations aggregates the findings of one repository for project export
+type RepositoryViolations struct {
+	Repository string
+	Severity   string
+	OwnerTeam  string
+	Counts     map[string]int // severity -> count
+	Findings   []Finding
+}
+
+// projectField is a field of a GitHub Project (v2)
+type projectField struct {
+	ID       string
+	DataType string
+	Options  map[string]string // single-select option name (lowercase) -> option ID
+}
+
+// groupViolationsByRepository aggregates findings per repository, keeping the highest severity
+func groupViolationsByRepository(findings []Finding) []RepositoryViolations {
+	byRepo := make(map[string]*RepositoryViolations)
+	var names []string
+
+	for _, finding := range findings {
+		v, ok := byRepo[finding.Repository]
+		if !ok {
+			v = &RepositoryViolations{Repository: finding.Repository, Counts: make(map[string]int)}
+			byRepo[finding.Repository] = v
+			names = append(names, finding.Repository)
+		}
+		v.Findings = append(v.Findings, finding)
+		v.Counts[finding.Severity]++
+		if severityRank(finding.Severity) > severityRank(v.Severity) {
+			v.Severity = finding.Severity
+		}
+	}
+
+	sort.Strings(names)
+	var violations []RepositoryViolations
+	for _, name := range names {
+		violations = append(violations, *byRepo[name])
+	}
+	return violations
+}
+
+// severityRank orders severities so that higher values are more severe
+func severityRank(severity string) int {
+	switch severity {
+	case SeverityError:
+		return 3
+	case SeverityWarning:
+		return 2
+	case SeverityInfo:
+		return 1
+	}
+	return 0
+}
+
+// fetchOwnerTeam returns the team with the highest permission on a repository, or "" if none
+func fetchOwnerTeam(org, repo string) string {
+	var teams []struct {
+		Slug       string `json:"slug"`
+		Permission string `json:"permission"`
+	}
+	if err := restGet("repos/"+org+"/"+repo+"/teams", &teams); err != nil {
+		return ""
+	}
+
+	rank := map[string]int{"admin": 5, "maintain": 4, "push": 3, "triage": 2, "pull": 1}
+	best := ""
+	bestRank := 0
+	for _, team := range teams {
+		if rank[team.Permission] > bestRank {
+			best = team.Slug
+			bestRank = rank[team.Permission]
+		}
+	}
+	return best
+}
+
+// exportFindingsToProject creates or updates one draft item per violating repository and scan scope on an
+// organization-owned GitHub Project (v2) and fills its severity, owner team, and count fields. Items of the
+// scope's repositories without violations are archived and restored once they violate again; a partial
+// scan did not see every repository, so it archives nothing. It returns the number of items archived.
+func exportFindingsToProject(org string, projectNumber int, scope string, findings []Finding, partial bool) (int, error) {
+	client, err := newGraphQLClient()
+	if err != nil {
+		return 0, err
+	}
+
+	projectID, fields, err := fetchProjectFields(client, org, projectNumber)
+	if err != nil {
+		return 0, err
+	}
+
+	existing, err := fetchProjectDraftItems(client, projectID, projectItemTitle(scope, ""))
+	if err != nil {
+		return 0, err
+	}
+
+	violations := groupViolationsByRepository(findings)
+	violating := make(map[string]bool, len(violations))
+	for _, v := range violations {
+		violating[v.Repository] = true
+	}
+
+	archived := 0
+	if !partial {
+		var repositories []string
+		for repo, item := range existing {
+			if !violating[repo] && !item.Archived {
+				repositories = append(repositories, repo)
+			}
+		}
+		sort.Strings(repositories)
+		for _, repo := range repositories {
+			var m struct {
+				ArchiveProjectV2Item struct {
+					Item struct{ ID string }
+				} `graphql:"archiveProjectV2Item(input: $input)"`
+			}
+			input := githubv4.ArchiveProjectV2ItemInput{ProjectID: projectID, ItemID: githubv4.ID(existing[repo].ItemID)}
+			if err := client.Mutate("ArchiveProjectItem", &m, map[string]interface{}{"input": input}); err != nil {
+				return archived, fmt.Errorf("failed to archive project item for %s: %v", repo, err)
+			}
+			archived++
+		}
+	}
+
+	for _, v := range violations {
+		v.OwnerTeam = fetchOwnerTeam(org, v.Repository)
+
+		title := projectItemTitle(scope, v.Repository)
+		body := projectItemBody(org, v)
+
+		item, ok := existing[v.Repository]
+		if ok {
+			var m struct {
+				UpdateProjectV2DraftIssue struct {
+					DraftIssue struct{ ID string }
+				} `graphql:"updateProjectV2DraftIssue(input: $input)"`
+			}
+			bodyStr := githubv4.String(body)
+			input := githubv4.UpdateProjectV2DraftIssueInput{DraftIssueID: item.DraftIssueID, Body: &bodyStr}
+			if err := client.Mutate("UpdateProjectItem", &m, map[string]interface{}{"input": input}); err != nil {
+				return archived, fmt.Errorf("failed to update project item for %s: %v", v.Repository, err)
+			}
+			if item.Archived {
+				var m struct {
+					UnarchiveProjectV2Item struct {
+						Item struct{ ID string }
+					} `graphql:"unarchiveProjectV2Item(input: $input)"`
+				}
+				input := githubv4.UnarchiveProjectV2ItemInput{ProjectID: projectID, ItemID: githubv4.ID(item.ItemID)}
+				if err := client.Mutate("UnarchiveProjectItem", &m, map[string]interface{}{"input": input}); err != nil {
+					return archived, fmt.Errorf("failed to restore project item for %s: %v", v.Repository, err)
+				}
+			}
+		} else {
+			var m struct {
+				AddProjectV2DraftIssue struct {
+					ProjectItem struct{ ID string }
+				} `graphql:"addProjectV2DraftIssue(input: $input)"`
+			}
+			bodyStr := githubv4.String(body)
+			input := githubv4.AddProjectV2DraftIssueInput{ProjectID: projectID, Title: githubv4.String(title), Body: &bodyStr}
+			if err := client.Mutate("AddProjectItem", &m, map[string]interface{}{"input": input}); err != nil {
+				return archived, fmt.Errorf("failed to add project item for %s: %v", v.Repository, err)
+			}
+			item = projectDraftItem{ItemID: m.AddProjectV2DraftIssue.ProjectItem.ID}
+		}
+
+		values := map[string]interface{}{
+			projectFieldSeverity:  v.Severity,
+			projectFieldOwnerTeam: v.OwnerTeam,
+			projectFieldFindings:  len(v.Findings),
+			projectFieldErrors:    v.Counts[SeverityError],
+			projectFieldWarnings:  v.Counts[SeverityWarning],
+		}
+		for name, value := range values {
+			field, ok := fields[strings.ToLower(name)]
+			if !ok {
+				continue
+			}
+			if err := setProjectFieldValue(client, projectID, item.ItemID, field, value); err != nil {
+				return archived, fmt.Errorf("failed to set %s for %s: %v", name, v.Repository, err)
+			}
+		}
+	}
+
+	return archived, nil
+}
+
+// fetchProjectFields looks up a project's node ID and its fields by lowercase name
+func fetchProjectFields(client *api.GraphQLClient, org string, number int) (githubv4.ID, map[string]projectField, error) {
+	var q struct {
+		Organization struct {
+			ProjectV2 struct {
+				ID     githubv4.ID
+				Fields struct {
+					Nodes []struct {
+						Common struct {
+							ID       string
+							Name     string
+							DataType string
+						} `graphql:"... on ProjectV2FieldCommon"`
+						SingleSelect struct {
+							Options []struct {
+								ID   string
+								Name string
+							}
+						} `graphql:"... on ProjectV2SingleSelectField"`
+					}
+				} `graphql:"fields(first: 50)"`
+			} `graphql:"projectV2(number: $number)"`
+		} `graphql:"organization(login: $org)"`
+	}
+
+	vars := map[string]interface{}{
+		"org":    githubv4.String(org),
+		"number": githubv4.Int(number),
+	}
+	if err := client.Query("ProjectFields", &q, vars); err != nil {
+		return nil, nil, fmt.Errorf("failed to load project %d: %v", number, err)
+	}
+
+	fields := make(map[string]projectField)
+	for _, node := range q.Organization.ProjectV2.Fields.Nodes {
+		field := projectField{ID: node.Common.ID, DataType: node.Common.DataType, Options: make(map[string]string)}
+		for _, option := range node.SingleSelect.Options {
+			field.Options[strings.ToLower(option.Name)] = option.ID
+		}
+		fields[strings.ToLower(node.Common.Name)] = field
+	}
+
+	return q.Organization.ProjectV2.ID, fields, nil
+}
+
+// projectDraftItem identifies a draft issue item on a project board
+type projectDraftItem struct {
+	ItemID       string
+	DraftIssueID githubv4.ID
+	Archived     bool
+}
+
+// fetchProjectDraftItems returns the project's draft items whose title starts with prefix, archived ones
+// included, keyed by the rest of the title: the repository name
+func fetchProjectDraftItems(client *api.GraphQLClient, projectID githubv4.ID, prefix string) (map[string]projectDraftItem, error) {
+	var q struct {
+		Node struct {
+			ProjectV2 struct {
+				Items struct {
+					Nodes []struct {
+						ID         string
+						IsArchived bool
+						Content    struct {
+							DraftIssue struct {
+								ID    githubv4.ID
+								Title string
+							} `graphql:"... on DraftIssue"`
+						}
+					}
+					PageInfo struct {
+						HasNextPage bool
+						EndCursor   githubv4.String
+					}
+				} `graphql:"items(first: 100, after: $cursor)"`
+			} `graphql:"... on ProjectV2"`
+		} `graphql:"node(id: $id)"`
+	}
+
+	vars := map[string]interface{}{
+		"id":     projectID,
+		"cursor": (*githubv4.String)(nil),
+	}
+
+	items := make(map[string]projectDraftItem)
+	for {
+		if err := client.Query("ProjectItems", &q, vars); err != nil {
+			return nil, fmt.Errorf("failed to list project items: %v", err)
+		}
+
+		for _, node := range q.Node.ProjectV2.Items.Nodes {
+			title := node.Content.DraftIssue.Title
+			if repo, ok := strings.CutPrefix(title, prefix); ok {
+				items[repo] = projectDraftItem{ItemID: node.ID, DraftIssueID: node.Content.DraftIssue.ID, Archived: node.IsArchived}
+			}
+		}
+
+		if !q.Node.ProjectV2.Items.PageInfo.HasNextPage {
+			break
+		}
+		vars["cursor"] = githubv4.NewString(q.Node.ProjectV2.Items.PageInfo.EndCursor)
+	}
+
+	return items, nil
+}
+
+// setProjectFieldValue writes a value into a text, number, or single-select project field
+func setProjectFieldValue(client *api.GraphQLClient, projectID githubv4.ID, itemID string, field projectField, value interface{}) error {
+	var fieldValue githubv4.ProjectV2FieldValue
+
+	switch field.DataType {
+	case "NUMBER":
+		n, ok := value.(int)
+		if !ok {
+			return nil
+		}
+		number := githubv4.Float(n)
+		fieldValue.Number = &number
+	case "SINGLE_SELECT":
+		optionID, ok := field.Options[strings.ToLower(fmt.Sprint(value))]
+		if !ok {
+			return nil
+		}
+		option := githubv4.String(optionID)
+		fieldValue.SingleSelectOptionID = &option
+	case "TEXT":
+		text := githubv4.String(fmt.Sprint(value))
+		fieldValue.Text = &text
+	default:
+		return nil
+	}
+
+	var m struct {
+		UpdateProjectV2ItemFieldValue struct {
+			ProjectV2Item struct{ ID string }
+		} `graphql:"updateProjectV2ItemFieldValue(input: $input)"`
+	}
+	input := githubv4.UpdateProjectV2ItemFieldValueInput{
+		ProjectID: projectID,
+		ItemID:    githubv4.ID(itemID),
+		FieldID:   githubv4.ID(field.ID),
+		Value:     fieldValue,
+	}
+	return client.Mutate("SetProjectFieldValue", &m, map[string]interface{}{"input": input})
+}
+
+// projectItemBody renders the Markdown body of a repository's project item
+func projectItemBody(org string, v RepositoryViolations) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Repository: [%s/%s](https://%s/%s/%s)\n\n", org, v.Repository, githubHost(), org, v.Repository)
+	fmt.Fprintf(&b, "| Rule | Severity | Workflow | Details |\n|---|---|---|---|\n")
+	for _, finding := range v.Findings {
+		fmt.Fprintf(&b, "| `%s` | %s | `%s` | %s |\n", finding.RuleID, finding.Severity, finding.Workflow,
+			strings.ReplaceAll(finding.Message, "|", "\\|"))
+	}
+
+	// One remediation hint per rule keeps the body short
+	b.WriteString("\n### How to fix\n\n")
+	seen := make(map[string]bool)
+	for _, finding := range v.Findings {
+		if seen[finding.RuleID] {
+			continue
+		}
+		seen[finding.RuleID] = true
+		fmt.Fprintf(&b, "- **%s**: %s\n", lookupRule(finding.RuleID).Name, lookupRule(finding.RuleID).Remediation)
+	}
+
+	b.WriteString("\n_Generated by gh-action-lens._\n")
+	return b.String()
+}
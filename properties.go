@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// unsetPropertyValue groups the repositories without a value for --group-by-property
+const unsetPropertyValue = "(unset)"
+
+// PropertyBreakdown is the part of a detailed report for one value of the --group-by-property property
+type PropertyBreakdown struct {
+	Property      string `json:"property"`
+	Value         string `json:"value"`
+	Repositories  int    `json:"repositories"` // repositories with workflows
+	Workflows     int    `json:"workflows"`
+	ActionUsages  int    `json:"action_usages"`
+	UniqueActions int    `json:"unique_actions"`
+	Findings      int    `json:"findings"`
+}
+
+// usesCustomProperties reports whether the scan needs the custom properties of the repositories
+func (o scanOptions) usesCustomProperties() bool {
+	return len(o.Properties) > 0 || o.GroupByProperty != ""
+}
+
+// fetchCustomProperties returns the custom property values of every repository of an organization,
+// keyed by repository name. Multi-select values are joined with commas; unset properties are left out.
+func fetchCustomProperties(org string) (map[string]map[string]string, error) {
+	type propertyValues struct {
+		RepositoryName string `json:"repository_name"`
+		Properties     []struct {
+			PropertyName string      `json:"property_name"`
+			Value        interface{} `json:"value"` // string, []string for multi-select, or null
+		} `json:"properties"`
+	}
+
+	properties := make(map[string]map[string]string)
+	for page := 1; ; page++ {
+		var repositories []propertyValues
+		err := restGet(fmt.Sprintf("orgs/%s/properties/values?per_page=100&page=%d", org, page), &repositories)
+		if err == errFileNotFound {
+			return nil, fmt.Errorf("custom properties are only available for organizations; %s has none", org)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the custom properties of %s: %v", org, err)
+		}
+		for _, repo := range repositories {
+			values := make(map[string]string)
+			for _, property := range repo.Properties {
+				switch value := property.Value.(type) {
+				case string:
+					values[property.PropertyName] = value
+				case []interface{}:
+					var items []string
+					for _, item := range value {
+						items = append(items, fmt.Sprint(item))
+					}
+					values[property.PropertyName] = strings.Join(items, ",")
+				}
+			}
+			properties[strings.ToLower(repo.RepositoryName)] = values
+		}
+		if len(repositories) < 100 {
+			return properties, nil
+		}
+	}
+}
+
+// propertyValues splits a property value into its multi-select items
+func propertyValues(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// matchesProperties reports whether repository properties pass every --property filter. Names and
+// values are compared case-insensitively; a multi-select property matches when any item does.
+func (o scanOptions) matchesProperties(properties map[string]string) bool {
+	for name, want := range o.Properties {
+		matched := false
+		for key, value := range properties {
+			if !strings.EqualFold(key, name) {
+				continue
+			}
+			for _, item := range propertyValues(value) {
+				if strings.EqualFold(item, want) {
+					matched = true
+				}
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// propertyBreakdown groups the repositories of a detailed report by the value of --group-by-property.
+// A repository with a multi-select value counts towards each of its items.
+func (o scanOptions) propertyBreakdown(repositories []ComprehensiveRepository, findings []Finding) []PropertyBreakdown {
+	if o.GroupByProperty == "" {
+		return nil
+	}
+
+	groups := make(map[string][]ComprehensiveRepository)
+	repositoryValues := make(map[string][]string)
+	for _, repo := range repositories {
+		values := []string{unsetPropertyValue}
+		for key, value := range repo.Properties {
+			if strings.EqualFold(key, o.GroupByProperty) && value != "" {
+				values = propertyValues(value)
+			}
+		}
+		for _, value := range values {
+			groups[value] = append(groups[value], repo)
+		}
+		repositoryValues[repo.Name] = values
+	}
+
+	findingCounts := make(map[string]int)
+	for _, finding := range findings {
+		for _, value := range repositoryValues[finding.Repository] {
+			findingCounts[value]++
+		}
+	}
+
+	var breakdown []PropertyBreakdown
+	for value, repos := range groups {
+		summary := summarizeComprehensive(repos)
+		breakdown = append(breakdown, PropertyBreakdown{
+			Property:      o.GroupByProperty,
+			Value:         value,
+			Repositories:  summary.RepositoriesWithWorkflows,
+			Workflows:     summary.TotalWorkflows,
+			ActionUsages:  summary.TotalActionUsages,
+			UniqueActions: summary.UniqueActions,
+			Findings:      findingCounts[value],
+		})
+	}
+	// Largest groups first, the unset group last
+	sort.Slice(breakdown, func(i, j int) bool {
+		a, b := breakdown[i], breakdown[j]
+		if (a.Value == unsetPropertyValue) != (b.Value == unsetPropertyValue) {
+			return b.Value == unsetPropertyValue
+		}
+		if a.Repositories != b.Repositories {
+			return a.Repositories > b.Repositories
+		}
+		return a.Value < b.Value
+	})
+	return breakdown
+}
+
+// outputPropertyBreakdown writes the per-property-value summary of a detailed report
+func outputPropertyBreakdown(breakdown []PropertyBreakdown, writer io.Writer) {
+	if len(breakdown) == 0 {
+		return
+	}
+
+	fmt.Fprintf(writer, "\n🏷️  By %s:\n", breakdown[0].Property)
+	for _, group := range breakdown {
+		fmt.Fprintf(writer, "   • %s: %d repositories, %d workflows, %d action usages, %d unique actions, %d findings\n",
+			group.Value, group.Repositories, group.Workflows, group.ActionUsages, group.UniqueActions, group.Findings)
+	}
+}
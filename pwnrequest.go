@@ -0,0 +1,375 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// privilegedTriggers run with the base repository's secrets and a write token while the pull request
+// that caused them can be from a fork
+var privilegedTriggers = map[string]bool{"pull_request_target": true, "workflow_run": true}
+
+// untrustedRefPattern matches expressions naming the code of a pull request or of the run that
+// triggered a workflow_run
+var untrustedRefPattern = regexp.MustCompile(`github\.event\.pull_request\.(head\.(sha|ref|repo\.full_name)|merge_commit_sha)|github\.head_ref|github\.event\.workflow_run\.(head_sha|head_branch|head_commit\.id|head_repository\.full_name)|refs/pull/`)
+
+// untrustedCheckoutCommandPattern matches run: scripts fetching a pull request, e.g. gh pr checkout or
+// git fetch origin pull/123/head
+var untrustedCheckoutCommandPattern = regexp.MustCompile(`gh\s+pr\s+checkout|git\s+fetch\s+\S+\s+(\+?refs/)?pull/`)
+
+// forkGuardPattern matches if: conditions that only let pull requests from the repository itself run
+var forkGuardPattern = regexp.MustCompile(`head\.repo\.full_name\s*==\s*github\.repository|github\.repository\s*==\s*github\.event\.pull_request\.head\.repo\.full_name|head\.repo\.fork\s*==\s*false|!\s*github\.event\.pull_request\.head\.repo\.fork|head_repository\.full_name\s*==\s*github\.repository`)
+
+// Kinds of a pwn request
+const (
+	PwnRequestCheckoutRun = "checkout-and-run" // untrusted code is checked out and a later step runs it
+	PwnRequestCheckout    = "checkout"         // untrusted code is checked out; no later step is known to run it
+	PwnRequestInjection   = "script-injection" // an attacker-controlled field is interpolated into a script
+)
+
+// PwnRequestReport lists the privileged workflows that check out or run untrusted pull request code
+type PwnRequestReport struct {
+	Organization          string              `json:"organization"`
+	Summary               PwnRequestSummary   `json:"summary"`
+	Requests              []PwnRequestFinding `json:"requests"`
+	Findings              []Finding           `json:"findings"`
+	Truncated             bool                `json:"truncated"`
+	RemainingRepositories []string            `json:"remaining_repositories,omitempty"`
+	ProcessTimeSeconds    float64             `json:"process_time_seconds"`
+}
+
+// PwnRequestSummary represents summary statistics of the pwn request scan
+type PwnRequestSummary struct {
+	WorkflowsScanned     int `json:"workflows_scanned"`
+	PrivilegedWorkflows  int `json:"privileged_workflows"` // triggered by pull_request_target or workflow_run
+	CheckoutAndRun       int `json:"checkout_and_run"`
+	CheckoutOnly         int `json:"checkout_only"`
+	ScriptInjections     int `json:"script_injections"`
+	Guarded              int `json:"guarded"` // jobs restricted to pull requests from the repository itself
+	AffectedRepositories int `json:"affected_repositories"`
+}
+
+// PwnRequestFinding is one job of a privileged workflow handling untrusted code or input
+type PwnRequestFinding struct {
+	Repository string `json:"repository"`
+	Workflow   string `json:"workflow"`
+	Trigger    string `json:"trigger"` // pull_request_target or workflow_run
+	Job        string `json:"job"`
+	Kind       string `json:"kind"`
+	Step       int    `json:"step"` // 1-based step checking out the code, or interpolating the input
+	StepName   string `json:"step_name,omitempty"`
+	Source     string `json:"source"`                // what brings in the untrusted code, e.g. the checkout ref
+	RunStep    int    `json:"run_step,omitempty"`    // 1-based step running the checked-out code
+	RunCommand string `json:"run_command,omitempty"` // first line of that step's script, or its local action
+
+	action, version string // uses: of the checkout step, for snippets
+}
+
+// untrustedCheckout returns what brings untrusted code into the workspace in a step, or "" if nothing does:
+// actions/checkout of the pull request head, a download of the triggering run's artifacts, or a run: script
+// fetching the pull request
+func untrustedCheckout(step workflowStep, trigger string) string {
+	name, _, _ := splitActionReference(step.Uses)
+	switch {
+	case strings.EqualFold(name, "actions/checkout"):
+		for _, key := range []string{"ref", "repository"} {
+			if value := fmt.Sprint(step.With[key]); untrustedRefPattern.MatchString(value) {
+				return fmt.Sprintf("checkout of %s: %s", key, value)
+			}
+		}
+	case trigger == "workflow_run" && (strings.EqualFold(name, "actions/download-artifact") || strings.EqualFold(name, "dawidd6/action-download-artifact")):
+		if strings.Contains(fmt.Sprint(step.With["run-id"]), "workflow_run") || strings.Contains(fmt.Sprint(step.With["run_id"]), "workflow_run") ||
+			strings.EqualFold(name, "dawidd6/action-download-artifact") {
+			return "artifacts of the triggering run (" + name + ")"
+		}
+	case step.Run != "":
+		if match := untrustedCheckoutCommandPattern.FindString(step.Run); match != "" {
+			return "run: " + match
+		}
+		if strings.Contains(step.Run, "git checkout") && untrustedRefPattern.MatchString(step.Run) {
+			return "run: git checkout of the pull request head"
+		}
+	}
+	return ""
+}
+
+// runsCheckedOutCode describes a step that executes code from the workspace, or "" for steps that are not
+// known to: run: scripts and local actions (uses: ./...)
+func runsCheckedOutCode(step workflowStep) string {
+	switch {
+	case step.Run != "":
+		return "run: " + strings.TrimSpace(strings.SplitN(strings.TrimSpace(step.Run), "\n", 2)[0])
+	case strings.HasPrefix(step.Uses, "./"):
+		return "uses: " + step.Uses
+	}
+	return ""
+}
+
+// privilegedTrigger returns pull_request_target or workflow_run if the workflow is triggered by one, or ""
+func privilegedTrigger(definition *workflowDefinition) string {
+	for _, event := range workflowTriggers(definition.On) {
+		if privilegedTriggers[event] {
+			return event
+		}
+	}
+	return ""
+}
+
+// findPwnRequests checks the jobs of a workflow triggered by pull_request_target or workflow_run. It
+// returns the pwn requests and the number of jobs skipped because an if: condition excludes forks.
+func findPwnRequests(definition *workflowDefinition, repo, workflowPath string) ([]PwnRequestFinding, int) {
+	trigger := privilegedTrigger(definition)
+	if trigger == "" {
+		return nil, 0
+	}
+
+	var requests []PwnRequestFinding
+	guarded := 0
+	for _, jobID := range definition.sortedJobIDs() {
+		job := definition.Jobs[jobID]
+		var found []PwnRequestFinding
+		checkout := -1
+		for i, step := range job.Steps {
+			if forkGuardPattern.MatchString(step.If) {
+				continue
+			}
+			if expressions, _ := untrustedExpressions(step.Run); expressions != nil {
+				found = append(found, PwnRequestFinding{Kind: PwnRequestInjection, Step: i + 1, StepName: step.Name, Source: expressions[0]})
+			}
+			if checkout < 0 {
+				if source := untrustedCheckout(step, trigger); source != "" {
+					checkout = len(found)
+					request := PwnRequestFinding{Kind: PwnRequestCheckout, Step: i + 1, StepName: step.Name, Source: source}
+					request.action, request.version, _ = splitActionReference(step.Uses)
+					found = append(found, request)
+					// The script fetching the code may run it too, but the following steps tell for sure
+					continue
+				}
+			} else if found[checkout].RunStep == 0 {
+				if command := runsCheckedOutCode(step); command != "" {
+					found[checkout].Kind = PwnRequestCheckoutRun
+					found[checkout].RunStep, found[checkout].RunCommand = i+1, command
+				}
+			}
+		}
+		if len(found) == 0 {
+			continue
+		}
+		if forkGuardPattern.MatchString(job.If) {
+			guarded++
+			continue
+		}
+		for _, request := range found {
+			request.Repository, request.Workflow, request.Trigger, request.Job = repo, workflowPath, trigger, jobID
+			requests = append(requests, request)
+		}
+	}
+	return requests, guarded
+}
+
+// pwnRequestFinding raises the finding of a pwn request
+func pwnRequestFinding(request PwnRequestFinding) Finding {
+	finding := Finding{
+		RuleID:     RulePwnRequest,
+		Severity:   SeverityError,
+		Repository: request.Repository,
+		Workflow:   request.Workflow,
+		Action:     request.action,
+		Version:    request.version,
+	}
+	switch request.Kind {
+	case PwnRequestCheckoutRun:
+		finding.Message = fmt.Sprintf("%s job %s step %d checks out untrusted code (%s) and step %d runs it (%s)",
+			request.Trigger, request.Job, request.Step, request.Source, request.RunStep, request.RunCommand)
+	case PwnRequestInjection:
+		finding.Message = fmt.Sprintf("%s job %s step %d interpolates %s into a run: script", request.Trigger, request.Job, request.Step, request.Source)
+		finding.Remediation = "Pass the value through an environment variable (`env: TITLE: ${{ github.event.pull_request.title }}`) and reference it as `\"$TITLE\"` in the script."
+	default:
+		finding.RuleID = RuleUntrustedCheckout
+		finding.Severity = SeverityWarning
+		finding.Message = fmt.Sprintf("%s job %s step %d checks out untrusted code (%s)", request.Trigger, request.Job, request.Step, request.Source)
+	}
+	return finding
+}
+
+// analyzePwnRequests flags workflows triggered by pull_request_target or workflow_run that check out or run
+// the code of the pull request
+func analyzePwnRequests(org string, startTime time.Time, outputFormat, outputFile string, opts scanOptions) error {
+	repositories, _, err := listRepositoryWorkflows(org, opts)
+	if err != nil {
+		return err
+	}
+
+	logInfof("🎣 Checking pull_request_target and workflow_run workflows in %d repositories...\n\n", len(repositories))
+
+	report := PwnRequestReport{
+		Organization: org,
+		Requests:     []PwnRequestFinding{},
+		Findings:     []Finding{},
+	}
+
+	report.RemainingRepositories = forEachWorkflowDefinition(org, repositories, opts, func(repo, workflowPath string, definition *workflowDefinition) {
+		report.Summary.WorkflowsScanned++
+		if privilegedTrigger(definition) != "" {
+			report.Summary.PrivilegedWorkflows++
+		}
+		requests, guarded := findPwnRequests(definition, repo, workflowPath)
+		report.Summary.Guarded += guarded
+		report.Requests = append(report.Requests, requests...)
+	})
+
+	kindRank := map[string]int{PwnRequestCheckoutRun: 0, PwnRequestInjection: 1, PwnRequestCheckout: 2}
+	sort.SliceStable(report.Requests, func(i, j int) bool {
+		a, b := report.Requests[i], report.Requests[j]
+		if a.Kind != b.Kind {
+			return kindRank[a.Kind] < kindRank[b.Kind]
+		}
+		if a.Repository != b.Repository {
+			return a.Repository < b.Repository
+		}
+		if a.Workflow != b.Workflow {
+			return a.Workflow < b.Workflow
+		}
+		if a.Job != b.Job {
+			return a.Job < b.Job
+		}
+		return a.Step < b.Step
+	})
+	affected := make(map[string]bool)
+	for _, request := range report.Requests {
+		switch request.Kind {
+		case PwnRequestCheckoutRun:
+			report.Summary.CheckoutAndRun++
+		case PwnRequestCheckout:
+			report.Summary.CheckoutOnly++
+		case PwnRequestInjection:
+			report.Summary.ScriptInjections++
+		}
+		affected[request.Repository] = true
+		report.Findings = append(report.Findings, pwnRequestFinding(request))
+	}
+	report.Summary.AffectedRepositories = len(affected)
+
+	opts.finishFindings(org, &report.Findings)
+	report.Truncated = len(report.RemainingRepositories) > 0
+	report.ProcessTimeSeconds = time.Since(startTime).Seconds()
+
+	return opts.writeFindingsReport(org, &report, report.Findings, outputFile, func(writer io.Writer) error {
+		return outputPwnRequestReport(report, outputFormat, writer)
+	})
+}
+
+// describePwnRequest renders a pwn request for status output
+func describePwnRequest(request PwnRequestFinding) string {
+	switch request.Kind {
+	case PwnRequestCheckoutRun:
+		return fmt.Sprintf("%s step %d checks out %s; step %d runs it: %s", request.Job, request.Step, request.Source, request.RunStep, request.RunCommand)
+	case PwnRequestInjection:
+		return fmt.Sprintf("%s step %d interpolates %s into run:", request.Job, request.Step, request.Source)
+	}
+	return fmt.Sprintf("%s step %d checks out %s", request.Job, request.Step, request.Source)
+}
+
+// outputPwnRequestReport outputs the pwn request scan in the specified format
+func outputPwnRequestReport(report PwnRequestReport, format string, writer io.Writer) error {
+	if formatter, ok := lookupFormatter(format); ok {
+		return formatter.Format(report, writer)
+	}
+
+	switch format {
+	case "table":
+		return outputPwnRequestTable(report, writer)
+
+	case "csv":
+		return outputPwnRequestCSV(report, writer)
+
+	case "markdown":
+		return outputFindingsMarkdown("🎣 Pwn Requests", report.Findings, report.RemainingRepositories, writer)
+
+	case "sarif":
+		return outputSARIF("pwn-requests", report.Findings, writer)
+
+	default: // "default"
+		fmt.Fprintln(writer, "🎣 Pwn Requests")
+		fmt.Fprintln(writer, "="+strings.Repeat("=", 50))
+
+		if len(report.Requests) == 0 {
+			fmt.Fprintln(writer, "✅ No pull_request_target or workflow_run workflow checks out or runs untrusted code")
+		}
+		icons := map[string]string{PwnRequestCheckoutRun: "❌", PwnRequestInjection: "❌", PwnRequestCheckout: "⚠️ "}
+		for _, request := range report.Requests {
+			fmt.Fprintf(writer, "%s %s → %s (%s) → %s\n", icons[request.Kind], request.Repository, request.Workflow, request.Trigger, describePwnRequest(request))
+		}
+
+		fmt.Fprintln(writer, "\n📊 Summary:")
+		fmt.Fprintf(writer, "   • Workflows scanned: %d (%d on pull_request_target or workflow_run)\n", report.Summary.WorkflowsScanned, report.Summary.PrivilegedWorkflows)
+		fmt.Fprintf(writer, "   • Untrusted code checked out and run: %d\n", report.Summary.CheckoutAndRun)
+		fmt.Fprintf(writer, "   • Untrusted code checked out only: %d\n", report.Summary.CheckoutOnly)
+		fmt.Fprintf(writer, "   • Script injections: %d\n", report.Summary.ScriptInjections)
+		fmt.Fprintf(writer, "   • Jobs restricted to same-repository pull requests: %d\n", report.Summary.Guarded)
+		fmt.Fprintf(writer, "   • Affected repositories: %d\n", report.Summary.AffectedRepositories)
+		fmt.Fprintf(writer, "   ⏱️  Process time: %.3fs\n", report.ProcessTimeSeconds)
+
+		outputFindings(report.Findings, writer)
+
+		if report.Truncated {
+			outputTruncationNotice(report.RemainingRepositories, writer)
+		}
+
+		return nil
+	}
+}
+
+// outputPwnRequestTable outputs the pwn request scan in table format
+func outputPwnRequestTable(report PwnRequestReport, writer io.Writer) error {
+	fmt.Fprintln(writer, "╔════════════════════════════════════════════════════════════════════════════════════════════════════╗")
+	fmt.Fprintf(writer, "║                                    🎣 PWN REQUESTS                                                  ║\n")
+	fmt.Fprintln(writer, "╚════════════════════════════════════════════════════════════════════════════════════════════════════╝")
+	fmt.Fprintf(writer, "  🏢 Organization: %-59s \n", report.Organization)
+	fmt.Fprintf(writer, "  🔓 Privileged Workflows: %-52d \n", report.Summary.PrivilegedWorkflows)
+	fmt.Fprintf(writer, "  ❌ Checkout and Run: %-56d \n", report.Summary.CheckoutAndRun)
+	fmt.Fprintf(writer, "  ❌ Script Injections: %-55d \n", report.Summary.ScriptInjections)
+	fmt.Fprintf(writer, "  ⚠️  Checkout Only: %-58d \n", report.Summary.CheckoutOnly)
+	fmt.Fprintln(writer, " ═════════════════════════════════════════════════════════════════════════════════════════════════════")
+	fmt.Fprintln(writer)
+
+	if len(report.Requests) == 0 {
+		fmt.Fprintln(writer, "┌─────────────────────────────────────────┐")
+		fmt.Fprintln(writer, "│    No pwn requests found                │")
+		fmt.Fprintln(writer, "└─────────────────────────────────────────┘")
+		return nil
+	}
+
+	fmt.Fprintln(writer, "┌─────────────────────┬────────────────────────────────┬─────────────────────┬──────────────────┬──────┬──────────────────┐")
+	fmt.Fprintf(writer, "│ %-18s │ %-29s │ %-19s │ %-16s │ %-4s │ %-16s │\n", "📁 REPOSITORY", "📄 WORKFLOW", "TRIGGER", "JOB", "STEP", "KIND")
+	fmt.Fprintln(writer, "├─────────────────────┼────────────────────────────────┼─────────────────────┼──────────────────┼──────┼──────────────────┤")
+	for _, request := range report.Requests {
+		fmt.Fprintf(writer, "│ %-19s │ %-30s │ %-19s │ %-16s │ %4d │ %-16s │\n",
+			truncate(request.Repository, 19), truncate(request.Workflow, 30), request.Trigger, truncate(request.Job, 16), request.Step, request.Kind)
+	}
+	fmt.Fprintln(writer, "└─────────────────────┴────────────────────────────────┴─────────────────────┴──────────────────┴──────┴──────────────────┘")
+	outputFindings(report.Findings, writer)
+	fmt.Fprintln(writer)
+	return nil
+}
+
+// outputPwnRequestCSV outputs the pwn request scan in CSV format
+func outputPwnRequestCSV(report PwnRequestReport, writer io.Writer) error {
+	w := newCSVWriter(writer)
+	w.Write([]string{"Repository", "Workflow", "Trigger", "Job", "Kind", "Step", "Step Name", "Source", "Run Step", "Run Command"})
+	for _, request := range report.Requests {
+		runStep := ""
+		if request.RunStep > 0 {
+			runStep = strconv.Itoa(request.RunStep)
+		}
+		w.Write([]string{request.Repository, request.Workflow, request.Trigger, request.Job, request.Kind,
+			strconv.Itoa(request.Step), request.StepName, request.Source, runStep, request.RunCommand})
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/cli/go-gh/v2/pkg/jq"
+	"github.com/itchyny/gojq"
+)
+
+// queryFormat is the --format name of the --query formatter
+const queryFormat = "query"
+
+// queryFormatter applies a jq expression to the JSON report, like gh's --jq. Scalar results are written
+// raw, objects and arrays as indented JSON, one result per line.
+type queryFormatter struct {
+	Expression string
+}
+
+// Format evaluates the expression against the report encoded as JSON
+func (f queryFormatter) Format(report interface{}, writer io.Writer) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to encode report: %v", err)
+	}
+	if err := jq.EvaluateFormatted(bytes.NewReader(data), writer, f.Expression, "  ", false); err != nil {
+		return fmt.Errorf("--query: %v", err)
+	}
+	return nil
+}
+
+// registerQueryFormatter checks the jq expression of --query and registers it as the query format
+func registerQueryFormatter(expression string) error {
+	if _, err := gojq.Parse(expression); err != nil {
+		return fmt.Errorf("invalid --query: %v", err)
+	}
+	RegisterFormatter(queryFormat, queryFormatter{Expression: expression})
+	return nil
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// lowBudgetFraction is the share of a rate limit below which requests are spread evenly over the
+// time left until the reset instead of running into the limit and pausing
+const lowBudgetFraction = 0.1
+
+// budgetReportSteps is the number of steps of a limit at which --verbose reports the remaining budget
+const budgetReportSteps = 10
+
+// rateLimitBudget is the last known state of one GitHub rate limit resource, such as core or graphql
+type rateLimitBudget struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+	Cost      int       // points charged for the last GraphQL query; zero when unknown
+	low       bool      // below lowBudgetFraction, so requests are spread out
+	reported  int       // remaining budget at the last --verbose report
+	next      time.Time // earliest start of the next request while the budget is low
+}
+
+// graphQLRateLimit is the rateLimit field added to expensive GraphQL queries to learn their cost
+type graphQLRateLimit struct {
+	Limit     int
+	Cost      int
+	Remaining int
+	ResetAt   time.Time
+}
+
+// rateLimitResource returns the rate limit resource a request is counted against
+func rateLimitResource(req *http.Request) string {
+	switch {
+	case req == nil:
+		return "core"
+	// GitHub Enterprise Server serves the API under /api/graphql and /api/v3
+	case req.URL.Path == "/graphql" || req.URL.Path == "/api/graphql":
+		return "graphql"
+	case strings.HasPrefix(strings.TrimPrefix(req.URL.Path, "/api/v3"), "/search/"):
+		return "search"
+	}
+	return "core"
+}
+
+// recordBudget updates the budget of a response's resource from its X-RateLimit headers
+func (g *rateLimitGate) recordBudget(resp *http.Response) {
+	limit, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return
+	}
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return
+	}
+	resource := resp.Header.Get("X-RateLimit-Resource")
+	if resource == "" {
+		resource = rateLimitResource(resp.Request)
+	}
+	g.updateBudget(resource, limit, remaining, time.Unix(reset, 0), 0)
+}
+
+// recordGraphQLBudget updates the graphql budget from the rateLimit field of a GraphQL query
+func (g *rateLimitGate) recordGraphQLBudget(rateLimit graphQLRateLimit) {
+	if rateLimit.Limit == 0 {
+		return
+	}
+	g.updateBudget("graphql", rateLimit.Limit, rateLimit.Remaining, rateLimit.ResetAt, rateLimit.Cost)
+}
+
+// updateBudget records the state of a rate limit, announcing when it runs low and, with --verbose,
+// every budgetReportSteps-th of the limit used
+func (g *rateLimitGate) updateBudget(resource string, limit, remaining int, reset time.Time, cost int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	b, ok := g.budgets[resource]
+	if !ok {
+		b = &rateLimitBudget{reported: limit + 1}
+		g.budgets[resource] = b
+	}
+	// A new window starts with the full limit again
+	if reset.After(b.Reset) {
+		b.reported = limit + 1
+	}
+	b.Limit, b.Remaining, b.Reset = limit, remaining, reset
+	if cost > 0 {
+		b.Cost = cost
+	}
+
+	low := float64(remaining) <= float64(limit)*lowBudgetFraction
+	if low && !b.low {
+		logWarnf("🐢 GitHub %s rate limit running low (%d of %d left); spreading requests until the reset at %s\n",
+			resource, remaining, limit, reset.Format("15:04:05"))
+	}
+	b.low = low
+
+	if logEnabled(logDebug) && remaining <= b.reported-max(limit/budgetReportSteps, 1) {
+		b.reported = remaining
+		logDebugf("📊 GitHub %s rate limit: %d of %d left, resets at %s\n", resource, remaining, limit, reset.Format("15:04:05"))
+	}
+}
+
+// remaining returns the last known remaining budget of a rate limit resource
+func (g *rateLimitGate) remaining(resource string) (int, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	b, ok := g.budgets[resource]
+	if !ok {
+		return 0, false
+	}
+	return b.Remaining, true
+}
+
+// throttle delays a request while the budget of its resource is low, so the remaining requests are
+// spread evenly until the reset. Exhausted budgets are handled by pausing the gate instead.
+func (g *rateLimitGate) throttle(resource string) {
+	g.mu.Lock()
+	b := g.budgets[resource]
+	if b == nil || !b.low || b.Remaining <= 0 {
+		g.mu.Unlock()
+		return
+	}
+	interval := time.Until(b.Reset) / time.Duration(b.Remaining)
+	if interval <= 0 {
+		g.mu.Unlock()
+		return
+	}
+	start := time.Now()
+	if b.next.After(start) {
+		start = b.next
+	}
+	b.next = start.Add(interval)
+	g.mu.Unlock()
+
+	sleepInterruptibly(time.Until(start), g.done)
+}
+
+// outputRateLimitBudgets writes the last known budget of every rate limit resource used by the scan
+func outputRateLimitBudgets(g *rateLimitGate, w io.Writer) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	resources := make([]string, 0, len(g.budgets))
+	for resource := range g.budgets {
+		resources = append(resources, resource)
+	}
+	sort.Strings(resources)
+
+	fmt.Fprintln(w, "\n📊 GitHub API rate limits")
+	if len(resources) == 0 {
+		fmt.Fprintln(w, "   No rate limit information received")
+		return
+	}
+	for _, resource := range resources {
+		b := g.budgets[resource]
+		line := fmt.Sprintf("   %-8s %d of %d left, resets at %s", resource, b.Remaining, b.Limit, b.Reset.Format("15:04:05"))
+		if b.Cost > 0 {
+			line += fmt.Sprintf(" (last query cost %d)", b.Cost)
+		}
+		fmt.Fprintln(w, line)
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Repository visibilities accepted by --visibility
+var validVisibilities = []string{"public", "private", "internal"}
+
+// repositoryPattern reports whether a --include-repos/--exclude-repos pattern is a regular expression,
+// written between slashes like /^platform-/, and returns the expression
+func repositoryPattern(pattern string) (string, bool) {
+	if len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		return pattern[1 : len(pattern)-1], true
+	}
+	return "", false
+}
+
+// matchesRepositoryPattern reports whether a repository name matches a glob or /regex/ pattern. Both
+// are matched case-insensitively, like repository names on GitHub.
+func matchesRepositoryPattern(pattern, name string) bool {
+	if expr, ok := repositoryPattern(pattern); ok {
+		re, err := regexp.Compile("(?i)" + expr)
+		return err == nil && re.MatchString(name)
+	}
+	ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(name))
+	return ok
+}
+
+// validateRepositoryFilters checks the repository patterns and visibilities
+func (o scanOptions) validateRepositoryFilters() error {
+	for _, pattern := range append(append([]string{}, o.IncludeRepositories...), o.ExcludeRepositories...) {
+		if expr, ok := repositoryPattern(pattern); ok {
+			if _, err := regexp.Compile(expr); err != nil {
+				return fmt.Errorf("invalid repository pattern '%s': %v", pattern, err)
+			}
+		} else if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid repository pattern '%s': %v", pattern, err)
+		}
+	}
+	for _, visibility := range o.Visibility {
+		if !containsFold(validVisibilities, visibility) {
+			return fmt.Errorf("invalid visibility '%s'. Valid options: %s", visibility, strings.Join(validVisibilities, ", "))
+		}
+	}
+	return nil
+}
+
+// includesRepository reports whether a repository passes the name, topic, and visibility filters:
+// it matches an --include-repos pattern when there are any and no --exclude-repos pattern, has one
+// of the --topic topics, and one of the --visibility visibilities
+func (o scanOptions) includesRepository(repo RepositoryWorkflows) bool {
+	if len(o.IncludeRepositories) > 0 && !matchesAnyRepositoryPattern(o.IncludeRepositories, repo.Name) {
+		return false
+	}
+	if matchesAnyRepositoryPattern(o.ExcludeRepositories, repo.Name) {
+		return false
+	}
+	if len(o.Topics) > 0 {
+		tagged := false
+		for _, topic := range repo.Topics {
+			if containsFold(o.Topics, topic) {
+				tagged = true
+			}
+		}
+		if !tagged {
+			return false
+		}
+	}
+	return len(o.Visibility) == 0 || containsFold(o.Visibility, repo.Visibility)
+}
+
+// matchesAnyRepositoryPattern reports whether a repository name matches one of the patterns
+func matchesAnyRepositoryPattern(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matchesRepositoryPattern(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsFold reports whether values contains value, ignoring case
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
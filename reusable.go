@@ -0,0 +1,843 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// githubWorkflowNestingLimit is how many levels of workflows GitHub connects: the calling workflow and
+// up to nine levels of reusable workflows. Deeper chains fail to start.
+const githubWorkflowNestingLimit = 10
+
+// maxWorkflowCallDepth bounds how deep nested reusable workflow calls are followed; one call past
+// GitHub's limit is enough to flag a chain as too deep
+const maxWorkflowCallDepth = githubWorkflowNestingLimit
+
+// ReusableReport is the call graph of reusable workflows and their adoption across an organization
+type ReusableReport struct {
+	Organization          string             `json:"organization"`
+	Summary               ReusableSummary    `json:"summary"`
+	Workflows             []ReusableWorkflow `json:"workflows"`
+	Calls                 []WorkflowCall     `json:"calls"`
+	Cycles                []string           `json:"cycles,omitempty"` // e.g. a.yml@v1 → b.yml@v1 → a.yml@v1
+	Findings              []Finding          `json:"findings"`
+	Truncated             bool               `json:"truncated"`
+	RemainingRepositories []string           `json:"remaining_repositories,omitempty"`
+	ProcessTimeSeconds    float64            `json:"process_time_seconds"`
+}
+
+// ReusableSummary represents summary statistics of the reusable workflow analysis
+type ReusableSummary struct {
+	RepositoriesScanned   int           `json:"repositories_scanned"`
+	WorkflowsScanned      int           `json:"workflows_scanned"`
+	CallingRepositories   int           `json:"calling_repositories"` // repositories with at least one workflow call
+	AdoptionRate          float64       `json:"adoption_rate"`        // share of scanned repositories calling a reusable workflow, 0-100
+	DirectCalls           int           `json:"direct_calls"`         // jobs of scanned workflows calling a workflow
+	NestedCalls           int           `json:"nested_calls"`         // jobs of called workflows calling another workflow
+	ReusableWorkflows     int           `json:"reusable_workflows"`
+	OrganizationWorkflows int           `json:"organization_workflows"` // reusable workflows defined in the organization
+	Unused                int           `json:"unused"`                 // organization reusable workflows without callers
+	Unresolved            int           `json:"unresolved"`
+	MaxDepth              int           `json:"max_depth"`
+	DeepestChain          int           `json:"deepest_chain"` // workflow levels of the longest call chain, the caller included
+	TooDeep               int           `json:"too_deep"`      // scanned workflows with a chain over the nesting threshold
+	Pinning               PinningCounts `json:"pinning"`       // remote calls by pinning kind; local calls run at the caller's commit
+}
+
+// ReusableWorkflow is a called or defined reusable workflow with its adoption
+type ReusableWorkflow struct {
+	Workflow            string         `json:"workflow"`     // owner/repo/path
+	Organization        bool           `json:"organization"` // defined in the scanned organization
+	Callers             int            `json:"callers"`      // calling jobs, direct and nested
+	CallingRepositories []string       `json:"calling_repositories"`
+	Refs                map[string]int `json:"refs"` // calls per ref; local calls use the caller's commit
+	Calls               []string       `json:"calls,omitempty"`
+	Branches            []string       `json:"branches,omitempty"` // mutable branches the workflow is called by
+	Resolved            bool           `json:"resolved"`
+	Error               string         `json:"error,omitempty"`
+}
+
+// WorkflowCall is one job calling a reusable workflow
+type WorkflowCall struct {
+	Repository  string `json:"repository"` // scanned repository the call graph starts in
+	Caller      string `json:"caller"`     // owner/repo/path[@ref] of the calling workflow
+	Job         string `json:"job"`
+	Workflow    string `json:"workflow"` // owner/repo/path of the called workflow
+	Ref         string `json:"ref,omitempty"`
+	Local       bool   `json:"local,omitempty"`
+	Depth       int    `json:"depth"`                  // 1 for calls of scanned workflows
+	Pinning     string `json:"pinning,omitempty"`      // sha, tag or branch; empty for local calls
+	ResolvedSHA string `json:"resolved_sha,omitempty"` // commit a tag or branch pointed to during the scan
+}
+
+// workflowCallTarget is the called workflow of a `uses:` reference
+type workflowCallTarget struct {
+	Repository string // owner/repo
+	Path       string
+	Ref        string // empty for local calls, which run at the caller's commit
+	Local      bool
+}
+
+// key returns owner/repo/path of the called workflow
+func (t workflowCallTarget) key() string {
+	return t.Repository + "/" + t.Path
+}
+
+// display returns the called workflow with its ref
+func (t workflowCallTarget) display() string {
+	if t.Local {
+		return t.key() + " (local)"
+	}
+	return t.key() + "@" + t.Ref
+}
+
+// isReusableWorkflowCall reports whether a `uses:` value calls a reusable workflow rather than an action
+func isReusableWorkflowCall(uses string) bool {
+	name := uses
+	if i := strings.LastIndex(uses, "@"); i > 0 {
+		name = uses[:i]
+	}
+	if !strings.Contains(name, ".github/workflows/") {
+		return false
+	}
+	return isYAMLFile(name)
+}
+
+// parseWorkflowCall resolves a job's `uses:` to the called workflow. Local calls (./.github/workflows/x.yml)
+// refer to the repository of the calling workflow.
+func parseWorkflowCall(uses, repository string) (workflowCallTarget, bool) {
+	uses = strings.TrimSpace(uses)
+	if !isReusableWorkflowCall(uses) {
+		return workflowCallTarget{}, false
+	}
+	if strings.HasPrefix(uses, "./") {
+		return workflowCallTarget{Repository: repository, Path: strings.TrimPrefix(uses, "./"), Local: true}, true
+	}
+
+	name, ref, ok := splitActionReference(uses)
+	if !ok {
+		return workflowCallTarget{}, false
+	}
+	parts := strings.SplitN(name, "/", 3)
+	if len(parts) < 3 {
+		return workflowCallTarget{}, false
+	}
+	return workflowCallTarget{Repository: parts[0] + "/" + parts[1], Path: parts[2], Ref: ref}, true
+}
+
+// declaresWorkflowCall reports whether a workflow can be called, i.e. has the workflow_call trigger
+func declaresWorkflowCall(on interface{}) bool {
+	for _, event := range workflowTriggers(on) {
+		if event == "workflow_call" {
+			return true
+		}
+	}
+	return false
+}
+
+// workflowCallTargets returns the workflows called by the jobs of a workflow, by job ID
+func workflowCallTargets(definition *workflowDefinition, repository string) ([]string, []workflowCallTarget) {
+	var jobs []string
+	var targets []workflowCallTarget
+	for _, jobID := range definition.sortedJobIDs() {
+		if target, ok := parseWorkflowCall(definition.Jobs[jobID].Uses, repository); ok {
+			jobs = append(jobs, jobID)
+			targets = append(targets, target)
+		}
+	}
+	return jobs, targets
+}
+
+// calledWorkflow is a fetched called workflow
+type calledWorkflow struct {
+	Definition *workflowDefinition
+	Error      string
+}
+
+// resolveCalledWorkflow fetches a called workflow at its ref; local calls read the default branch
+func resolveCalledWorkflow(target workflowCallTarget) calledWorkflow {
+	owner, repo, _ := strings.Cut(target.Repository, "/")
+	content, err := fetchRepositoryFileAtRef(owner, repo, target.Path, target.Ref)
+	if err == errFileNotFound {
+		return calledWorkflow{Error: "was not found or is not accessible"}
+	}
+	if err != nil {
+		return calledWorkflow{Error: fmt.Sprintf("could not be read: %v", err)}
+	}
+	definition, err := parseWorkflowDefinition(content)
+	if err != nil {
+		return calledWorkflow{Error: fmt.Sprintf("could not be parsed: %v", err)}
+	}
+	if !declaresWorkflowCall(definition.On) {
+		return calledWorkflow{Definition: definition, Error: "does not declare the workflow_call trigger"}
+	}
+	return calledWorkflow{Definition: definition}
+}
+
+// analyzeReusableWorkflows builds the reusable workflow call graph of an organization: the calls of every
+// scanned workflow, the called workflows resolved at their refs with their own nested calls, and the
+// adoption of each reusable workflow
+func analyzeReusableWorkflows(org string, startTime time.Time, outputFormat, outputFile string, maxLevels int, opts scanOptions) error {
+	repositories, _, err := listRepositoryWorkflows(org, opts)
+	if err != nil {
+		return err
+	}
+
+	logInfof("🔁 Tracing reusable workflow calls in %d repositories...\n\n", len(repositories))
+
+	report := ReusableReport{
+		Organization: org,
+		Workflows:    []ReusableWorkflow{},
+		Calls:        []WorkflowCall{},
+		Findings:     []Finding{},
+	}
+	defined := make(map[string]bool)                           // owner/repo/path of organization reusable workflows
+	resolved := make(map[string]calledWorkflow)                // display() of called workflows
+	scannedDefinitions := make(map[string]*workflowDefinition) // owner/repo/path of scanned workflows
+
+	report.RemainingRepositories = forEachWorkflowDefinition(org, repositories, opts, func(repo, workflowPath string, definition *workflowDefinition) {
+		report.Summary.WorkflowsScanned++
+		key := org + "/" + repo + "/" + workflowPath
+		scannedDefinitions[key] = definition
+		if declaresWorkflowCall(definition.On) {
+			defined[key] = true
+		}
+		jobs, targets := workflowCallTargets(definition, org+"/"+repo)
+		for j, target := range targets {
+			report.Calls = append(report.Calls, WorkflowCall{
+				Repository: repo, Caller: key, Job: jobs[j],
+				Workflow: target.key(), Ref: target.Ref, Local: target.Local, Depth: 1,
+			})
+		}
+	})
+	report.Summary.RepositoriesScanned = len(repositories) - len(report.RemainingRepositories)
+
+	// Resolve the called workflows breadth-first; calls they make are appended and resolved in turn
+	for i := 0; i < len(report.Calls); i++ {
+		call := report.Calls[i]
+		target := call.target()
+		if _, seen := resolved[target.display()]; seen {
+			continue
+		}
+
+		var called calledWorkflow
+		if definition, ok := scannedDefinitions[target.key()]; ok && target.Local {
+			// Local calls of scanned workflows read the same default branch that was scanned
+			called = calledWorkflow{Definition: definition}
+			if !declaresWorkflowCall(definition.On) {
+				called.Error = "does not declare the workflow_call trigger"
+			}
+		} else {
+			stopFetch := opts.Profile.track(stageFetch)
+			called = resolveCalledWorkflow(target)
+			stopFetch()
+		}
+		resolved[target.display()] = called
+
+		if called.Definition == nil || call.Depth >= maxWorkflowCallDepth {
+			continue
+		}
+		jobs, targets := workflowCallTargets(called.Definition, target.Repository)
+		for j, nested := range targets {
+			if nested.Local {
+				// A local call inside a called workflow runs at the called workflow's ref
+				nested.Local, nested.Ref = target.Local, target.Ref
+			}
+			report.Calls = append(report.Calls, WorkflowCall{
+				Repository: call.Repository, Caller: target.display(), Job: jobs[j],
+				Workflow: nested.key(), Ref: nested.Ref, Local: nested.Local, Depth: call.Depth + 1,
+			})
+		}
+	}
+
+	// Classify the ref of every remote call, including the nested calls the pinning scan never sees
+	seen := make(map[string]bool)
+	var references []string
+	for _, call := range report.Calls {
+		if reference := call.Workflow + "@" + call.Ref; !call.Local && !seen[reference] {
+			seen[reference] = true
+			references = append(references, reference)
+		}
+	}
+	sort.Strings(references)
+	resolutions := resolvePinning(references, opts)
+	for i, call := range report.Calls {
+		if call.Local {
+			continue
+		}
+		resolution := resolutions[call.Workflow+"@"+call.Ref]
+		report.Calls[i].Pinning = resolution.Kind
+		if resolution.Kind != PinSHA {
+			report.Calls[i].ResolvedSHA = resolution.SHA
+		}
+		report.Summary.Pinning.add(resolution.Kind, 1)
+	}
+
+	// Adoption of every called or defined reusable workflow
+	byWorkflow := make(map[string]*ReusableWorkflow)
+	workflowEntry := func(key string) *ReusableWorkflow {
+		if byWorkflow[key] == nil {
+			byWorkflow[key] = &ReusableWorkflow{
+				Workflow:            key,
+				Organization:        strings.EqualFold(strings.SplitN(key, "/", 2)[0], org),
+				CallingRepositories: []string{},
+				Refs:                make(map[string]int),
+				Resolved:            true,
+			}
+		}
+		return byWorkflow[key]
+	}
+	for key := range defined {
+		workflowEntry(key)
+	}
+
+	callingRepositories := make(map[string]bool)
+	callingByWorkflow := make(map[string]map[string]bool)
+	nestedByWorkflow := make(map[string]map[string]bool)
+	branchesByWorkflow := make(map[string]map[string]bool)
+	flagged := make(map[string]bool)
+	for _, call := range report.Calls {
+		target := call.target()
+		entry := workflowEntry(call.Workflow)
+		entry.Callers++
+		ref := call.Ref
+		if call.Local {
+			ref = "local"
+		}
+		entry.Refs[ref]++
+		if called := resolved[target.display()]; called.Error != "" {
+			entry.Resolved = false
+			entry.Error = called.Error
+		}
+
+		if call.Depth == 1 {
+			report.Summary.DirectCalls++
+			callingRepositories[call.Repository] = true
+			if callingByWorkflow[call.Workflow] == nil {
+				callingByWorkflow[call.Workflow] = make(map[string]bool)
+			}
+			callingByWorkflow[call.Workflow][call.Repository] = true
+		} else {
+			report.Summary.NestedCalls++
+			caller := strings.TrimSuffix(call.Caller, " (local)")
+			if i := strings.LastIndex(caller, "@"); i > 0 {
+				caller = caller[:i]
+			}
+			if nestedByWorkflow[caller] == nil {
+				nestedByWorkflow[caller] = make(map[string]bool)
+			}
+			nestedByWorkflow[caller][call.Workflow] = true
+		}
+		if call.Depth > report.Summary.MaxDepth {
+			report.Summary.MaxDepth = call.Depth
+		}
+
+		if call.Depth == 1 {
+			if called := resolved[target.display()]; called.Error != "" {
+				report.Findings = append(report.Findings, Finding{
+					RuleID:     RuleUnresolvedWorkflowCall,
+					Severity:   SeverityError,
+					Repository: call.Repository,
+					Workflow:   strings.TrimPrefix(call.Caller, org+"/"+call.Repository+"/"),
+					Action:     call.Workflow,
+					Version:    call.Ref,
+					Message:    fmt.Sprintf("Job '%s' calls %s, which %s", call.Job, target.display(), called.Error),
+				})
+			}
+		}
+
+		if call.Pinning == PinBranch {
+			if branchesByWorkflow[call.Workflow] == nil {
+				branchesByWorkflow[call.Workflow] = make(map[string]bool)
+			}
+			branchesByWorkflow[call.Workflow][call.Ref] = true
+
+			// One finding per calling job, however many scanned repositories reach it
+			key := call.Caller + "|" + call.Job + "|" + target.display()
+			if !flagged[key] {
+				flagged[key] = true
+				report.Findings = append(report.Findings, branchPinnedCallFinding(call, org))
+			}
+		}
+	}
+
+	for key, entry := range byWorkflow {
+		for repo := range callingByWorkflow[key] {
+			entry.CallingRepositories = append(entry.CallingRepositories, repo)
+		}
+		sort.Strings(entry.CallingRepositories)
+		for nested := range nestedByWorkflow[key] {
+			entry.Calls = append(entry.Calls, nested)
+		}
+		sort.Strings(entry.Calls)
+		for branch := range branchesByWorkflow[key] {
+			entry.Branches = append(entry.Branches, branch)
+		}
+		sort.Strings(entry.Branches)
+
+		report.Workflows = append(report.Workflows, *entry)
+		if entry.Organization && defined[key] {
+			report.Summary.OrganizationWorkflows++
+			if entry.Callers == 0 {
+				report.Summary.Unused++
+			}
+		}
+		if !entry.Resolved {
+			report.Summary.Unresolved++
+		}
+	}
+	checkCallNesting(&report, org, maxLevels)
+
+	sort.Slice(report.Workflows, func(i, j int) bool {
+		a, b := report.Workflows[i], report.Workflows[j]
+		if a.Callers != b.Callers {
+			return a.Callers > b.Callers
+		}
+		return a.Workflow < b.Workflow
+	})
+	report.Summary.ReusableWorkflows = len(report.Workflows)
+	report.Summary.CallingRepositories = len(callingRepositories)
+	if report.Summary.RepositoriesScanned > 0 {
+		report.Summary.AdoptionRate = float64(report.Summary.CallingRepositories) * 100 / float64(report.Summary.RepositoriesScanned)
+	}
+
+	opts.finishFindings(org, &report.Findings)
+	report.Truncated = len(report.RemainingRepositories) > 0
+	report.ProcessTimeSeconds = time.Since(startTime).Seconds()
+
+	return opts.writeFindingsReport(org, &report, report.Findings, outputFile, func(writer io.Writer) error {
+		return outputReusableReport(report, outputFormat, writer)
+	})
+}
+
+// target returns the called workflow of a call
+func (c WorkflowCall) target() workflowCallTarget {
+	return workflowCallTarget{Repository: actionRepository(c.Workflow), Path: strings.SplitN(c.Workflow, "/", 3)[2], Ref: c.Ref, Local: c.Local}
+}
+
+// branchPinnedCallFinding flags a call of a reusable workflow by branch. The finding points at the
+// workflow making the call, which for nested calls is a called workflow rather than a scanned one.
+func branchPinnedCallFinding(call WorkflowCall, org string) Finding {
+	repository, workflow := workflowLocation(call.Caller, org)
+	finding := Finding{
+		RuleID:     RuleBranchPinnedWorkflow,
+		Severity:   SeverityWarning,
+		Repository: repository,
+		Workflow:   workflow,
+		Action:     call.Workflow,
+		Version:    call.Ref,
+		Message: fmt.Sprintf("Job '%s' calls %s@%s, which follows branch %s; every push to it changes the jobs that run",
+			call.Job, call.Workflow, call.Ref, call.Ref),
+	}
+	if call.Depth > 1 {
+		finding.Message += fmt.Sprintf(" for %s and every other caller", call.Repository)
+	}
+	if call.ResolvedSHA != "" {
+		finding.Message += fmt.Sprintf(" (currently %.7s)", call.ResolvedSHA)
+		finding.Remediation = fmt.Sprintf("Replace the reference with `uses: %s`, the commit %s currently points to.",
+			pinnedUses(call.Workflow, call.Ref, call.ResolvedSHA), call.Ref)
+	}
+	return finding
+}
+
+// workflowLocation returns the repository and path of a calling workflow, owner/repo/path[@ref] or
+// owner/repo/path (local); repositories of the organization are named without the owner like in
+// findings of scanned workflows
+func workflowLocation(caller, org string) (string, string) {
+	caller = strings.TrimSuffix(caller, " (local)")
+	if i := strings.LastIndex(caller, "@"); i > 0 {
+		caller = caller[:i]
+	}
+	parts := strings.SplitN(caller, "/", 3)
+	if len(parts) < 3 {
+		return "", caller
+	}
+	if strings.EqualFold(parts[0], org) {
+		return parts[1], parts[2]
+	}
+	return parts[0] + "/" + parts[1], parts[2]
+}
+
+// callGraphNode returns the node of the call graph a call starts from: the display() of the called
+// workflow making it, or for scanned workflows the display() of a local call of them, which reads the
+// same default branch
+func callGraphNode(call WorkflowCall) string {
+	if call.Depth == 1 {
+		return call.Caller + " (local)"
+	}
+	return call.Caller
+}
+
+// findCallCycles returns the distinct cycles of the call graph, each starting and ending at its
+// smallest node
+func findCallCycles(edges map[string][]string) [][]string {
+	var cycles [][]string
+	seen := make(map[string]bool)
+	state := make(map[string]int) // 0 not visited, 1 on the current path, 2 done
+	var path []string
+
+	var visit func(node string)
+	visit = func(node string) {
+		state[node] = 1
+		path = append(path, node)
+		for _, next := range edges[node] {
+			switch state[next] {
+			case 0:
+				visit(next)
+			case 1:
+				start := len(path) - 1
+				for path[start] != next {
+					start--
+				}
+				cycle := append([]string{}, path[start:]...)
+				smallest := 0
+				for i := range cycle {
+					if cycle[i] < cycle[smallest] {
+						smallest = i
+					}
+				}
+				cycle = append(cycle[smallest:], cycle[:smallest]...)
+				if key := strings.Join(cycle, "\n"); !seen[key] {
+					seen[key] = true
+					cycles = append(cycles, append(cycle, cycle[0]))
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[node] = 2
+	}
+
+	var nodes []string
+	for node := range edges {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	for _, node := range nodes {
+		if state[node] == 0 {
+			visit(node)
+		}
+	}
+	return cycles
+}
+
+// longestCallChain returns the longest chain of workflows starting at a node, the node included. Calls back
+// into the chain are cycles, reported on their own, and are not followed.
+func longestCallChain(edges map[string][]string, node string, onChain map[string]bool, memo map[string][]string) []string {
+	if chain, ok := memo[node]; ok {
+		return chain
+	}
+	onChain[node] = true
+	var longest []string
+	for _, next := range edges[node] {
+		if onChain[next] {
+			continue
+		}
+		if chain := longestCallChain(edges, next, onChain, memo); len(chain) > len(longest) {
+			longest = chain
+		}
+	}
+	delete(onChain, node)
+
+	chain := append([]string{node}, longest...)
+	memo[node] = chain
+	return chain
+}
+
+// checkCallNesting flags cycles of the call graph, and scanned workflows whose longest call chain has more
+// levels than the threshold or GitHub's nesting limit
+func checkCallNesting(report *ReusableReport, org string, maxLevels int) {
+	edges := make(map[string][]string)
+	linked := make(map[string]bool)
+	var callers []WorkflowCall
+	for _, call := range report.Calls {
+		from, to := callGraphNode(call), call.target().display()
+		if !linked[from+"\n"+to] {
+			linked[from+"\n"+to] = true
+			edges[from] = append(edges[from], to)
+		}
+		if call.Depth == 1 && (len(callers) == 0 || callers[len(callers)-1].Caller != call.Caller) {
+			callers = append(callers, call)
+		}
+	}
+	for node := range edges {
+		sort.Strings(edges[node])
+	}
+
+	for _, cycle := range findCallCycles(edges) {
+		display := strings.Join(cycle, " → ")
+		report.Cycles = append(report.Cycles, display)
+		repository, workflow := workflowLocation(cycle[0], org)
+		report.Findings = append(report.Findings, Finding{
+			RuleID:     RuleWorkflowCallCycle,
+			Severity:   SeverityError,
+			Repository: repository,
+			Workflow:   workflow,
+			Action:     strings.TrimSuffix(cycle[1], " (local)"),
+			Message:    fmt.Sprintf("Reusable workflows call each other in a cycle: %s; every run that reaches it fails", display),
+		})
+	}
+
+	memo := make(map[string][]string)
+	for _, call := range callers {
+		chain := longestCallChain(edges, callGraphNode(call), make(map[string]bool), memo)
+		levels := len(chain)
+		if levels > report.Summary.DeepestChain {
+			report.Summary.DeepestChain = levels
+		}
+		if levels <= maxLevels && levels <= githubWorkflowNestingLimit {
+			continue
+		}
+
+		report.Summary.TooDeep++
+		finding := Finding{
+			RuleID:     RuleWorkflowNestingDepth,
+			Severity:   SeverityWarning,
+			Repository: call.Repository,
+			Workflow:   strings.TrimPrefix(call.Caller, org+"/"+call.Repository+"/"),
+			Action:     strings.TrimSuffix(chain[1], " (local)"),
+		}
+		path := strings.Join(chain[1:], " → ")
+		if levels > githubWorkflowNestingLimit {
+			finding.Severity = SeverityError
+			finding.Message = fmt.Sprintf("Calls %s, %d levels of workflows, over GitHub's limit of %d; the workflow fails to start",
+				path, levels, githubWorkflowNestingLimit)
+		} else {
+			finding.Message = fmt.Sprintf("Calls %s, %d levels of workflows, over the threshold of %d", path, levels, maxLevels)
+		}
+		report.Findings = append(report.Findings, finding)
+	}
+}
+
+// describeCall returns the called workflow of a call with its ref
+func describeCall(call WorkflowCall) string {
+	return call.target().display()
+}
+
+// writeCallTree writes the calls made by a called workflow, indented below it
+func writeCallTree(writer io.Writer, calls map[string][]WorkflowCall, caller, indent string, depth int) {
+	if depth > maxWorkflowCallDepth {
+		return
+	}
+	for _, call := range calls[caller] {
+		fmt.Fprintf(writer, "%s└── %s (job %s)\n", indent, describeCall(call), call.Job)
+		writeCallTree(writer, calls, describeCall(call), indent+"    ", depth+1)
+	}
+}
+
+// outputReusableReport outputs the reusable workflow analysis in the specified format
+func outputReusableReport(report ReusableReport, format string, writer io.Writer) error {
+	if formatter, ok := lookupFormatter(format); ok {
+		return formatter.Format(report, writer)
+	}
+
+	switch format {
+	case "table":
+		return outputReusableTable(report, writer)
+
+	case "csv":
+		return outputReusableCSV(report, writer)
+
+	case "markdown":
+		return outputReusableMarkdown(report, writer)
+
+	case "sarif":
+		return outputSARIF("reusable", report.Findings, writer)
+
+	default: // "default"
+		fmt.Fprintln(writer, "🔁 Reusable Workflow Call Graph")
+		fmt.Fprintln(writer, "="+strings.Repeat("=", 50))
+
+		nested := make(map[string][]WorkflowCall) // caller -> calls
+		for _, call := range report.Calls {
+			if call.Depth > 1 {
+				nested[call.Caller] = append(nested[call.Caller], call)
+			}
+		}
+		repository := ""
+		for _, call := range report.Calls {
+			if call.Depth != 1 {
+				continue
+			}
+			if call.Repository != repository {
+				repository = call.Repository
+				fmt.Fprintf(writer, "\n📁 %s\n", repository)
+			}
+			fmt.Fprintf(writer, "   📄 %s → %s → %s\n",
+				strings.TrimPrefix(call.Caller, report.Organization+"/"+call.Repository+"/"), call.Job, describeCall(call))
+			writeCallTree(writer, nested, describeCall(call), "         ", 2)
+		}
+
+		if len(report.Workflows) > 0 {
+			fmt.Fprintln(writer, "\n📈 Adoption:")
+			for _, workflow := range report.Workflows {
+				icon := "✅"
+				switch {
+				case !workflow.Resolved:
+					icon = "❌"
+				case workflow.Callers == 0:
+					icon = "💤"
+				}
+				fmt.Fprintf(writer, "   %s %s: %d callers in %d repositories (%s)\n", icon, workflow.Workflow,
+					workflow.Callers, len(workflow.CallingRepositories), formatRefCounts(workflow.Refs))
+			}
+		}
+
+		var branchCalled []ReusableWorkflow
+		for _, workflow := range report.Workflows {
+			if len(workflow.Branches) > 0 {
+				branchCalled = append(branchCalled, workflow)
+			}
+		}
+		if len(branchCalled) > 0 {
+			fmt.Fprintln(writer, "\n🌿 Called by mutable branch:")
+			for _, workflow := range branchCalled {
+				fmt.Fprintf(writer, "   ⚠️  %s@%s (%d callers)\n", workflow.Workflow, strings.Join(workflow.Branches, ", @"), workflow.Callers)
+			}
+		}
+
+		fmt.Fprintln(writer, "\n📊 Summary:")
+		fmt.Fprintf(writer, "   • Workflows scanned: %d in %d repositories\n", report.Summary.WorkflowsScanned, report.Summary.RepositoriesScanned)
+		fmt.Fprintf(writer, "   • Repositories calling reusable workflows: %d (%.1f%%)\n", report.Summary.CallingRepositories, report.Summary.AdoptionRate)
+		fmt.Fprintf(writer, "   • Workflow calls: %d direct, %d nested (max depth %d)\n", report.Summary.DirectCalls, report.Summary.NestedCalls, report.Summary.MaxDepth)
+		fmt.Fprintf(writer, "   • Deepest chain: %d levels (%d workflows over the threshold)\n", report.Summary.DeepestChain, report.Summary.TooDeep)
+		fmt.Fprintf(writer, "   • Cycles: %d\n", len(report.Cycles))
+		fmt.Fprintf(writer, "   • Reusable workflows: %d (%d defined in the organization, %d unused)\n",
+			report.Summary.ReusableWorkflows, report.Summary.OrganizationWorkflows, report.Summary.Unused)
+		fmt.Fprintf(writer, "   • Unresolved: %d\n", report.Summary.Unresolved)
+		fmt.Fprintf(writer, "   • Call pinning: %s\n", report.Summary.Pinning)
+		fmt.Fprintf(writer, "   ⏱️  Process time: %.3fs\n", report.ProcessTimeSeconds)
+
+		outputFindings(report.Findings, writer)
+
+		if report.Truncated {
+			outputTruncationNotice(report.RemainingRepositories, writer)
+		}
+
+		return nil
+	}
+}
+
+// formatRefCounts formats the calls per ref of a reusable workflow, e.g. "v1 ×3, main ×1"
+func formatRefCounts(refs map[string]int) string {
+	if len(refs) == 0 {
+		return "no callers"
+	}
+	var names []string
+	for ref := range refs {
+		names = append(names, ref)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if refs[names[i]] != refs[names[j]] {
+			return refs[names[i]] > refs[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	var parts []string
+	for _, ref := range names {
+		parts = append(parts, fmt.Sprintf("%s ×%d", ref, refs[ref]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// outputReusableTable outputs the reusable workflow adoption in table format
+func outputReusableTable(report ReusableReport, writer io.Writer) error {
+	fmt.Fprintln(writer, "╔════════════════════════════════════════════════════════════════════════════════════════════════════╗")
+	fmt.Fprintf(writer, "║                                    🔁 REUSABLE WORKFLOWS                                            ║\n")
+	fmt.Fprintln(writer, "╚════════════════════════════════════════════════════════════════════════════════════════════════════╝")
+	fmt.Fprintf(writer, "  🏢 Organization: %-59s \n", report.Organization)
+	fmt.Fprintf(writer, "  📈 Adoption: %-63s \n", fmt.Sprintf("%d of %d repositories (%.1f%%)", report.Summary.CallingRepositories, report.Summary.RepositoriesScanned, report.Summary.AdoptionRate))
+	fmt.Fprintf(writer, "  🔗 Workflow Calls: %-57s \n", fmt.Sprintf("%d direct, %d nested", report.Summary.DirectCalls, report.Summary.NestedCalls))
+	fmt.Fprintf(writer, "  ❌ Unresolved: %-61d \n", report.Summary.Unresolved)
+	fmt.Fprintf(writer, "  🪆 Deepest Chain: %-58s \n", fmt.Sprintf("%d levels, %d cycles", report.Summary.DeepestChain, len(report.Cycles)))
+	fmt.Fprintf(writer, "  📌 Call Pinning: %-59s \n", report.Summary.Pinning)
+	fmt.Fprintln(writer, " ═════════════════════════════════════════════════════════════════════════════════════════════════════")
+	fmt.Fprintln(writer)
+
+	if len(report.Workflows) == 0 {
+		fmt.Fprintln(writer, "┌─────────────────────────────────────────┐")
+		fmt.Fprintln(writer, "│    No reusable workflows found          │")
+		fmt.Fprintln(writer, "└─────────────────────────────────────────┘")
+		return nil
+	}
+
+	fmt.Fprintln(writer, "┌──────────────────────────────────────────────────┬─────────┬──────────────┬──────────────────────────┬────────────┐")
+	fmt.Fprintf(writer, "│ %-47s │ %-7s │ %-12s │ %-24s │ %-10s │\n", "🔁 REUSABLE WORKFLOW", "CALLERS", "REPOSITORIES", "REFS", "STATUS")
+	fmt.Fprintln(writer, "├──────────────────────────────────────────────────┼─────────┼──────────────┼──────────────────────────┼────────────┤")
+	for _, workflow := range report.Workflows {
+		status := "resolved"
+		switch {
+		case !workflow.Resolved:
+			status = "unresolved"
+		case workflow.Callers == 0:
+			status = "unused"
+		}
+		fmt.Fprintf(writer, "│ %-48s │ %7d │ %12d │ %-24s │ %-10s │\n",
+			truncate(workflow.Workflow, 48), workflow.Callers, len(workflow.CallingRepositories),
+			truncate(formatRefCounts(workflow.Refs), 24), status)
+	}
+	fmt.Fprintln(writer, "└──────────────────────────────────────────────────┴─────────┴──────────────┴──────────────────────────┴────────────┘")
+	outputFindings(report.Findings, writer)
+	fmt.Fprintln(writer)
+	return nil
+}
+
+// outputReusableCSV outputs the call graph in CSV format, one row per call
+func outputReusableCSV(report ReusableReport, writer io.Writer) error {
+	resolved := make(map[string]bool)
+	for _, workflow := range report.Workflows {
+		resolved[workflow.Workflow] = workflow.Resolved
+	}
+
+	w := newCSVWriter(writer)
+	w.Write([]string{"Repository", "Caller", "Job", "Workflow", "Ref", "Depth", "Resolved", "Pinning"})
+	for _, call := range report.Calls {
+		ref := call.Ref
+		if call.Local {
+			ref = "local"
+		}
+		w.Write([]string{call.Repository, call.Caller, call.Job, call.Workflow, ref, strconv.Itoa(call.Depth),
+			strconv.FormatBool(resolved[call.Workflow]), call.Pinning})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// outputReusableMarkdown outputs the reusable workflow adoption as a Markdown report
+func outputReusableMarkdown(report ReusableReport, writer io.Writer) error {
+	fmt.Fprintf(writer, "## 🔁 Reusable Workflows of `%s`\n\n", report.Organization)
+	writeMarkdownSummary([][2]string{
+		{"Repositories calling reusable workflows", fmt.Sprintf("%d of %d (%.1f%%)", report.Summary.CallingRepositories, report.Summary.RepositoriesScanned, report.Summary.AdoptionRate)},
+		{"Workflow calls", fmt.Sprintf("%d direct, %d nested (max depth %d)", report.Summary.DirectCalls, report.Summary.NestedCalls, report.Summary.MaxDepth)},
+		{"Reusable workflows", fmt.Sprintf("%d (%d defined in the organization, %d unused)", report.Summary.ReusableWorkflows, report.Summary.OrganizationWorkflows, report.Summary.Unused)},
+		{"Unresolved", fmt.Sprint(report.Summary.Unresolved)},
+		{"Deepest chain", fmt.Sprintf("%d levels (%d workflows over the threshold)", report.Summary.DeepestChain, report.Summary.TooDeep)},
+		{"Cycles", fmt.Sprint(len(report.Cycles))},
+		{"Call pinning", report.Summary.Pinning.String()},
+		{"Process time", fmt.Sprintf("%.3fs", report.ProcessTimeSeconds)},
+	}, writer)
+
+	if len(report.Workflows) > 0 {
+		fmt.Fprintln(writer, "| Reusable workflow | Callers | Repositories | Refs | Calls |")
+		fmt.Fprintln(writer, "|---|---:|---:|---|---|")
+		for _, workflow := range report.Workflows {
+			icon := ""
+			switch {
+			case !workflow.Resolved:
+				icon = "❌ "
+			case workflow.Callers == 0:
+				icon = "💤 "
+			}
+			fmt.Fprintf(writer, "| %s`%s` | %d | %d | %s | %s |\n", icon, workflow.Workflow, workflow.Callers,
+				len(workflow.CallingRepositories), markdownCell(formatRefCounts(workflow.Refs)), markdownCell(strings.Join(workflow.Calls, ", ")))
+		}
+		fmt.Fprintln(writer)
+	}
+
+	writeMarkdownFindings(report.Findings, writer)
+	writeMarkdownTruncation(report.RemainingRepositories, writer)
+	return nil
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// runRulesCommand implements `gh action-lens rules list|describe <id>`
+func runRulesCommand(args []string) error {
+	fs := flag.NewFlagSet("rules", flag.ExitOnError)
+
+	var outputFormat string
+	var outputFile string
+
+	fs.StringVar(&outputFormat, "format", "default", "Output format: default, json, markdown")
+	fs.StringVar(&outputFormat, "f", "default", "Output format: default, json, markdown")
+	fs.StringVar(&outputFile, "output", "", "Write output to file instead of stdout")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "\nUsage:\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens rules list [flags]\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens rules describe <rule-id> [flags]\n\n")
+		fmt.Fprintf(os.Stderr, "List the rules gh-action-lens checks, or show the details of one rule.\n\n")
+		fmt.Fprintf(os.Stderr, "Flags:\n")
+		fmt.Fprintf(os.Stderr, "  -f, --format <string>\n")
+		fmt.Fprintf(os.Stderr, "        Output format: default, json, markdown (default \"default\")\n\n")
+		fmt.Fprintf(os.Stderr, "      --output <string>\n")
+		fmt.Fprintf(os.Stderr, "        Write output to file instead of stdout\n\n")
+		fmt.Fprintf(os.Stderr, "Examples:\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens rules list\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens rules describe eol-action\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens rules describe eol-action --format json\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens rules list --format markdown --output RULES.md\n\n")
+	}
+
+	if len(args) == 0 {
+		fs.Usage()
+		return fmt.Errorf("missing rules command: list or describe")
+	}
+	action := args[0]
+	// Flags may come before or after the rule ID: parse again after every positional argument
+	fs.Parse(args[1:])
+	var positional []string
+	for fs.NArg() > 0 {
+		positional = append(positional, fs.Arg(0))
+		fs.Parse(fs.Args()[1:])
+	}
+
+	switch outputFormat {
+	case "default", "json", "markdown":
+	default:
+		return fmt.Errorf("invalid output format '%s'. Valid options: default, json, markdown", outputFormat)
+	}
+
+	var rules []Rule
+	switch action {
+	case "list":
+		rules = sortedRules()
+	case "describe":
+		if len(positional) != 1 {
+			fs.Usage()
+			return fmt.Errorf("describe requires exactly one rule ID")
+		}
+		rule, ok := ruleRegistry[positional[0]]
+		if !ok {
+			return fmt.Errorf("unknown rule '%s'; run 'gh action-lens rules list' to see all rules", positional[0])
+		}
+		rules = []Rule{rule}
+	default:
+		fs.Usage()
+		return fmt.Errorf("unknown rules command '%s'", action)
+	}
+
+	writer, file, err := getOutputWriter(outputFile)
+	if err != nil {
+		return fmt.Errorf("error opening output file: %v", err)
+	}
+	if file != nil {
+		defer file.Close()
+	}
+
+	switch outputFormat {
+	case "json":
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		if action == "describe" {
+			return encoder.Encode(rules[0])
+		}
+		return encoder.Encode(rules)
+	case "markdown":
+		return outputRulesMarkdown(rules, writer)
+	default:
+		if action == "describe" {
+			outputRuleDetails(rules[0], writer)
+			return nil
+		}
+		outputRuleList(rules, writer)
+		return nil
+	}
+}
+
+// outputRuleList writes one line per rule
+func outputRuleList(rules []Rule, writer io.Writer) {
+	fmt.Fprintf(writer, "\n📏 %d rules\n\n", len(rules))
+	for _, rule := range rules {
+		fmt.Fprintf(writer, "%s %-28s %-8s %s\n", severityIcon(rule.Severity), rule.ID, rule.Severity, rule.Name)
+	}
+	fmt.Fprintf(writer, "\nUse 'gh action-lens rules describe <rule-id>' for details.\n")
+}
+
+// outputRuleDetails writes the full metadata of one rule
+func outputRuleDetails(rule Rule, writer io.Writer) {
+	fmt.Fprintf(writer, "\n%s %s — %s\n\n", severityIcon(rule.Severity), rule.ID, rule.Name)
+	fmt.Fprintf(writer, "Severity: %s\n", rule.Severity)
+	fmt.Fprintf(writer, "Checked by: %s\n\n", rule.Scan)
+	fmt.Fprintf(writer, "%s\n\n", rule.Description)
+	fmt.Fprintf(writer, "💡 Fix: %s\n", rule.Remediation)
+	if rule.Example != "" {
+		fmt.Fprintf(writer, "\nFlagged:\n%s\n", indentLines(rule.Example, "    "))
+	}
+	if rule.FixExample != "" {
+		fmt.Fprintf(writer, "\nFixed:\n%s\n", indentLines(rule.FixExample, "    "))
+	}
+	fmt.Fprintln(writer)
+}
+
+// outputRulesMarkdown generates rule reference documentation
+func outputRulesMarkdown(rules []Rule, writer io.Writer) error {
+	fmt.Fprintf(writer, "# gh-action-lens Rules\n\n")
+	fmt.Fprintf(writer, "| Rule | Severity | Checked by | Name |\n|------|----------|------------|------|\n")
+	for _, rule := range rules {
+		fmt.Fprintf(writer, "| [`%s`](#%s) | %s | `%s` | %s |\n", rule.ID, rule.ID, rule.Severity, rule.Scan, rule.Name)
+	}
+
+	for _, rule := range rules {
+		fmt.Fprintf(writer, "\n## %s\n\n", rule.ID)
+		fmt.Fprintf(writer, "**%s** · severity `%s` · checked by `%s`\n\n", rule.Name, rule.Severity, rule.Scan)
+		fmt.Fprintf(writer, "%s\n\n", rule.Description)
+		fmt.Fprintf(writer, "**Remediation:** %s\n", rule.Remediation)
+		if rule.Example != "" {
+			fmt.Fprintf(writer, "\nFlagged:\n\n```yaml\n%s\n```\n", rule.Example)
+		}
+		if rule.FixExample != "" {
+			fmt.Fprintf(writer, "\nFixed:\n\n```yaml\n%s\n```\n", rule.FixExample)
+		}
+	}
+	return nil
+}
+
+// indentLines prefixes every line of s with indent
+func indentLines(s, indent string) string {
+	return indent + strings.ReplaceAll(s, "\n", "\n"+indent)
+}
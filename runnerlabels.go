@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Kinds of runner a job runs on
+const (
+	RunnerGitHubHosted = "github-hosted" // standard image labels such as ubuntu-latest
+	RunnerSelfHosted   = "self-hosted"   // labels including self-hosted
+	RunnerGroup        = "group"         // runs-on: {group: ...}, larger runners or self-hosted runner groups
+	RunnerCustom       = "custom"        // other labels: larger runners or runner scale sets
+	RunnerUnresolved   = "unresolved"    // expressions only known at run time
+)
+
+// RunnerInventory counts the runners jobs run on and the labels they request
+type RunnerInventory struct {
+	RunnerUses   map[string]int     `json:"runner_uses"` // job × runner pairs per kind; a matrix counts each runner
+	Labels       []RunnerLabelUsage `json:"labels"`
+	Repositories map[string]int     `json:"repositories"` // repositories per kind
+}
+
+// RunnerLabelUsage is how often a runs-on label or runner group is requested, and by whom
+type RunnerLabelUsage struct {
+	Label        string   `json:"label"` // a label, or group:<name> for a runner group
+	Kind         string   `json:"kind"`
+	Jobs         int      `json:"jobs"`
+	Workflows    int      `json:"workflows"`
+	Repositories []string `json:"repositories"`
+
+	workflows, repositories map[string]bool
+}
+
+// runnerKind classifies the labels and group of a runner
+func runnerKind(labels []string, group string) string {
+	if group != "" {
+		return RunnerGroup
+	}
+	kind := RunnerGitHubHosted
+	for _, label := range labels {
+		switch {
+		case isExpression(label):
+			return RunnerUnresolved
+		case strings.EqualFold(label, "self-hosted"):
+			return RunnerSelfHosted
+		case !isGitHubHostedLabel(label):
+			kind = RunnerCustom
+		}
+	}
+	return kind
+}
+
+// isGitHubHostedLabel reports whether a label names a standard GitHub-hosted image, e.g. ubuntu-24.04,
+// windows-latest, or macos-15
+func isGitHubHostedLabel(label string) bool {
+	label = strings.ToLower(label)
+	for _, prefix := range []string{"ubuntu-", "windows-", "macos-"} {
+		if strings.HasPrefix(label, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// runnerGroup returns the group of a runs-on: {group: ..., labels: ...}
+func runnerGroup(job workflowJob) string {
+	if runsOn, ok := job.RunsOn.(map[string]interface{}); ok {
+		if group, ok := runsOn["group"].(string); ok {
+			return group
+		}
+	}
+	return ""
+}
+
+// runnerInventory collects the runners of the jobs of the scanned workflows
+type runnerInventory struct {
+	uses         map[string]int
+	labels       map[string]*RunnerLabelUsage
+	repositories map[string]map[string]bool
+}
+
+// newRunnerInventory returns an empty inventory
+func newRunnerInventory() *runnerInventory {
+	return &runnerInventory{
+		uses:         make(map[string]int),
+		labels:       make(map[string]*RunnerLabelUsage),
+		repositories: make(map[string]map[string]bool),
+	}
+}
+
+// addJob records the runners of a job, one per matrix value
+func (inventory *runnerInventory) addJob(repo, workflowPath string, job workflowJob) {
+	group := runnerGroup(job)
+	for _, runner := range jobRunners(job) {
+		var labels []string
+		if runner.Label != "" {
+			labels = strings.Split(runner.Label, ",")
+		}
+		kind := runnerKind(labels, group)
+		inventory.uses[kind]++
+		if inventory.repositories[kind] == nil {
+			inventory.repositories[kind] = make(map[string]bool)
+		}
+		inventory.repositories[kind][repo] = true
+
+		if group != "" {
+			labels = append([]string{"group:" + group}, labels...)
+		}
+		for _, label := range labels {
+			key := kind + "\x00" + label
+			usage, ok := inventory.labels[key]
+			if !ok {
+				usage = &RunnerLabelUsage{Label: label, Kind: kind, workflows: make(map[string]bool), repositories: make(map[string]bool)}
+				inventory.labels[key] = usage
+			}
+			usage.Jobs++
+			usage.workflows[repo+"/"+workflowPath] = true
+			usage.repositories[repo] = true
+		}
+	}
+}
+
+// result returns the inventory with labels ordered by kind, then by the number of jobs requesting them
+func (inventory *runnerInventory) result() RunnerInventory {
+	result := RunnerInventory{
+		RunnerUses:   inventory.uses,
+		Labels:       []RunnerLabelUsage{},
+		Repositories: make(map[string]int),
+	}
+	for kind, repositories := range inventory.repositories {
+		result.Repositories[kind] = len(repositories)
+	}
+	for _, usage := range inventory.labels {
+		usage.Workflows = len(usage.workflows)
+		usage.Repositories = sortedKeys(usage.repositories)
+		result.Labels = append(result.Labels, *usage)
+	}
+	kindRank := map[string]int{RunnerSelfHosted: 0, RunnerGroup: 1, RunnerCustom: 2, RunnerGitHubHosted: 3, RunnerUnresolved: 4}
+	sort.Slice(result.Labels, func(i, j int) bool {
+		a, b := result.Labels[i], result.Labels[j]
+		if a.Kind != b.Kind {
+			return kindRank[a.Kind] < kindRank[b.Kind]
+		}
+		if a.Jobs != b.Jobs {
+			return a.Jobs > b.Jobs
+		}
+		return a.Label < b.Label
+	})
+	return result
+}
+
+// outputRunnerInventory writes the runner kinds and the labels requested per kind
+func outputRunnerInventory(inventory RunnerInventory, writer io.Writer) {
+	fmt.Fprintln(writer, "\n🏷️  Runner Inventory:")
+	for _, kind := range []string{RunnerGitHubHosted, RunnerSelfHosted, RunnerGroup, RunnerCustom, RunnerUnresolved} {
+		if inventory.RunnerUses[kind] > 0 {
+			fmt.Fprintf(writer, "   • %s: %d jobs in %d repositories\n", kind, inventory.RunnerUses[kind], inventory.Repositories[kind])
+		}
+	}
+	for _, usage := range inventory.Labels {
+		line := fmt.Sprintf("     - %-30s %-13s %4d jobs, %3d workflows", usage.Label, usage.Kind, usage.Jobs, usage.Workflows)
+		if usage.Kind == RunnerSelfHosted || usage.Kind == RunnerGroup || usage.Kind == RunnerCustom {
+			line += ": " + strings.Join(usage.Repositories, ", ")
+		}
+		fmt.Fprintln(writer, line)
+	}
+}
@@ -0,0 +1,470 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Runner operating systems, as reported by runner.os in lower case
+const (
+	RunnerLinux   = "linux"
+	RunnerWindows = "windows"
+	RunnerMacOS   = "macos"
+)
+
+// Problems of an OS-specific run step
+const (
+	RunnerProblemMismatch = "mismatch" // the command does not exist on (one of) the job's runners
+	RunnerProblemLatest   = "latest"   // the runner is a -latest label that moves to new images
+)
+
+// osCommand is a shell command that only exists on one runner operating system
+type osCommand struct {
+	OS      string
+	Pattern *regexp.Regexp
+}
+
+// osCommands are the package managers and system tools checked in run: scripts. A command counts only
+// at the start of a line or after a shell separator, so words inside strings and paths are ignored.
+var osCommands = []osCommand{
+	{RunnerLinux, commandPattern(`apt-get|apt|dpkg|yum|dnf|apk|zypper|snap|systemctl|update-alternatives`)},
+	{RunnerMacOS, commandPattern(`brew|xcodebuild|xcrun|xcode-select|softwareupdate|hdiutil|launchctl`)},
+	{RunnerWindows, commandPattern(`choco|winget|scoop|msiexec|reg\.exe|certutil`)},
+}
+
+// commandPattern matches any of the given commands in command position, optionally prefixed with sudo
+func commandPattern(commands string) *regexp.Regexp {
+	return regexp.MustCompile(`(?m)(?:^|[;&|(]|\$\()\s*(?:sudo\s+(?:-\S+\s+)*)?(` + commands + `)(?:\s|$)`)
+}
+
+// osGuardPattern matches scripts that check the operating system before running OS-specific commands
+var osGuardPattern = regexp.MustCompile(`RUNNER_OS|runner\.os|\buname\b|\$IsLinux|\$IsMacOS|\$IsWindows|\$OSTYPE|command -v|\bwhich\b|Get-Command`)
+
+// RunnerReport lists the run steps whose commands assume a runner operating system
+type RunnerReport struct {
+	Organization          string             `json:"organization"`
+	Summary               RunnerSummary      `json:"summary"`
+	Assumptions           []RunnerAssumption `json:"assumptions"`
+	Inventory             RunnerInventory    `json:"inventory"`
+	Findings              []Finding          `json:"findings"`
+	Truncated             bool               `json:"truncated"`
+	RemainingRepositories []string           `json:"remaining_repositories,omitempty"`
+	ProcessTimeSeconds    float64            `json:"process_time_seconds"`
+}
+
+// RunnerSummary represents summary statistics of the runner OS analysis
+type RunnerSummary struct {
+	WorkflowsScanned int `json:"workflows_scanned"`
+	JobsScanned      int `json:"jobs_scanned"`
+	UnknownRunners   int `json:"unknown_runners"` // jobs on self-hosted or expression labels without an OS
+	OSSpecificSteps  int `json:"os_specific_steps"`
+	Guarded          int `json:"guarded"` // steps checking the OS before running the command
+	Mismatched       int `json:"mismatched"`
+	LatestLabels     int `json:"latest_labels"`
+}
+
+// RunnerAssumption is one unguarded run step using a command of one operating system
+type RunnerAssumption struct {
+	Repository string   `json:"repository"`
+	Workflow   string   `json:"workflow"`
+	Job        string   `json:"job"`
+	Step       int      `json:"step"` // 1-based step index
+	StepName   string   `json:"step_name,omitempty"`
+	Runners    []string `json:"runners"` // runs-on labels, one per matrix value
+	Command    string   `json:"command"`
+	CommandOS  string   `json:"command_os"`
+	Problem    string   `json:"problem"`
+}
+
+// runnerLabel is one runner a job runs on and the operating system it implies
+type runnerLabel struct {
+	Label  string
+	OS     string // "" when the label does not imply an operating system
+	Latest bool
+}
+
+// runnerOS returns the operating system implied by a runs-on label: GitHub-hosted image labels such as
+// ubuntu-24.04 or macos-latest-xlarge, and the linux/windows/macOS labels of self-hosted runners
+func runnerOS(label string) string {
+	label = strings.ToLower(strings.TrimSpace(label))
+	switch {
+	case label == "linux" || strings.HasPrefix(label, "ubuntu"):
+		return RunnerLinux
+	case label == "windows" || strings.HasPrefix(label, "windows-"):
+		return RunnerWindows
+	case label == "macos" || strings.HasPrefix(label, "macos-"):
+		return RunnerMacOS
+	}
+	return ""
+}
+
+// jobRunners resolves the runs-on of a job to the runners it uses. A list of labels is one runner whose
+// OS is the first label implying one; `${{ matrix.<key> }}` expands to every value of that dimension and
+// of include entries. Other expressions, runner groups, and labels without an OS yield an unknown OS.
+func jobRunners(job workflowJob) []runnerLabel {
+	switch v := job.RunsOn.(type) {
+	case string:
+		if key, ok := matrixReference(v); ok {
+			return matrixRunners(job.Strategy.Matrix, key)
+		}
+		return []runnerLabel{newRunnerLabel([]string{v})}
+	case []interface{}:
+		var labels []string
+		for _, item := range v {
+			labels = append(labels, fmt.Sprint(item))
+		}
+		return []runnerLabel{newRunnerLabel(labels)}
+	case map[string]interface{}:
+		return []runnerLabel{newRunnerLabel(collectStrings(v["labels"]))}
+	}
+	return nil
+}
+
+// newRunnerLabel builds the runner for a set of labels that must all match
+func newRunnerLabel(labels []string) runnerLabel {
+	runner := runnerLabel{Label: strings.Join(labels, ",")}
+	for _, label := range labels {
+		if isExpression(label) {
+			return runnerLabel{Label: runner.Label}
+		}
+		if runner.OS == "" {
+			runner.OS = runnerOS(label)
+		}
+		if strings.Contains(strings.ToLower(label), "-latest") {
+			runner.Latest = true
+		}
+	}
+	return runner
+}
+
+// matrixReferencePattern matches a runs-on that is exactly one matrix value
+var matrixReferencePattern = regexp.MustCompile(`^\$\{\{\s*matrix\.([A-Za-z0-9_-]+)\s*\}\}$`)
+
+// matrixReference returns the matrix key of a runs-on such as ${{ matrix.os }}
+func matrixReference(runsOn string) (string, bool) {
+	match := matrixReferencePattern.FindStringSubmatch(strings.TrimSpace(runsOn))
+	if match == nil {
+		return "", false
+	}
+	return match[1], true
+}
+
+// matrixRunners returns one runner per distinct value of a matrix dimension, including the values
+// added by include entries
+func matrixRunners(matrix interface{}, key string) []runnerLabel {
+	definition, ok := yamlMap(matrix)
+	if !ok {
+		return []runnerLabel{{Label: "${{ matrix." + key + " }}"}}
+	}
+
+	var values []interface{}
+	switch v := definition[key].(type) {
+	case []interface{}:
+		values = append(values, v...)
+	case nil:
+	default:
+		// A dimension built by an expression; its values are only known at run time
+		return []runnerLabel{{Label: "${{ matrix." + key + " }}"}}
+	}
+	if includes, ok := definition["include"].([]interface{}); ok {
+		for _, include := range includes {
+			if entry, ok := yamlMap(include); ok && entry[key] != nil {
+				values = append(values, entry[key])
+			}
+		}
+	}
+
+	var runners []runnerLabel
+	seen := make(map[string]bool)
+	for _, value := range values {
+		var runner runnerLabel
+		switch v := value.(type) {
+		case []interface{}:
+			var labels []string
+			for _, item := range v {
+				labels = append(labels, fmt.Sprint(item))
+			}
+			runner = newRunnerLabel(labels)
+		default:
+			runner = newRunnerLabel([]string{fmt.Sprint(v)})
+		}
+		if !seen[runner.Label] {
+			seen[runner.Label] = true
+			runners = append(runners, runner)
+		}
+	}
+	return runners
+}
+
+// findOSCommand returns the first OS-specific command of a script and the OS it belongs to
+func findOSCommand(script string) (string, string, bool) {
+	first, command, commandOS := -1, "", ""
+	for _, candidate := range osCommands {
+		match := candidate.Pattern.FindStringSubmatchIndex(script)
+		if match != nil && (first < 0 || match[2] < first) {
+			first, command, commandOS = match[2], script[match[2]:match[3]], candidate.OS
+		}
+	}
+	return command, commandOS, first >= 0
+}
+
+// isOSGuarded reports whether a step checks the runner OS, in its if: condition or in the script itself
+func isOSGuarded(step workflowStep) bool {
+	return strings.Contains(step.If, "runner.os") || strings.Contains(step.If, "matrix.") || osGuardPattern.MatchString(step.Run)
+}
+
+// runnerAssumptions returns the OS-specific run steps of a job that fail on, or depend on the image of,
+// the runners the job uses, the number of OS-specific steps that check the OS first, and whether the OS of
+// every runner is known
+func runnerAssumptions(job workflowJob) ([]RunnerAssumption, int, bool) {
+	runners := jobRunners(job)
+	known := len(runners) > 0
+	for _, runner := range runners {
+		if runner.OS == "" {
+			known = false
+		}
+	}
+
+	var assumptions []RunnerAssumption
+	guarded := 0
+	for i, step := range job.Steps {
+		command, commandOS, ok := findOSCommand(step.Run)
+		if !ok {
+			continue
+		}
+		if isOSGuarded(step) {
+			guarded++
+			continue
+		}
+
+		var labels, mismatched, latest []string
+		for _, runner := range runners {
+			labels = append(labels, runner.Label)
+			switch {
+			case runner.OS == "":
+			case runner.OS != commandOS:
+				mismatched = append(mismatched, runner.Label)
+			case runner.Latest:
+				latest = append(latest, runner.Label)
+			}
+		}
+
+		assumption := RunnerAssumption{
+			Step:      i + 1,
+			StepName:  step.Name,
+			Runners:   labels,
+			Command:   command,
+			CommandOS: commandOS,
+		}
+		switch {
+		case len(mismatched) > 0:
+			assumption.Problem = RunnerProblemMismatch
+			assumption.Runners = mismatched
+		case len(latest) > 0:
+			assumption.Problem = RunnerProblemLatest
+			assumption.Runners = latest
+		default:
+			continue
+		}
+		assumptions = append(assumptions, assumption)
+	}
+	return assumptions, guarded, known
+}
+
+// runnerFinding converts an OS assumption into a finding
+func runnerFinding(assumption RunnerAssumption) Finding {
+	finding := Finding{
+		Repository: assumption.Repository,
+		Workflow:   assumption.Workflow,
+	}
+	runners := strings.Join(assumption.Runners, ", ")
+	if assumption.Problem == RunnerProblemMismatch {
+		finding.RuleID = RuleRunnerOSMismatch
+		finding.Severity = SeverityError
+		finding.Message = fmt.Sprintf("job %s step %d runs %s (%s) on %s without checking runner.os",
+			assumption.Job, assumption.Step, assumption.Command, assumption.CommandOS, runners)
+	} else {
+		finding.RuleID = RuleRunnerLatestLabel
+		finding.Severity = SeverityWarning
+		finding.Message = fmt.Sprintf("job %s step %d runs %s (%s) on %s, which moves to new runner images",
+			assumption.Job, assumption.Step, assumption.Command, assumption.CommandOS, runners)
+	}
+	return finding
+}
+
+// analyzeRunnerAssumptions flags run steps using OS-specific commands on mismatched or -latest runners
+func analyzeRunnerAssumptions(org string, startTime time.Time, outputFormat, outputFile string, opts scanOptions) error {
+	repositories, _, err := listRepositoryWorkflows(org, opts)
+	if err != nil {
+		return err
+	}
+
+	logInfof("🖥️  Checking runner OS assumptions in %d repositories...\n\n", len(repositories))
+
+	report := RunnerReport{
+		Organization: org,
+		Assumptions:  []RunnerAssumption{},
+		Findings:     []Finding{},
+	}
+	inventory := newRunnerInventory()
+
+	report.RemainingRepositories = forEachWorkflowDefinition(org, repositories, opts, func(repo, workflowPath string, definition *workflowDefinition) {
+		report.Summary.WorkflowsScanned++
+		for _, jobID := range definition.sortedJobIDs() {
+			job := definition.Jobs[jobID]
+			if job.Uses != "" {
+				continue
+			}
+			report.Summary.JobsScanned++
+			inventory.addJob(repo, workflowPath, job)
+			assumptions, guarded, known := runnerAssumptions(job)
+			if !known {
+				report.Summary.UnknownRunners++
+			}
+			report.Summary.Guarded += guarded
+			for _, assumption := range assumptions {
+				assumption.Repository, assumption.Workflow, assumption.Job = repo, workflowPath, jobID
+				report.Assumptions = append(report.Assumptions, assumption)
+			}
+		}
+	})
+
+	sort.SliceStable(report.Assumptions, func(i, j int) bool {
+		a, b := report.Assumptions[i], report.Assumptions[j]
+		if a.Problem != b.Problem {
+			return a.Problem == RunnerProblemMismatch
+		}
+		if a.Repository != b.Repository {
+			return a.Repository < b.Repository
+		}
+		if a.Workflow != b.Workflow {
+			return a.Workflow < b.Workflow
+		}
+		if a.Job != b.Job {
+			return a.Job < b.Job
+		}
+		return a.Step < b.Step
+	})
+	for _, assumption := range report.Assumptions {
+		if assumption.Problem == RunnerProblemMismatch {
+			report.Summary.Mismatched++
+		} else {
+			report.Summary.LatestLabels++
+		}
+		report.Findings = append(report.Findings, runnerFinding(assumption))
+	}
+	report.Summary.OSSpecificSteps = len(report.Assumptions) + report.Summary.Guarded
+	report.Inventory = inventory.result()
+
+	opts.finishFindings(org, &report.Findings)
+	report.Truncated = len(report.RemainingRepositories) > 0
+	report.ProcessTimeSeconds = time.Since(startTime).Seconds()
+
+	return opts.writeFindingsReport(org, &report, report.Findings, outputFile, func(writer io.Writer) error {
+		return outputRunnerReport(report, outputFormat, writer)
+	})
+}
+
+// outputRunnerReport outputs the runner OS analysis in the specified format
+func outputRunnerReport(report RunnerReport, format string, writer io.Writer) error {
+	if formatter, ok := lookupFormatter(format); ok {
+		return formatter.Format(report, writer)
+	}
+
+	switch format {
+	case "table":
+		return outputRunnerTable(report, writer)
+
+	case "csv":
+		return outputRunnerCSV(report, writer)
+
+	case "markdown":
+		return outputFindingsMarkdown("🖥️ Runner OS Assumptions", report.Findings, report.RemainingRepositories, writer)
+
+	case "sarif":
+		return outputSARIF("runners", report.Findings, writer)
+
+	default: // "default"
+		fmt.Fprintln(writer, "🖥️  Runner OS Assumptions")
+		fmt.Fprintln(writer, "="+strings.Repeat("=", 50))
+
+		for _, assumption := range report.Assumptions {
+			icon := "⚠️ "
+			if assumption.Problem == RunnerProblemMismatch {
+				icon = "❌"
+			}
+			fmt.Fprintf(writer, "%s %s → %s → %s step %d: %s (%s) on %s\n",
+				icon, assumption.Repository, assumption.Workflow, assumption.Job, assumption.Step,
+				assumption.Command, assumption.CommandOS, strings.Join(assumption.Runners, ", "))
+		}
+
+		fmt.Fprintln(writer, "\n📊 Summary:")
+		fmt.Fprintf(writer, "   • Workflows scanned: %d (%d jobs)\n", report.Summary.WorkflowsScanned, report.Summary.JobsScanned)
+		fmt.Fprintf(writer, "   • Jobs on runners without a known OS: %d\n", report.Summary.UnknownRunners)
+		fmt.Fprintf(writer, "   • OS-specific run steps: %d (%d check the OS)\n", report.Summary.OSSpecificSteps, report.Summary.Guarded)
+		fmt.Fprintf(writer, "   • On a mismatched runner: %d\n", report.Summary.Mismatched)
+		fmt.Fprintf(writer, "   • On a -latest label: %d\n", report.Summary.LatestLabels)
+		fmt.Fprintf(writer, "   ⏱️  Process time: %.3fs\n", report.ProcessTimeSeconds)
+
+		outputRunnerInventory(report.Inventory, writer)
+		outputFindings(report.Findings, writer)
+
+		if report.Truncated {
+			outputTruncationNotice(report.RemainingRepositories, writer)
+		}
+
+		return nil
+	}
+}
+
+// outputRunnerTable outputs the runner OS analysis in table format
+func outputRunnerTable(report RunnerReport, writer io.Writer) error {
+	fmt.Fprintln(writer, "╔════════════════════════════════════════════════════════════════════════════════════════════════════╗")
+	fmt.Fprintf(writer, "║                                    🖥️  RUNNER OS ASSUMPTIONS                                        ║\n")
+	fmt.Fprintln(writer, "╚════════════════════════════════════════════════════════════════════════════════════════════════════╝")
+	fmt.Fprintf(writer, "  🏢 Organization: %-59s \n", report.Organization)
+	fmt.Fprintf(writer, "  🧩 OS-specific Steps: %-54d \n", report.Summary.OSSpecificSteps)
+	fmt.Fprintf(writer, "  ❌ Mismatched Runner: %-54d \n", report.Summary.Mismatched)
+	fmt.Fprintf(writer, "  ⚠️  -latest Label: %-57d \n", report.Summary.LatestLabels)
+	fmt.Fprintln(writer, " ═════════════════════════════════════════════════════════════════════════════════════════════════════")
+	fmt.Fprintln(writer)
+
+	if len(report.Assumptions) == 0 {
+		fmt.Fprintln(writer, "┌─────────────────────────────────────────┐")
+		fmt.Fprintln(writer, "│    No runner OS assumptions found       │")
+		fmt.Fprintln(writer, "└─────────────────────────────────────────┘")
+		outputRunnerInventory(report.Inventory, writer)
+		return nil
+	}
+
+	fmt.Fprintln(writer, "┌─────────────────────┬────────────────────────────────┬──────────────────┬──────┬────────────────┬──────────────────────────┐")
+	fmt.Fprintf(writer, "│ %-18s │ %-29s │ %-16s │ %-4s │ %-14s │ %-24s │\n", "📁 REPOSITORY", "📄 WORKFLOW", "JOB", "STEP", "COMMAND", "RUNNER")
+	fmt.Fprintln(writer, "├─────────────────────┼────────────────────────────────┼──────────────────┼──────┼────────────────┼──────────────────────────┤")
+	for _, assumption := range report.Assumptions {
+		fmt.Fprintf(writer, "│ %-19s │ %-30s │ %-16s │ %4d │ %-14s │ %-24s │\n",
+			truncate(assumption.Repository, 19), truncate(assumption.Workflow, 30), truncate(assumption.Job, 16), assumption.Step,
+			truncate(assumption.Command+" ("+assumption.CommandOS+")", 14), truncate(strings.Join(assumption.Runners, ", "), 24))
+	}
+	fmt.Fprintln(writer, "└─────────────────────┴────────────────────────────────┴──────────────────┴──────┴────────────────┴──────────────────────────┘")
+	outputRunnerInventory(report.Inventory, writer)
+	outputFindings(report.Findings, writer)
+	fmt.Fprintln(writer)
+	return nil
+}
+
+// outputRunnerCSV outputs the runner OS analysis in CSV format
+func outputRunnerCSV(report RunnerReport, writer io.Writer) error {
+	w := newCSVWriter(writer)
+	w.Write([]string{"Repository", "Workflow", "Job", "Step", "Step Name", "Command", "Command OS", "Runners", "Problem"})
+	for _, assumption := range report.Assumptions {
+		w.Write([]string{assumption.Repository, assumption.Workflow, assumption.Job, strconv.Itoa(assumption.Step), assumption.StepName,
+			assumption.Command, assumption.CommandOS, strings.Join(assumption.Runners, " "), assumption.Problem})
+	}
+	w.Flush()
+	return w.Error()
+}
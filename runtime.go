@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// RuntimeDocker is the runtime of container actions, declared as runs.using: docker or referenced as docker://image
+const RuntimeDocker = "docker"
+
+// deprecatedRuntimes are runs.using values GitHub no longer supports or runs on a newer Node.js version
+var deprecatedRuntimes = map[string]bool{"node12": true, "node16": true}
+
+// RuntimeUsages counts the action usages of one runtime
+type RuntimeUsages struct {
+	Runtime    string   `json:"runtime"` // runs.using: node20, docker, composite, ...
+	Usages     int      `json:"usages"`
+	Actions    []string `json:"actions"` // action@ref references on this runtime
+	Deprecated bool     `json:"deprecated,omitempty"`
+}
+
+// actionRuntime returns the runs.using of an action reference, or "" when its action.yml cannot be read,
+// e.g. for reusable workflow calls
+func actionRuntime(cache *enrichmentCache, org, action, ref string) string {
+	if strings.HasPrefix(action, "docker://") {
+		return RuntimeDocker
+	}
+	metadata, err := fetchActionMetadata(cache, org, "", action, ref)
+	if err != nil {
+		return ""
+	}
+	return metadata.Using
+}
+
+// resolveRuntimes returns the runtime of the given action@ref references, read with at most
+// opts.Concurrency lookups in flight
+func resolveRuntimes(references []string, org string, opts scanOptions) map[string]string {
+	runtimes := make([]string, len(references))
+	runConcurrently(len(references), opts.Concurrency, func(i int) {
+		action, ref, _ := splitActionReference(references[i])
+		apiRateLimit.acquire()
+		runtimes[i] = actionRuntime(opts.Cache, org, action, ref)
+		apiRateLimit.release()
+	})
+
+	resolved := make(map[string]string, len(references))
+	for i, reference := range references {
+		resolved[reference] = runtimes[i]
+	}
+	return resolved
+}
+
+// classifyComprehensiveRuntimes sets the runtime of every action of the detailed report, reading the
+// action.yml of each distinct action@ref once
+func classifyComprehensiveRuntimes(repositories []ComprehensiveRepository, org string, opts scanOptions) {
+	seen := make(map[string]bool)
+	var references []string
+	for _, repo := range repositories {
+		for _, workflow := range repo.Workflows {
+			for _, action := range workflow.Actions {
+				reference := action.Name + "@" + action.Version
+				if !seen[reference] {
+					seen[reference] = true
+					references = append(references, reference)
+				}
+			}
+		}
+	}
+	sort.Strings(references)
+
+	byReference := resolveRuntimes(references, org, opts)
+	for _, repo := range repositories {
+		for _, workflow := range repo.Workflows {
+			for i, action := range workflow.Actions {
+				workflow.Actions[i].Runtime = byReference[action.Name+"@"+action.Version]
+			}
+		}
+	}
+}
+
+// summarizeRuntimes counts the action usages of every runtime, most used first; actions whose runtime
+// is unknown are left out
+func summarizeRuntimes(repositories []ComprehensiveRepository) []RuntimeUsages {
+	byRuntime := make(map[string]*RuntimeUsages)
+	seen := make(map[string]bool)
+	for _, repo := range repositories {
+		for _, workflow := range repo.Workflows {
+			for _, action := range workflow.Actions {
+				if action.Runtime == "" {
+					continue
+				}
+				entry := byRuntime[action.Runtime]
+				if entry == nil {
+					entry = &RuntimeUsages{Runtime: action.Runtime, Actions: []string{}, Deprecated: deprecatedRuntimes[action.Runtime]}
+					byRuntime[action.Runtime] = entry
+				}
+				entry.Usages += action.Count
+				if reference := action.Name + "@" + action.Version; !seen[reference] {
+					seen[reference] = true
+					entry.Actions = append(entry.Actions, reference)
+				}
+			}
+		}
+	}
+
+	runtimes := []RuntimeUsages{}
+	for _, entry := range byRuntime {
+		sort.Strings(entry.Actions)
+		runtimes = append(runtimes, *entry)
+	}
+	sort.Slice(runtimes, func(i, j int) bool {
+		if runtimes[i].Usages != runtimes[j].Usages {
+			return runtimes[i].Usages > runtimes[j].Usages
+		}
+		return runtimes[i].Runtime < runtimes[j].Runtime
+	})
+	return runtimes
+}
+
+// formatRuntimes formats the usages per runtime, e.g. "node20 ×40, composite ×3, node16 ×2"
+func formatRuntimes(runtimes []RuntimeUsages) string {
+	if len(runtimes) == 0 {
+		return "unknown"
+	}
+	var parts []string
+	for _, runtime := range runtimes {
+		parts = append(parts, fmt.Sprintf("%s ×%d", runtime.Runtime, runtime.Usages))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// outputDeprecatedRuntimes lists the actions still running on a deprecated runtime
+func outputDeprecatedRuntimes(runtimes []RuntimeUsages, writer io.Writer) {
+	for _, runtime := range runtimes {
+		if !runtime.Deprecated {
+			continue
+		}
+		fmt.Fprintf(writer, "\n🕰️  Deprecated runtime %s (%d usages):\n", runtime.Runtime, runtime.Usages)
+		for _, action := range runtime.Actions {
+			fmt.Fprintf(writer, "   • %s\n", action)
+		}
+	}
+}
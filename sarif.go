@@ -0,0 +1,210 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// sarifSchema is the JSON schema of SARIF 2.1.0 as accepted by GitHub code scanning
+const sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+// sarifLog is the root object of a SARIF 2.1.0 file
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+// sarifRun holds the results of one scan
+type sarifRun struct {
+	Tool              sarifTool              `json:"tool"`
+	AutomationDetails sarifAutomationDetails `json:"automationDetails"`
+	Results           []sarifResult          `json:"results"`
+}
+
+// sarifAutomationDetails identifies the scan so code scanning can track results across uploads
+type sarifAutomationDetails struct {
+	ID string `json:"id"`
+}
+
+// sarifTool describes gh-action-lens and its rules
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+// sarifDriver is the analysis tool component
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+// sarifRule is the SARIF reportingDescriptor of a rule
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	Name                 string             `json:"name"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	FullDescription      sarifMessage       `json:"fullDescription"`
+	Help                 sarifMessage       `json:"help"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+	Properties           sarifRuleProps     `json:"properties"`
+}
+
+// sarifConfiguration sets the default level of a rule
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+// sarifRuleProps carries the rule tags shown by code scanning
+type sarifRuleProps struct {
+	Tags []string `json:"tags"`
+}
+
+// sarifMessage is a plain text message with an optional Markdown rendering
+type sarifMessage struct {
+	Text     string `json:"text"`
+	Markdown string `json:"markdown,omitempty"`
+}
+
+// sarifResult is one finding
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+	Properties          map[string]string `json:"properties"`
+}
+
+// sarifLocation points at the workflow file of a finding and, with --snippets, its line
+type sarifLocation struct {
+	PhysicalLocation struct {
+		ArtifactLocation struct {
+			URI       string `json:"uri"`
+			URIBaseID string `json:"uriBaseId"`
+		} `json:"artifactLocation"`
+		Region        *sarifRegion `json:"region,omitempty"`
+		ContextRegion *sarifRegion `json:"contextRegion,omitempty"`
+	} `json:"physicalLocation"`
+}
+
+// sarifRegion is a range of lines of a workflow with their source
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+	EndLine   int `json:"endLine"`
+	Snippet   struct {
+		Text string `json:"text"`
+	} `json:"snippet"`
+}
+
+// sarifLevel maps a finding severity to a SARIF level
+func sarifLevel(severity string) string {
+	switch severity {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	}
+	return "note"
+}
+
+// buildSARIF converts findings into a SARIF log with one run. Workflow paths are relative to the
+// repository root; the repository of each result is kept in its properties.
+func buildSARIF(scan string, findings []Finding) sarifLog {
+	driver := sarifDriver{
+		Name:           "gh-action-lens",
+		InformationURI: "https://github.com/jefeish/gh-action-lens",
+	}
+	ruleIndex := make(map[string]int)
+	for _, rule := range sortedRules() {
+		ruleIndex[rule.ID] = len(driver.Rules)
+		driver.Rules = append(driver.Rules, sarifRuleFor(rule))
+	}
+
+	results := []sarifResult{}
+	for _, finding := range findings {
+		index, ok := ruleIndex[finding.RuleID]
+		if !ok {
+			index = len(driver.Rules)
+			ruleIndex[finding.RuleID] = index
+			driver.Rules = append(driver.Rules, sarifRuleFor(lookupRule(finding.RuleID)))
+		}
+
+		var location sarifLocation
+		location.PhysicalLocation.ArtifactLocation.URI = finding.Workflow
+		location.PhysicalLocation.ArtifactLocation.URIBaseID = "%SRCROOT%"
+		if snippet := finding.Snippet; snippet != nil {
+			region := &sarifRegion{StartLine: snippet.Line, EndLine: snippet.Line}
+			region.Snippet.Text = snippet.Lines[snippet.Line-snippet.StartLine]
+			contextRegion := &sarifRegion{StartLine: snippet.StartLine, EndLine: snippet.StartLine + len(snippet.Lines) - 1}
+			contextRegion.Snippet.Text = strings.Join(snippet.Lines, "\n")
+			location.PhysicalLocation.Region = region
+			location.PhysicalLocation.ContextRegion = contextRegion
+		}
+
+		fingerprint := sha256.Sum256([]byte(findingKey(finding)))
+		properties := map[string]string{"repository": finding.Repository}
+		if finding.Action != "" {
+			properties["action"] = finding.Action + "@" + finding.Version
+		}
+		if finding.Authorship != "" {
+			properties["authorship"] = finding.Authorship
+			properties["lastModifiedBy"] = finding.LastModifiedBy
+		}
+		// Hook metadata never replaces the built-in properties
+		for key, value := range finding.Metadata {
+			if _, ok := properties[key]; !ok {
+				properties[key] = value
+			}
+		}
+
+		results = append(results, sarifResult{
+			RuleID:              finding.RuleID,
+			RuleIndex:           index,
+			Level:               sarifLevel(finding.Severity),
+			Message:             sarifMessage{Text: finding.Message + ". Fix: " + finding.remediation()},
+			Locations:           []sarifLocation{location},
+			PartialFingerprints: map[string]string{"actionLensFinding/v1": hex.EncodeToString(fingerprint[:])},
+			Properties:          properties,
+		})
+	}
+
+	return sarifLog{
+		Schema:  sarifSchema,
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool:              sarifTool{Driver: driver},
+			AutomationDetails: sarifAutomationDetails{ID: "gh-action-lens/" + scan + "/"},
+			Results:           results,
+		}},
+	}
+}
+
+// sarifRuleFor converts rule metadata into a SARIF rule
+func sarifRuleFor(rule Rule) sarifRule {
+	markdown := "**Remediation:** " + rule.Remediation
+	if rule.Example != "" && rule.FixExample != "" {
+		markdown += "\n\nFlagged:\n\n```yaml\n" + rule.Example + "\n```\n\nFixed:\n\n```yaml\n" + rule.FixExample + "\n```"
+	}
+
+	return sarifRule{
+		ID:                   rule.ID,
+		Name:                 rule.Name,
+		ShortDescription:     sarifMessage{Text: rule.Name},
+		FullDescription:      sarifMessage{Text: rule.Description},
+		Help:                 sarifMessage{Text: rule.Remediation, Markdown: markdown},
+		DefaultConfiguration: sarifConfiguration{Level: sarifLevel(rule.Severity)},
+		Properties:           sarifRuleProps{Tags: []string{"security", "github-actions"}},
+	}
+}
+
+// outputSARIF writes findings as SARIF 2.1.0
+func outputSARIF(scan string, findings []Finding, writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(buildSARIF(scan, findings))
+}
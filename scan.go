@@ -0,0 +1,830 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shurcooL/githubv4"
+)
+
+// scanOptions holds settings shared by all scan modes
+type scanOptions struct {
+	IncludeWorkflows    []string          // glob patterns a workflow file must match
+	ExcludeWorkflows    []string          // glob patterns that drop a workflow file
+	WorkflowPaths       []string          // directories searched for workflow files besides .github/workflows
+	Deadline            time.Time         // no new repositories are started after this point; zero means no limit
+	Context             context.Context   // cancelled by SIGINT or SIGTERM, which stops the scan like the deadline; nil means never
+	ProjectNumber       int               // organization project (v2) that receives violations; zero disables export
+	Cache               *enrichmentCache  // action metadata lookups persisted across runs
+	Profile             *scanProfile      // stage timings for --profile-scan and --telemetry; nil disables profiling
+	Config              *Config           // policy file passed with --config; nil when none
+	FailOn              map[string]int    // severity -> finding count that fails the scan
+	SkipRepositories    []string          // repository kinds excluded from the scan: forks, archived, templates, mirrors
+	Branches            []string          // glob patterns of branches whose workflows are scanned besides the default branch
+	IncludeRepositories []string          // glob or /regex/ patterns a repository name must match
+	ExcludeRepositories []string          // glob or /regex/ patterns that drop a repository
+	Topics              []string          // topics a repository must have one of
+	Visibility          []string          // visibilities a repository must have one of: public, private, internal
+	Properties          map[string]string // custom property values a repository must have, e.g. criticality=tier1
+	GroupByProperty     string            // custom property the detailed report is broken down by
+	BadgesDir           string            // directory receiving SVG/JSON badges of the detailed report
+	BadgesGist          string            // gist ID whose files are replaced with the badges
+	OutputDir           string            // directory receiving the static HTML report site
+	SnapshotDir         string            // directory receiving a timestamped copy of every detailed report (--snapshot-dir)
+	Concurrency         int               // maximum number of workflow files fetched in parallel
+	Events              *eventStream      // machine-readable progress events; nil when not requested
+	Branding            *ReportBranding   // custom title, logo, and metadata of the detailed report; nil when none
+	Policy              *Policy           // allow/deny rules passed with --policy; nil when none
+	Transitive          int               // levels of composite actions resolved for transitive usages; zero disables
+	ExcludeGitHubOwned  bool              // drop the usages of actions owned by actions and github (--exclude-github-owned)
+	Scorecard           bool              // add the OpenSSF Scorecard result of third-party action repositories (--scorecard)
+	Enforce             string            // block fails on reached thresholds, warn only reports them
+	Authorship          bool              // classify findings by whether a bot or a human last modified the workflow
+	BotAccounts         []string          // logins or glob patterns of user accounts that are bots, e.g. internal scaffolding bots
+	WorkflowTemplates   bool              // also scan the workflow templates of the organization's .github repository
+	Gists               []string          // IDs of gists scanned as workflow sources
+	LocalPaths          []string          // local directories scanned like repositories (--path)
+	MaxReportSize       int               // MB above which HTML and Markdown detailed reports written to a file are paginated; zero disables
+	FetchMode           string            // api lists and fetches files through the contents API, tarball reads each repository archive
+	Scope               string            // scan scope, separating the notification state of scans of the same organization
+	Notify              bool              // notify the configured channels of new and resolved findings
+	NotifyState         string            // file recording the findings already notified
+	Snippets            bool              // attach the workflow source around each finding
+}
+
+// finishFindings post-processes the findings of a scan before they are reported: normalizes them, classifies
+// their authorship, attaches snippets, applies the severity overrides of the config, and runs the finding
+// hooks, which may drop some
+func (o scanOptions) finishFindings(org string, findings *[]Finding) {
+	normalizeFindings(*findings)
+	o.classifyAuthorship(org, *findings)
+	o.attachSnippets(org, *findings)
+	o.Config.applySeverityOverrides(*findings, org)
+	*findings = o.emitFindings(org, *findings)
+}
+
+// writeFindingsReport completes a scan: runs the report hooks on the finished report, writes it to
+// outputFile (stdout when empty) with output, exports its findings to the configured integrations, and
+// enforces the --fail-on thresholds
+func (o scanOptions) writeFindingsReport(org string, report interface{}, findings []Finding, outputFile string, output func(writer io.Writer) error) error {
+	reportComplete(org, report)
+
+	writer, file, err := getOutputWriter(outputFile)
+	if err != nil {
+		return fmt.Errorf("error opening output file: %v", err)
+	}
+	if file != nil {
+		defer file.Close()
+	}
+
+	if err := output(writer); err != nil {
+		return err
+	}
+
+	if err := o.exportFindings(org, findings); err != nil {
+		return err
+	}
+	return o.enforceFailOn(findings)
+}
+
+// exportFindings sends findings to the configured integrations
+func (o scanOptions) exportFindings(org string, findings []Finding) error {
+	defer o.Profile.track(stageExport)()
+
+	if o.ProjectNumber > 0 {
+		archived, err := exportFindingsToProject(org, o.ProjectNumber, o.Scope, findings, !o.coversOrganization())
+		if err != nil {
+			return fmt.Errorf("project export failed: %v", err)
+		}
+		logInfof("📋 Exported %d violating repositories to project #%d, archived %d without violations\n",
+			len(groupViolationsByRepository(findings)), o.ProjectNumber, archived)
+	}
+	if o.Notify {
+		added, resolved, err := o.notifyFindingChanges(org, findings)
+		if err != nil {
+			return fmt.Errorf("notification failed: %v", err)
+		}
+		if added == 0 && resolved == 0 {
+			logInfof("📨 No new or resolved findings since the last notification\n")
+		} else {
+			logInfof("📨 Notified %d new and %d resolved findings\n", added, resolved)
+		}
+	}
+	return nil
+}
+
+// publishBadges writes and publishes the badges of a detailed report when requested
+func (o scanOptions) publishBadges(report ComprehensiveReport) error {
+	if o.BadgesDir == "" && o.BadgesGist == "" {
+		return nil
+	}
+
+	files, err := badgeFiles(generateBadges(report))
+	if err != nil {
+		return err
+	}
+	if o.BadgesDir != "" {
+		if err := writeBadges(o.BadgesDir, files); err != nil {
+			return err
+		}
+		logInfof("🏅 Wrote %d badge files to %s\n", len(files), o.BadgesDir)
+	}
+	if o.BadgesGist != "" {
+		if err := publishBadgesToGist(o.BadgesGist, files); err != nil {
+			return err
+		}
+		logInfof("🏅 Published %d badge files to gist %s\n", len(files), o.BadgesGist)
+	}
+	return nil
+}
+
+// writeSite writes the static HTML report site of a detailed report when --output-dir is set
+func (o scanOptions) writeSite(report ComprehensiveReport) error {
+	if o.OutputDir == "" {
+		return nil
+	}
+	if err := writeReportSite(o.OutputDir, report); err != nil {
+		return err
+	}
+	logInfof("🌐 Wrote report site for %d repositories to %s\n", len(report.Repositories), filepath.Join(o.OutputDir, "index.html"))
+	return nil
+}
+
+// enforceFailOn returns an error when the findings reach a --fail-on or config threshold, unless
+// --enforce warn only reports it
+func (o scanOptions) enforceFailOn(findings []Finding) error {
+	if len(o.FailOn) == 0 {
+		return nil
+	}
+	return o.enforce(findings, o.FailOn)
+}
+
+// expired reports whether the scan deadline has passed or the scan was interrupted
+func (o scanOptions) expired() bool {
+	return o.interrupted() || (!o.Deadline.IsZero() && time.Now().After(o.Deadline))
+}
+
+// coversOrganization reports whether the scan saw every repository and workflow of the organization: it was
+// neither timed out nor interrupted, and no repository or workflow filter narrowed it. Forks and archived
+// repositories, skipped by default, do not count. Findings missing from a scan that does not cover the
+// organization are not resolved.
+func (o scanOptions) coversOrganization() bool {
+	if o.expired() {
+		return false
+	}
+	filters := len(o.IncludeRepositories) + len(o.ExcludeRepositories) + len(o.Topics) + len(o.Visibility) +
+		len(o.Properties) + len(o.IncludeWorkflows) + len(o.ExcludeWorkflows)
+	if filters > 0 {
+		return false
+	}
+	for _, kind := range o.SkipRepositories {
+		if kind != repoKindForks && kind != repoKindArchived {
+			return false
+		}
+	}
+	return true
+}
+
+// context returns the context of the scan's API requests
+func (o scanOptions) context() context.Context {
+	if o.Context == nil {
+		return context.Background()
+	}
+	return o.Context
+}
+
+// remainingRepositories returns the distinct repositories of the workflows not yet processed
+func remainingRepositories(workflows []WorkflowFile) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, wf := range workflows {
+		if !seen[wf.Repo] {
+			seen[wf.Repo] = true
+			names = append(names, wf.Repo)
+		}
+	}
+	return names
+}
+
+// countRepositoryWorkflows counts the workflows of a repository at the start of a list grouped by repository
+func countRepositoryWorkflows(workflows []WorkflowFile, repo string) int {
+	count := 0
+	for count < len(workflows) && workflows[count].Repo == repo {
+		count++
+	}
+	return count
+}
+
+// outputTruncationNotice writes the list of repositories skipped because the scan timed out
+func outputTruncationNotice(remaining []string, writer io.Writer) {
+	if scanInterrupted.Load() {
+		fmt.Fprintf(writer, "\n⏹️  Scan interrupted; partial report. %d repositories not scanned:\n", len(remaining))
+	} else {
+		fmt.Fprintf(writer, "\n⏳ Scan timed out; partial report. %d repositories not scanned:\n", len(remaining))
+	}
+	for _, name := range remaining {
+		fmt.Fprintf(writer, "   • %s\n", name)
+	}
+}
+
+// Repository kinds accepted by --skip-repos
+const (
+	repoKindForks     = "forks"
+	repoKindArchived  = "archived"
+	repoKindTemplates = "templates"
+	repoKindMirrors   = "mirrors"
+)
+
+// RepositoryCounts breaks down the repositories of an organization so totals can be reconciled
+type RepositoryCounts struct {
+	Total     int  `json:"total"`
+	Forks     int  `json:"forks"`
+	Archived  int  `json:"archived"`
+	Templates int  `json:"templates"`
+	Mirrors   int  `json:"mirrors"`
+	Skipped   int  `json:"skipped"`           // excluded by --skip-repos
+	Partial   bool `json:"partial,omitempty"` // the listing was interrupted; counts cover the repositories listed so far
+}
+
+// String summarizes the breakdown, e.g. "3 forks, 2 archived, 0 templates, 0 mirrors; 5 skipped"
+func (c RepositoryCounts) String() string {
+	return fmt.Sprintf("%d forks, %d archived, %d templates, %d mirrors; %d skipped",
+		c.Forks, c.Archived, c.Templates, c.Mirrors, c.Skipped)
+}
+
+// skippedRepositoryKinds returns the repository kinds excluded by a scan: those of --skip-repos plus
+// forks and archived repositories, which are skipped by default because their workflows do not run and
+// distort the usage statistics, unless --include-forks or --include-archived is set
+func skippedRepositoryKinds(skipRepos []string, includeForks, includeArchived bool) ([]string, error) {
+	kinds := append([]string{}, skipRepos...)
+	for _, kind := range []struct {
+		name     string
+		included bool
+		flag     string
+	}{
+		{repoKindForks, includeForks, "--include-forks"},
+		{repoKindArchived, includeArchived, "--include-archived"},
+	} {
+		switch {
+		case kind.included && containsFold(skipRepos, kind.name):
+			return nil, fmt.Errorf("%s cannot be combined with --skip-repos %s", kind.flag, kind.name)
+		case !kind.included && !containsFold(skipRepos, kind.name):
+			kinds = append(kinds, kind.name)
+		}
+	}
+	return kinds, nil
+}
+
+// subcommandScanOptions returns the scan options shared by the subcommands that read an organization's
+// workflows outside the top-level scan, such as matrix and migrate: forks and archived repositories are
+// skipped as in the top-level scan by default
+func subcommandScanOptions() scanOptions {
+	skipped, _ := skippedRepositoryKinds(nil, false, false)
+	return scanOptions{SkipRepositories: skipped, Concurrency: defaultConcurrency}
+}
+
+// skipsRepository reports whether a repository is of a skipped kind
+func (o scanOptions) skipsRepository(repo RepositoryWorkflows) bool {
+	for _, kind := range o.SkipRepositories {
+		switch {
+		case kind == repoKindForks && repo.IsFork,
+			kind == repoKindArchived && repo.IsArchived,
+			kind == repoKindTemplates && repo.IsTemplate,
+			kind == repoKindMirrors && repo.IsMirror:
+			return true
+		}
+	}
+	return false
+}
+
+// validate checks the concurrency, the fetch mode, and that all glob patterns, workflow paths, repository
+// kinds, repository filters and branch patterns are well-formed
+func (o scanOptions) validate() error {
+	if o.Concurrency < 1 {
+		return fmt.Errorf("invalid concurrency %d; must be at least 1", o.Concurrency)
+	}
+	for _, kind := range o.SkipRepositories {
+		switch kind {
+		case repoKindForks, repoKindArchived, repoKindTemplates, repoKindMirrors:
+		default:
+			return fmt.Errorf("invalid repository kind '%s'. Valid options: forks, archived, templates, mirrors", kind)
+		}
+	}
+	switch o.FetchMode {
+	case "", FetchModeAPI, FetchModeTarball:
+	default:
+		return fmt.Errorf("invalid fetch mode '%s'. Valid options: api, tarball", o.FetchMode)
+	}
+	if err := o.validateRepositoryFilters(); err != nil {
+		return err
+	}
+	for _, pattern := range o.Branches {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid branch pattern '%s': %v", pattern, err)
+		}
+	}
+	for _, dir := range o.WorkflowPaths {
+		if path.IsAbs(dir) || strings.HasPrefix(path.Clean(dir), "..") {
+			return fmt.Errorf("invalid workflow path '%s'; must be relative to the repository root", dir)
+		}
+	}
+	for _, pattern := range append(append([]string{}, o.IncludeWorkflows...), o.ExcludeWorkflows...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid workflow pattern '%s': %v", pattern, err)
+		}
+	}
+	return nil
+}
+
+// includesWorkflow reports whether a workflow file passes the include/exclude filters.
+// Patterns are matched against both the file name and the full path.
+func (o scanOptions) includesWorkflow(workflowPath string) bool {
+	if len(o.IncludeWorkflows) > 0 && !matchesAnyPattern(o.IncludeWorkflows, workflowPath) {
+		return false
+	}
+	return !matchesAnyPattern(o.ExcludeWorkflows, workflowPath)
+}
+
+// matchesAnyPattern reports whether a path or its base name matches one of the glob patterns
+func matchesAnyPattern(patterns []string, filePath string) bool {
+	name := path.Base(filePath)
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, filePath); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// splitList splits a comma-separated flag value into trimmed, non-empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// maxWorkflowPathDepth bounds how many levels of subdirectories of a --workflow-paths directory are searched
+const maxWorkflowPathDepth = 4
+
+// isYAMLFile reports whether a file name has a YAML extension
+func isYAMLFile(name string) bool {
+	return strings.HasSuffix(name, ".yml") || strings.HasSuffix(name, ".yaml")
+}
+
+// uniqueSortedStrings returns the distinct values of a list in sorted order
+func uniqueSortedStrings(values []string) []string {
+	sort.Strings(values)
+	unique := values[:0]
+	for i, value := range values {
+		if i == 0 || value != values[i-1] {
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
+
+// listWorkflowDirectory returns the YAML files under a directory of a repository's default branch or
+// workflowRef that pass the workflow filters, searching subdirectories up to maxWorkflowPathDepth levels deep.
+// A missing directory yields no files.
+func listWorkflowDirectory(org, repo, dir string, opts scanOptions) ([]string, error) {
+	var files []string
+	var walk func(dir string, depth int) error
+	walk = func(dir string, depth int) error {
+		var entries []struct {
+			Name string `json:"name"`
+			Path string `json:"path"`
+			Type string `json:"type"`
+		}
+		apiPath := fmt.Sprintf("repos/%s/%s/contents/%s", org, repo, dir)
+		if workflowRef != "" {
+			apiPath += "?ref=" + url.QueryEscape(workflowRef)
+		}
+		err := restGet(apiPath, &entries)
+		if err == errFileNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			switch {
+			case entry.Type == "dir" && depth < maxWorkflowPathDepth:
+				if err := walk(entry.Path, depth+1); err != nil {
+					return err
+				}
+			case entry.Type == "file" && isYAMLFile(entry.Name) && opts.includesWorkflow(entry.Path):
+				files = append(files, entry.Path)
+			}
+		}
+		return nil
+	}
+
+	return files, walk(strings.Trim(path.Clean(dir), "/"), 0)
+}
+
+// fetchOwnerType returns "Organization" or "User" for an account login
+func fetchOwnerType(login string) (string, error) {
+	var owner struct {
+		Type string `json:"type"`
+	}
+	if err := restGet("users/"+login, &owner); err != nil {
+		return "", err
+	}
+	return owner.Type, nil
+}
+
+// restGet performs an authenticated GET against the GitHub REST API and decodes the JSON response into v
+func restGet(apiPath string, v interface{}) error {
+	return restSend("GET", apiPath, nil, v)
+}
+
+// restSend performs an authenticated REST request with an optional JSON body and decodes the
+// JSON response into v unless v is nil
+func restSend(method, apiPath string, body, v interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, restURL(apiPath), reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "token "+githubToken())
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := apiClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 404 {
+		return errFileNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("API request failed with status %d", resp.StatusCode)
+	}
+
+	if v == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// workflowContents holds the workflow file contents returned by the repository listing, so that most
+// workflows need no REST request of their own
+type workflowContents struct {
+	mu    sync.Mutex
+	files map[string]string // owner/repo/path -> content
+}
+
+// listedWorkflows is filled by listRepositoryWorkflows and consulted by every workflow file fetch
+var listedWorkflows = &workflowContents{files: make(map[string]string)}
+
+// workflowRef is the branch, tag, or commit SHA whose workflows are scanned (--ref); empty scans the
+// default branch of every repository
+var workflowRef string
+
+// refExpression returns the Git object expression of a directory at workflowRef, e.g. HEAD:.github/workflows
+func refExpression(dir string) string {
+	if workflowRef == "" {
+		return "HEAD:" + dir
+	}
+	return workflowRef + ":" + dir
+}
+
+// store records the content of a listed workflow file
+func (w *workflowContents) store(org, repo, path, content string) {
+	w.mu.Lock()
+	w.files[strings.ToLower(org+"/"+repo)+"/"+path] = content
+	w.mu.Unlock()
+}
+
+// lookup returns the content of a workflow file returned by the listing
+func (w *workflowContents) lookup(org, repo, path string) (string, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	content, ok := w.files[strings.ToLower(org+"/"+repo)+"/"+path]
+	return content, ok
+}
+
+// hasUnlistedWorkflows reports whether the listing did not return the content of some workflow of a repository
+func hasUnlistedWorkflows(org string, repo RepositoryWorkflows) bool {
+	for _, workflowPath := range repo.Workflows {
+		if _, ok := listedWorkflows.lookup(org, repo.Name, workflowPath); !ok {
+			return true
+		}
+	}
+	return false
+}
+
+// listRepositoryWorkflows pages through the repositories of an organization or user account and returns those with
+// workflow files passing the scan filters, along with counts of all repositories seen
+func listRepositoryWorkflows(org string, opts scanOptions) ([]RepositoryWorkflows, RepositoryCounts, error) {
+	defer opts.Profile.track(stageEnumerate)()
+
+	// Local directories alone are listed without the API, in the order of --path
+	if offline {
+		local, err := listLocalWorkflows(org, opts)
+		if err != nil {
+			return nil, RepositoryCounts{}, err
+		}
+		var repositories []RepositoryWorkflows
+		for _, repo := range local {
+			if len(repo.Workflows) > 0 {
+				repo.Workflows = uniqueSortedStrings(repo.Workflows)
+				repositories = append(repositories, repo)
+			}
+		}
+		scanProgress.listedRepositories(org, repositories)
+		return repositories, RepositoryCounts{Total: len(local)}, nil
+	}
+
+	client, err := newGraphQLClient()
+	if err != nil {
+		return nil, RepositoryCounts{}, err
+	}
+
+	// Define GraphQL query structure
+	var q struct {
+		RepositoryOwner struct {
+			Login        string
+			Repositories struct {
+				Nodes []struct {
+					Name             string
+					IsFork           bool
+					IsArchived       bool
+					IsTemplate       bool
+					IsMirror         bool
+					PushedAt         time.Time
+					Visibility       string
+					DefaultBranchRef struct {
+						Name string
+					}
+					RepositoryTopics struct {
+						Nodes []struct {
+							Topic struct {
+								Name string
+							}
+						}
+					} `graphql:"repositoryTopics(first: 20)"`
+					Workflows struct {
+						Tree struct {
+							Entries []struct {
+								Name   string
+								Path   string
+								Type   string
+								Object struct {
+									Blob struct {
+										Oid         string
+										Text        *string
+										IsTruncated bool
+									} `graphql:"... on Blob"`
+								}
+							}
+						} `graphql:"... on Tree"`
+					} `graphql:"workflows: object(expression: $expression)"`
+				}
+				PageInfo struct {
+					HasNextPage bool
+					EndCursor   githubv4.String
+				}
+			} `graphql:"repositories(first: 50, after: $cursor, ownerAffiliations: [OWNER])"`
+		} `graphql:"repositoryOwner(login: $org)"`
+		RateLimit graphQLRateLimit
+	}
+
+	vars := map[string]interface{}{
+		"org":        githubv4.String(org),
+		"cursor":     (*githubv4.String)(nil),
+		"expression": githubv4.String(refExpression(githubWorkflowsDir)),
+	}
+
+	var candidates []RepositoryWorkflows
+	var counts RepositoryCounts
+	scannedBranches := make(map[string]string)      // repository -> branch of the listed workflows
+	listedBlobs := make(map[string]map[string]bool) // repository -> blob SHAs of the listed workflows
+
+	var properties map[string]map[string]string
+	if opts.usesCustomProperties() {
+		if properties, err = fetchCustomProperties(org); err != nil {
+			return nil, RepositoryCounts{}, err
+		}
+	}
+
+	for {
+		err := client.QueryWithContext(opts.context(), "RepositoryWorkflows", &q, vars)
+		if err != nil && opts.interrupted() {
+			counts.Partial = true
+			break
+		}
+		if err != nil {
+			return nil, RepositoryCounts{}, fmt.Errorf("GraphQL query failed: %v", err)
+		}
+		apiRateLimit.recordGraphQLBudget(q.RateLimit)
+		// The owner may be an organization or a user account
+		if q.RepositoryOwner.Login == "" {
+			return nil, RepositoryCounts{}, fmt.Errorf("could not resolve to a user or organization with the login '%s'", org)
+		}
+
+		for _, repo := range q.RepositoryOwner.Repositories.Nodes {
+			counts.Total++
+			attributes := RepositoryWorkflows{
+				Name:       repo.Name,
+				IsFork:     repo.IsFork,
+				IsArchived: repo.IsArchived,
+				IsTemplate: repo.IsTemplate,
+				IsMirror:   repo.IsMirror,
+				Properties: properties[strings.ToLower(repo.Name)],
+				Visibility: strings.ToLower(repo.Visibility),
+			}
+			for _, node := range repo.RepositoryTopics.Nodes {
+				attributes.Topics = append(attributes.Topics, node.Topic.Name)
+			}
+			if repo.IsFork {
+				counts.Forks++
+			}
+			if repo.IsArchived {
+				counts.Archived++
+			}
+			if repo.IsTemplate {
+				counts.Templates++
+			}
+			if repo.IsMirror {
+				counts.Mirrors++
+			}
+			if opts.skipsRepository(attributes) || !opts.includesRepository(attributes) || !opts.matchesProperties(attributes.Properties) {
+				counts.Skipped++
+				continue
+			}
+			workflowCache.observe(org, repo.Name, repo.PushedAt)
+			scannedBranches[repo.Name] = repo.DefaultBranchRef.Name
+			if workflowRef != "" {
+				scannedBranches[repo.Name] = workflowRef
+			}
+			listedBlobs[repo.Name] = make(map[string]bool)
+
+			for _, entry := range repo.Workflows.Tree.Entries {
+				if entry.Type != "blob" || !isYAMLFile(entry.Name) {
+					continue
+				}
+				// Filters are applied here, before any file content is fetched
+				if !opts.includesWorkflow(entry.Path) {
+					continue
+				}
+				attributes.Workflows = append(attributes.Workflows, entry.Path)
+				listedBlobs[repo.Name][entry.Object.Blob.Oid] = true
+				// Text is null for binary blobs; truncated blobs are fetched in full through REST
+				if blob := entry.Object.Blob; blob.Text != nil && !blob.IsTruncated {
+					listedWorkflows.store(org, repo.Name, entry.Path, *blob.Text)
+				}
+			}
+			candidates = append(candidates, attributes)
+		}
+		scanProgress.listingRepositories(counts.Total)
+
+		if !q.RepositoryOwner.Repositories.PageInfo.HasNextPage {
+			break
+		}
+		// An interrupted scan reports the repositories listed so far
+		if opts.interrupted() {
+			counts.Partial = true
+			break
+		}
+		vars["cursor"] = githubv4.NewString(q.RepositoryOwner.Repositories.PageInfo.EndCursor)
+	}
+
+	// In tarball mode each repository's archive provides the files of the additional workflow
+	// directories and the workflows the listing could not return in full
+	if opts.FetchMode == FetchModeTarball {
+		errs := make([]error, len(candidates))
+		runConcurrently(len(candidates), opts.Concurrency, func(i int) {
+			if opts.interrupted() || (len(opts.WorkflowPaths) == 0 && !hasUnlistedWorkflows(org, candidates[i])) {
+				return
+			}
+			stopFetch := opts.Profile.track(stageFetch)
+			files, err := fetchArchiveWorkflows(org, candidates[i].Name, opts)
+			stopFetch()
+			if err != nil {
+				errs[i] = fmt.Errorf("failed to download the archive of %s: %v", candidates[i].Name, err)
+				return
+			}
+			candidates[i].Workflows = append(candidates[i].Workflows, files...)
+		})
+		for _, err := range errs {
+			if err != nil {
+				return nil, RepositoryCounts{}, err
+			}
+		}
+	} else if len(opts.WorkflowPaths) > 0 {
+		// Additional workflow directories are listed per repository through the REST API
+		errs := make([]error, len(candidates))
+		runConcurrently(len(candidates), opts.Concurrency, func(i int) {
+			if opts.interrupted() {
+				return
+			}
+			for _, dir := range opts.WorkflowPaths {
+				files, err := listWorkflowDirectory(org, candidates[i].Name, dir, opts)
+				if err != nil {
+					errs[i] = fmt.Errorf("failed to list %s in %s: %v", dir, candidates[i].Name, err)
+					return
+				}
+				candidates[i].Workflows = append(candidates[i].Workflows, files...)
+			}
+		})
+		for _, err := range errs {
+			if err != nil {
+				return nil, RepositoryCounts{}, err
+			}
+		}
+	}
+
+	// Workflows that exist only on other branches are listed per repository through the REST API
+	if len(opts.Branches) > 0 {
+		errs := make([]error, len(candidates))
+		runConcurrently(len(candidates), opts.Concurrency, func(i int) {
+			if opts.interrupted() {
+				return
+			}
+			name := candidates[i].Name
+			files, err := listBranchWorkflows(org, name, scannedBranches[name], listedBlobs[name], opts)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			candidates[i].Workflows = append(candidates[i].Workflows, files...)
+		})
+		for _, err := range errs {
+			if err != nil {
+				return nil, RepositoryCounts{}, err
+			}
+		}
+	}
+
+	// Workflow templates and gists join the repository of the same name, if listed
+	sources, err := listWorkflowSources(org, opts)
+	if err != nil {
+		return nil, RepositoryCounts{}, err
+	}
+	for _, source := range sources {
+		merged := false
+		for i := range candidates {
+			if candidates[i].Name == source.Name {
+				candidates[i].Workflows = append(candidates[i].Workflows, source.Workflows...)
+				merged = true
+			}
+		}
+		if !merged {
+			candidates = append(candidates, source)
+		}
+	}
+
+	var repositories []RepositoryWorkflows
+	for _, repo := range candidates {
+		if len(repo.Workflows) > 0 {
+			repo.Workflows = uniqueSortedStrings(repo.Workflows)
+			repositories = append(repositories, repo)
+		}
+	}
+
+	// Keep output independent of API ordering so saved reports are diffable
+	sort.Slice(repositories, func(i, j int) bool {
+		return repositories[i].Name < repositories[j].Name
+	})
+
+	workflowCount := 0
+	for _, repo := range repositories {
+		workflowCount += len(repo.Workflows)
+	}
+	opts.Profile.recordShape(len(repositories), workflowCount)
+
+	scanProgress.listedRepositories(org, repositories)
+	return repositories, counts, nil
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cachedRepository holds the workflow files fetched from one repository at its last known push
+type cachedRepository struct {
+	PushedAt time.Time         `json:"pushed_at"`
+	Files    map[string]string `json:"files"` // workflow path -> content
+}
+
+// scanCache persists workflow file contents across runs, keyed by owner/repository and invalidated
+// whenever the repository's pushedAt changes, so unchanged repositories are not fetched again
+type scanCache struct {
+	path     string
+	disabled bool
+
+	mu           sync.Mutex
+	repositories map[string]*cachedRepository
+	current      map[string]bool // repositories whose pushedAt was confirmed by this run's listing
+	reused       int             // workflow files served from the cache
+	dirty        bool
+}
+
+// workflowCache is consulted by every workflow file fetch; it stays disabled until main opens it
+var workflowCache = openScanCache("", true)
+
+// defaultCacheDir returns the directory of the caches when --cache-dir is not set, or "" when the
+// user cache directory is unknown
+func defaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "gh-action-lens")
+}
+
+// openScanCache loads the scan cache from dir, or from the default cache directory when dir is empty.
+// A disabled cache never returns hits and never writes to disk.
+func openScanCache(dir string, disabled bool) *scanCache {
+	cache := &scanCache{disabled: disabled, repositories: make(map[string]*cachedRepository), current: make(map[string]bool)}
+	if disabled {
+		return cache
+	}
+
+	if dir == "" {
+		dir = defaultCacheDir()
+	}
+	if dir == "" {
+		cache.disabled = true
+		return cache
+	}
+	cache.path = filepath.Join(dir, "workflows.json")
+
+	if data, err := os.ReadFile(cache.path); err == nil {
+		// A corrupt cache is simply rebuilt
+		json.Unmarshal(data, &cache.repositories)
+	}
+	return cache
+}
+
+// scanCacheKey builds the cache key of a repository; files of a --ref are cached apart from those of
+// the default branch
+func scanCacheKey(org, repo string) string {
+	if workflowRef != "" {
+		return strings.ToLower(org+"/"+repo) + "@" + workflowRef
+	}
+	return strings.ToLower(org + "/" + repo)
+}
+
+// observe records the pushedAt of a listed repository, dropping its cached files when it was pushed to
+// since they were fetched
+func (c *scanCache) observe(org, repo string, pushedAt time.Time) {
+	if c == nil || c.disabled || pushedAt.IsZero() {
+		return
+	}
+
+	key := scanCacheKey(org, repo)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.current[key] = true
+	if entry, ok := c.repositories[key]; ok && entry.PushedAt.Equal(pushedAt) {
+		return
+	}
+	c.repositories[key] = &cachedRepository{PushedAt: pushedAt, Files: make(map[string]string)}
+	c.dirty = true
+}
+
+// lookup returns the cached content of a workflow file of a repository unchanged since it was fetched
+func (c *scanCache) lookup(org, repo, path string) (string, bool) {
+	if c == nil || c.disabled {
+		return "", false
+	}
+
+	key := scanCacheKey(org, repo)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.current[key] {
+		return "", false
+	}
+	content, ok := c.repositories[key].Files[path]
+	if ok {
+		c.reused++
+	}
+	return content, ok
+}
+
+// store records the content of a workflow file; files of repositories whose pushedAt is unknown are not
+// cached, as the cache could not tell when they change
+func (c *scanCache) store(org, repo, path, content string) {
+	if c == nil || c.disabled {
+		return
+	}
+
+	key := scanCacheKey(org, repo)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.current[key] {
+		return
+	}
+	c.repositories[key].Files[path] = content
+	c.dirty = true
+}
+
+// reusedFiles returns how many workflow files were served from the cache during this run
+func (c *scanCache) reusedFiles() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.reused
+}
+
+// save writes the cache to disk
+func (c *scanCache) save() error {
+	if c == nil || c.disabled || !c.dirty {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, err := json.Marshal(c.repositories)
+	if err != nil {
+		return err
+	}
+	// The files of private repositories are cached, so only the user may read the cache
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o700); err != nil {
+		return err
+	}
+	if err := os.WriteFile(c.path, data, 0o600); err != nil {
+		return err
+	}
+	if err := os.Chmod(c.path, 0o600); err != nil {
+		return err
+	}
+	c.dirty = false
+	return nil
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// defaultMinScheduleInterval is the --min-schedule-interval below which a schedule is flagged
+const defaultMinScheduleInterval = 15 * time.Minute
+
+// githubScheduleInterval is the shortest interval GitHub runs scheduled workflows at, in minutes; more
+// frequent schedules are run every 5 minutes
+const githubScheduleInterval = 5
+
+// daysPerMonth averages the length of a month
+const daysPerMonth = 30.44
+
+// cronShortestInterval returns the shortest time between two runs of a schedule, in minutes: the gap
+// between minute values within an hour, across consecutive hours, or between the hours of a day. Schedules
+// running at most once a day return their average interval.
+func cronShortestInterval(expression string, runsPerDay float64) (int, error) {
+	fields, err := parseCron(expression)
+	if err != nil {
+		return 0, err
+	}
+	minutes, hours := fields[0], fields[1]
+
+	consecutiveHours := false
+	for i := 1; i < len(hours); i++ {
+		if hours[i] == hours[i-1]+1 {
+			consecutiveHours = true
+		}
+	}
+	if len(hours) == 24 {
+		consecutiveHours = true
+	}
+
+	shortest := math.MaxInt
+	switch {
+	case len(minutes) > 1:
+		for i := 1; i < len(minutes); i++ {
+			shortest = min(shortest, minutes[i]-minutes[i-1])
+		}
+		if consecutiveHours {
+			shortest = min(shortest, 60-minutes[len(minutes)-1]+minutes[0])
+		}
+	case len(hours) > 1:
+		for i := 1; i < len(hours); i++ {
+			shortest = min(shortest, 60*(hours[i]-hours[i-1]))
+		}
+		shortest = min(shortest, 60*(24-hours[len(hours)-1]+hours[0]))
+	case runsPerDay > 0:
+		shortest = int(math.Round(1440 / runsPerDay))
+	default:
+		shortest = 0
+	}
+	return max(shortest, githubScheduleInterval), nil
+}
+
+// jobsPerRun counts the jobs one run of a workflow starts, expanding matrices; dynamic matrices and
+// reusable workflow calls count as one job
+func jobsPerRun(definition *workflowDefinition) int {
+	jobs := 0
+	for _, job := range definition.Jobs {
+		if job.Strategy.Matrix == nil {
+			jobs++
+			continue
+		}
+		usage := expandMatrix(job.Strategy.Matrix)
+		if usage.Dynamic || usage.Jobs < 1 {
+			jobs++
+		} else {
+			jobs += usage.Jobs
+		}
+	}
+	return jobs
+}
+
+// frequentScheduleFinding flags a schedule running more often than the threshold
+func frequentScheduleFinding(triggers WorkflowEvents, schedule CronSchedule, threshold time.Duration) (Finding, bool) {
+	if schedule.Error != "" || schedule.IntervalMinutes == 0 || float64(schedule.IntervalMinutes) >= threshold.Minutes() {
+		return Finding{}, false
+	}
+	return Finding{
+		RuleID:     RuleFrequentSchedule,
+		Severity:   SeverityWarning,
+		Repository: triggers.Repository,
+		Workflow:   triggers.Workflow,
+		Message: fmt.Sprintf("schedule `%s` runs every %d minutes (~%.0f runs, ~%.0f job runs per month), more often than every %s",
+			schedule.Cron, schedule.IntervalMinutes, schedule.RunsPerMonth, schedule.RunsPerMonth*float64(max(triggers.JobsPerRun, 1)), formatInterval(threshold)),
+	}, true
+}
+
+// formatInterval renders a threshold such as 15m0s as 15m, or 1h0m0s as 1h
+func formatInterval(d time.Duration) string {
+	if d%time.Hour == 0 {
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dm", int(d.Minutes()))
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// scorecardAPI is the public OpenSSF Scorecard API queried with --scorecard
+const scorecardAPI = "https://api.securityscorecards.dev/projects/github.com/"
+
+// scorecardTimeout bounds a single Scorecard API request
+const scorecardTimeout = 15 * time.Second
+
+// scorecardFailingScore is the check score below which a Scorecard check counts as failing
+const scorecardFailingScore = 5
+
+// ScorecardResult is the OpenSSF Scorecard result of an action repository
+type ScorecardResult struct {
+	Score         float64          `json:"score"`          // aggregate score, 0-10
+	Date          string           `json:"date,omitempty"` // when Scorecard last scanned the repository
+	FailingChecks []ScorecardCheck `json:"failing_checks"` // checks scoring below 5, lowest first
+}
+
+// ScorecardCheck is one check of a Scorecard result
+type ScorecardCheck struct {
+	Name   string `json:"name"`
+	Score  int    `json:"score"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// scorecardResponse is the subset of the Scorecard API response used by --scorecard
+type scorecardResponse struct {
+	Date   string           `json:"date"`
+	Score  float64          `json:"score"`
+	Checks []ScorecardCheck `json:"checks"` // score -1 when the check does not apply
+}
+
+// fetchScorecard returns the Scorecard result of a repository through the enrichment cache, or
+// errFileNotFound when Scorecard has not scanned it
+func fetchScorecard(cache *enrichmentCache, repository string) (ScorecardResult, error) {
+	var result ScorecardResult
+	err := cache.fetch(EnrichmentScorecard, repository, &result, func() error {
+		client := &http.Client{Timeout: scorecardTimeout}
+		resp, err := client.Get(scorecardAPI + repository)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode == 404 {
+			return errFileNotFound
+		}
+		if resp.StatusCode != 200 {
+			return fmt.Errorf("Scorecard API returned status %d", resp.StatusCode)
+		}
+
+		var response scorecardResponse
+		if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+			return fmt.Errorf("failed to parse Scorecard result: %v", err)
+		}
+		result = ScorecardResult{Score: response.Score, Date: response.Date, FailingChecks: []ScorecardCheck{}}
+		for _, check := range response.Checks {
+			if check.Score >= 0 && check.Score < scorecardFailingScore {
+				result.FailingChecks = append(result.FailingChecks, check)
+			}
+		}
+		sort.SliceStable(result.FailingChecks, func(i, j int) bool { return result.FailingChecks[i].Score < result.FailingChecks[j].Score })
+		return nil
+	})
+	return result, err
+}
+
+// scoreActionRepositories adds the Scorecard result of every available action repository (--scorecard),
+// with at most opts.Concurrency requests in flight, and orders the repositories by it
+func scoreActionRepositories(repositories []ActionRepository, opts scanOptions) {
+	runConcurrently(len(repositories), opts.Concurrency, func(i int) {
+		if repositories[i].Health == HealthUnavailable {
+			return
+		}
+		result, err := fetchScorecard(opts.Cache, repositories[i].Repository)
+		switch err {
+		case nil:
+			repositories[i].Scorecard = &result
+		case errFileNotFound:
+		default:
+			logWarnf("⚠️  Warning: Could not fetch the Scorecard of %s: %v\n", repositories[i].Repository, err)
+		}
+	})
+	sortActionRepositories(repositories)
+}
+
+// scorecardRank orders repositories by Scorecard score, unscored ones last
+func scorecardRank(repository ActionRepository) float64 {
+	if repository.Scorecard == nil {
+		return 11
+	}
+	return repository.Scorecard.Score
+}
+
+// describeScorecard renders a Scorecard result, e.g. "scorecard 4.3 (failing: Maintained 0, Code-Review 2)"
+func describeScorecard(result *ScorecardResult) string {
+	if result == nil {
+		return ""
+	}
+	text := fmt.Sprintf("scorecard %.1f", result.Score)
+	if len(result.FailingChecks) > 0 {
+		var checks []string
+		for _, check := range result.FailingChecks {
+			checks = append(checks, fmt.Sprintf("%s %d", check.Name, check.Score))
+		}
+		text += " (failing: " + strings.Join(checks, ", ") + ")"
+	}
+	return text
+}
@@ -0,0 +1,466 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Secret exposure kinds
+const (
+	ExposureDirect  = "direct"  // secret passed to the action through its own with:/env:
+	ExposureJob     = "job"     // secret set in workflow or job env, visible to every step in the job
+	ExposureInherit = "inherit" // all secrets forwarded to a reusable workflow with secrets: inherit
+)
+
+var secretReferencePattern = regexp.MustCompile(`secrets\.([A-Za-z_][A-Za-z0-9_]*)|secrets\[\s*['"]([A-Za-z_][A-Za-z0-9_]*)['"]\s*\]`)
+
+// SecretScopeReport represents the environment × secrets × third-party actions matrix
+type SecretScopeReport struct {
+	Organization          string                  `json:"organization"`
+	Repositories          []SecretScopeRepository `json:"repositories"`
+	TokenHandoffs         []TokenHandoff          `json:"token_handoffs"`
+	TokenTally            []TokenTally            `json:"token_tally"` // per third-party action receiving the GITHUB_TOKEN
+	Findings              []Finding               `json:"findings"`
+	Summary               SecretScopeSummary      `json:"summary"`
+	Truncated             bool                    `json:"truncated"`
+	RemainingRepositories []string                `json:"remaining_repositories,omitempty"`
+	ProcessTimeSeconds    float64                 `json:"process_time_seconds"`
+}
+
+// SecretScopeRepository lists the secret exposures found in one repository
+type SecretScopeRepository struct {
+	Name      string           `json:"name"`
+	Exposures []SecretExposure `json:"exposures"`
+}
+
+// SecretExposure records that a third-party action can touch a secret in a given environment
+type SecretExposure struct {
+	Environment string `json:"environment"`
+	Secret      string `json:"secret"`
+	Action      string `json:"action"`
+	Workflow    string `json:"workflow"`
+	Job         string `json:"job"`
+	Exposure    string `json:"exposure"`
+}
+
+// SecretScopeSummary represents summary statistics for the secret scoping matrix
+type SecretScopeSummary struct {
+	TotalWorkflows               int `json:"total_workflows"`
+	RepositoriesWithExposures    int `json:"repositories_with_exposures"`
+	TotalExposures               int `json:"total_exposures"`
+	EnvironmentScopedExposures   int `json:"environment_scoped_exposures"`
+	ThirdPartyActionsWithSecrets int `json:"third_party_actions_with_secrets"`
+	UniqueSecretsExposed         int `json:"unique_secrets_exposed"`
+	TokenHandoffs                int `json:"token_handoffs"`
+	ActionsReceivingToken        int `json:"actions_receiving_token"`
+}
+
+// analyzeSecretScopes builds the environment × secrets × third-party actions matrix for an organization
+func analyzeSecretScopes(org string, startTime time.Time, outputFormat, outputFile string, opts scanOptions) error {
+	workflows, err := getWorkflowFiles(org, opts)
+	if err != nil {
+		return err
+	}
+
+	logInfof("🔐 Analyzing secret exposure in %d workflow files...\n\n", len(workflows))
+
+	repoExposures := make(map[string][]SecretExposure)
+	handoffs := []TokenHandoff{}
+	var remaining []string
+	failed := 0
+	for i, wf := range workflows {
+		if i == 0 || workflows[i-1].Repo != wf.Repo {
+			// Stop before starting a new repository once the timeout is reached
+			if opts.expired() {
+				remaining = remainingRepositories(workflows[i:])
+				break
+			}
+			opts.repositoryStarted(org, wf.Repo, countRepositoryWorkflows(workflows[i:], wf.Repo))
+			failed = 0
+		}
+
+		exposures, workflowHandoffs, err := workflowSecretExposures(org, wf, opts)
+		if err != nil {
+			logWarnf("⚠️  Warning: Could not analyze %s/%s: %v\n", wf.Repo, wf.Path, err)
+			failed++
+		} else {
+			repoExposures[wf.Repo] = append(repoExposures[wf.Repo], exposures...)
+			handoffs = append(handoffs, workflowHandoffs...)
+		}
+
+		if i == len(workflows)-1 || workflows[i+1].Repo != wf.Repo {
+			opts.repositoryScanned(org, wf.Repo, failed)
+		}
+	}
+
+	// Build the report in a stable order
+	var repoNames []string
+	for name, exposures := range repoExposures {
+		if len(exposures) > 0 {
+			repoNames = append(repoNames, name)
+		}
+	}
+	sort.Strings(repoNames)
+
+	report := SecretScopeReport{
+		Organization:  org,
+		TokenHandoffs: handoffs,
+		TokenTally:    tallyTokenHandoffs(handoffs),
+		Findings:      tokenHandoffFindings(handoffs),
+	}
+	actions := make(map[string]bool)
+	secrets := make(map[string]bool)
+	for _, name := range repoNames {
+		exposures := repoExposures[name]
+		sortSecretExposures(exposures)
+
+		for _, exposure := range exposures {
+			actions[exposure.Action] = true
+			secrets[name+"/"+exposure.Secret] = true
+			if exposure.Environment != "" {
+				report.Summary.EnvironmentScopedExposures++
+			}
+		}
+
+		report.Summary.TotalExposures += len(exposures)
+		report.Repositories = append(report.Repositories, SecretScopeRepository{
+			Name:      name,
+			Exposures: exposures,
+		})
+	}
+
+	report.Summary.TotalWorkflows = len(workflows)
+	report.Summary.RepositoriesWithExposures = len(report.Repositories)
+	report.Summary.ThirdPartyActionsWithSecrets = len(actions)
+	report.Summary.UniqueSecretsExposed = len(secrets)
+	report.Summary.TokenHandoffs = len(handoffs)
+	report.Summary.ActionsReceivingToken = len(report.TokenTally)
+	opts.finishFindings(org, &report.Findings)
+	report.Truncated = len(remaining) > 0
+	report.RemainingRepositories = remaining
+	report.ProcessTimeSeconds = time.Since(startTime).Seconds()
+
+	return opts.writeFindingsReport(org, &report, report.Findings, outputFile, func(writer io.Writer) error {
+		return outputSecretScopeReport(report, outputFormat, writer)
+	})
+}
+
+// workflowSecretExposures fetches one workflow file and finds its secret exposures and GITHUB_TOKEN handoffs
+func workflowSecretExposures(org string, wf WorkflowFile, opts scanOptions) ([]SecretExposure, []TokenHandoff, error) {
+	stopFetch := opts.Profile.track(stageFetch)
+	content, err := fetchWorkflowContent(org, wf.Repo, wf.Path)
+	stopFetch()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	definition, err := parseWorkflowDefinition(content)
+	if err != nil {
+		return nil, nil, err
+	}
+	return findSecretExposures(definition, wf.Path, org), findTokenHandoffs(definition, wf.Repo, wf.Path, org), nil
+}
+
+// findSecretExposures lists which secrets each third-party action in a workflow can touch
+func findSecretExposures(definition *workflowDefinition, workflowPath, org string) []SecretExposure {
+	var exposures []SecretExposure
+
+	workflowSecrets := secretReferences(definition.Env)
+
+	for _, jobID := range definition.sortedJobIDs() {
+		job := definition.Jobs[jobID]
+		environment := job.environmentName()
+
+		// Reusable workflow calls receive secrets through the secrets: block
+		if job.Uses != "" {
+			name, _, ok := splitActionReference(job.Uses)
+			if !ok || actionClass(name, org) != actionClassThirdParty {
+				continue
+			}
+			if inherit, ok := job.Secrets.(string); ok && inherit == "inherit" {
+				exposures = append(exposures, SecretExposure{
+					Environment: environment,
+					Secret:      "*",
+					Action:      name,
+					Workflow:    workflowPath,
+					Job:         jobID,
+					Exposure:    ExposureInherit,
+				})
+				continue
+			}
+			for _, secret := range secretReferences(job.Secrets, job.With) {
+				exposures = append(exposures, SecretExposure{
+					Environment: environment,
+					Secret:      secret,
+					Action:      name,
+					Workflow:    workflowPath,
+					Job:         jobID,
+					Exposure:    ExposureDirect,
+				})
+			}
+			continue
+		}
+
+		jobSecrets := mergeSecretNames(workflowSecrets, secretReferences(job.Env))
+
+		for _, step := range job.Steps {
+			name, _, ok := splitActionReference(step.Uses)
+			if !ok || actionClass(name, org) != actionClassThirdParty {
+				continue
+			}
+
+			direct := secretReferences(step.With, step.Env)
+			directSet := make(map[string]bool)
+			for _, secret := range direct {
+				directSet[secret] = true
+				exposures = append(exposures, SecretExposure{
+					Environment: environment,
+					Secret:      secret,
+					Action:      name,
+					Workflow:    workflowPath,
+					Job:         jobID,
+					Exposure:    ExposureDirect,
+				})
+			}
+
+			for _, secret := range jobSecrets {
+				if directSet[secret] {
+					continue
+				}
+				exposures = append(exposures, SecretExposure{
+					Environment: environment,
+					Secret:      secret,
+					Action:      name,
+					Workflow:    workflowPath,
+					Job:         jobID,
+					Exposure:    ExposureJob,
+				})
+			}
+		}
+	}
+
+	return exposures
+}
+
+// secretReferences returns the sorted, unique secret names referenced in YAML values
+func secretReferences(values ...interface{}) []string {
+	seen := make(map[string]bool)
+	var names []string
+
+	for _, value := range values {
+		for _, str := range collectStrings(value) {
+			for _, match := range secretReferencePattern.FindAllStringSubmatch(str, -1) {
+				name := match[1]
+				if name == "" {
+					name = match[2]
+				}
+				if !seen[name] {
+					seen[name] = true
+					names = append(names, name)
+				}
+			}
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// mergeSecretNames returns the sorted union of secret name lists
+func mergeSecretNames(lists ...[]string) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, list := range lists {
+		for _, name := range list {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sortSecretExposures orders exposures by environment, secret, action and location
+func sortSecretExposures(exposures []SecretExposure) {
+	sort.SliceStable(exposures, func(i, j int) bool {
+		a, b := exposures[i], exposures[j]
+		if a.Environment != b.Environment {
+			return a.Environment < b.Environment
+		}
+		if a.Secret != b.Secret {
+			return a.Secret < b.Secret
+		}
+		if a.Action != b.Action {
+			return a.Action < b.Action
+		}
+		if a.Workflow != b.Workflow {
+			return a.Workflow < b.Workflow
+		}
+		return a.Job < b.Job
+	})
+}
+
+// displayEnvironment returns the environment label used in human-readable output
+func displayEnvironment(environment string) string {
+	if environment == "" {
+		return "(no environment)"
+	}
+	return environment
+}
+
+// outputSecretScopeReport outputs the secret scoping matrix in the specified format
+func outputSecretScopeReport(report SecretScopeReport, format string, writer io.Writer) error {
+	if formatter, ok := lookupFormatter(format); ok {
+		return formatter.Format(report, writer)
+	}
+
+	switch format {
+	case "table":
+		return outputSecretScopeTable(report, writer)
+
+	case "csv":
+		return outputSecretScopeCSV(report, writer)
+
+	case "markdown":
+		return outputFindingsMarkdown("🔐 Secret Scoping Matrix", report.Findings, report.RemainingRepositories, writer)
+
+	case "sarif":
+		return outputSARIF("secrets", report.Findings, writer)
+
+	default: // "default"
+		fmt.Fprintln(writer, "🔐 Secret Scoping Matrix")
+		fmt.Fprintln(writer, "="+strings.Repeat("=", 50))
+
+		for _, repo := range report.Repositories {
+			fmt.Fprintf(writer, "\n📁 %s\n", repo.Name)
+			currentEnvironment := "\x00"
+			for _, exposure := range repo.Exposures {
+				if exposure.Environment != currentEnvironment {
+					currentEnvironment = exposure.Environment
+					fmt.Fprintf(writer, "   🌐 %s\n", displayEnvironment(exposure.Environment))
+				}
+				fmt.Fprintf(writer, "      🔑 %s → 🔧 %s (%s, %s/%s)\n",
+					exposure.Secret, exposure.Action, exposure.Exposure, exposure.Workflow, exposure.Job)
+			}
+		}
+
+		if len(report.TokenTally) > 0 {
+			fmt.Fprintln(writer, "\n🎟️  GITHUB_TOKEN passed to third-party actions:")
+			for _, tally := range report.TokenTally {
+				fmt.Fprintf(writer, "   • %s: %d handoffs in %d repositories\n", tally.Action, tally.Handoffs, tally.Repositories)
+			}
+		}
+
+		fmt.Fprintln(writer, "\n📊 Summary:")
+		fmt.Fprintf(writer, "   • Total workflows analyzed: %d\n", report.Summary.TotalWorkflows)
+		fmt.Fprintf(writer, "   • Repositories with exposures: %d\n", report.Summary.RepositoriesWithExposures)
+		fmt.Fprintf(writer, "   • Total secret exposures: %d\n", report.Summary.TotalExposures)
+		fmt.Fprintf(writer, "   • Environment-scoped exposures: %d\n", report.Summary.EnvironmentScopedExposures)
+		fmt.Fprintf(writer, "   • Third-party actions with secrets: %d\n", report.Summary.ThirdPartyActionsWithSecrets)
+		fmt.Fprintf(writer, "   • Unique secrets exposed: %d\n", report.Summary.UniqueSecretsExposed)
+		fmt.Fprintf(writer, "   • GITHUB_TOKEN handoffs: %d to %d actions\n", report.Summary.TokenHandoffs, report.Summary.ActionsReceivingToken)
+		fmt.Fprintf(writer, "   ⏱️  Process time: %.3fs\n", report.ProcessTimeSeconds)
+
+		outputFindings(report.Findings, writer)
+
+		if report.Truncated {
+			outputTruncationNotice(report.RemainingRepositories, writer)
+		}
+
+		return nil
+	}
+}
+
+// outputSecretScopeTable outputs the secret scoping matrix in table format
+func outputSecretScopeTable(report SecretScopeReport, writer io.Writer) error {
+	fmt.Fprintln(writer, "╔════════════════════════════════════════════════════════════════════════════════════════════════════╗")
+	fmt.Fprintf(writer, "║                                     🔐 SECRET SCOPING MATRIX                                       ║\n")
+	fmt.Fprintln(writer, "╚════════════════════════════════════════════════════════════════════════════════════════════════════╝")
+	fmt.Fprintf(writer, "  🏢 Organization: %-59s \n", report.Organization)
+	fmt.Fprintf(writer, "  📁 Repositories with Exposures: %-44d \n", report.Summary.RepositoriesWithExposures)
+	fmt.Fprintf(writer, "  🔑 Total Secret Exposures: %-49d \n", report.Summary.TotalExposures)
+	fmt.Fprintf(writer, "  🌐 Environment-Scoped Exposures: %-43d \n", report.Summary.EnvironmentScopedExposures)
+	fmt.Fprintln(writer, " ═════════════════════════════════════════════════════════════════════════════════════════════════════")
+	fmt.Fprintln(writer)
+
+	if len(report.Repositories) == 0 {
+		fmt.Fprintln(writer, "┌─────────────────────────────────────────┐")
+		fmt.Fprintln(writer, "│   No secret exposures found             │")
+		fmt.Fprintln(writer, "└─────────────────────────────────────────┘")
+		outputTokenTallyTable(report.TokenTally, writer)
+		return nil
+	}
+
+	fmt.Fprintln(writer, "┌─────────────────────┬──────────────────┬──────────────────────────┬──────────────────────────────┬─────────┐")
+	fmt.Fprintf(writer, "│ %-18s │ %-15s │ %-23s │ %-27s │ %-7s │\n", "📁 REPOSITORY", "🌐 ENVIRONMENT", "🔑 SECRET", "🔧 ACTION", "SCOPE")
+	fmt.Fprintln(writer, "├─────────────────────┼──────────────────┼──────────────────────────┼──────────────────────────────┼─────────┤")
+
+	for i, repo := range report.Repositories {
+		for j, exposure := range repo.Exposures {
+			repoName := ""
+			if j == 0 {
+				repoName = truncate(repo.Name, 19)
+			}
+			fmt.Fprintf(writer, "│ %-19s │ %-16s │ %-24s │ %-28s │ %-7s │\n",
+				repoName, truncate(displayEnvironment(exposure.Environment), 16),
+				truncate(exposure.Secret, 24), truncate(exposure.Action, 28), exposure.Exposure)
+		}
+
+		if i < len(report.Repositories)-1 {
+			fmt.Fprintln(writer, "├─────────────────────┼──────────────────┼──────────────────────────┼──────────────────────────────┼─────────┤")
+		}
+	}
+
+	fmt.Fprintln(writer, "└─────────────────────┴──────────────────┴──────────────────────────┴──────────────────────────────┴─────────┘")
+	outputTokenTallyTable(report.TokenTally, writer)
+	fmt.Fprintln(writer)
+	return nil
+}
+
+// outputTokenTallyTable outputs the third-party actions receiving the GITHUB_TOKEN in table format
+func outputTokenTallyTable(tally []TokenTally, writer io.Writer) {
+	if len(tally) == 0 {
+		return
+	}
+
+	fmt.Fprintln(writer)
+	fmt.Fprintln(writer, "┌──────────────────────────────────────────────────┬──────────┬──────────────┐")
+	fmt.Fprintf(writer, "│ %-47s │ %-8s │ %-12s │\n", "🎟️  ACTION RECEIVING GITHUB_TOKEN", "HANDOFFS", "REPOSITORIES")
+	fmt.Fprintln(writer, "├──────────────────────────────────────────────────┼──────────┼──────────────┤")
+	for _, entry := range tally {
+		fmt.Fprintf(writer, "│ %-48s │ %8d │ %12d │\n", truncate(entry.Action, 48), entry.Handoffs, entry.Repositories)
+	}
+	fmt.Fprintln(writer, "└──────────────────────────────────────────────────┴──────────┴──────────────┘")
+}
+
+// outputSecretScopeCSV outputs the secret scoping matrix in CSV format
+func outputSecretScopeCSV(report SecretScopeReport, writer io.Writer) error {
+	w := newCSVWriter(writer)
+	w.Write([]string{"Repository", "Environment", "Secret", "Action", "Workflow", "Job", "Exposure"})
+	for _, repo := range report.Repositories {
+		for _, exposure := range repo.Exposures {
+			w.Write([]string{repo.Name, exposure.Environment, exposure.Secret, exposure.Action, exposure.Workflow, exposure.Job, exposure.Exposure})
+		}
+	}
+
+	// GITHUB_TOKEN handoffs follow as rows with exposure "token-handoff"
+	for _, handoff := range report.TokenHandoffs {
+		w.Write([]string{handoff.Repository, "", "GITHUB_TOKEN", handoff.Action, handoff.Workflow, handoff.Job, "token-handoff"})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// truncate shortens a string to max characters, marking the cut with "..."
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max-3] + "..."
+}
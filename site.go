@@ -0,0 +1,212 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// siteStyle is shared by the index and the per-repository pages
+const siteStyle = `body{font-family:-apple-system,Segoe UI,Helvetica,Arial,sans-serif;margin:2rem;color:#1f2328}
+table{border-collapse:collapse;width:100%}th,td{border:1px solid #d0d7de;padding:4px 10px;text-align:left}
+th{background:#f6f8fa}td.n{text-align:right}a{color:#0969da;text-decoration:none}
+.tiles{display:flex;flex-wrap:wrap;gap:1rem;margin:1rem 0 2rem}
+.tile{border:1px solid #d0d7de;border-radius:6px;padding:1rem 1.5rem;min-width:9rem}
+.tile b{display:block;font-size:1.8rem}.error{color:#cf222e}.warning{color:#9a6700}.info{color:#0969da}
+input#search{width:100%;padding:6px 10px;margin-bottom:1rem;border:1px solid #d0d7de;border-radius:6px}
+img.logo{max-height:64px}dl.metadata{display:grid;grid-template-columns:max-content auto;gap:2px 1rem}dl.metadata dt{font-weight:bold}dl.metadata dd{margin:0}
+table.sortable th{cursor:pointer;user-select:none}table.sortable th:after{content:" \2195";color:#8c959f}
+.chart{margin:0 0 2rem}.chart .row{display:flex;align-items:center;gap:.75rem;margin:3px 0}
+.chart .label{width:18rem;overflow:hidden;text-overflow:ellipsis;white-space:nowrap;font-size:.9rem}
+.chart .bar{display:flex;flex:1;height:1.1rem;background:#f6f8fa;border-radius:3px;overflow:hidden}
+.chart .bar span{display:block;height:100%}.chart .total{width:4rem;text-align:right;font-size:.9rem}
+.legend span{display:inline-block;margin-right:1rem;font-size:.85rem}.legend i{display:inline-block;width:.8rem;height:.8rem;margin-right:.3rem;border-radius:2px}
+details.repo{border:1px solid #d0d7de;border-radius:6px;padding:.5rem 1rem;margin:.5rem 0}details.repo summary{cursor:pointer;font-weight:bold}
+pre.snippet{background:#f6f8fa;border-radius:6px;padding:.5rem;margin:.5rem 0 0;font-size:.8rem;overflow-x:auto}pre.snippet mark{display:block;background:#fff8c5}`
+
+// siteSearchScript filters the repository table of the index as the user types
+const siteSearchScript = `document.getElementById('search').addEventListener('input', function (e) {
+  var q = e.target.value.toLowerCase();
+  document.querySelectorAll('#repos tbody tr').forEach(function (row) {
+    row.style.display = row.textContent.toLowerCase().indexOf(q) === -1 ? 'none' : '';
+  });
+});`
+
+// writeReportSite writes a self-contained static site for a detailed report: index.html with summary
+// tiles, search, and per-organization navigation, one page per repository, and the full JSON report
+func writeReportSite(dir string, report ComprehensiveReport) error {
+	if err := os.MkdirAll(filepath.Join(dir, "repos"), 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %v", err)
+	}
+
+	findingsByRepo := make(map[string][]Finding)
+	for _, finding := range report.Findings {
+		findingsByRepo[finding.Repository] = append(findingsByRepo[finding.Repository], finding)
+	}
+
+	for _, repo := range report.Repositories {
+		if err := writeSiteFile(filepath.Join(dir, "repos", repositoryPageName(repo.Name)), func(w io.Writer) {
+			outputRepositoryPage(w, report, repo, findingsByRepo[repo.Name])
+		}); err != nil {
+			return err
+		}
+	}
+
+	if err := writeSiteFile(filepath.Join(dir, "index.html"), func(w io.Writer) {
+		outputSiteIndex(w, report, findingsByRepo)
+	}); err != nil {
+		return err
+	}
+
+	return writeSiteFile(filepath.Join(dir, "report.json"), func(w io.Writer) {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(report)
+	})
+}
+
+// writeSiteFile creates a file and fills it with render
+func writeSiteFile(filePath string, render func(io.Writer)) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %v", filePath, err)
+	}
+	defer file.Close()
+	render(file)
+	return nil
+}
+
+// repositoryPageName returns the file name of a repository page; enterprise names contain a slash
+func repositoryPageName(repo string) string {
+	return strings.ReplaceAll(repo, "/", "__") + ".html"
+}
+
+// writeSiteHeader opens an HTML page, followed by the logo and metadata of the report branding
+func writeSiteHeader(w io.Writer, title string, branding *ReportBranding) {
+	fmt.Fprintln(w, "<!DOCTYPE html>")
+	fmt.Fprintln(w, "<html lang=\"en\">")
+	fmt.Fprintf(w, "<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n", html.EscapeString(title))
+	fmt.Fprintf(w, "<style>\n%s\n</style>\n</head>\n<body>\n", siteStyle)
+	fmt.Fprintf(w, "<h1>%s</h1>\n", html.EscapeString(title))
+	branding.writeHTML(w)
+}
+
+// outputSiteIndex writes the aggregate index page
+func outputSiteIndex(w io.Writer, report ComprehensiveReport, findingsByRepo map[string][]Finding) {
+	writeSiteHeader(w, report.Report.title("GitHub Actions report – "+report.Organization), report.Report)
+	fmt.Fprintf(w, "<p>Scanned %s</p>\n", html.EscapeString(report.ScanTimestamp))
+
+	writeSummaryTiles(w, report)
+
+	if len(report.Organizations) > 0 {
+		fmt.Fprintln(w, "<h2>Organizations</h2>\n<ul>")
+		for _, org := range report.Organizations {
+			if org.Error != "" {
+				fmt.Fprintf(w, "<li>%s – not scanned (%s)</li>\n", html.EscapeString(org.Organization), html.EscapeString(org.Error))
+				continue
+			}
+			fmt.Fprintf(w, "<li><a href=\"#org-%s\">%s</a> – %d repositories, %d workflows, %d findings</li>\n",
+				html.EscapeString(org.Organization), html.EscapeString(org.Organization),
+				org.Summary.RepositoriesWithWorkflows, org.Summary.TotalWorkflows, org.Findings)
+		}
+		fmt.Fprintln(w, "</ul>")
+	}
+
+	fmt.Fprintln(w, "<h2>Repositories</h2>")
+	fmt.Fprintln(w, "<input id=\"search\" type=\"search\" placeholder=\"Filter repositories, actions, rules…\">")
+	fmt.Fprintln(w, "<table id=\"repos\">\n<thead><tr><th>Repository</th><th>Workflows</th><th>Action usages</th><th>Findings</th><th>Actions</th></tr></thead>\n<tbody>")
+
+	currentOrg := ""
+	for _, repo := range report.Repositories {
+		// Anchor the first repository of each organization for the navigation list
+		id := ""
+		if org, _, ok := strings.Cut(repo.Name, "/"); ok && len(report.Organizations) > 0 && org != currentOrg {
+			currentOrg = org
+			id = fmt.Sprintf(" id=\"org-%s\"", html.EscapeString(org))
+		}
+
+		usages := 0
+		actionNames := make(map[string]bool)
+		var names []string
+		for _, workflow := range repo.Workflows {
+			usages += workflow.TotalActionCount
+			for _, action := range workflow.Actions {
+				if !actionNames[action.Name] {
+					actionNames[action.Name] = true
+					names = append(names, action.Name)
+				}
+			}
+		}
+
+		fmt.Fprintf(w, "<tr%s><td><a href=\"repos/%s\">%s</a></td><td class=\"n\">%d</td><td class=\"n\">%d</td><td class=\"n\">%d</td><td>%s</td></tr>\n",
+			id, html.EscapeString(repositoryPageName(repo.Name)), html.EscapeString(repo.Name),
+			repo.WorkflowCount, usages, len(findingsByRepo[repo.Name]), html.EscapeString(strings.Join(names, ", ")))
+	}
+	fmt.Fprintln(w, "</tbody>\n</table>")
+	fmt.Fprintln(w, "<p><a href=\"report.json\">Full JSON report</a></p>")
+	fmt.Fprintf(w, "<script>\n%s\n</script>\n</body>\n</html>\n", siteSearchScript)
+}
+
+// writeSummaryTiles writes the summary tiles of a detailed report
+func writeSummaryTiles(w io.Writer, report ComprehensiveReport) {
+	counts := make(map[string]int)
+	for _, finding := range report.Findings {
+		counts[finding.Severity]++
+	}
+
+	fmt.Fprintln(w, "<div class=\"tiles\">")
+	tiles := []struct {
+		label string
+		value string
+	}{
+		{"Repositories", fmt.Sprintf("%d / %d", report.Summary.RepositoriesWithWorkflows, report.Summary.TotalRepositories)},
+		{"Workflows", fmt.Sprint(report.Summary.TotalWorkflows)},
+		{"Unique actions", fmt.Sprint(report.Summary.UniqueActions)},
+		{"Action usages", fmt.Sprint(report.Summary.TotalActionUsages)},
+		{"SHA pinned", fmt.Sprintf("%.0f%%", pinningRate(report))},
+		{"Errors", fmt.Sprint(counts[SeverityError])},
+		{"Warnings", fmt.Sprint(counts[SeverityWarning])},
+	}
+	for _, tile := range tiles {
+		fmt.Fprintf(w, "<div class=\"tile\"><b>%s</b>%s</div>\n", html.EscapeString(tile.value), html.EscapeString(tile.label))
+	}
+	fmt.Fprintln(w, "</div>")
+}
+
+// outputRepositoryPage writes the page of one repository
+func outputRepositoryPage(w io.Writer, report ComprehensiveReport, repo ComprehensiveRepository, findings []Finding) {
+	writeSiteHeader(w, repo.Name, report.Report)
+	fmt.Fprintf(w, "<p><a href=\"../index.html\">← %s</a></p>\n", html.EscapeString(report.Organization))
+
+	fmt.Fprintln(w, "<h2>Workflows</h2>")
+	fmt.Fprintln(w, "<table>\n<thead><tr><th>Workflow</th><th>Action</th><th>Version</th><th>Count</th></tr></thead>\n<tbody>")
+	for _, workflow := range repo.Workflows {
+		for _, action := range workflow.Actions {
+			version := "<code>" + html.EscapeString(action.Version) + "</code>"
+			if action.ResolvedVersion != "" {
+				version += " (" + html.EscapeString(action.ResolvedVersion) + ")"
+			}
+			fmt.Fprintf(w, "<tr><td>%s</td><td>%s</td><td>%s</td><td class=\"n\">%d</td></tr>\n",
+				html.EscapeString(workflow.Path), html.EscapeString(action.Name), version, action.Count)
+		}
+	}
+	fmt.Fprintln(w, "</tbody>\n</table>")
+
+	fmt.Fprintf(w, "<h2>Findings (%d)</h2>\n", len(findings))
+	if len(findings) == 0 {
+		fmt.Fprintln(w, "<p>No findings.</p>")
+	} else {
+		fmt.Fprintln(w, "<table>\n<thead><tr><th>Severity</th><th>Rule</th><th>Workflow</th><th>Details</th><th>Fix</th></tr></thead>\n<tbody>")
+		for _, finding := range findings {
+			fmt.Fprintf(w, "<tr><td class=\"%s\">%s</td><td><code>%s</code></td><td>%s</td><td>%s</td><td>%s</td></tr>\n",
+				html.EscapeString(finding.Severity), html.EscapeString(finding.Severity), html.EscapeString(finding.RuleID),
+				html.EscapeString(finding.Workflow), html.EscapeString(finding.Message)+htmlSnippet(finding.Snippet), html.EscapeString(finding.remediation()))
+		}
+		fmt.Fprintln(w, "</tbody>\n</table>")
+	}
+	fmt.Fprintln(w, "</body>\n</html>")
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// snippetContext is the number of lines shown above and below the line a finding points to
+const snippetContext = 3
+
+// SourceSnippet is the workflow source around the line a finding points to
+type SourceSnippet struct {
+	StartLine int      `json:"start_line"` // line number of the first of Lines
+	Line      int      `json:"line"`       // line number of the offending line
+	Lines     []string `json:"lines"`
+}
+
+// highlighted reports whether the i-th of the snippet's lines is the offending line
+func (s SourceSnippet) highlighted(i int) bool {
+	return s.StartLine+i == s.Line
+}
+
+// findingLine returns the 1-based line of a workflow a finding points to: the uses: line of its action
+// and version. It returns 0 for findings without an action and actions the workflow does not use
+// directly, such as those of composite actions.
+func findingLine(lines []string, finding Finding) int {
+	if finding.Action == "" {
+		return 0
+	}
+	for i, line := range lines {
+		match := usesLinePattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		name, ref, ok := splitActionReference(match[3])
+		if !ok {
+			name = match[3]
+		}
+		if name == finding.Action && (finding.Version == "" || ref == finding.Version) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// sourceSnippet cuts the lines around the line a finding points to out of a workflow; nil when the
+// line cannot be told
+func sourceSnippet(content string, finding Finding) *SourceSnippet {
+	lines := strings.Split(strings.TrimSuffix(strings.ReplaceAll(content, "\r\n", "\n"), "\n"), "\n")
+	line := findingLine(lines, finding)
+	if line == 0 {
+		return nil
+	}
+	start := max(line-snippetContext, 1)
+	end := min(line+snippetContext, len(lines))
+	return &SourceSnippet{StartLine: start, Line: line, Lines: lines[start-1 : end]}
+}
+
+// attachSnippets records the workflow source around each finding with --snippets. Workflows come from
+// the listing and caches of the scan, so this rarely costs a request; findings whose workflow cannot be
+// read are left without a snippet.
+func (o scanOptions) attachSnippets(org string, findings []Finding) {
+	if !o.Snippets {
+		return
+	}
+	contents := make(map[string]string)
+	for i, finding := range findings {
+		if finding.Workflow == "" || finding.Action == "" {
+			continue
+		}
+		key := finding.Repository + "|" + finding.Workflow
+		content, ok := contents[key]
+		if !ok {
+			owner, repo := splitRepository(org, finding.Repository)
+			content, _ = fetchWorkflowContent(owner, repo, finding.Workflow)
+			contents[key] = content
+		}
+		if content != "" {
+			findings[i].Snippet = sourceSnippet(content, finding)
+		}
+	}
+}
+
+// writeTextSnippet writes a snippet with line numbers below a finding, marking the offending line
+func writeTextSnippet(writer io.Writer, snippet *SourceSnippet, indent string) {
+	if snippet == nil {
+		return
+	}
+	width := len(fmt.Sprint(snippet.StartLine + len(snippet.Lines) - 1))
+	for i, line := range snippet.Lines {
+		marker := " "
+		if snippet.highlighted(i) {
+			marker = ">"
+		}
+		fmt.Fprintf(writer, "%s%s %*d │ %s\n", indent, marker, width, snippet.StartLine+i, line)
+	}
+}
+
+// htmlSnippet renders a snippet as a <pre> block with the offending line marked; empty without one
+func htmlSnippet(snippet *SourceSnippet) string {
+	if snippet == nil {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("<pre class=\"snippet\">")
+	for i, line := range snippet.Lines {
+		text := fmt.Sprintf("%4d  %s", snippet.StartLine+i, html.EscapeString(line))
+		if snippet.highlighted(i) {
+			text = "<mark>" + text + "</mark>"
+		}
+		b.WriteString(text + "\n")
+	}
+	b.WriteString("</pre>")
+	return b.String()
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// gistRepositoryPrefix names the pseudo-repository of a gist in reports, e.g. gist:aa5a315d61ae9438b18d
+const gistRepositoryPrefix = "gist:"
+
+// workflowTemplatesRepository and workflowTemplatesDir hold an organization's workflow templates, the
+// starter workflows offered to every repository of the organization
+const (
+	workflowTemplatesRepository = ".github"
+	workflowTemplatesDir        = "workflow-templates"
+)
+
+// listWorkflowSources returns the workflow sources teams copy from that are scanned besides the
+// repositories' own workflows: the organization's workflow templates with --workflow-templates, the
+// local directories passed with --path, and the gists passed with --gists. Local and gist contents are
+// recorded with the listed workflows, so they are never fetched again.
+func listWorkflowSources(org string, opts scanOptions) ([]RepositoryWorkflows, error) {
+	var sources []RepositoryWorkflows
+
+	if opts.WorkflowTemplates {
+		files, err := listWorkflowDirectory(org, workflowTemplatesRepository, workflowTemplatesDir, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list workflow templates of %s: %v", org, err)
+		}
+		if len(files) > 0 {
+			sources = append(sources, RepositoryWorkflows{Name: workflowTemplatesRepository, Workflows: files})
+		}
+	}
+
+	if len(opts.LocalPaths) > 0 {
+		local, err := listLocalWorkflows(org, opts)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, local...)
+	}
+
+	for _, id := range opts.Gists {
+		gist, err := listGistWorkflows(org, id, opts)
+		if err != nil {
+			return nil, err
+		}
+		if len(gist.Workflows) > 0 {
+			sources = append(sources, gist)
+		}
+	}
+	return sources, nil
+}
+
+// listGistWorkflows returns the YAML files of a gist passing the workflow filters as a pseudo-repository
+func listGistWorkflows(org, id string, opts scanOptions) (RepositoryWorkflows, error) {
+	var gist struct {
+		Files map[string]struct {
+			Filename  string `json:"filename"`
+			Content   string `json:"content"`
+			Truncated bool   `json:"truncated"`
+		} `json:"files"`
+	}
+	if err := restGet("gists/"+id, &gist); err != nil {
+		return RepositoryWorkflows{}, fmt.Errorf("failed to read gist %s: %v", id, err)
+	}
+
+	repository := RepositoryWorkflows{Name: gistRepositoryPrefix + id}
+	for _, file := range gist.Files {
+		if !isYAMLFile(file.Filename) || !opts.includesWorkflow(file.Filename) {
+			continue
+		}
+		// Gist files are truncated past 1 MB, far beyond any workflow
+		if file.Truncated {
+			return RepositoryWorkflows{}, fmt.Errorf("gist %s: %s is too large", id, file.Filename)
+		}
+		listedWorkflows.store(org, repository.Name, file.Filename, file.Content)
+		repository.Workflows = append(repository.Workflows, file.Filename)
+	}
+	return repository, nil
+}
+
+// isGistRepository reports whether a report repository is a gist scanned with --gists
+func isGistRepository(name string) bool {
+	return strings.HasPrefix(name, gistRepositoryPrefix)
+}
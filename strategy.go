@@ -0,0 +1,383 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// githubMatrixLimit is the maximum number of jobs GitHub generates from one matrix; larger matrices fail the run
+const githubMatrixLimit = 256
+
+// defaultMaxMatrixJobs is the --max-matrix-jobs threshold above which a matrix is flagged
+const defaultMaxMatrixJobs = 100
+
+// maxEnumeratedCombinations bounds the combinations expanded to apply include/exclude entries
+const maxEnumeratedCombinations = 100000
+
+// MatrixReport lists the effective job count of every strategy.matrix in an organization
+type MatrixReport struct {
+	Organization          string        `json:"organization"`
+	Threshold             int           `json:"threshold"`
+	Matrices              []MatrixUsage `json:"matrices"` // largest first
+	Findings              []Finding     `json:"findings"`
+	Summary               MatrixSummary `json:"summary"`
+	Truncated             bool          `json:"truncated"`
+	RemainingRepositories []string      `json:"remaining_repositories,omitempty"`
+	ProcessTimeSeconds    float64       `json:"process_time_seconds"`
+}
+
+// MatrixUsage describes how many jobs one matrix job definition expands to
+type MatrixUsage struct {
+	Repository string   `json:"repository"`
+	Workflow   string   `json:"workflow"`
+	Job        string   `json:"job"`
+	Dimensions []string `json:"dimensions"`          // e.g. ["os=3", "node=4"]
+	Base       int      `json:"base"`                // size of the cartesian product
+	Excluded   int      `json:"excluded"`            // combinations removed by exclude
+	Included   int      `json:"included"`            // combinations added by include
+	Jobs       int      `json:"jobs"`                // effective job count
+	Dynamic    bool     `json:"dynamic,omitempty"`   // built from an expression; size unknown until run time
+	Estimated  bool     `json:"estimated,omitempty"` // too large to expand; include/exclude ignored
+}
+
+// MatrixSummary represents summary statistics of the matrix analysis
+type MatrixSummary struct {
+	WorkflowsScanned int `json:"workflows_scanned"`
+	Matrices         int `json:"matrices"`
+	TotalJobs        int `json:"total_jobs"`
+	OverThreshold    int `json:"over_threshold"`
+	OverGitHubLimit  int `json:"over_github_limit"`
+	Dynamic          int `json:"dynamic"`
+}
+
+// isExpression reports whether a YAML value is a ${{ }} expression evaluated at run time
+func isExpression(value interface{}) bool {
+	s, ok := value.(string)
+	return ok && strings.Contains(s, "${{")
+}
+
+// matrixCombination is one set of matrix values, compared by their string form
+type matrixCombination map[string]string
+
+// matches reports whether every key of entry that is also a matrix dimension has the same value
+func (c matrixCombination) matches(entry map[string]interface{}, dimensions map[string]bool) bool {
+	for key, value := range entry {
+		if dimensions[key] && c[key] != fmt.Sprint(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// expandMatrix computes the effective job count of a strategy.matrix value following GitHub's
+// rules: the cartesian product of all dimensions, minus exclude entries matching a combination,
+// plus include entries that cannot extend any remaining combination without overwriting one of
+// its original values.
+func expandMatrix(matrix interface{}) MatrixUsage {
+	var usage MatrixUsage
+	definition, ok := yamlMap(matrix)
+	if !ok {
+		usage.Dynamic = isExpression(matrix)
+		return usage
+	}
+
+	var keys []string
+	dimensions := make(map[string]bool)
+	values := make(map[string][]string)
+	usage.Base = 1
+	for _, key := range sortedYAMLKeys(definition) {
+		if key == "include" || key == "exclude" {
+			continue
+		}
+		vector, ok := definition[key].([]interface{})
+		if !ok {
+			// A dimension built by an expression, e.g. ${{ fromJSON(needs.setup.outputs.targets) }}
+			usage.Dynamic = true
+			continue
+		}
+		keys = append(keys, key)
+		dimensions[key] = true
+		for _, value := range vector {
+			values[key] = append(values[key], fmt.Sprint(value))
+		}
+		usage.Base *= len(vector)
+		usage.Dimensions = append(usage.Dimensions, fmt.Sprintf("%s=%d", key, len(vector)))
+	}
+	if len(keys) == 0 {
+		usage.Base = 0
+	}
+
+	includes, includeOK := definition["include"].([]interface{})
+	excludes, excludeOK := definition["exclude"].([]interface{})
+	if (definition["include"] != nil && !includeOK) || (definition["exclude"] != nil && !excludeOK) {
+		usage.Dynamic = true
+	}
+
+	if usage.Base > maxEnumeratedCombinations {
+		usage.Estimated = true
+		usage.Jobs = usage.Base
+		return usage
+	}
+
+	// Expand the cartesian product
+	var combinations []matrixCombination
+	if len(keys) > 0 {
+		combinations = []matrixCombination{{}}
+		for _, key := range keys {
+			var next []matrixCombination
+			for _, combination := range combinations {
+				for _, value := range values[key] {
+					extended := matrixCombination{key: value}
+					for k, v := range combination {
+						extended[k] = v
+					}
+					next = append(next, extended)
+				}
+			}
+			combinations = next
+		}
+	}
+
+	// Apply exclude entries
+	for _, item := range excludes {
+		entry, ok := yamlMap(item)
+		if !ok {
+			continue
+		}
+		kept := combinations[:0]
+		for _, combination := range combinations {
+			if combination.matches(entry, dimensions) {
+				usage.Excluded++
+				continue
+			}
+			kept = append(kept, combination)
+		}
+		combinations = kept
+	}
+
+	// Include entries extend matching original combinations or add a new job
+	for _, item := range includes {
+		entry, ok := yamlMap(item)
+		if !ok {
+			usage.Dynamic = true
+			continue
+		}
+		extends := false
+		for _, combination := range combinations {
+			if combination.matches(entry, dimensions) {
+				extends = true
+				break
+			}
+		}
+		if !extends {
+			usage.Included++
+		}
+	}
+
+	usage.Jobs = len(combinations) + usage.Included
+	return usage
+}
+
+// matrixFinding flags a matrix over the threshold or over GitHub's hard limit
+func matrixFinding(usage MatrixUsage, threshold int) (Finding, bool) {
+	finding := Finding{
+		RuleID:     RuleMatrixExplosion,
+		Repository: usage.Repository,
+		Workflow:   usage.Workflow,
+	}
+	shape := strings.Join(usage.Dimensions, " × ")
+	if shape == "" {
+		shape = "include only"
+	}
+
+	switch {
+	case usage.Jobs > githubMatrixLimit:
+		finding.Severity = SeverityError
+		finding.Message = fmt.Sprintf("job %s expands to %d jobs (%s), over GitHub's limit of %d; the run will fail",
+			usage.Job, usage.Jobs, shape, githubMatrixLimit)
+	case usage.Jobs > threshold:
+		finding.Severity = SeverityWarning
+		finding.Message = fmt.Sprintf("job %s expands to %d jobs (%s), over the threshold of %d",
+			usage.Job, usage.Jobs, shape, threshold)
+	default:
+		return Finding{}, false
+	}
+	return finding, true
+}
+
+// analyzeMatrices computes the effective job count of every matrix and flags those over the threshold
+func analyzeMatrices(org string, startTime time.Time, outputFormat, outputFile string, threshold int, opts scanOptions) error {
+	repositories, _, err := listRepositoryWorkflows(org, opts)
+	if err != nil {
+		return err
+	}
+
+	logInfof("🧮 Expanding job matrices in %d repositories...\n\n", len(repositories))
+
+	report := MatrixReport{
+		Organization: org,
+		Threshold:    threshold,
+		Matrices:     []MatrixUsage{},
+		Findings:     []Finding{},
+	}
+
+	report.RemainingRepositories = forEachWorkflowDefinition(org, repositories, opts, func(repo, workflowPath string, definition *workflowDefinition) {
+		report.Summary.WorkflowsScanned++
+		for _, jobID := range definition.sortedJobIDs() {
+			job := definition.Jobs[jobID]
+			if job.Strategy.Matrix == nil {
+				continue
+			}
+			usage := expandMatrix(job.Strategy.Matrix)
+			usage.Repository, usage.Workflow, usage.Job = repo, workflowPath, jobID
+			report.Matrices = append(report.Matrices, usage)
+		}
+	})
+
+	sort.SliceStable(report.Matrices, func(i, j int) bool {
+		return report.Matrices[i].Jobs > report.Matrices[j].Jobs
+	})
+	for _, usage := range report.Matrices {
+		report.Summary.Matrices++
+		report.Summary.TotalJobs += usage.Jobs
+		if usage.Dynamic {
+			report.Summary.Dynamic++
+		}
+		if usage.Jobs > threshold {
+			report.Summary.OverThreshold++
+		}
+		if usage.Jobs > githubMatrixLimit {
+			report.Summary.OverGitHubLimit++
+		}
+		if finding, ok := matrixFinding(usage, threshold); ok {
+			report.Findings = append(report.Findings, finding)
+		}
+	}
+
+	opts.finishFindings(org, &report.Findings)
+	report.Truncated = len(report.RemainingRepositories) > 0
+	report.ProcessTimeSeconds = time.Since(startTime).Seconds()
+
+	return opts.writeFindingsReport(org, &report, report.Findings, outputFile, func(writer io.Writer) error {
+		return outputMatrixReport(report, outputFormat, writer)
+	})
+}
+
+// describeMatrix returns a compact description of how a matrix expands
+func describeMatrix(usage MatrixUsage) string {
+	parts := []string{strings.Join(usage.Dimensions, " × ")}
+	if usage.Excluded > 0 {
+		parts = append(parts, fmt.Sprintf("-%d excluded", usage.Excluded))
+	}
+	if usage.Included > 0 {
+		parts = append(parts, fmt.Sprintf("+%d included", usage.Included))
+	}
+	if usage.Dynamic {
+		parts = append(parts, "dynamic")
+	}
+	if usage.Estimated {
+		parts = append(parts, "estimated")
+	}
+	return strings.TrimPrefix(strings.Join(parts, ", "), ", ")
+}
+
+// outputMatrixReport outputs the matrix analysis in the specified format
+func outputMatrixReport(report MatrixReport, format string, writer io.Writer) error {
+	if formatter, ok := lookupFormatter(format); ok {
+		return formatter.Format(report, writer)
+	}
+
+	switch format {
+	case "table":
+		return outputMatrixTable(report, writer)
+
+	case "csv":
+		return outputMatrixCSV(report, writer)
+
+	case "markdown":
+		return outputFindingsMarkdown("🧮 Job Matrix Sizes", report.Findings, report.RemainingRepositories, writer)
+
+	case "sarif":
+		return outputSARIF("matrices", report.Findings, writer)
+
+	default: // "default"
+		fmt.Fprintln(writer, "🧮 Job Matrix Sizes")
+		fmt.Fprintln(writer, "="+strings.Repeat("=", 50))
+
+		for _, usage := range report.Matrices {
+			icon := "✅"
+			switch {
+			case usage.Jobs > githubMatrixLimit:
+				icon = "❌"
+			case usage.Jobs > report.Threshold:
+				icon = "⚠️ "
+			}
+			fmt.Fprintf(writer, "%s %4d jobs  %s → %s → %s (%s)\n",
+				icon, usage.Jobs, usage.Repository, usage.Workflow, usage.Job, describeMatrix(usage))
+		}
+
+		fmt.Fprintln(writer, "\n📊 Summary:")
+		fmt.Fprintf(writer, "   • Workflows scanned: %d\n", report.Summary.WorkflowsScanned)
+		fmt.Fprintf(writer, "   • Matrices: %d generating %d jobs\n", report.Summary.Matrices, report.Summary.TotalJobs)
+		fmt.Fprintf(writer, "   • Over threshold (%d jobs): %d\n", report.Threshold, report.Summary.OverThreshold)
+		fmt.Fprintf(writer, "   • Over GitHub's limit (%d jobs): %d\n", githubMatrixLimit, report.Summary.OverGitHubLimit)
+		fmt.Fprintf(writer, "   • Dynamic (sized at run time): %d\n", report.Summary.Dynamic)
+		fmt.Fprintf(writer, "   ⏱️  Process time: %.3fs\n", report.ProcessTimeSeconds)
+
+		outputFindings(report.Findings, writer)
+
+		if report.Truncated {
+			outputTruncationNotice(report.RemainingRepositories, writer)
+		}
+
+		return nil
+	}
+}
+
+// outputMatrixTable outputs the matrix analysis in table format
+func outputMatrixTable(report MatrixReport, writer io.Writer) error {
+	fmt.Fprintln(writer, "╔════════════════════════════════════════════════════════════════════════════════════════════════════╗")
+	fmt.Fprintf(writer, "║                                       🧮 JOB MATRIX SIZES                                          ║\n")
+	fmt.Fprintln(writer, "╚════════════════════════════════════════════════════════════════════════════════════════════════════╝")
+	fmt.Fprintf(writer, "  🏢 Organization: %-59s \n", report.Organization)
+	fmt.Fprintf(writer, "  🧮 Matrices: %-63d \n", report.Summary.Matrices)
+	fmt.Fprintf(writer, "  🚀 Generated Jobs: %-57d \n", report.Summary.TotalJobs)
+	fmt.Fprintf(writer, "  ⚠️  Over Threshold (%d): %-52d \n", report.Threshold, report.Summary.OverThreshold)
+	fmt.Fprintln(writer, " ═════════════════════════════════════════════════════════════════════════════════════════════════════")
+	fmt.Fprintln(writer)
+
+	if len(report.Matrices) == 0 {
+		fmt.Fprintln(writer, "┌─────────────────────────────────────────┐")
+		fmt.Fprintln(writer, "│        No job matrices found            │")
+		fmt.Fprintln(writer, "└─────────────────────────────────────────┘")
+		return nil
+	}
+
+	fmt.Fprintln(writer, "┌─────────────────────┬────────────────────────────────┬──────────────────┬───────┬──────────────────────────────┐")
+	fmt.Fprintf(writer, "│ %-18s │ %-29s │ %-16s │ %-5s │ %-28s │\n", "📁 REPOSITORY", "📄 WORKFLOW", "JOB", "JOBS", "MATRIX")
+	fmt.Fprintln(writer, "├─────────────────────┼────────────────────────────────┼──────────────────┼───────┼──────────────────────────────┤")
+	for _, usage := range report.Matrices {
+		fmt.Fprintf(writer, "│ %-19s │ %-30s │ %-16s │ %5d │ %-28s │\n",
+			truncate(usage.Repository, 19), truncate(usage.Workflow, 30), truncate(usage.Job, 16), usage.Jobs, truncate(describeMatrix(usage), 28))
+	}
+	fmt.Fprintln(writer, "└─────────────────────┴────────────────────────────────┴──────────────────┴───────┴──────────────────────────────┘")
+	outputFindings(report.Findings, writer)
+	fmt.Fprintln(writer)
+	return nil
+}
+
+// outputMatrixCSV outputs the matrix analysis in CSV format
+func outputMatrixCSV(report MatrixReport, writer io.Writer) error {
+	w := newCSVWriter(writer)
+	w.Write([]string{"Repository", "Workflow", "Job", "Dimensions", "Base", "Excluded", "Included", "Jobs", "Dynamic"})
+	for _, usage := range report.Matrices {
+		w.Write([]string{usage.Repository, usage.Workflow, usage.Job, strings.Join(usage.Dimensions, " "),
+			strconv.Itoa(usage.Base), strconv.Itoa(usage.Excluded), strconv.Itoa(usage.Included), strconv.Itoa(usage.Jobs), strconv.FormatBool(usage.Dynamic)})
+	}
+	w.Flush()
+	return w.Error()
+}
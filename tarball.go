@@ -0,0 +1,107 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// Fetch modes of --fetch-mode
+const (
+	FetchModeAPI     = "api"     // workflow directories are listed and files fetched through the contents API
+	FetchModeTarball = "tarball" // each repository's archive is downloaded once and read locally
+)
+
+// githubWorkflowsDir is the directory GitHub runs workflows from
+const githubWorkflowsDir = ".github/workflows"
+
+// fetchArchiveWorkflows downloads the default-branch or workflowRef archive of a repository and records the content of
+// every YAML file under .github/workflows and the --workflow-paths directories with the listed
+// workflows. It returns the files of the --workflow-paths directories that pass the workflow filters.
+func fetchArchiveWorkflows(org, repo string, opts scanOptions) ([]string, error) {
+	// The API redirects to a short-lived codeload URL that carries its own authorization
+	apiPath := fmt.Sprintf("repos/%s/%s/tarball", org, repo)
+	if workflowRef != "" {
+		apiPath += "/" + workflowRef
+	}
+	req, err := http.NewRequest("GET", restURL(apiPath), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "token "+githubToken())
+
+	resp, err := apiClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Empty repositories have no archive
+	if resp.StatusCode == 404 {
+		return nil, nil
+	}
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("archive request failed with status %d", resp.StatusCode)
+	}
+
+	gz, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive: %v", err)
+	}
+	defer gz.Close()
+
+	dirs := []string{githubWorkflowsDir}
+	for _, dir := range opts.WorkflowPaths {
+		dirs = append(dirs, strings.Trim(path.Clean(dir), "/"))
+	}
+
+	var files []string
+	archive := tar.NewReader(gz)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %v", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		// Entries are prefixed with a <owner>-<repo>-<sha>/ directory
+		_, filePath, ok := strings.Cut(header.Name, "/")
+		if !ok || !isYAMLFile(filePath) || !opts.includesWorkflow(filePath) {
+			continue
+		}
+		for i, dir := range dirs {
+			depth, ok := directoryDepth(dir, filePath)
+			if !ok || (i == 0 && depth > 0) || depth > maxWorkflowPathDepth {
+				continue
+			}
+			content, err := io.ReadAll(archive)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s from archive: %v", filePath, err)
+			}
+			listedWorkflows.store(org, repo, filePath, string(content))
+			if i > 0 {
+				files = append(files, filePath)
+			}
+			break
+		}
+	}
+	return files, nil
+}
+
+// directoryDepth returns how many levels of subdirectories below dir a file is, and whether it is below dir at all
+func directoryDepth(dir, filePath string) (int, bool) {
+	rel, ok := strings.CutPrefix(filePath, dir+"/")
+	if !ok {
+		return 0, false
+	}
+	return strings.Count(rel, "/"), true
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// telemetryEndpoint receives anonymous usage statistics. Release builds set it with
+// -ldflags "-X main.telemetryEndpoint=<url>"; GH_ACTION_LENS_TELEMETRY_URL overrides it.
+var telemetryEndpoint = ""
+
+// telemetryTimeout bounds how long a scan waits for the statistics upload
+const telemetryTimeout = 5 * time.Second
+
+// UsageStatistics is the anonymous payload sent with --telemetry. It never contains
+// organization, repository, workflow, or action names.
+type UsageStatistics struct {
+	Scope           string             `json:"scope"`
+	Detailed        bool               `json:"detailed"`
+	Format          string             `json:"format"`
+	Repositories    int                `json:"repositories"`
+	Workflows       int                `json:"workflows"`
+	DurationSeconds float64            `json:"duration_seconds"`
+	StageSeconds    map[string]float64 `json:"stage_seconds"`
+	TimedOut        bool               `json:"timed_out"`
+	OS              string             `json:"os"`
+	Arch            string             `json:"arch"`
+}
+
+// resolveTelemetryEndpoint returns the configured statistics endpoint, or "" if none
+func resolveTelemetryEndpoint() string {
+	if endpoint := os.Getenv("GH_ACTION_LENS_TELEMETRY_URL"); endpoint != "" {
+		return endpoint
+	}
+	return telemetryEndpoint
+}
+
+// buildUsageStatistics derives the anonymous statistics of a finished scan from its profile
+func buildUsageStatistics(p *scanProfile, scope string, detailed bool, format string, timedOut bool) UsageStatistics {
+	stages, total := p.timings()
+
+	stats := UsageStatistics{
+		Scope:           scope,
+		Detailed:        detailed,
+		Format:          telemetryFormat(format),
+		Repositories:    p.Repositories,
+		Workflows:       p.Workflows,
+		DurationSeconds: total.Seconds(),
+		StageSeconds:    make(map[string]float64),
+		TimedOut:        timedOut,
+		OS:              runtime.GOOS,
+		Arch:            runtime.GOARCH,
+	}
+	for stage, timing := range stages {
+		stats.StageSeconds[stage] = timing.Duration.Seconds()
+	}
+	return stats
+}
+
+// telemetryFormat returns the name of an output format that is safe to send: exec:<command> is reduced to
+// exec, so command lines never leave the machine, and unknown formats to other
+func telemetryFormat(format string) string {
+	if strings.HasPrefix(format, execFormatPrefix) {
+		return "exec"
+	}
+	for _, name := range outputFormatNames() {
+		if format == name {
+			return format
+		}
+	}
+	return "other"
+}
+
+// sendUsageStatistics posts the statistics to the endpoint; failures are ignored so
+// telemetry can never affect a scan
+func sendUsageStatistics(endpoint string, stats UsageStatistics) {
+	body, err := json.Marshal(stats)
+	if err != nil {
+		return
+	}
+
+	client := &http.Client{Timeout: telemetryTimeout}
+	resp, err := client.Post(endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	resp.Body.Close()
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// templateFormat is the --format name of the --template and --template-string formatter
+const templateFormat = "template"
+
+// templateFuncs are the helper functions available to --template templates in addition to the
+// text/template builtins
+var templateFuncs = template.FuncMap{
+	"reportType": func(report interface{}) string { return reflect.TypeOf(report).Name() },
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"jsonIndent": func(v interface{}) (string, error) {
+		data, err := json.MarshalIndent(v, "", "  ")
+		return string(data), err
+	},
+	"join":      func(sep string, values []string) string { return strings.Join(values, sep) },
+	"upper":     strings.ToUpper,
+	"lower":     strings.ToLower,
+	"trim":      strings.TrimSpace,
+	"replace":   func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+	"contains":  func(substr, s string) bool { return strings.Contains(s, substr) },
+	"hasPrefix": func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+	"hasSuffix": func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+	"repeat":    func(count int, s string) string { return strings.Repeat(s, count) },
+	"padRight":  func(width int, s string) string { return fmt.Sprintf("%-*s", width, s) },
+	"padLeft":   func(width int, s string) string { return fmt.Sprintf("%*s", width, s) },
+	"short": func(s string) string {
+		if isPinnedToSHA(s) {
+			return s[:7]
+		}
+		return s
+	},
+	"add": func(a, b int) int { return a + b },
+	"sub": func(a, b int) int { return a - b },
+	"percent": func(part, total int) float64 {
+		if total == 0 {
+			return 0
+		}
+		return float64(part) / float64(total) * 100
+	},
+	"sortedKeys": func(m interface{}) ([]string, error) {
+		value := reflect.ValueOf(m)
+		if value.Kind() != reflect.Map || value.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("sortedKeys needs a map with string keys, got %T", m)
+		}
+		var keys []string
+		for _, key := range value.MapKeys() {
+			keys = append(keys, key.String())
+		}
+		sort.Strings(keys)
+		return keys, nil
+	},
+	"now":    func(layout string) string { return time.Now().Format(layout) },
+	"pinned": isPinnedToSHA,
+}
+
+// templateFormatter renders a report through a Go text/template. The template receives the report
+// struct itself, so fields are referenced by their Go names, e.g. {{.Summary.TotalWorkflows}}.
+type templateFormatter struct {
+	Template *template.Template
+}
+
+// Format executes the template with the report
+func (f templateFormatter) Format(report interface{}, writer io.Writer) error {
+	if err := f.Template.Execute(writer, report); err != nil {
+		return fmt.Errorf("rendering %T: %v", report, err)
+	}
+	return nil
+}
+
+// registerTemplateFormatter parses the template of --template (a file) or --template-string and
+// registers it as the template format
+func registerTemplateFormatter(file, text string) error {
+	name := "--template-string"
+	if file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read template: %v", err)
+		}
+		name, text = file, string(data)
+	}
+
+	tmpl, err := template.New(name).Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return fmt.Errorf("invalid template: %v", err)
+	}
+	RegisterFormatter(templateFormat, templateFormatter{Template: tmpl})
+	return nil
+}
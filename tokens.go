@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// githubTokenPattern matches expressions that evaluate to the workflow's GITHUB_TOKEN. Expression
+// contexts are case-insensitive.
+var githubTokenPattern = regexp.MustCompile(`(?i)secrets\.GITHUB_TOKEN\b|secrets\[\s*['"]GITHUB_TOKEN['"]\s*\]|github\.token\b|github\[\s*['"]token['"]\s*\]`)
+
+// TokenHandoff records a with: or env: entry forwarding the GITHUB_TOKEN to a third-party action
+type TokenHandoff struct {
+	Repository string `json:"repository"`
+	Workflow   string `json:"workflow"`
+	Job        string `json:"job"`
+	Step       int    `json:"step,omitempty"` // 1-based step index; 0 for a reusable workflow call
+	Action     string `json:"action"`
+	Version    string `json:"version"`
+	Input      string `json:"input"` // e.g. with.token, env.GH_TOKEN, secrets.token
+}
+
+// TokenTally counts how often one third-party action receives the GITHUB_TOKEN
+type TokenTally struct {
+	Action       string `json:"action"`
+	Handoffs     int    `json:"handoffs"`
+	Repositories int    `json:"repositories"`
+}
+
+// tokenInputs returns the keys of a with:, env: or secrets: block whose values reference the
+// GITHUB_TOKEN, prefixed with the block name
+func tokenInputs(block string, values interface{}) []string {
+	entries, ok := yamlMap(values)
+	if !ok {
+		return nil
+	}
+
+	var inputs []string
+	for _, key := range sortedYAMLKeys(entries) {
+		for _, str := range collectStrings(entries[key]) {
+			if githubTokenPattern.MatchString(str) {
+				inputs = append(inputs, block+"."+key)
+				break
+			}
+		}
+	}
+	return inputs
+}
+
+// findTokenHandoffs lists the with:/env: entries of a workflow that pass the GITHUB_TOKEN to
+// third-party actions and reusable workflows
+func findTokenHandoffs(definition *workflowDefinition, repo, workflowPath, org string) []TokenHandoff {
+	var handoffs []TokenHandoff
+
+	for _, jobID := range definition.sortedJobIDs() {
+		job := definition.Jobs[jobID]
+
+		if job.Uses != "" {
+			name, version, ok := splitActionReference(job.Uses)
+			if !ok || actionClass(name, org) != actionClassThirdParty {
+				continue
+			}
+			inputs := append(tokenInputs("with", job.With), tokenInputs("secrets", job.Secrets)...)
+			for _, input := range inputs {
+				handoffs = append(handoffs, TokenHandoff{
+					Repository: repo, Workflow: workflowPath, Job: jobID,
+					Action: name, Version: version, Input: input,
+				})
+			}
+			continue
+		}
+
+		for i, step := range job.Steps {
+			name, version, ok := splitActionReference(step.Uses)
+			if !ok || actionClass(name, org) != actionClassThirdParty {
+				continue
+			}
+			inputs := append(tokenInputs("with", step.With), tokenInputs("env", step.Env)...)
+			for _, input := range inputs {
+				handoffs = append(handoffs, TokenHandoff{
+					Repository: repo, Workflow: workflowPath, Job: jobID, Step: i + 1,
+					Action: name, Version: version, Input: input,
+				})
+			}
+		}
+	}
+
+	return handoffs
+}
+
+// tallyTokenHandoffs counts handoffs and distinct repositories per action, most handoffs first
+func tallyTokenHandoffs(handoffs []TokenHandoff) []TokenTally {
+	counts := make(map[string]int)
+	repositories := make(map[string]map[string]bool)
+	for _, handoff := range handoffs {
+		counts[handoff.Action]++
+		if repositories[handoff.Action] == nil {
+			repositories[handoff.Action] = make(map[string]bool)
+		}
+		repositories[handoff.Action][handoff.Repository] = true
+	}
+
+	tally := []TokenTally{}
+	for action, count := range counts {
+		tally = append(tally, TokenTally{Action: action, Handoffs: count, Repositories: len(repositories[action])})
+	}
+	sort.Slice(tally, func(i, j int) bool {
+		if tally[i].Handoffs != tally[j].Handoffs {
+			return tally[i].Handoffs > tally[j].Handoffs
+		}
+		return tally[i].Action < tally[j].Action
+	})
+	return tally
+}
+
+// tokenHandoffFindings raises one finding per action reference and workflow passing the GITHUB_TOKEN
+func tokenHandoffFindings(handoffs []TokenHandoff) []Finding {
+	findings := []Finding{}
+	seen := make(map[string]int)
+	inputs := make(map[string][]string)
+	for _, handoff := range handoffs {
+		key := handoff.Repository + "|" + handoff.Workflow + "|" + handoff.Action + "@" + handoff.Version
+		inputs[key] = append(inputs[key], describeTokenInput(handoff))
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = len(findings)
+		findings = append(findings, Finding{
+			RuleID:     RuleGitHubTokenHandoff,
+			Severity:   SeverityWarning,
+			Repository: handoff.Repository,
+			Workflow:   handoff.Workflow,
+			Action:     handoff.Action,
+			Version:    handoff.Version,
+		})
+	}
+
+	for key, index := range seen {
+		findings[index].Message = fmt.Sprintf("GITHUB_TOKEN is passed to third-party action %s@%s (%s)",
+			findings[index].Action, findings[index].Version, strings.Join(inputs[key], ", "))
+	}
+	return findings
+}
+
+// describeTokenInput names the job, step and input of a handoff, e.g. build step 2 with.token
+func describeTokenInput(handoff TokenHandoff) string {
+	if handoff.Step == 0 {
+		return fmt.Sprintf("%s %s", handoff.Job, handoff.Input)
+	}
+	return fmt.Sprintf("%s step %d %s", handoff.Job, handoff.Step, handoff.Input)
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// snapshotTimeLayout is the timestamp in the file names of --snapshot-dir, sortable and valid on every
+// file system
+const snapshotTimeLayout = "20060102T150405Z"
+
+// TrendPoint summarizes one saved scan of a trend
+type TrendPoint struct {
+	Time                        time.Time `json:"time"`
+	Path                        string    `json:"path"`
+	Repositories                int       `json:"repositories"` // repositories with workflows
+	Workflows                   int       `json:"workflows"`
+	ActionUsages                int       `json:"action_usages"`
+	UniqueActions               int       `json:"unique_actions"`
+	UnpinnedUsages              int       `json:"unpinned_usages"` // tag- and branch-pinned usages
+	PinningRate                 float64   `json:"pinning_rate"`    // share of usages pinned to a commit SHA, 0-100
+	ActionsWithMultipleVersions int       `json:"actions_with_multiple_versions"`
+	Findings                    int       `json:"findings"`
+}
+
+// TrendChange is the change of one metric between the baseline and the latest scan
+type TrendChange struct {
+	Metric  string  `json:"metric"`
+	From    float64 `json:"from"`
+	To      float64 `json:"to"`
+	Percent float64 `json:"percent"` // relative change; 0 when From is 0
+}
+
+// Trend reports how the scans of a history directory evolved
+type Trend struct {
+	Organization string        `json:"organization"`
+	Since        time.Time     `json:"since"` // time of the baseline scan
+	Points       []TrendPoint  `json:"points"`
+	Changes      []TrendChange `json:"changes"`
+}
+
+// saveSnapshot writes a detailed report to --snapshot-dir as <organization>-<timestamp>.json, the
+// saved report format read by the digest, diff, and trend commands
+func (o scanOptions) saveSnapshot(report ComprehensiveReport) error {
+	if o.SnapshotDir == "" {
+		return nil
+	}
+	taken, err := time.Parse(time.RFC3339, report.ScanTimestamp)
+	if err != nil {
+		taken = time.Now()
+	}
+	if err := os.MkdirAll(o.SnapshotDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %v", err)
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(o.SnapshotDir, fmt.Sprintf("%s-%s.json", report.Organization, taken.UTC().Format(snapshotTimeLayout)))
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to save snapshot: %v", err)
+	}
+	logInfof("🗂️  Saved snapshot to %s\n", path)
+	return nil
+}
+
+// runTrendCommand implements `gh action-lens trend`
+func runTrendCommand(args []string) error {
+	fs := flag.NewFlagSet("trend", flag.ExitOnError)
+
+	var historyDir string
+	var organization string
+	var outputFormat string
+	var days int
+
+	fs.StringVar(&historyDir, "history", "", "Directory of saved detailed JSON reports, e.g. the --snapshot-dir of the scans")
+	fs.StringVar(&organization, "org", "", "Only use reports of this organization")
+	fs.StringVar(&organization, "o", "", "Only use reports of this organization")
+	fs.StringVar(&outputFormat, "format", "default", "Output format: default, json, markdown")
+	fs.StringVar(&outputFormat, "f", "default", "Output format: default, json, markdown")
+	fs.IntVar(&days, "days", 30, "Compare the latest scan with the newest one at least this many days older")
+
+	addHostnameFlag(fs)
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "\nUsage:\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens trend --history <dir> [flags]\n\n")
+		fmt.Fprintf(os.Stderr, "Report adoption, pinning, and version drift trends over the saved scans of a history directory.\n\n")
+		fmt.Fprintf(os.Stderr, "Flags:\n")
+		fmt.Fprintf(os.Stderr, "      --history <dir>\n")
+		fmt.Fprintf(os.Stderr, "        Directory of saved detailed JSON reports, e.g. the --snapshot-dir of the scans\n\n")
+		fmt.Fprintf(os.Stderr, "  -o, --org <string>\n")
+		fmt.Fprintf(os.Stderr, "        Only use reports of this organization\n\n")
+		fmt.Fprintf(os.Stderr, "      --days <n>\n")
+		fmt.Fprintf(os.Stderr, "        Compare the latest scan with the newest one at least this many days older (default 30)\n\n")
+		fmt.Fprintf(os.Stderr, "  -f, --format <string>\n")
+		fmt.Fprintf(os.Stderr, "        Output format: default, json, markdown (default \"default\")\n\n")
+		fmt.Fprintf(os.Stderr, "      --hostname <host>\n")
+		fmt.Fprintf(os.Stderr, "        GitHub host, e.g. a GitHub Enterprise Server (default: GH_HOST or the gh default host)\n\n")
+		fmt.Fprintf(os.Stderr, "Examples:\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens report -o myorg --snapshot-dir ./scans\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens trend --history ./scans --org myorg\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens trend --history ./scans --org myorg --days 90 --format markdown\n\n")
+	}
+
+	fs.Parse(args)
+
+	if historyDir == "" {
+		fs.Usage()
+		return fmt.Errorf("--history is required")
+	}
+	if days < 1 {
+		return fmt.Errorf("--days must be at least 1")
+	}
+	switch outputFormat {
+	case "default", "json", "markdown":
+	default:
+		return fmt.Errorf("invalid output format '%s'. Valid options: default, json, markdown", outputFormat)
+	}
+
+	snapshots, err := loadSnapshots(historyDir, organization)
+	if err != nil {
+		return err
+	}
+	if len(snapshots) < 2 {
+		return fmt.Errorf("a trend needs at least two saved detailed reports in %s, found %d", historyDir, len(snapshots))
+	}
+	if organization == "" {
+		for _, s := range snapshots[1:] {
+			if !strings.EqualFold(s.Report.Organization, snapshots[0].Report.Organization) {
+				return fmt.Errorf("%s has reports of several organizations; choose one with --org", historyDir)
+			}
+		}
+	}
+
+	trend := buildTrend(snapshots, time.Duration(days)*24*time.Hour)
+
+	switch outputFormat {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(trend)
+	case "markdown":
+		outputTrendMarkdown(trend, os.Stdout)
+	default:
+		outputTrend(trend, os.Stdout)
+	}
+	return nil
+}
+
+// trendPoint summarizes a saved scan
+func trendPoint(s snapshot) TrendPoint {
+	summary := summarizeComprehensive(s.Report.Repositories)
+	return TrendPoint{
+		Time:                        s.Time,
+		Path:                        s.Path,
+		Repositories:                summary.RepositoriesWithWorkflows,
+		Workflows:                   summary.TotalWorkflows,
+		ActionUsages:                summary.TotalActionUsages,
+		UniqueActions:               summary.UniqueActions,
+		UnpinnedUsages:              summary.Pinning.TagPinned + summary.Pinning.BranchPinned,
+		PinningRate:                 pinningRate(s.Report),
+		ActionsWithMultipleVersions: summary.ActionsWithMultipleVersions,
+		Findings:                    len(s.Report.Findings),
+	}
+}
+
+// buildTrend summarizes every snapshot, oldest first, and compares the latest one with the baseline
+// at least window older
+func buildTrend(snapshots []snapshot, window time.Duration) Trend {
+	latest := snapshots[len(snapshots)-1]
+	baseline := selectBaseline(snapshots, latest.Time.Add(-window))
+
+	trend := Trend{Organization: latest.Report.Organization, Since: baseline.Time}
+	for _, s := range snapshots {
+		trend.Points = append(trend.Points, trendPoint(s))
+	}
+
+	from, to := trendPoint(baseline), trendPoint(latest)
+	metrics := []struct {
+		name     string
+		from, to float64
+	}{
+		{"repositories with workflows", float64(from.Repositories), float64(to.Repositories)},
+		{"workflows", float64(from.Workflows), float64(to.Workflows)},
+		{"action usages", float64(from.ActionUsages), float64(to.ActionUsages)},
+		{"unique actions", float64(from.UniqueActions), float64(to.UniqueActions)},
+		{"unpinned action usages", float64(from.UnpinnedUsages), float64(to.UnpinnedUsages)},
+		{"pinning rate", from.PinningRate, to.PinningRate},
+		{"actions with multiple versions", float64(from.ActionsWithMultipleVersions), float64(to.ActionsWithMultipleVersions)},
+		{"findings", float64(from.Findings), float64(to.Findings)},
+	}
+	for _, m := range metrics {
+		change := TrendChange{Metric: m.name, From: m.from, To: m.to}
+		if m.from != 0 {
+			change.Percent = (m.to - m.from) / m.from * 100
+		}
+		trend.Changes = append(trend.Changes, change)
+	}
+	return trend
+}
+
+// describe phrases a change, e.g. "unpinned action usages down 30% (120 → 84)"
+func (c TrendChange) describe() string {
+	values := fmt.Sprintf("%g → %g", c.From, c.To)
+	if c.Metric == "pinning rate" {
+		values = fmt.Sprintf("%.1f%% → %.1f%%", c.From, c.To)
+	}
+	switch {
+	case c.From == c.To:
+		return fmt.Sprintf("%s unchanged (%s)", c.Metric, values)
+	case c.From == 0:
+		return fmt.Sprintf("%s up from none (%s)", c.Metric, values)
+	case c.To > c.From:
+		return fmt.Sprintf("%s up %.0f%% (%s)", c.Metric, math.Abs(c.Percent), values)
+	}
+	return fmt.Sprintf("%s down %.0f%% (%s)", c.Metric, math.Abs(c.Percent), values)
+}
+
+// outputTrend writes a trend in the default format
+func outputTrend(t Trend, writer io.Writer) {
+	latest := t.Points[len(t.Points)-1]
+	fmt.Fprintf(writer, "\n📈 Trend for %s: %d scans, %s → %s\n\n", t.Organization, len(t.Points), t.Points[0].Time.Format("2006-01-02"), latest.Time.Format("2006-01-02"))
+	fmt.Fprintf(writer, "Since %s:\n", t.Since.Format("2006-01-02"))
+	for _, change := range t.Changes {
+		fmt.Fprintf(writer, "   • %s\n", change.describe())
+	}
+
+	fmt.Fprintf(writer, "\n%-10s %6s %9s %7s %7s %9s %8s %8s %8s\n", "Date", "Repos", "Workflows", "Usages", "Actions", "Unpinned", "Pinned", "Drift", "Findings")
+	for _, p := range t.Points {
+		fmt.Fprintf(writer, "%-10s %6d %9d %7d %7d %9d %7.1f%% %8d %8d\n", p.Time.Format("2006-01-02"), p.Repositories, p.Workflows,
+			p.ActionUsages, p.UniqueActions, p.UnpinnedUsages, p.PinningRate, p.ActionsWithMultipleVersions, p.Findings)
+	}
+}
+
+// outputTrendMarkdown writes a trend as Markdown, e.g. for a job summary
+func outputTrendMarkdown(t Trend, writer io.Writer) {
+	fmt.Fprintf(writer, "## Trend for %s\n\n", t.Organization)
+	fmt.Fprintf(writer, "Since %s:\n\n", t.Since.Format("2006-01-02"))
+	for _, change := range t.Changes {
+		fmt.Fprintf(writer, "- %s\n", change.describe())
+	}
+
+	fmt.Fprintf(writer, "\n| Date | Repositories | Workflows | Action usages | Unique actions | Unpinned | Pinned to SHA | Multiple versions | Findings |\n")
+	fmt.Fprintf(writer, "|---|---:|---:|---:|---:|---:|---:|---:|---:|\n")
+	for _, p := range t.Points {
+		fmt.Fprintf(writer, "| %s | %d | %d | %d | %d | %d | %.1f%% | %d | %d |\n", p.Time.Format("2006-01-02"), p.Repositories, p.Workflows,
+			p.ActionUsages, p.UniqueActions, p.UnpinnedUsages, p.PinningRate, p.ActionsWithMultipleVersions, p.Findings)
+	}
+}
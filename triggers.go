@@ -0,0 +1,597 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronMonthNames and cronWeekdayNames are the names cron accepts in the month and day-of-week fields
+var (
+	cronMonthNames   = map[string]int{"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6, "jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12}
+	cronWeekdayNames = map[string]int{"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6}
+)
+
+// TriggerReport summarizes the events that start the workflows of an organization
+type TriggerReport struct {
+	Organization          string              `json:"organization"`
+	Summary               TriggerSummary      `json:"summary"`
+	Events                []TriggerEventUsage `json:"events"`
+	Workflows             []WorkflowEvents    `json:"workflows"`
+	MinScheduleInterval   float64             `json:"min_schedule_interval_minutes"` // schedules running more often are flagged
+	Findings              []Finding           `json:"findings"`
+	Truncated             bool                `json:"truncated"`
+	RemainingRepositories []string            `json:"remaining_repositories,omitempty"`
+	ProcessTimeSeconds    float64             `json:"process_time_seconds"`
+}
+
+// TriggerSummary represents summary statistics of the trigger inventory
+type TriggerSummary struct {
+	WorkflowsScanned    int     `json:"workflows_scanned"`
+	Push                int     `json:"push"`
+	PullRequest         int     `json:"pull_request"`
+	Scheduled           int     `json:"scheduled"`
+	Schedules           int     `json:"schedules"`
+	ScheduledRunsPerDay float64 `json:"scheduled_runs_per_day"` // all cron schedules together
+	ScheduledRunsMonth  float64 `json:"scheduled_runs_per_month"`
+	ScheduledJobsMonth  float64 `json:"scheduled_job_runs_per_month"` // runs × jobs per run, a proxy for runner minutes
+	FrequentSchedules   int     `json:"frequent_schedules"`
+	Dispatchable        int     `json:"dispatchable"` // workflows with workflow_dispatch
+	DispatchInputs      int     `json:"dispatch_inputs"`
+}
+
+// TriggerEventUsage is how many workflows an event starts
+type TriggerEventUsage struct {
+	Event        string `json:"event"`
+	Workflows    int    `json:"workflows"`
+	Repositories int    `json:"repositories"`
+}
+
+// WorkflowEvents are the events starting one workflow
+type WorkflowEvents struct {
+	Repository string          `json:"repository"`
+	Workflow   string          `json:"workflow"`
+	Events     []string        `json:"events"`
+	Schedules  []CronSchedule  `json:"schedules,omitempty"`
+	Inputs     []DispatchInput `json:"inputs,omitempty"` // workflow_dispatch inputs
+	Dispatch   bool            `json:"workflow_dispatch"`
+	JobsPerRun int             `json:"jobs_per_run"` // matrices expanded
+
+	RunsPerMonth    float64 `json:"scheduled_runs_per_month,omitempty"`
+	JobRunsPerMonth float64 `json:"scheduled_job_runs_per_month,omitempty"`
+}
+
+// CronSchedule is one schedule of a workflow and how often it runs
+type CronSchedule struct {
+	Cron            string  `json:"cron"`
+	RunsPerDay      float64 `json:"runs_per_day"`
+	RunsPerMonth    float64 `json:"runs_per_month"`
+	IntervalMinutes int     `json:"interval_minutes"` // shortest time between two runs
+	Frequency       string  `json:"frequency"`
+	Error           string  `json:"error,omitempty"`
+}
+
+// DispatchInput is an input of a manually dispatched workflow
+type DispatchInput struct {
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Required bool     `json:"required"`
+	Default  string   `json:"default,omitempty"`
+	Options  []string `json:"options,omitempty"`
+}
+
+// cronFieldValues returns the values a cron field matches within [min, max], in ascending order: *, values,
+// names, ranges, lists, and steps such as */15 or 1-5/2
+func cronFieldValues(field string, min, max int, names map[string]int) ([]int, error) {
+	value := func(s string) (int, error) {
+		if n, ok := names[strings.ToLower(s)]; ok {
+			return n, nil
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil || n < min || n > max {
+			return 0, fmt.Errorf("invalid value %q", s)
+		}
+		return n, nil
+	}
+
+	matched := make(map[int]bool)
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid step in %q", part)
+			}
+			rangePart, step = part[:i], n
+		}
+
+		from, to := min, max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if from, err = value(bounds[0]); err != nil {
+				return nil, err
+			}
+			if to, err = value(bounds[1]); err != nil {
+				return nil, err
+			}
+		default:
+			n, err := value(rangePart)
+			if err != nil {
+				return nil, err
+			}
+			from, to = n, n
+			if step > 1 {
+				to = max
+			}
+		}
+		for n := from; n <= to; n += step {
+			matched[n] = true
+		}
+	}
+
+	values := make([]int, 0, len(matched))
+	for n := range matched {
+		values = append(values, n)
+	}
+	sort.Ints(values)
+	return values, nil
+}
+
+// cronRunsPerDay estimates how often a five-field cron expression runs per day, averaging days of the
+// month over 30.44 days and, as cron does, running on days matching either day field when both are set
+func cronRunsPerDay(expression string) (float64, error) {
+	fields, err := parseCron(expression)
+	if err != nil {
+		return 0, err
+	}
+	minutes, hours, daysOfMonth, months, weekdays := len(fields[0]), len(fields[1]), len(fields[2]), len(fields[3]), len(fields[4])
+
+	days := 1.0
+	switch monthRestricted, weekRestricted := daysOfMonth < 31, weekdays < 7; {
+	case monthRestricted && weekRestricted:
+		days = math.Min(1, float64(daysOfMonth)/30.44+float64(weekdays)/7)
+	case monthRestricted:
+		days = float64(daysOfMonth) / 30.44
+	case weekRestricted:
+		days = float64(weekdays) / 7
+	}
+	return float64(minutes*hours) * days * float64(months) / 12, nil
+}
+
+// parseCron returns the values of the minute, hour, day-of-month, month, and day-of-week fields of a
+// five-field cron expression; Sunday is 0 only
+func parseCron(expression string) ([5][]int, error) {
+	var values [5][]int
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return values, fmt.Errorf("expected 5 fields, got %d", len(fields))
+	}
+	ranges := [5]struct {
+		min, max int
+		names    map[string]int
+	}{{0, 59, nil}, {0, 23, nil}, {1, 31, nil}, {1, 12, cronMonthNames}, {0, 7, cronWeekdayNames}}
+	for i, field := range fields {
+		var err error
+		if values[i], err = cronFieldValues(field, ranges[i].min, ranges[i].max, ranges[i].names); err != nil {
+			return values, err
+		}
+	}
+	if weekdays := values[4]; weekdays[len(weekdays)-1] == 7 { // 0 and 7 are both Sunday
+		values[4] = weekdays[:len(weekdays)-1]
+		if len(values[4]) == 0 || values[4][0] != 0 {
+			values[4] = append([]int{0}, values[4]...)
+		}
+	}
+	return values, nil
+}
+
+// cronFrequency describes how often a schedule running the given times per day fires
+func cronFrequency(runsPerDay float64) string {
+	if runsPerDay <= 0 {
+		return "never"
+	}
+	minutes := 1440 / runsPerDay
+	daysAWeek := math.Round(runsPerDay * 7)
+	switch {
+	case minutes <= 1:
+		return "every minute"
+	case minutes < 60:
+		return fmt.Sprintf("every %.0f minutes", minutes)
+	case math.Abs(minutes-60) < 1:
+		return "hourly"
+	case minutes < 1440-1:
+		return "every " + formatAmount(minutes/60) + " hours"
+	case math.Abs(minutes-1440) < 1:
+		return "daily"
+	case daysAWeek >= 2 && daysAWeek <= 6 && math.Abs(runsPerDay*7-daysAWeek) < 0.01:
+		return fmt.Sprintf("%.0f days a week", daysAWeek)
+	case math.Abs(minutes-7*1440) < 1:
+		return "weekly"
+	case minutes > 27*1440 && minutes < 32*1440:
+		return "monthly"
+	case minutes > 360*1440 && minutes < 370*1440:
+		return "yearly"
+	}
+	return "every " + formatAmount(minutes/1440) + " days"
+}
+
+// formatAmount formats a number with at most one decimal, dropping a trailing .0
+func formatAmount(value float64) string {
+	return strconv.FormatFloat(math.Round(value*10)/10, 'f', -1, 64)
+}
+
+// workflowSchedules returns the cron schedules of a workflow
+func workflowSchedules(on interface{}) []CronSchedule {
+	events, ok := yamlMap(on)
+	if !ok {
+		return nil
+	}
+	entries, _ := events["schedule"].([]interface{})
+	var schedules []CronSchedule
+	for _, entry := range entries {
+		definition, ok := yamlMap(entry)
+		if !ok {
+			continue
+		}
+		expression, _ := definition["cron"].(string)
+		schedule := CronSchedule{Cron: expression}
+		runsPerDay, err := cronRunsPerDay(expression)
+		if err != nil {
+			schedule.Error = err.Error()
+			schedule.Frequency = "invalid"
+		} else {
+			schedule.RunsPerDay = math.Min(runsPerDay, 1440/githubScheduleInterval)
+			schedule.RunsPerMonth = schedule.RunsPerDay * daysPerMonth
+			schedule.IntervalMinutes, _ = cronShortestInterval(expression, runsPerDay)
+			schedule.Frequency = cronFrequency(schedule.RunsPerDay)
+		}
+		schedules = append(schedules, schedule)
+	}
+	return schedules
+}
+
+// dispatchInputs returns the inputs of workflow_dispatch, ordered by name
+func dispatchInputs(on interface{}) []DispatchInput {
+	events, ok := yamlMap(on)
+	if !ok {
+		return nil
+	}
+	dispatch, ok := yamlMap(events["workflow_dispatch"])
+	if !ok {
+		return nil
+	}
+	definitions, ok := yamlMap(dispatch["inputs"])
+	if !ok {
+		return nil
+	}
+	var inputs []DispatchInput
+	for _, name := range sortedYAMLKeys(definitions) {
+		input := DispatchInput{Name: name, Type: "string"}
+		if definition, ok := yamlMap(definitions[name]); ok {
+			if kind, ok := definition["type"].(string); ok {
+				input.Type = kind
+			}
+			input.Required, _ = definition["required"].(bool)
+			if value, ok := definition["default"]; ok && value != nil {
+				input.Default = fmt.Sprint(value)
+			}
+			input.Options = collectStrings(definition["options"])
+		}
+		inputs = append(inputs, input)
+	}
+	return inputs
+}
+
+// workflowTriggerInventory collects the events of a workflow
+func workflowTriggerInventory(definition *workflowDefinition, repo, workflowPath string) WorkflowEvents {
+	triggers := WorkflowEvents{
+		Repository: repo,
+		Workflow:   workflowPath,
+		Events:     workflowTriggers(definition.On),
+		Schedules:  workflowSchedules(definition.On),
+		Inputs:     dispatchInputs(definition.On),
+		JobsPerRun: jobsPerRun(definition),
+	}
+	for _, event := range triggers.Events {
+		if event == "workflow_dispatch" {
+			triggers.Dispatch = true
+		}
+	}
+	for _, schedule := range triggers.Schedules {
+		triggers.RunsPerMonth += schedule.RunsPerMonth
+	}
+	triggers.JobRunsPerMonth = triggers.RunsPerMonth * float64(triggers.JobsPerRun)
+	return triggers
+}
+
+// analyzeTriggers inventories the events starting the workflows of an organization and flags schedules
+// running more often than the minimum interval
+func analyzeTriggers(org string, startTime time.Time, outputFormat, outputFile string, minInterval time.Duration, opts scanOptions) error {
+	repositories, _, err := listRepositoryWorkflows(org, opts)
+	if err != nil {
+		return err
+	}
+
+	logInfof("⏰ Collecting workflow triggers in %d repositories...\n\n", len(repositories))
+
+	report := TriggerReport{
+		Organization: org,
+		Events:       []TriggerEventUsage{},
+		Workflows:    []WorkflowEvents{},
+		Findings:     []Finding{},
+
+		MinScheduleInterval: minInterval.Minutes(),
+	}
+
+	report.RemainingRepositories = forEachWorkflowDefinition(org, repositories, opts, func(repo, workflowPath string, definition *workflowDefinition) {
+		report.Workflows = append(report.Workflows, workflowTriggerInventory(definition, repo, workflowPath))
+	})
+
+	workflows := make(map[string]int)
+	repositoriesByEvent := make(map[string]map[string]bool)
+	for _, triggers := range report.Workflows {
+		report.Summary.WorkflowsScanned++
+		for _, event := range triggers.Events {
+			workflows[event]++
+			if repositoriesByEvent[event] == nil {
+				repositoriesByEvent[event] = make(map[string]bool)
+			}
+			repositoriesByEvent[event][triggers.Repository] = true
+		}
+		if len(triggers.Schedules) > 0 {
+			report.Summary.Scheduled++
+		}
+		report.Summary.Schedules += len(triggers.Schedules)
+		for _, schedule := range triggers.Schedules {
+			report.Summary.ScheduledRunsPerDay += schedule.RunsPerDay
+			if finding, ok := frequentScheduleFinding(triggers, schedule, minInterval); ok {
+				report.Summary.FrequentSchedules++
+				report.Findings = append(report.Findings, finding)
+			}
+		}
+		report.Summary.ScheduledRunsMonth += triggers.RunsPerMonth
+		report.Summary.ScheduledJobsMonth += triggers.JobRunsPerMonth
+		if triggers.Dispatch {
+			report.Summary.Dispatchable++
+		}
+		report.Summary.DispatchInputs += len(triggers.Inputs)
+	}
+	report.Summary.Push = workflows["push"]
+	report.Summary.PullRequest = workflows["pull_request"]
+	for event, count := range workflows {
+		report.Events = append(report.Events, TriggerEventUsage{Event: event, Workflows: count, Repositories: len(repositoriesByEvent[event])})
+	}
+	sort.Slice(report.Events, func(i, j int) bool {
+		if report.Events[i].Workflows != report.Events[j].Workflows {
+			return report.Events[i].Workflows > report.Events[j].Workflows
+		}
+		return report.Events[i].Event < report.Events[j].Event
+	})
+
+	opts.finishFindings(org, &report.Findings)
+	report.Truncated = len(report.RemainingRepositories) > 0
+	report.ProcessTimeSeconds = time.Since(startTime).Seconds()
+
+	return opts.writeFindingsReport(org, &report, report.Findings, outputFile, func(writer io.Writer) error {
+		return outputTriggerReport(report, outputFormat, writer)
+	})
+}
+
+// scheduledWorkflows returns the workflows with a schedule, most job runs per month first
+func scheduledWorkflows(report TriggerReport) []WorkflowEvents {
+	var scheduled []WorkflowEvents
+	for _, triggers := range report.Workflows {
+		if len(triggers.Schedules) > 0 {
+			scheduled = append(scheduled, triggers)
+		}
+	}
+	sort.SliceStable(scheduled, func(i, j int) bool {
+		return scheduled[i].JobRunsPerMonth > scheduled[j].JobRunsPerMonth
+	})
+	return scheduled
+}
+
+// describeSchedules renders the schedules of a workflow as `cron` (frequency) pairs
+func describeSchedules(schedules []CronSchedule) string {
+	var parts []string
+	for _, schedule := range schedules {
+		parts = append(parts, fmt.Sprintf("%s (%s)", schedule.Cron, schedule.Frequency))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// describeInputs renders workflow_dispatch inputs as name:type, marking required ones with *
+func describeInputs(inputs []DispatchInput) string {
+	var parts []string
+	for _, input := range inputs {
+		part := input.Name + ":" + input.Type
+		if input.Required {
+			part += "*"
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// outputTriggerReport outputs the trigger inventory in the specified format
+func outputTriggerReport(report TriggerReport, format string, writer io.Writer) error {
+	if formatter, ok := lookupFormatter(format); ok {
+		return formatter.Format(report, writer)
+	}
+
+	switch format {
+	case "table":
+		return outputTriggerTable(report, writer)
+
+	case "csv":
+		return outputTriggerCSV(report, writer)
+
+	case "markdown":
+		return outputTriggerMarkdown(report, writer)
+
+	case "sarif":
+		return outputSARIF("triggers", report.Findings, writer)
+
+	default: // "default"
+		fmt.Fprintln(writer, "⏰ Workflow Triggers")
+		fmt.Fprintln(writer, "="+strings.Repeat("=", 50))
+
+		for _, event := range report.Events {
+			fmt.Fprintf(writer, "   %-28s %4d workflows in %d repositories\n", event.Event, event.Workflows, event.Repositories)
+		}
+
+		if scheduled := scheduledWorkflows(report); len(scheduled) > 0 {
+			fmt.Fprintln(writer, "\n🕒 Schedules:")
+			for _, triggers := range scheduled {
+				fmt.Fprintf(writer, "   %s → %s: %s, ~%.0f runs × %d jobs = ~%.0f job runs per month\n", triggers.Repository, triggers.Workflow,
+					describeSchedules(triggers.Schedules), triggers.RunsPerMonth, triggers.JobsPerRun, triggers.JobRunsPerMonth)
+			}
+		}
+
+		if report.Summary.Dispatchable > 0 {
+			fmt.Fprintln(writer, "\n▶️  workflow_dispatch:")
+			for _, triggers := range report.Workflows {
+				if !triggers.Dispatch {
+					continue
+				}
+				inputs := "no inputs"
+				if len(triggers.Inputs) > 0 {
+					inputs = describeInputs(triggers.Inputs)
+				}
+				fmt.Fprintf(writer, "   %s → %s: %s\n", triggers.Repository, triggers.Workflow, inputs)
+			}
+		}
+
+		fmt.Fprintln(writer, "\n📊 Summary:")
+		fmt.Fprintf(writer, "   • Workflows scanned: %d\n", report.Summary.WorkflowsScanned)
+		fmt.Fprintf(writer, "   • On push: %d, on pull_request: %d\n", report.Summary.Push, report.Summary.PullRequest)
+		fmt.Fprintf(writer, "   • Scheduled: %d workflows, %d schedules, ~%.0f runs per day\n",
+			report.Summary.Scheduled, report.Summary.Schedules, report.Summary.ScheduledRunsPerDay)
+		fmt.Fprintf(writer, "   • Scheduled per month: ~%.0f runs, ~%.0f job runs\n", report.Summary.ScheduledRunsMonth, report.Summary.ScheduledJobsMonth)
+		fmt.Fprintf(writer, "   • Schedules more frequent than every %s: %d\n", formatInterval(time.Duration(report.MinScheduleInterval)*time.Minute), report.Summary.FrequentSchedules)
+		fmt.Fprintf(writer, "   • workflow_dispatch: %d workflows, %d inputs\n", report.Summary.Dispatchable, report.Summary.DispatchInputs)
+		fmt.Fprintf(writer, "   ⏱️  Process time: %.3fs\n", report.ProcessTimeSeconds)
+
+		outputFindings(report.Findings, writer)
+
+		if report.Truncated {
+			outputTruncationNotice(report.RemainingRepositories, writer)
+		}
+
+		return nil
+	}
+}
+
+// outputTriggerTable outputs the trigger inventory in table format
+func outputTriggerTable(report TriggerReport, writer io.Writer) error {
+	fmt.Fprintln(writer, "╔════════════════════════════════════════════════════════════════════════════════════════════════════╗")
+	fmt.Fprintf(writer, "║                                    ⏰ WORKFLOW TRIGGERS                                             ║\n")
+	fmt.Fprintln(writer, "╚════════════════════════════════════════════════════════════════════════════════════════════════════╝")
+	fmt.Fprintf(writer, "  🏢 Organization: %-59s \n", report.Organization)
+	fmt.Fprintf(writer, "  📄 Workflows: %-63d \n", report.Summary.WorkflowsScanned)
+	fmt.Fprintf(writer, "  🕒 Scheduled: %-63d \n", report.Summary.Scheduled)
+	fmt.Fprintf(writer, "  💸 Scheduled Job Runs / Month: %-45.0f \n", report.Summary.ScheduledJobsMonth)
+	fmt.Fprintf(writer, "  ⚠️  Frequent Schedules: %-53d \n", report.Summary.FrequentSchedules)
+	fmt.Fprintf(writer, "  ▶️  workflow_dispatch: %-54d \n", report.Summary.Dispatchable)
+	fmt.Fprintln(writer, " ═════════════════════════════════════════════════════════════════════════════════════════════════════")
+	fmt.Fprintln(writer)
+
+	if len(report.Events) == 0 {
+		fmt.Fprintln(writer, "┌─────────────────────────────────────────┐")
+		fmt.Fprintln(writer, "│    No workflows found                   │")
+		fmt.Fprintln(writer, "└─────────────────────────────────────────┘")
+		return nil
+	}
+
+	fmt.Fprintln(writer, "┌──────────────────────────────┬───────────┬──────────────┐")
+	fmt.Fprintf(writer, "│ %-28s │ %-9s │ %-12s │\n", "EVENT", "WORKFLOWS", "REPOSITORIES")
+	fmt.Fprintln(writer, "├──────────────────────────────┼───────────┼──────────────┤")
+	for _, event := range report.Events {
+		fmt.Fprintf(writer, "│ %-28s │ %9d │ %12d │\n", truncate(event.Event, 28), event.Workflows, event.Repositories)
+	}
+	fmt.Fprintln(writer, "└──────────────────────────────┴───────────┴──────────────┘")
+
+	if scheduled := scheduledWorkflows(report); len(scheduled) > 0 {
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "┌─────────────────────┬────────────────────────────────┬──────────────────────┬──────────────────────┬────────────┬──────────────┐")
+		fmt.Fprintf(writer, "│ %-18s │ %-29s │ %-20s │ %-20s │ %-10s │ %-12s │\n", "📁 REPOSITORY", "📄 WORKFLOW", "CRON", "FREQUENCY", "RUNS/MONTH", "JOBS/MONTH")
+		fmt.Fprintln(writer, "├─────────────────────┼────────────────────────────────┼──────────────────────┼──────────────────────┼────────────┼──────────────┤")
+		for _, triggers := range scheduled {
+			for _, schedule := range triggers.Schedules {
+				fmt.Fprintf(writer, "│ %-19s │ %-30s │ %-20s │ %-20s │ %10.0f │ %12.0f │\n",
+					truncate(triggers.Repository, 19), truncate(triggers.Workflow, 30), truncate(schedule.Cron, 20), truncate(schedule.Frequency, 20),
+					schedule.RunsPerMonth, schedule.RunsPerMonth*float64(triggers.JobsPerRun))
+			}
+		}
+		fmt.Fprintln(writer, "└─────────────────────┴────────────────────────────────┴──────────────────────┴──────────────────────┴────────────┴──────────────┘")
+	}
+	outputFindings(report.Findings, writer)
+	fmt.Fprintln(writer)
+	return nil
+}
+
+// outputTriggerCSV outputs the trigger inventory in CSV format, one row per workflow
+func outputTriggerCSV(report TriggerReport, writer io.Writer) error {
+	w := newCSVWriter(writer)
+	w.Write([]string{"Repository", "Workflow", "Events", "Schedules", "Jobs Per Run", "Scheduled Runs Per Month", "Scheduled Job Runs Per Month", "Dispatch Inputs"})
+	for _, triggers := range report.Workflows {
+		var crons []string
+		for _, schedule := range triggers.Schedules {
+			crons = append(crons, schedule.Cron)
+		}
+		w.Write([]string{triggers.Repository, triggers.Workflow, strings.Join(triggers.Events, " "), strings.Join(crons, "; "), strconv.Itoa(triggers.JobsPerRun),
+			strconv.FormatFloat(triggers.RunsPerMonth, 'f', 0, 64), strconv.FormatFloat(triggers.JobRunsPerMonth, 'f', 0, 64), describeInputs(triggers.Inputs)})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// outputTriggerMarkdown outputs the trigger inventory as Markdown
+func outputTriggerMarkdown(report TriggerReport, writer io.Writer) error {
+	fmt.Fprintln(writer, "## ⏰ Workflow Triggers")
+	fmt.Fprintln(writer)
+	writeMarkdownSummary([][2]string{
+		{"Workflows scanned", fmt.Sprint(report.Summary.WorkflowsScanned)},
+		{"On push / pull_request", fmt.Sprintf("%d / %d", report.Summary.Push, report.Summary.PullRequest)},
+		{"Scheduled workflows", fmt.Sprintf("%d (%d schedules, ~%.0f runs per day)", report.Summary.Scheduled, report.Summary.Schedules, report.Summary.ScheduledRunsPerDay)},
+		{"Scheduled per month", fmt.Sprintf("~%.0f runs, ~%.0f job runs", report.Summary.ScheduledRunsMonth, report.Summary.ScheduledJobsMonth)},
+		{"workflow_dispatch", fmt.Sprintf("%d workflows, %d inputs", report.Summary.Dispatchable, report.Summary.DispatchInputs)},
+		{"Process time", fmt.Sprintf("%.3fs", report.ProcessTimeSeconds)},
+	}, writer)
+
+	if len(report.Events) > 0 {
+		fmt.Fprintln(writer, "| Event | Workflows | Repositories |")
+		fmt.Fprintln(writer, "|---|---:|---:|")
+		for _, event := range report.Events {
+			fmt.Fprintf(writer, "| `%s` | %d | %d |\n", event.Event, event.Workflows, event.Repositories)
+		}
+		fmt.Fprintln(writer)
+	}
+
+	if scheduled := scheduledWorkflows(report); len(scheduled) > 0 {
+		fmt.Fprintln(writer, "### 🕒 Schedules")
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "| Repository | Workflow | Cron | Frequency | Runs / month | Job runs / month |")
+		fmt.Fprintln(writer, "|---|---|---|---|---:|---:|")
+		for _, triggers := range scheduled {
+			for _, schedule := range triggers.Schedules {
+				fmt.Fprintf(writer, "| %s | `%s` | `%s` | %s | %.0f | %.0f |\n",
+					markdownCell(triggers.Repository), markdownCell(triggers.Workflow), markdownCell(schedule.Cron), schedule.Frequency,
+					schedule.RunsPerMonth, schedule.RunsPerMonth*float64(triggers.JobsPerRun))
+			}
+		}
+		fmt.Fprintln(writer)
+	}
+
+	writeMarkdownFindings(report.Findings, writer)
+
+	writeMarkdownTruncation(report.RemainingRepositories, writer)
+	return nil
+}
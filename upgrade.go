@@ -0,0 +1,289 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Pull requests are opened in batches with a pause in between, keeping well below the secondary rate
+// limit GitHub applies to content-creating requests
+const (
+	defaultUpgradeBatchSize  = 10
+	defaultUpgradeBatchDelay = time.Minute
+)
+
+// actionUpgrader rewrites the version refs of one action that are behind a target version
+type actionUpgrader struct {
+	action string // action repository, e.g. actions/checkout
+	to     string // target ref; "" upgrades to the latest release at the precision of each ref
+	latest string // latest release of the action, used when to is empty
+}
+
+// target returns the ref replacing a version ref of the action, or false when the ref is not a version
+// or not behind the target. SHAs and branches are left unchanged.
+func (u actionUpgrader) target(ref string) (string, bool) {
+	current, ok := parseVersion(ref)
+	if !ok || isPinnedToSHA(ref) {
+		return "", false
+	}
+	to := u.to
+	if to == "" {
+		to = suggestedRef(ref, u.latest)
+	}
+	version, ok := parseVersion(to)
+	if !ok || upgradeLevel(current, version) == "" {
+		return "", false
+	}
+	return to, true
+}
+
+// rewrite upgrades the references of the action in a workflow, including subpaths such as
+// github/codeql-action/init
+func (u actionUpgrader) rewrite(content string) (string, []AppliedRewrite) {
+	var rewrites RewriteMap
+	for _, match := range usesLinePattern.FindAllStringSubmatch(content, -1) {
+		name, ref, ok := splitActionReference(match[3])
+		if !ok || !strings.EqualFold(actionRepository(name), u.action) {
+			continue
+		}
+		if to, ok := u.target(ref); ok {
+			rewrites.Rewrites = append(rewrites.Rewrites, Rewrite{From: match[3], To: name + "@" + to})
+		}
+	}
+	if len(rewrites.Rewrites) == 0 {
+		return content, nil
+	}
+	return rewriteWorkflow(content, rewrites)
+}
+
+// runUpgradeCommand implements `gh action-lens upgrade`
+func runUpgradeCommand(args []string) error {
+	fs := flag.NewFlagSet("upgrade", flag.ExitOnError)
+
+	var organization string
+	var action string
+	var to string
+	var apply bool
+	var dryRun bool
+	var branch string
+	var title string
+	var batchSize int
+	var batchDelay time.Duration
+	var outputFormat string
+	var includeWorkflows string
+	var excludeWorkflows string
+	var skipRepos string
+
+	fs.StringVar(&organization, "org", "", "Organization whose workflows are upgraded")
+	fs.StringVar(&organization, "o", "", "Organization whose workflows are upgraded")
+	fs.StringVar(&action, "action", "", "Action to upgrade, e.g. actions/checkout")
+	fs.StringVar(&to, "to", "", "Version to upgrade to, e.g. v4 (default: the latest release)")
+	fs.BoolVar(&apply, "apply", false, "Open a pull request per repository upgrading the action")
+	fs.BoolVar(&dryRun, "dry-run", false, "Print the upgrades without opening pull requests")
+	fs.StringVar(&branch, "branch", "", "Branch the upgraded workflows are committed to (default \"action-lens/upgrade-<owner>-<action>-<version>\")")
+	fs.StringVar(&title, "title", "", "Title of the pull requests (default \"Upgrade <action> to <version>\")")
+	fs.IntVar(&batchSize, "batch-size", defaultUpgradeBatchSize, "Pull requests opened before pausing")
+	fs.DurationVar(&batchDelay, "batch-delay", defaultUpgradeBatchDelay, "Pause between batches of pull requests")
+	fs.StringVar(&outputFormat, "format", "default", "Output format: default, json")
+	fs.StringVar(&outputFormat, "f", "default", "Output format: default, json")
+	fs.StringVar(&includeWorkflows, "include-workflows", "", "Only upgrade workflow files matching these comma-separated glob patterns")
+	fs.StringVar(&excludeWorkflows, "exclude-workflows", "", "Skip workflow files matching these comma-separated glob patterns")
+	fs.StringVar(&skipRepos, "skip-repos", "", "Comma-separated repository names to leave unchanged")
+
+	addHostnameFlag(fs)
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "\nUsage:\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens upgrade --org <org> --action <owner/repo> [--to <version>] (--apply | --dry-run) [flags]\n\n")
+		fmt.Fprintf(os.Stderr, "Bump the version refs of an action that are behind --to, or behind its latest release, across\n")
+		fmt.Fprintf(os.Stderr, "an organization and open one pull request per affected repository, in rate-limited batches.\n\n")
+		fmt.Fprintf(os.Stderr, "Flags:\n")
+		fmt.Fprintf(os.Stderr, "  -o, --org <string>\n")
+		fmt.Fprintf(os.Stderr, "        Organization whose workflows are upgraded\n\n")
+		fmt.Fprintf(os.Stderr, "      --action <owner/repo>\n")
+		fmt.Fprintf(os.Stderr, "        Action to upgrade, e.g. actions/checkout\n\n")
+		fmt.Fprintf(os.Stderr, "      --to <version>\n")
+		fmt.Fprintf(os.Stderr, "        Version to upgrade to, e.g. v4 (default: the latest release, at the precision of each ref)\n\n")
+		fmt.Fprintf(os.Stderr, "      --apply\n")
+		fmt.Fprintf(os.Stderr, "        Open a pull request per repository upgrading the action\n\n")
+		fmt.Fprintf(os.Stderr, "      --dry-run\n")
+		fmt.Fprintf(os.Stderr, "        Print the upgrades without opening pull requests\n\n")
+		fmt.Fprintf(os.Stderr, "      --branch <string>\n")
+		fmt.Fprintf(os.Stderr, "        Branch the upgraded workflows are committed to (default \"action-lens/upgrade-<owner>-<action>-<version>\")\n\n")
+		fmt.Fprintf(os.Stderr, "      --title <string>\n")
+		fmt.Fprintf(os.Stderr, "        Title of the pull requests (default \"Upgrade <action> to <version>\")\n\n")
+		fmt.Fprintf(os.Stderr, "      --batch-size <int>\n")
+		fmt.Fprintf(os.Stderr, "        Pull requests opened before pausing (default %d)\n\n", defaultUpgradeBatchSize)
+		fmt.Fprintf(os.Stderr, "      --batch-delay <duration>\n")
+		fmt.Fprintf(os.Stderr, "        Pause between batches of pull requests (default %s)\n\n", defaultUpgradeBatchDelay)
+		fmt.Fprintf(os.Stderr, "  -f, --format <string>\n")
+		fmt.Fprintf(os.Stderr, "        Output format: default, json (default \"default\")\n\n")
+		fmt.Fprintf(os.Stderr, "      --include-workflows <patterns>\n")
+		fmt.Fprintf(os.Stderr, "        Only upgrade workflow files matching these comma-separated glob patterns\n\n")
+		fmt.Fprintf(os.Stderr, "      --exclude-workflows <patterns>\n")
+		fmt.Fprintf(os.Stderr, "        Skip workflow files matching these comma-separated glob patterns\n\n")
+		fmt.Fprintf(os.Stderr, "      --skip-repos <list>\n")
+		fmt.Fprintf(os.Stderr, "        Comma-separated repository names to leave unchanged\n\n")
+		fmt.Fprintf(os.Stderr, "      --hostname <host>\n")
+		fmt.Fprintf(os.Stderr, "        GitHub host, e.g. a GitHub Enterprise Server (default: GH_HOST or the gh default host)\n\n")
+		fmt.Fprintf(os.Stderr, "Examples:\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens upgrade -o myorg --action actions/checkout --to v4 --dry-run\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens upgrade -o myorg --action actions/checkout --to v4 --apply\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens upgrade -o myorg --action actions/setup-node --apply --batch-size 5 --batch-delay 2m\n\n")
+	}
+
+	fs.Parse(args)
+
+	if organization == "" || action == "" {
+		fs.Usage()
+		return fmt.Errorf("both --org and --action are required")
+	}
+	if apply == dryRun {
+		fs.Usage()
+		return fmt.Errorf("exactly one of --apply and --dry-run is required")
+	}
+	action = strings.TrimSuffix(action, "/")
+	if strings.Contains(action, "@") || strings.Count(action, "/") != 1 {
+		return fmt.Errorf("--action '%s' must be an action repository such as actions/checkout; the version goes in --to", action)
+	}
+	if to != "" {
+		if _, ok := parseVersion(to); !ok || isPinnedToSHA(to) {
+			return fmt.Errorf("--to '%s' is not a version such as v4 or v4.2.1; pin to SHAs with gh action-lens pin --apply", to)
+		}
+	}
+	if batchSize < 1 {
+		return fmt.Errorf("--batch-size must be at least 1")
+	}
+	if batchDelay < 0 {
+		return fmt.Errorf("--batch-delay must not be negative")
+	}
+	switch outputFormat {
+	case "default", "json":
+	default:
+		return fmt.Errorf("invalid output format '%s'. Valid options: default, json", outputFormat)
+	}
+
+	opts := rewriteScanOptions(includeWorkflows, excludeWorkflows, skipRepos)
+	if err := opts.validate(); err != nil {
+		return err
+	}
+
+	startTime := time.Now()
+	upgrader := actionUpgrader{action: action, to: to}
+	if to == "" {
+		apiRateLimit.acquire()
+		latest, err := latestActionRelease(openEnrichmentCache("", true), action)
+		apiRateLimit.release()
+		if err != nil {
+			return fmt.Errorf("could not look up the latest release of %s: %v", action, err)
+		}
+		if latest == "" {
+			return fmt.Errorf("%s has no releases or version tags; choose the version with --to", action)
+		}
+		logInfof("🆕 Latest release of %s: %s\n", action, latest)
+		upgrader.latest = latest
+	}
+	version := to
+	if version == "" {
+		version = upgrader.latest
+	}
+	if branch == "" {
+		branch = "action-lens/upgrade-" + strings.ReplaceAll(action, "/", "-") + "-" + version
+	}
+	if title == "" {
+		title = fmt.Sprintf("Upgrade %s to %s", action, version)
+	}
+
+	report, err := planMigration(organization, upgrader.rewrite, opts)
+	if err != nil {
+		return err
+	}
+	report.DryRun = dryRun
+	report.Branch = branch
+
+	opened := 0
+	for i := range report.Repositories {
+		repo := &report.Repositories[i]
+		if dryRun {
+			repo.Status = MigrationPlanned
+			continue
+		}
+		if opened > 0 && opened%batchSize == 0 && batchDelay > 0 {
+			logInfof("⏸️  Opened %d pull requests; pausing %s before the next batch...\n", opened, batchDelay)
+			time.Sleep(batchDelay)
+		}
+		logInfof("⬆️  Opening pull request for %s...\n", repo.Name)
+		if err := openMigrationPullRequest(organization, repo, branch, title, upgradePullRequestBody(*repo, upgrader)); err != nil {
+			repo.Status = MigrationFailed
+			repo.Error = err.Error()
+			report.Summary.Failed++
+			continue
+		}
+		if repo.Status == MigrationOpened {
+			report.Summary.PullRequests++
+			opened++
+		}
+	}
+	report.ProcessTimeSeconds = time.Since(startTime).Seconds()
+
+	if outputFormat == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		outputMigrationReport(report, os.Stdout)
+	}
+
+	if report.Summary.Failed > 0 {
+		return fmt.Errorf("%d of %d pull requests could not be opened", report.Summary.Failed, len(report.Repositories))
+	}
+	return nil
+}
+
+// upgradePullRequestBody summarizes the upgrade of a repository as Markdown: the versions moved from, the
+// level of each upgrade, and every rewritten line
+func upgradePullRequestBody(repo MigrationRepository, upgrader actionUpgrader) string {
+	levels := make(map[string]string) // from -> to (level)
+	for _, workflow := range repo.Workflows {
+		for _, change := range workflow.Changes {
+			_, fromRef, _ := splitActionReference(change.From)
+			_, toRef, _ := splitActionReference(change.To)
+			current, _ := parseVersion(fromRef)
+			target, _ := parseVersion(toRef)
+			levels[fromRef+" → "+toRef] = upgradeLevel(current, target)
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "This pull request upgrades [`%s`](https://%s/%s) ", upgrader.action, githubHost(), upgrader.action)
+	if upgrader.to != "" {
+		fmt.Fprintf(&b, "to `%s`.", upgrader.to)
+	} else {
+		fmt.Fprintf(&b, "to its latest release, `%s`.", upgrader.latest)
+	}
+	fmt.Fprintf(&b, " %d references in %d workflow files are changed.\n\n", countMigrationChanges(repo), len(repo.Workflows))
+	for _, change := range sortedKeys(levels) {
+		fmt.Fprintf(&b, "- `%s` (%s)\n", change, levels[change])
+	}
+	for _, level := range levels {
+		if level == UpgradeMajor {
+			fmt.Fprintf(&b, "\nMajor upgrades can contain breaking changes: review the [release notes](https://%s/%s/releases) ", githubHost(), upgrader.action)
+			b.WriteString("and the inputs and outputs the workflows use before merging.\n")
+			break
+		}
+	}
+	b.WriteString("\n| Workflow | Line | From | To |\n|---|---:|---|---|\n")
+	for _, workflow := range repo.Workflows {
+		for _, change := range workflow.Changes {
+			fmt.Fprintf(&b, "| `%s` | %d | `%s` | `%s` |\n", markdownCell(workflow.Path), change.Line, change.From, change.To)
+		}
+	}
+	b.WriteString("\n_Opened by gh-action-lens upgrade._\n")
+	return b.String()
+}
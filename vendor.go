@@ -0,0 +1,495 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Vendoring outcomes of an action repository
+const (
+	VendorCreated  = "created"  // the organization copy was created by this run
+	VendorExisting = "existing" // the organization already held a copy, which was reused
+	VendorPlanned  = "planned"  // --dry-run: the copy would be created
+	VendorFailed   = "failed"
+)
+
+// forkReadyAttempts bounds how long to wait for an asynchronously created fork to become available
+const forkReadyAttempts = 15
+
+// RewriteMap maps action references to the references that replace them in workflows
+type RewriteMap struct {
+	Rewrites []Rewrite `json:"rewrites" yaml:"rewrites"`
+}
+
+// Rewrite replaces the `uses:` reference From with To. From is either owner/repo[/path]@ref, matching
+// that ref only, or owner/repo[/path], matching every ref.
+type Rewrite struct {
+	From string `json:"from" yaml:"from"`
+	To   string `json:"to" yaml:"to"`
+}
+
+// VendorReport is the result of the vendor command
+type VendorReport struct {
+	Organization       string           `json:"organization,omitempty"` // organization whose workflows were scanned for refs in use
+	Target             string           `json:"target"`                 // organization holding the vendored copies
+	Mode               string           `json:"mode"`                   // fork or copy
+	DryRun             bool             `json:"dry_run"`
+	Actions            []VendoredAction `json:"actions"`
+	Rewrites           []Rewrite        `json:"rewrites"`
+	ProcessTimeSeconds float64          `json:"process_time_seconds"`
+}
+
+// VendoredAction is one action repository copied into the target organization
+type VendoredAction struct {
+	Source string        `json:"source"` // owner/repo
+	Target string        `json:"target"` // target-org/repo
+	Status string        `json:"status"` // created, existing, planned, or failed
+	Error  string        `json:"error,omitempty"`
+	Refs   []VendoredRef `json:"refs"`
+}
+
+// VendoredRef is a ref of an action pinned in the organization copy
+type VendoredRef struct {
+	Ref     string   `json:"ref"`
+	SHA     string   `json:"sha,omitempty"`
+	Tag     string   `json:"tag,omitempty"` // tag of the organization copy keeping the commit reachable
+	Actions []string `json:"actions"`       // action names using the ref, e.g. owner/repo/subpath
+	Error   string   `json:"error,omitempty"`
+}
+
+// runVendorCommand implements `gh action-lens vendor`
+func runVendorCommand(args []string) error {
+	fs := flag.NewFlagSet("vendor", flag.ExitOnError)
+
+	var organization string
+	var target string
+	var actionList string
+	var mode string
+	var visibility string
+	var mapFile string
+	var outputFormat string
+	var dryRun bool
+
+	fs.StringVar(&organization, "org", "", "Organization whose workflows are scanned for the refs in use")
+	fs.StringVar(&organization, "o", "", "Organization whose workflows are scanned for the refs in use")
+	fs.StringVar(&target, "to", "", "Internal organization receiving the copies")
+	fs.StringVar(&actionList, "actions", "", "Comma-separated action repositories to vendor (owner/repo or owner/repo@ref)")
+	fs.StringVar(&actionList, "a", "", "Comma-separated action repositories to vendor (owner/repo or owner/repo@ref)")
+	fs.StringVar(&mode, "mode", MirrorFork, "How to copy the actions: fork or copy")
+	fs.StringVar(&visibility, "visibility", "private", "Visibility of repositories created with --mode copy: private or internal")
+	fs.StringVar(&mapFile, "map", "", "Write the rewrite map (old reference → internal reference) to this YAML file")
+	fs.StringVar(&outputFormat, "format", "default", "Output format: default, json")
+	fs.StringVar(&outputFormat, "f", "default", "Output format: default, json")
+	fs.BoolVar(&dryRun, "dry-run", false, "Resolve the refs and print the plan without creating anything")
+
+	addHostnameFlag(fs)
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "\nUsage:\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens vendor --to <org> --actions <owner/repo,...> [flags]\n\n")
+		fmt.Fprintf(os.Stderr, "Fork or copy third-party actions into an internal organization at the commit SHAs in use,\n")
+		fmt.Fprintf(os.Stderr, "and emit the rewrite map from the original references to the internal ones.\n\n")
+		fmt.Fprintf(os.Stderr, "Flags:\n")
+		fmt.Fprintf(os.Stderr, "  -o, --org <string>\n")
+		fmt.Fprintf(os.Stderr, "        Organization whose workflows are scanned for the refs in use\n")
+		fmt.Fprintf(os.Stderr, "        (required for actions given without @ref)\n\n")
+		fmt.Fprintf(os.Stderr, "      --to <string>\n")
+		fmt.Fprintf(os.Stderr, "        Internal organization receiving the copies\n\n")
+		fmt.Fprintf(os.Stderr, "  -a, --actions <list>\n")
+		fmt.Fprintf(os.Stderr, "        Comma-separated action repositories to vendor (owner/repo or owner/repo@ref)\n\n")
+		fmt.Fprintf(os.Stderr, "      --mode <string>\n")
+		fmt.Fprintf(os.Stderr, "        How to copy the actions: fork or copy (default \"fork\")\n")
+		fmt.Fprintf(os.Stderr, "        copy creates a standalone repository and pushes the pinned commits with git\n\n")
+		fmt.Fprintf(os.Stderr, "      --visibility <string>\n")
+		fmt.Fprintf(os.Stderr, "        Visibility of repositories created with --mode copy: private or internal (default \"private\")\n\n")
+		fmt.Fprintf(os.Stderr, "      --map <path>\n")
+		fmt.Fprintf(os.Stderr, "        Write the rewrite map (old reference → internal reference) to this YAML file\n\n")
+		fmt.Fprintf(os.Stderr, "  -f, --format <string>\n")
+		fmt.Fprintf(os.Stderr, "        Output format: default, json (default \"default\")\n\n")
+		fmt.Fprintf(os.Stderr, "      --dry-run\n")
+		fmt.Fprintf(os.Stderr, "        Resolve the refs and print the plan without creating anything\n\n")
+		fmt.Fprintf(os.Stderr, "      --hostname <host>\n")
+		fmt.Fprintf(os.Stderr, "        GitHub host, e.g. a GitHub Enterprise Server (default: GH_HOST or the gh default host)\n\n")
+		fmt.Fprintf(os.Stderr, "Examples:\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens vendor -o myorg --to myorg-actions --actions docker/login-action,hashicorp/setup-terraform --dry-run\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens vendor -o myorg --to myorg-actions --actions docker/login-action --map rewrite-map.yml\n")
+		fmt.Fprintf(os.Stderr, "  gh action-lens vendor --to myorg-actions --actions softprops/action-gh-release@v2 --mode copy --visibility internal\n\n")
+	}
+
+	fs.Parse(args)
+
+	selected := splitList(actionList)
+	if target == "" || len(selected) == 0 {
+		fs.Usage()
+		return fmt.Errorf("both --to and --actions are required")
+	}
+	if mode != MirrorFork && mode != MirrorCopy {
+		return fmt.Errorf("invalid mode '%s'. Valid options: fork, copy", mode)
+	}
+	if visibility != "private" && visibility != "internal" {
+		return fmt.Errorf("invalid visibility '%s'. Valid options: private, internal", visibility)
+	}
+	switch outputFormat {
+	case "default", "json":
+	default:
+		return fmt.Errorf("invalid output format '%s'. Valid options: default, json", outputFormat)
+	}
+
+	// Explicit refs are vendored as given; the refs of bare repositories are taken from the organization
+	refs := make(map[string]map[string]map[string]bool) // repository -> ref -> action names
+	var scanned []string
+	for _, entry := range selected {
+		name, ref, pinned := splitActionReference(entry)
+		if !pinned {
+			name = entry
+		}
+		repository := actionRepository(name)
+		if strings.Count(repository, "/") != 1 || actionClass(repository, target) != actionClassThirdParty {
+			return fmt.Errorf("'%s' is not a third-party action repository", entry)
+		}
+		if refs[repository] == nil {
+			refs[repository] = make(map[string]map[string]bool)
+		}
+		if pinned {
+			if refs[repository][ref] == nil {
+				refs[repository][ref] = make(map[string]bool)
+			}
+			refs[repository][ref][name] = true
+		} else {
+			scanned = append(scanned, repository)
+		}
+	}
+	if len(scanned) > 0 && organization == "" {
+		return fmt.Errorf("--org is required to find the refs in use of %s; or give them as owner/repo@ref", strings.Join(scanned, ", "))
+	}
+
+	startTime := time.Now()
+	if len(scanned) > 0 {
+		if err := collectVendorRefs(organization, scanned, refs); err != nil {
+			return err
+		}
+	}
+
+	report := vendorActions(refs, target, mode, visibility, dryRun)
+	report.Organization = organization
+	report.ProcessTimeSeconds = time.Since(startTime).Seconds()
+
+	if mapFile != "" {
+		if err := writeRewriteMap(mapFile, RewriteMap{Rewrites: report.Rewrites}); err != nil {
+			return fmt.Errorf("error writing rewrite map: %v", err)
+		}
+	}
+
+	if outputFormat == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		outputVendorReport(report, os.Stdout)
+	}
+	if mapFile != "" {
+		logInfof("🗺️  Rewrite map written to %s\n", mapFile)
+	}
+
+	for _, action := range report.Actions {
+		if action.Status == VendorFailed {
+			return fmt.Errorf("vendoring failed for %s: %s", action.Source, action.Error)
+		}
+	}
+	return nil
+}
+
+// collectVendorRefs adds the refs of the given action repositories used by the workflows of an organization
+func collectVendorRefs(org string, repositories []string, refs map[string]map[string]map[string]bool) error {
+	wanted := make(map[string]string) // lowercase repository -> repository as given
+	for _, repository := range repositories {
+		wanted[strings.ToLower(repository)] = repository
+	}
+
+	workflows, err := getWorkflowFiles(org, subcommandScanOptions())
+	if err != nil {
+		return err
+	}
+	logInfof("🔍 Finding the refs of %d actions in %d workflow files...\n", len(repositories), len(workflows))
+
+	for _, wf := range workflows {
+		actions, err := extractActionsFromFile(org, wf.Repo, wf.Path)
+		if err != nil {
+			logWarnf("⚠️  Warning: Could not analyze %s/%s: %v\n", wf.Repo, wf.Path, err)
+			continue
+		}
+		for _, action := range actions {
+			repository, ok := wanted[strings.ToLower(actionRepository(action.Name))]
+			if !ok {
+				continue
+			}
+			if refs[repository][action.Version] == nil {
+				refs[repository][action.Version] = make(map[string]bool)
+			}
+			refs[repository][action.Version][repository+action.Name[len(repository):]] = true
+		}
+	}
+
+	for _, repository := range repositories {
+		if len(refs[repository]) == 0 {
+			logWarnf("⚠️  Warning: %s is not used by %s\n", repository, org)
+		}
+	}
+	return nil
+}
+
+// vendorActions resolves every ref to its commit SHA, copies each action repository into the target
+// organization, and returns the outcome along with the rewrites of the pinned refs
+func vendorActions(refs map[string]map[string]map[string]bool, target, mode, visibility string, dryRun bool) VendorReport {
+	report := VendorReport{Target: target, Mode: mode, DryRun: dryRun, Actions: []VendoredAction{}, Rewrites: []Rewrite{}}
+
+	// Vendoring pins the commits refs point to now, so lookups bypass the enrichment cache
+	cache := openEnrichmentCache("", true)
+
+	var repositories []string
+	for repository := range refs {
+		repositories = append(repositories, repository)
+	}
+	sort.Strings(repositories)
+
+	for _, repository := range repositories {
+		if len(refs[repository]) == 0 {
+			continue
+		}
+		_, name, _ := strings.Cut(repository, "/")
+		vendored := VendoredAction{Source: repository, Target: target + "/" + name}
+
+		var names []string
+		for ref := range refs[repository] {
+			names = append(names, ref)
+		}
+		sort.Strings(names)
+		for _, ref := range names {
+			pinned := VendoredRef{Ref: ref, Actions: []string{}}
+			for action := range refs[repository][ref] {
+				pinned.Actions = append(pinned.Actions, action)
+			}
+			sort.Strings(pinned.Actions)
+			if sha := resolveRef(cache, repository, ref).SHA; sha != "" {
+				pinned.SHA = sha
+				pinned.Tag = "vendor/" + sha
+			} else {
+				pinned.Error = "ref not found"
+			}
+			vendored.Refs = append(vendored.Refs, pinned)
+		}
+
+		logInfof("📦 Vendoring %s into %s (%d refs)...\n", repository, vendored.Target, len(vendored.Refs))
+		if err := vendorRepository(&vendored, mode, visibility, dryRun, cache); err != nil {
+			vendored.Status = VendorFailed
+			vendored.Error = err.Error()
+		}
+
+		if vendored.Status != VendorFailed {
+			for _, ref := range vendored.Refs {
+				if ref.SHA == "" {
+					continue
+				}
+				for _, action := range ref.Actions {
+					report.Rewrites = append(report.Rewrites, Rewrite{
+						From: action + "@" + ref.Ref,
+						To:   vendored.Target + action[len(repository):] + "@" + ref.SHA,
+					})
+				}
+			}
+		}
+		report.Actions = append(report.Actions, vendored)
+	}
+	return report
+}
+
+// vendorRepository creates or reuses the organization copy of an action repository and tags every
+// pinned commit in it, so the commits stay reachable when the upstream refs move or are deleted
+func vendorRepository(vendored *VendoredAction, mode, visibility string, dryRun bool, cache *enrichmentCache) error {
+	target, _, _ := strings.Cut(vendored.Target, "/")
+	existing, kind := findActionMirror(target, vendored.Source, cache)
+	switch {
+	case existing != "" && kind != mode:
+		return fmt.Errorf("%s already exists as a %s, not a %s", existing, kind, mode)
+	case existing != "":
+		vendored.Status = VendorExisting
+	case dryRun:
+		vendored.Status = VendorPlanned
+	default:
+		vendored.Status = VendorCreated
+	}
+	if dryRun {
+		return nil
+	}
+
+	if mode == MirrorCopy {
+		if vendored.Status == VendorCreated {
+			_, name, _ := strings.Cut(vendored.Target, "/")
+			repo := map[string]interface{}{
+				"name":        name,
+				"visibility":  visibility,
+				"description": "Vendored copy of " + vendored.Source,
+				"has_issues":  false,
+				"has_wiki":    false,
+			}
+			if err := restSend("POST", "orgs/"+target+"/repos", repo, nil); err != nil {
+				return fmt.Errorf("could not create %s: %v", vendored.Target, err)
+			}
+		}
+		return pushPinnedCommits(vendored)
+	}
+
+	if vendored.Status == VendorCreated {
+		_, name, _ := strings.Cut(vendored.Target, "/")
+		fork := map[string]interface{}{"organization": target, "name": name, "default_branch_only": false}
+		if err := restSend("POST", "repos/"+vendored.Source+"/forks", fork, nil); err != nil {
+			return fmt.Errorf("could not fork into %s: %v", vendored.Target, err)
+		}
+		if err := waitForRepository(vendored.Target); err != nil {
+			return err
+		}
+	}
+	for i, ref := range vendored.Refs {
+		if ref.SHA == "" {
+			continue
+		}
+		if _, err := lookupGitRef(vendored.Target, "tags/"+ref.Tag); err == nil {
+			continue
+		}
+		tag := map[string]string{"ref": "refs/tags/" + ref.Tag, "sha": ref.SHA}
+		if err := restSend("POST", "repos/"+vendored.Target+"/git/refs", tag, nil); err != nil {
+			vendored.Refs[i].Error = fmt.Sprintf("could not tag %s: %v", ref.SHA, err)
+			vendored.Refs[i].SHA = ""
+		}
+	}
+	return nil
+}
+
+// waitForRepository waits until a repository created asynchronously, such as a fork, can be read
+func waitForRepository(repository string) error {
+	for attempt := 0; attempt < forkReadyAttempts; attempt++ {
+		var metadata repositoryMetadata
+		if err := restGet("repos/"+repository, &metadata); err == nil {
+			return nil
+		}
+		time.Sleep(2 * time.Second)
+	}
+	return fmt.Errorf("%s did not become available", repository)
+}
+
+// pushPinnedCommits fetches the pinned commits from the action repository and pushes them as tags,
+// and the first one as the default branch, to the standalone copy with git, preserving the commit SHAs
+func pushPinnedCommits(vendored *VendoredAction) error {
+	var shas []string
+	for _, ref := range vendored.Refs {
+		if ref.SHA != "" {
+			shas = append(shas, ref.SHA)
+		}
+	}
+	if len(shas) == 0 {
+		return nil
+	}
+
+	dir, err := os.MkdirTemp("", "action-lens-vendor-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	if err := runGit(dir, "init", "--bare", "--quiet"); err != nil {
+		return err
+	}
+	fetch := append([]string{"fetch", "--quiet", "--no-tags", "https://" + githubHost() + "/" + vendored.Source + ".git"}, shas...)
+	if err := runGit(dir, fetch...); err != nil {
+		return fmt.Errorf("could not fetch the pinned commits: %v", err)
+	}
+
+	push := []string{"push", "--quiet", "https://" + githubHost() + "/" + vendored.Target + ".git"}
+	if vendored.Status == VendorCreated {
+		push = append(push, shas[0]+":refs/heads/main")
+	}
+	for _, ref := range vendored.Refs {
+		if ref.SHA != "" {
+			push = append(push, ref.SHA+":refs/tags/"+ref.Tag)
+		}
+	}
+	if err := runGit(dir, push...); err != nil {
+		return fmt.Errorf("could not push to %s: %v", vendored.Target, err)
+	}
+	return nil
+}
+
+// runGit runs a git command in dir, authenticating to the GitHub host with the GitHub token. The token is
+// passed through git's environment configuration (git 2.31 or later), never on the command line, where
+// other local users could read it from the process list.
+func runGit(dir string, args ...string) error {
+	credentials := base64.StdEncoding.EncodeToString([]byte("x-access-token:" + githubToken()))
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_CONFIG_COUNT=1",
+		"GIT_CONFIG_KEY_0=http.https://"+githubHost()+"/.extraheader",
+		"GIT_CONFIG_VALUE_0=Authorization: basic "+credentials,
+	)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git %s: %v: %s", args[0], err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// writeRewriteMap writes a rewrite map as YAML
+func writeRewriteMap(path string, rewrites RewriteMap) error {
+	data, err := yaml.Marshal(rewrites)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// outputVendorReport outputs the vendoring outcome and the rewrite map
+func outputVendorReport(report VendorReport, writer io.Writer) {
+	fmt.Fprintln(writer, "\n📦 Vendoring Results")
+	fmt.Fprintln(writer, "="+strings.Repeat("=", 60))
+	if report.DryRun {
+		fmt.Fprintln(writer, "🧪 Dry run: nothing was created")
+	}
+
+	icons := map[string]string{VendorCreated: "✅", VendorExisting: "♻️ ", VendorPlanned: "📝", VendorFailed: "❌"}
+	for _, action := range report.Actions {
+		fmt.Fprintf(writer, "\n%s %s → %s (%s %s)\n", icons[action.Status], action.Source, action.Target, report.Mode, action.Status)
+		if action.Error != "" {
+			fmt.Fprintf(writer, "   ⚠️  %s\n", action.Error)
+		}
+		for _, ref := range action.Refs {
+			if ref.Error != "" {
+				fmt.Fprintf(writer, "   ├── @%s: ⚠️  %s\n", ref.Ref, ref.Error)
+				continue
+			}
+			fmt.Fprintf(writer, "   ├── @%s → %s\n", ref.Ref, ref.SHA)
+		}
+	}
+
+	if len(report.Rewrites) > 0 {
+		fmt.Fprintln(writer, "\n🗺️  Rewrite map:")
+		for _, rewrite := range report.Rewrites {
+			fmt.Fprintf(writer, "   %s → %s\n", rewrite.From, rewrite.To)
+		}
+	}
+
+	fmt.Fprintf(writer, "\n📊 Summary: %d action repositories, %d rewrites\n", len(report.Actions), len(report.Rewrites))
+	fmt.Fprintf(writer, "⏱️  Process time: %.3fs\n\n", report.ProcessTimeSeconds)
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// workflowDefinition is the subset of a workflow file used by job-level analyses
+type workflowDefinition struct {
+	Name        string                 `yaml:"name"`
+	On          interface{}            `yaml:"on"`
+	Permissions interface{}            `yaml:"permissions"`
+	Env         map[string]interface{} `yaml:"env"`
+	Jobs        map[string]workflowJob `yaml:"jobs"`
+}
+
+// workflowJob represents a single job of a workflow
+type workflowJob struct {
+	Name        string                 `yaml:"name"`
+	If          string                 `yaml:"if"`
+	RunsOn      interface{}            `yaml:"runs-on"`
+	Environment interface{}            `yaml:"environment"`
+	Permissions interface{}            `yaml:"permissions"`
+	Uses        string                 `yaml:"uses"`
+	With        map[string]interface{} `yaml:"with"`
+	Secrets     interface{}            `yaml:"secrets"`
+	Env         map[string]interface{} `yaml:"env"`
+	Steps       []workflowStep         `yaml:"steps"`
+	Continue    interface{}            `yaml:"continue-on-error"` // true or an expression
+	Strategy    struct {
+		Matrix interface{} `yaml:"matrix"`
+	} `yaml:"strategy"`
+}
+
+// workflowStep represents a single step of a job
+type workflowStep struct {
+	Name     string                 `yaml:"name"`
+	ID       string                 `yaml:"id"`
+	If       string                 `yaml:"if"`
+	Uses     string                 `yaml:"uses"`
+	Run      string                 `yaml:"run"`
+	With     map[string]interface{} `yaml:"with"`
+	Env      map[string]interface{} `yaml:"env"`
+	Continue interface{}            `yaml:"continue-on-error"` // true or an expression
+}
+
+// parseWorkflowDefinition parses workflow YAML into its job structure. Jobs of all documents in a
+// multi-document file are merged; the first document supplies the workflow-level settings.
+func parseWorkflowDefinition(yamlContent string) (*workflowDefinition, error) {
+	decoder := yaml.NewDecoder(strings.NewReader(yamlContent))
+
+	var workflow *workflowDefinition
+	for {
+		var document workflowDefinition
+		err := decoder.Decode(&document)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse YAML: %v", err)
+		}
+
+		if workflow == nil {
+			workflow = &document
+			continue
+		}
+		for id, job := range document.Jobs {
+			if workflow.Jobs == nil {
+				workflow.Jobs = make(map[string]workflowJob)
+			}
+			workflow.Jobs[id] = job
+		}
+	}
+
+	if workflow == nil {
+		workflow = &workflowDefinition{}
+	}
+	return workflow, nil
+}
+
+// decodeYAMLDocuments decodes every document of a YAML stream into generic maps, resolving
+// anchors, aliases, and merge keys. Empty and non-mapping documents are skipped.
+func decodeYAMLDocuments(yamlContent string) ([]map[string]interface{}, error) {
+	decoder := yaml.NewDecoder(strings.NewReader(yamlContent))
+
+	var documents []map[string]interface{}
+	for {
+		var document interface{}
+		err := decoder.Decode(&document)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if m, ok := yamlMap(document); ok {
+			documents = append(documents, m)
+		}
+	}
+	return documents, nil
+}
+
+// yamlMap returns a decoded YAML mapping with string keys
+func yamlMap(value interface{}) (map[string]interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, true
+	case map[interface{}]interface{}:
+		// Mappings with non-string keys (e.g. `on:` parsed as a boolean elsewhere) are normalized
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			m[fmt.Sprint(key)] = item
+		}
+		return m, true
+	}
+	return nil, false
+}
+
+// sortedYAMLKeys returns the keys of a mapping in a stable order
+func sortedYAMLKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sortedJobIDs returns the job IDs of a workflow in a stable order
+func (w *workflowDefinition) sortedJobIDs() []string {
+	var ids []string
+	for id := range w.Jobs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// environmentName returns the deployment environment a job targets, or "" if none
+func (j workflowJob) environmentName() string {
+	switch v := j.Environment.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		if name, ok := v["name"].(string); ok {
+			return name
+		}
+	}
+	return ""
+}
+
+// workflowTriggers returns the events of the on: block of a workflow, written as a single event, a list,
+// or a mapping of events to their filters
+func workflowTriggers(on interface{}) []string {
+	switch v := on.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		var events []string
+		for _, event := range v {
+			events = append(events, fmt.Sprint(event))
+		}
+		return events
+	case map[string]interface{}:
+		return sortedYAMLKeys(v)
+	}
+	return nil
+}
+
+// splitActionReference splits a `uses:` value into action name and ref
+func splitActionReference(uses string) (string, string, bool) {
+	idx := strings.LastIndex(uses, "@")
+	if idx <= 0 || idx == len(uses)-1 {
+		return "", "", false
+	}
+	return uses[:idx], uses[idx+1:], true
+}
+
+// isPinnedToSHA reports whether a ref is a full 40-character commit SHA
+func isPinnedToSHA(ref string) bool {
+	return commitSHAPattern.MatchString(ref)
+}
+
+// collectStrings returns every string value nested inside a YAML value
+func collectStrings(value interface{}) []string {
+	var values []string
+
+	switch v := value.(type) {
+	case string:
+		values = append(values, v)
+	case map[string]interface{}:
+		for _, item := range v {
+			values = append(values, collectStrings(item)...)
+		}
+	case []interface{}:
+		for _, item := range v {
+			values = append(values, collectStrings(item)...)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// xlsxMaxCellLength is the most characters Excel keeps in a cell
+const xlsxMaxCellLength = 32767
+
+// xlsxSheet is a worksheet of an XLSX workbook: a bold, frozen header row with an autofilter, and rows
+// of string and int cells
+type xlsxSheet struct {
+	Name   string
+	Header []string
+	Rows   [][]interface{}
+}
+
+// outputComprehensiveXLSX writes a detailed report as an Excel workbook with Summary, Repositories,
+// Actions, and Violations sheets
+func outputComprehensiveXLSX(report ComprehensiveReport, writer io.Writer) error {
+	summary := xlsxSheet{Name: "Summary", Header: []string{"Metric", "Value"}}
+	add := func(metric string, value interface{}) {
+		summary.Rows = append(summary.Rows, []interface{}{metric, value})
+	}
+	if report.Report != nil && report.Report.Title != "" {
+		add("Title", report.Report.Title)
+	}
+	if report.Enterprise != "" {
+		add("Enterprise", report.Enterprise)
+		add("Organizations", len(report.Organizations))
+	} else {
+		add("Organization", report.Organization)
+	}
+	if report.Ref != "" {
+		add("Ref", report.Ref)
+	}
+	add("Scan time", report.ScanTimestamp)
+	add("Total repositories", report.Summary.TotalRepositories)
+	add("Repositories with workflows", report.Summary.RepositoriesWithWorkflows)
+	add("Total workflows", report.Summary.TotalWorkflows)
+	add("Total action usages", report.Summary.TotalActionUsages)
+	add("Unique actions", report.Summary.UniqueActions)
+	add("Actions with multiple versions", report.Summary.ActionsWithMultipleVersions)
+	add("Most used action", report.Summary.MostUsedAction.Name)
+	add("End-of-life action usages", report.Summary.EOLActionUsages)
+	add("Forked action usages", report.Summary.ForkedActionUsages)
+	add("Archived action usages", report.Summary.ArchivedActionUsages)
+	add("Unavailable action usages", report.Summary.UnavailableActionUsages)
+	add("Vulnerable action usages", report.Summary.VulnerableActionUsages)
+	add("SHA-pinned usages", report.Summary.Pinning.SHAPinned)
+	add("Tag-pinned usages", report.Summary.Pinning.TagPinned)
+	add("Branch-pinned usages", report.Summary.Pinning.BranchPinned)
+	add("Pinned to a SHA (%)", strconv.FormatFloat(report.Summary.Pinning.PinnedRate, 'f', 1, 64))
+	add("Violations", len(report.Findings))
+	if report.Truncated {
+		add("Repositories not scanned (partial report)", len(report.RemainingRepositories))
+	}
+	if report.Report != nil {
+		for _, key := range sortedKeys(report.Report.Metadata) {
+			add(key, report.Report.Metadata[key])
+		}
+	}
+
+	// Repositories, with a column per custom property
+	properties := make(map[string]bool)
+	for _, repo := range report.Repositories {
+		for name := range repo.Properties {
+			properties[name] = true
+		}
+	}
+	propertyNames := sortedKeys(properties)
+	findingCounts := make(map[string]int)
+	for _, finding := range report.Findings {
+		findingCounts[finding.Repository]++
+	}
+	repositories := xlsxSheet{
+		Name:   "Repositories",
+		Header: append([]string{"Repository", "Workflows", "Action usages", "Unique actions", "SHA-pinned", "Tag-pinned", "Branch-pinned", "Violations"}, propertyNames...),
+	}
+	actions := xlsxSheet{
+		Name:   "Actions",
+		Header: []string{"Repository", "Workflow", "Action", "Version", "Count", "Pinning", "Resolved SHA", "Resolved version", "Runtime", "Via"},
+	}
+	for _, repo := range report.Repositories {
+		counts := summarizeComprehensive([]ComprehensiveRepository{repo})
+		row := []interface{}{repo.Name, repo.WorkflowCount, counts.TotalActionUsages, counts.UniqueActions,
+			counts.Pinning.SHAPinned, counts.Pinning.TagPinned, counts.Pinning.BranchPinned, findingCounts[repo.Name]}
+		for _, name := range propertyNames {
+			row = append(row, repo.Properties[name])
+		}
+		repositories.Rows = append(repositories.Rows, row)
+
+		for _, workflow := range repo.Workflows {
+			for _, action := range workflow.Actions {
+				actions.Rows = append(actions.Rows, []interface{}{repo.Name, workflow.Path, action.Name, action.Version, action.Count,
+					action.Pinning, action.ResolvedSHA, action.ResolvedVersion, action.Runtime, action.Via})
+			}
+		}
+	}
+
+	violations := xlsxSheet{
+		Name:   "Violations",
+		Header: []string{"Severity", "Rule", "Repository", "Workflow", "Action", "Version", "Message", "Remediation"},
+	}
+	for _, f := range report.Findings {
+		violations.Rows = append(violations.Rows, []interface{}{f.Severity, f.RuleID, f.Repository, f.Workflow, f.Action, f.Version, f.Message, f.Remediation})
+	}
+
+	return writeXLSX([]xlsxSheet{summary, repositories, actions, violations}, writer)
+}
+
+// writeXLSX writes sheets as an Office Open XML workbook
+func writeXLSX(sheets []xlsxSheet, writer io.Writer) error {
+	archive := zip.NewWriter(writer)
+
+	var contentTypes, workbook, relationships strings.Builder
+	contentTypes.WriteString(xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	workbook.WriteString(xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	relationships.WriteString(xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+
+	var filters strings.Builder
+	for i, sheet := range sheets {
+		fmt.Fprintf(&contentTypes, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i+1)
+		fmt.Fprintf(&workbook, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xmlEscape(sheet.Name), i+1, i+1)
+		fmt.Fprintf(&relationships, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i+1, i+1)
+		fmt.Fprintf(&filters, `<definedName name="_xlnm._FilterDatabase" localSheetId="%d" hidden="1">'%s'!%s</definedName>`, i, xmlEscape(sheet.Name), sheet.filterRange(true))
+	}
+	contentTypes.WriteString(`</Types>`)
+	workbook.WriteString(`</sheets><definedNames>` + filters.String() + `</definedNames></workbook>`)
+	fmt.Fprintf(&relationships, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, len(sheets)+1)
+	relationships.WriteString(`</Relationships>`)
+
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", contentTypes.String()},
+		{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+		{"xl/workbook.xml", workbook.String()},
+		{"xl/_rels/workbook.xml.rels", relationships.String()},
+		// Style 1 is the bold header font
+		{"xl/styles.xml", xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+			`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+			`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+			`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+			`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+			`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>` +
+			`</styleSheet>`},
+	}
+	for i, sheet := range sheets {
+		parts = append(parts, struct{ name, content string }{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), sheet.xml()})
+	}
+
+	for _, part := range parts {
+		w, err := archive.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, part.content); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+// xml renders the worksheet
+func (s xlsxSheet) xml() string {
+	var b strings.Builder
+	b.WriteString(xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	b.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+
+	// Columns as wide as their longest value, within reason
+	b.WriteString(`<cols>`)
+	for column, title := range s.Header {
+		width := utf8.RuneCountInString(title)
+		for _, row := range s.Rows {
+			if column < len(row) {
+				if n := utf8.RuneCountInString(fmt.Sprint(row[column])); n > width {
+					width = n
+				}
+			}
+		}
+		fmt.Fprintf(&b, `<col min="%d" max="%d" width="%d" customWidth="1"/>`, column+1, column+1, min(width, 80)+2)
+	}
+	b.WriteString(`</cols><sheetData>`)
+
+	header := make([]interface{}, len(s.Header))
+	for i, title := range s.Header {
+		header[i] = title
+	}
+	for i, row := range append([][]interface{}{header}, s.Rows...) {
+		fmt.Fprintf(&b, `<row r="%d">`, i+1)
+		for column, value := range row {
+			ref := xlsxColumn(column) + strconv.Itoa(i+1)
+			style := ""
+			if i == 0 {
+				style = ` s="1"`
+			}
+			switch v := value.(type) {
+			case int:
+				fmt.Fprintf(&b, `<c r="%s"%s><v>%d</v></c>`, ref, style, v)
+			default:
+				text := fmt.Sprint(v)
+				if utf8.RuneCountInString(text) > xlsxMaxCellLength {
+					text = string([]rune(text)[:xlsxMaxCellLength])
+				}
+				fmt.Fprintf(&b, `<c r="%s" t="inlineStr"%s><is><t xml:space="preserve">%s</t></is></c>`, ref, style, xmlEscape(text))
+			}
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData>`)
+	fmt.Fprintf(&b, `<autoFilter ref="%s"/></worksheet>`, s.filterRange(false))
+	return b.String()
+}
+
+// filterRange returns the range of the header and rows, e.g. A1:H42, or $A$1:$H$42 when absolute
+func (s xlsxSheet) filterRange(absolute bool) string {
+	column, row := xlsxColumn(len(s.Header)-1), strconv.Itoa(len(s.Rows)+1)
+	if absolute {
+		return "$A$1:$" + column + "$" + row
+	}
+	return "A1:" + column + row
+}
+
+// xlsxColumn returns the letters of a 0-based column index: A, B, ..., Z, AA, ...
+func xlsxColumn(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+	return name
+}
+
+// xmlEscape escapes text for XML content and attributes
+func xmlEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}